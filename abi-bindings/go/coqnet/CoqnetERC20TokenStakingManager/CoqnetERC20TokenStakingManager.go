@@ -0,0 +1,4093 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package coqneterc20tokenstakingmanager
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = interfaces.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ConversionData is an auto generated low-level Go binding around an user-defined struct.
+type ConversionData struct {
+	SubnetID                     [32]byte
+	ValidatorManagerBlockchainID [32]byte
+	ValidatorManagerAddress      common.Address
+	InitialValidators            []InitialValidator
+}
+
+// CoqnetERC20TokenStakingManagerValidationEpoch is an auto generated low-level Go binding around an user-defined struct.
+type CoqnetERC20TokenStakingManagerValidationEpoch struct {
+	Epoch         *big.Int
+	StartTime     *big.Int
+	EndTime       *big.Int
+	ValidationIDs [][32]byte
+}
+
+// InitialValidator is an auto generated low-level Go binding around an user-defined struct.
+type InitialValidator struct {
+	NodeID       []byte
+	BlsPublicKey []byte
+	Weight       uint64
+}
+
+// PChainOwner is an auto generated low-level Go binding around an user-defined struct.
+type PChainOwner struct {
+	Threshold uint32
+	Addresses []common.Address
+}
+
+// PoSValidatorManagerSettings is an auto generated low-level Go binding around an user-defined struct.
+type PoSValidatorManagerSettings struct {
+	BaseSettings             ValidatorManagerSettings
+	MinimumStakeAmount       *big.Int
+	MaximumStakeAmount       *big.Int
+	MinimumStakeDuration     uint64
+	MinimumDelegationFeeBips uint16
+	MaximumStakeMultiplier   uint8
+	WeightToValueFactor      *big.Int
+	RewardCalculator         common.Address
+	UptimeBlockchainID       [32]byte
+}
+
+// Validator is an auto generated low-level Go binding around an user-defined struct.
+type Validator struct {
+	Status         uint8
+	NodeID         []byte
+	StartingWeight uint64
+	MessageNonce   uint64
+	Weight         uint64
+	StartedAt      uint64
+	EndedAt        uint64
+}
+
+// ValidatorManagerSettings is an auto generated low-level Go binding around an user-defined struct.
+type ValidatorManagerSettings struct {
+	SubnetID               [32]byte
+	ChurnPeriodSeconds     uint64
+	MaximumChurnPercentage uint8
+}
+
+// ValidatorRegistrationInput is an auto generated low-level Go binding around an user-defined struct.
+type ValidatorRegistrationInput struct {
+	NodeID                []byte
+	BlsPublicKey          []byte
+	RegistrationExpiry    uint64
+	RemainingBalanceOwner PChainOwner
+	DisableOwner          PChainOwner
+}
+
+// CoqnetERC20TokenStakingManagerMetaData contains all meta data concerning the CoqnetERC20TokenStakingManager contract.
+var CoqnetERC20TokenStakingManagerMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"enumICMInitializable\",\"name\":\"init\",\"type\":\"uint8\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"AccessControlBadConfirmation\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"neededRole\",\"type\":\"bytes32\"}],\"name\":\"AccessControlUnauthorizedAccount\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"}],\"name\":\"AddressEmptyCode\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"AddressInsufficientBalance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"}],\"name\":\"DelegatorIneligibleForRewards\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"FailedInnerCall\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}],\"name\":\"InvalidBLSKeyLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"encodedConversionID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"expectedConversionID\",\"type\":\"bytes32\"}],\"name\":\"InvalidConversionID\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint16\",\"name\":\"delegationFeeBips\",\"type\":\"uint16\"}],\"name\":\"InvalidDelegationFee\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"}],\"name\":\"InvalidDelegationID\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"enumDelegatorStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"name\":\"InvalidDelegatorStatus\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidInitialization\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidInitializationStatus\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"maximumChurnPercentage\",\"type\":\"uint8\"}],\"name\":\"InvalidMaximumChurnPercentage\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"minStakeDuration\",\"type\":\"uint64\"}],\"name\":\"InvalidMinStakeDuration\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"}],\"name\":\"InvalidNodeID\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"nonce\",\"type\":\"uint64\"}],\"name\":\"InvalidNonce\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"addressesLength\",\"type\":\"uint256\"}],\"name\":\"InvalidPChainOwnerThreshold\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"registrationExpiry\",\"type\":\"uint64\"}],\"name\":\"InvalidRegistrationExpiry\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"rewardRecipient\",\"type\":\"address\"}],\"name\":\"InvalidRewardRecipient\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"stakeAmount\",\"type\":\"uint256\"}],\"name\":\"InvalidStakeAmount\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"maximumStakeMultiplier\",\"type\":\"uint8\"}],\"name\":\"InvalidStakeMultiplier\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"tokenAddress\",\"type\":\"address\"}],\"name\":\"InvalidTokenAddress\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"}],\"name\":\"InvalidTotalWeight\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"uptimeBlockchainID\",\"type\":\"bytes32\"}],\"name\":\"InvalidUptimeBlockchainID\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"InvalidValidationID\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"validatorManagerAddress\",\"type\":\"address\"}],\"name\":\"InvalidValidatorManagerAddress\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"blockchainID\",\"type\":\"bytes32\"}],\"name\":\"InvalidValidatorManagerBlockchainID\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"enumValidatorStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"name\":\"InvalidValidatorStatus\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidWarpMessage\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"senderAddress\",\"type\":\"address\"}],\"name\":\"InvalidWarpOriginSenderAddress\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"sourceChainID\",\"type\":\"bytes32\"}],\"name\":\"InvalidWarpSourceChainID\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"churnAmount\",\"type\":\"uint64\"}],\"name\":\"MaxChurnRateExceeded\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"newValidatorWeight\",\"type\":\"uint64\"}],\"name\":\"MaxWeightExceeded\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"endTime\",\"type\":\"uint64\"}],\"name\":\"MinStakeDurationNotPassed\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"MustWaitOneEpoch\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"}],\"name\":\"NodeAlreadyRegistered\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotInitializing\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"PChainOwnerAddressesNotSorted\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"SafeERC20FailedOperation\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"UnauthorizedOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bool\",\"name\":\"validRegistration\",\"type\":\"bool\"}],\"name\":\"UnexpectedRegistrationStatus\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"ValidatorIneligibleForRewards\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"ValidatorNotPoS\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ValidatorRegistrationExceeded\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ZeroWeightToValueFactor\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"rewards\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"fees\",\"type\":\"uint256\"}],\"name\":\"DelegationEnded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"delegatorAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"nonce\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"validatorWeight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"delegatorWeight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"setWeightMessageID\",\"type\":\"bytes32\"}],\"name\":\"DelegatorAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"}],\"name\":\"DelegatorRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"DelegatorRemovalInitialized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"}],\"name\":\"InitialValidatorCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"}],\"name\":\"Initialized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"previousAdminRole\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"newAdminRole\",\"type\":\"bytes32\"}],\"name\":\"RoleAdminChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleRevoked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"uptime\",\"type\":\"uint64\"}],\"name\":\"UptimeUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"registerValidationMessageID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"registrationExpiry\",\"type\":\"uint64\"}],\"name\":\"ValidationPeriodCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"enumValidatorStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"name\":\"ValidationPeriodEnded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"ValidationPeriodRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"setWeightMessageID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"endTime\",\"type\":\"uint256\"}],\"name\":\"ValidatorRemovalInitialized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"uint64\",\"name\":\"nonce\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"setWeightMessageID\",\"type\":\"bytes32\"}],\"name\":\"ValidatorWeightUpdate\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"ADDRESS_LENGTH\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"BIPS_CONVERSION_FACTOR\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"BLS_PUBLIC_KEY_LENGTH\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"COQNET_METRICS_STORAGE_LOCATION\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DEFAULT_ADMIN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"EPOCH_DURATION\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"ERC20_STAKING_MANAGER_STORAGE_LOCATION\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAXIMUM_CHURN_PERCENTAGE_LIMIT\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAXIMUM_DELEGATION_FEE_BIPS\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAXIMUM_REGISTRATION_EXPIRY_LENGTH\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAXIMUM_STAKE_MULTIPLIER_LIMIT\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_EPOCH_VALIDATORS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"POS_VALIDATOR_MANAGER_STORAGE_LOCATION\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"P_CHAIN_BLOCKCHAIN_ID\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REGISTER_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"UPTIME_THRESHOLD_PERCENTAGE\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_MANAGER_STORAGE_LOCATION\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"WARP_MESSENGER\",\"outputs\":[{\"internalType\":\"contractIWarpMessenger\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"rewardRecipient\",\"type\":\"address\"}],\"name\":\"changeDelegatorRewardRecipient\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"rewardRecipient\",\"type\":\"address\"}],\"name\":\"changeValidatorRewardRecipient\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"claimDelegationFees\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"completeDelegatorRegistration\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"completeEndDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"completeEndValidation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"completeValidatorRegistration\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"erc20\",\"outputs\":[{\"internalType\":\"contractIERC20Mintable\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"forceInitializeEndDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"},{\"internalType\":\"address\",\"name\":\"rewardRecipient\",\"type\":\"address\"}],\"name\":\"forceInitializeEndDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"forceInitializeEndValidation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"},{\"internalType\":\"address\",\"name\":\"rewardRecipient\",\"type\":\"address\"}],\"name\":\"forceInitializeEndValidation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCurrentEpochIndex\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"}],\"name\":\"getLastValidationID\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"epoch\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32[]\",\"name\":\"validationIDs\",\"type\":\"bytes32[]\"}],\"name\":\"getPoSValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"owners\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"}],\"name\":\"getRoleAdmin\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"epochIdx\",\"type\":\"uint256\"}],\"name\":\"getValidationEpoch\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"epoch\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"endTime\",\"type\":\"uint256\"},{\"internalType\":\"bytes32[]\",\"name\":\"validationIDs\",\"type\":\"bytes32[]\"}],\"internalType\":\"structCoqnetERC20TokenStakingManager.ValidationEpoch\",\"name\":\"epoch\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"getValidator\",\"outputs\":[{\"components\":[{\"internalType\":\"enumValidatorStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"startingWeight\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"messageNonce\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"startedAt\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"endedAt\",\"type\":\"uint64\"}],\"internalType\":\"structValidator\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"subnetID\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"churnPeriodSeconds\",\"type\":\"uint64\"},{\"internalType\":\"uint8\",\"name\":\"maximumChurnPercentage\",\"type\":\"uint8\"}],\"internalType\":\"structValidatorManagerSettings\",\"name\":\"baseSettings\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"minimumStakeAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maximumStakeAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"minimumStakeDuration\",\"type\":\"uint64\"},{\"internalType\":\"uint16\",\"name\":\"minimumDelegationFeeBips\",\"type\":\"uint16\"},{\"internalType\":\"uint8\",\"name\":\"maximumStakeMultiplier\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"weightToValueFactor\",\"type\":\"uint256\"},{\"internalType\":\"contractIRewardCalculator\",\"name\":\"rewardCalculator\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"uptimeBlockchainID\",\"type\":\"bytes32\"}],\"internalType\":\"structPoSValidatorManagerSettings\",\"name\":\"settings\",\"type\":\"tuple\"},{\"internalType\":\"contractIERC20Mintable\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"admin\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"delegationAmount\",\"type\":\"uint256\"}],\"name\":\"initializeDelegatorRegistration\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"initializeEndDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"},{\"internalType\":\"address\",\"name\":\"rewardRecipient\",\"type\":\"address\"}],\"name\":\"initializeEndDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"},{\"internalType\":\"address\",\"name\":\"rewardRecipient\",\"type\":\"address\"}],\"name\":\"initializeEndValidation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"includeUptimeProof\",\"type\":\"bool\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"initializeEndValidation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"blsPublicKey\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"registrationExpiry\",\"type\":\"uint64\"},{\"components\":[{\"internalType\":\"uint32\",\"name\":\"threshold\",\"type\":\"uint32\"},{\"internalType\":\"address[]\",\"name\":\"addresses\",\"type\":\"address[]\"}],\"internalType\":\"structPChainOwner\",\"name\":\"remainingBalanceOwner\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"uint32\",\"name\":\"threshold\",\"type\":\"uint32\"},{\"internalType\":\"address[]\",\"name\":\"addresses\",\"type\":\"address[]\"}],\"internalType\":\"structPChainOwner\",\"name\":\"disableOwner\",\"type\":\"tuple\"}],\"internalType\":\"structValidatorRegistrationInput\",\"name\":\"registrationInput\",\"type\":\"tuple\"},{\"internalType\":\"uint16\",\"name\":\"delegationFeeBips\",\"type\":\"uint16\"},{\"internalType\":\"uint64\",\"name\":\"minStakeDuration\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"stakeAmount\",\"type\":\"uint256\"}],\"name\":\"initializeValidatorRegistration\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"blsPublicKey\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"registrationExpiry\",\"type\":\"uint64\"},{\"components\":[{\"internalType\":\"uint32\",\"name\":\"threshold\",\"type\":\"uint32\"},{\"internalType\":\"address[]\",\"name\":\"addresses\",\"type\":\"address[]\"}],\"internalType\":\"structPChainOwner\",\"name\":\"remainingBalanceOwner\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"uint32\",\"name\":\"threshold\",\"type\":\"uint32\"},{\"internalType\":\"address[]\",\"name\":\"addresses\",\"type\":\"address[]\"}],\"internalType\":\"structPChainOwner\",\"name\":\"disableOwner\",\"type\":\"tuple\"}],\"internalType\":\"structValidatorRegistrationInput\",\"name\":\"registrationInput\",\"type\":\"tuple\"},{\"internalType\":\"uint16\",\"name\":\"delegationFeeBips\",\"type\":\"uint16\"},{\"internalType\":\"uint64\",\"name\":\"minStakeDuration\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"stakeAmount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"}],\"name\":\"initializeValidatorRegistrationOnBehalfOf\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"subnetID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"validatorManagerBlockchainID\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"validatorManagerAddress\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"blsPublicKey\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"}],\"internalType\":\"structInitialValidator[]\",\"name\":\"initialValidators\",\"type\":\"tuple[]\"}],\"internalType\":\"structConversionData\",\"name\":\"conversionData\",\"type\":\"tuple\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"initializeValidatorSet\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"nodeID\",\"type\":\"bytes\"}],\"name\":\"registeredValidators\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"callerConfirmation\",\"type\":\"address\"}],\"name\":\"renounceRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"resendEndValidatorMessage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"}],\"name\":\"resendRegisterValidatorMessage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"delegationID\",\"type\":\"bytes32\"}],\"name\":\"resendUpdateDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"maxNodesPerValidator\",\"type\":\"uint256\"}],\"name\":\"setMaxNodesPerValidator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"maxValidators\",\"type\":\"uint256\"}],\"name\":\"setMaxValidators\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"validationID\",\"type\":\"bytes32\"},{\"internalType\":\"uint32\",\"name\":\"messageIndex\",\"type\":\"uint32\"}],\"name\":\"submitUptimeProof\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"valueToWeight\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"weight\",\"type\":\"uint64\"}],\"name\":\"weightToValue\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// CoqnetERC20TokenStakingManagerABI is the input ABI used to generate the binding from.
+// Deprecated: Use CoqnetERC20TokenStakingManagerMetaData.ABI instead.
+var CoqnetERC20TokenStakingManagerABI = CoqnetERC20TokenStakingManagerMetaData.ABI
+
+// CoqnetERC20TokenStakingManager is an auto generated Go binding around an Ethereum contract.
+type CoqnetERC20TokenStakingManager struct {
+	CoqnetERC20TokenStakingManagerCaller     // Read-only binding to the contract
+	CoqnetERC20TokenStakingManagerTransactor // Write-only binding to the contract
+	CoqnetERC20TokenStakingManagerFilterer   // Log filterer for contract events
+}
+
+// CoqnetERC20TokenStakingManagerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type CoqnetERC20TokenStakingManagerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CoqnetERC20TokenStakingManagerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type CoqnetERC20TokenStakingManagerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CoqnetERC20TokenStakingManagerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type CoqnetERC20TokenStakingManagerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CoqnetERC20TokenStakingManagerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type CoqnetERC20TokenStakingManagerSession struct {
+	Contract     *CoqnetERC20TokenStakingManager // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts                   // Call options to use throughout this session
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// CoqnetERC20TokenStakingManagerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type CoqnetERC20TokenStakingManagerCallerSession struct {
+	Contract *CoqnetERC20TokenStakingManagerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                         // Call options to use throughout this session
+}
+
+// CoqnetERC20TokenStakingManagerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type CoqnetERC20TokenStakingManagerTransactorSession struct {
+	Contract     *CoqnetERC20TokenStakingManagerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                         // Transaction auth options to use throughout this session
+}
+
+// CoqnetERC20TokenStakingManagerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type CoqnetERC20TokenStakingManagerRaw struct {
+	Contract *CoqnetERC20TokenStakingManager // Generic contract binding to access the raw methods on
+}
+
+// CoqnetERC20TokenStakingManagerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type CoqnetERC20TokenStakingManagerCallerRaw struct {
+	Contract *CoqnetERC20TokenStakingManagerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// CoqnetERC20TokenStakingManagerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type CoqnetERC20TokenStakingManagerTransactorRaw struct {
+	Contract *CoqnetERC20TokenStakingManagerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewCoqnetERC20TokenStakingManager creates a new instance of CoqnetERC20TokenStakingManager, bound to a specific deployed contract.
+func NewCoqnetERC20TokenStakingManager(address common.Address, backend bind.ContractBackend) (*CoqnetERC20TokenStakingManager, error) {
+	contract, err := bindCoqnetERC20TokenStakingManager(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManager{CoqnetERC20TokenStakingManagerCaller: CoqnetERC20TokenStakingManagerCaller{contract: contract}, CoqnetERC20TokenStakingManagerTransactor: CoqnetERC20TokenStakingManagerTransactor{contract: contract}, CoqnetERC20TokenStakingManagerFilterer: CoqnetERC20TokenStakingManagerFilterer{contract: contract}}, nil
+}
+
+// NewCoqnetERC20TokenStakingManagerCaller creates a new read-only instance of CoqnetERC20TokenStakingManager, bound to a specific deployed contract.
+func NewCoqnetERC20TokenStakingManagerCaller(address common.Address, caller bind.ContractCaller) (*CoqnetERC20TokenStakingManagerCaller, error) {
+	contract, err := bindCoqnetERC20TokenStakingManager(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerCaller{contract: contract}, nil
+}
+
+// NewCoqnetERC20TokenStakingManagerTransactor creates a new write-only instance of CoqnetERC20TokenStakingManager, bound to a specific deployed contract.
+func NewCoqnetERC20TokenStakingManagerTransactor(address common.Address, transactor bind.ContractTransactor) (*CoqnetERC20TokenStakingManagerTransactor, error) {
+	contract, err := bindCoqnetERC20TokenStakingManager(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerTransactor{contract: contract}, nil
+}
+
+// NewCoqnetERC20TokenStakingManagerFilterer creates a new log filterer instance of CoqnetERC20TokenStakingManager, bound to a specific deployed contract.
+func NewCoqnetERC20TokenStakingManagerFilterer(address common.Address, filterer bind.ContractFilterer) (*CoqnetERC20TokenStakingManagerFilterer, error) {
+	contract, err := bindCoqnetERC20TokenStakingManager(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerFilterer{contract: contract}, nil
+}
+
+// bindCoqnetERC20TokenStakingManager binds a generic wrapper to an already deployed contract.
+func bindCoqnetERC20TokenStakingManager(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := CoqnetERC20TokenStakingManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CoqnetERC20TokenStakingManager.Contract.CoqnetERC20TokenStakingManagerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CoqnetERC20TokenStakingManagerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CoqnetERC20TokenStakingManagerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CoqnetERC20TokenStakingManager.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.contract.Transact(opts, method, params...)
+}
+
+// ADDRESSLENGTH is a free data retrieval call binding the contract method 0x60305d62.
+//
+// Solidity: function ADDRESS_LENGTH() view returns(uint32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) ADDRESSLENGTH(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "ADDRESS_LENGTH")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// ADDRESSLENGTH is a free data retrieval call binding the contract method 0x60305d62.
+//
+// Solidity: function ADDRESS_LENGTH() view returns(uint32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ADDRESSLENGTH() (uint32, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ADDRESSLENGTH(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// ADDRESSLENGTH is a free data retrieval call binding the contract method 0x60305d62.
+//
+// Solidity: function ADDRESS_LENGTH() view returns(uint32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) ADDRESSLENGTH() (uint32, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ADDRESSLENGTH(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// BIPSCONVERSIONFACTOR is a free data retrieval call binding the contract method 0xa9778a7a.
+//
+// Solidity: function BIPS_CONVERSION_FACTOR() view returns(uint16)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) BIPSCONVERSIONFACTOR(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "BIPS_CONVERSION_FACTOR")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// BIPSCONVERSIONFACTOR is a free data retrieval call binding the contract method 0xa9778a7a.
+//
+// Solidity: function BIPS_CONVERSION_FACTOR() view returns(uint16)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) BIPSCONVERSIONFACTOR() (uint16, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.BIPSCONVERSIONFACTOR(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// BIPSCONVERSIONFACTOR is a free data retrieval call binding the contract method 0xa9778a7a.
+//
+// Solidity: function BIPS_CONVERSION_FACTOR() view returns(uint16)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) BIPSCONVERSIONFACTOR() (uint16, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.BIPSCONVERSIONFACTOR(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// BLSPUBLICKEYLENGTH is a free data retrieval call binding the contract method 0x8280a25a.
+//
+// Solidity: function BLS_PUBLIC_KEY_LENGTH() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) BLSPUBLICKEYLENGTH(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "BLS_PUBLIC_KEY_LENGTH")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// BLSPUBLICKEYLENGTH is a free data retrieval call binding the contract method 0x8280a25a.
+//
+// Solidity: function BLS_PUBLIC_KEY_LENGTH() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) BLSPUBLICKEYLENGTH() (uint8, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.BLSPUBLICKEYLENGTH(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// BLSPUBLICKEYLENGTH is a free data retrieval call binding the contract method 0x8280a25a.
+//
+// Solidity: function BLS_PUBLIC_KEY_LENGTH() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) BLSPUBLICKEYLENGTH() (uint8, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.BLSPUBLICKEYLENGTH(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// COQNETMETRICSSTORAGELOCATION is a free data retrieval call binding the contract method 0xdc090d0f.
+//
+// Solidity: function COQNET_METRICS_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) COQNETMETRICSSTORAGELOCATION(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "COQNET_METRICS_STORAGE_LOCATION")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// COQNETMETRICSSTORAGELOCATION is a free data retrieval call binding the contract method 0xdc090d0f.
+//
+// Solidity: function COQNET_METRICS_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) COQNETMETRICSSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.COQNETMETRICSSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// COQNETMETRICSSTORAGELOCATION is a free data retrieval call binding the contract method 0xdc090d0f.
+//
+// Solidity: function COQNET_METRICS_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) COQNETMETRICSSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.COQNETMETRICSSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) DEFAULTADMINROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "DEFAULT_ADMIN_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) DEFAULTADMINROLE() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.DEFAULTADMINROLE(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) DEFAULTADMINROLE() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.DEFAULTADMINROLE(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// EPOCHDURATION is a free data retrieval call binding the contract method 0xa70b9f0c.
+//
+// Solidity: function EPOCH_DURATION() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) EPOCHDURATION(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "EPOCH_DURATION")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EPOCHDURATION is a free data retrieval call binding the contract method 0xa70b9f0c.
+//
+// Solidity: function EPOCH_DURATION() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) EPOCHDURATION() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.EPOCHDURATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// EPOCHDURATION is a free data retrieval call binding the contract method 0xa70b9f0c.
+//
+// Solidity: function EPOCH_DURATION() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) EPOCHDURATION() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.EPOCHDURATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// ERC20STAKINGMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xe4a63c40.
+//
+// Solidity: function ERC20_STAKING_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) ERC20STAKINGMANAGERSTORAGELOCATION(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "ERC20_STAKING_MANAGER_STORAGE_LOCATION")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ERC20STAKINGMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xe4a63c40.
+//
+// Solidity: function ERC20_STAKING_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ERC20STAKINGMANAGERSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ERC20STAKINGMANAGERSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// ERC20STAKINGMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xe4a63c40.
+//
+// Solidity: function ERC20_STAKING_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) ERC20STAKINGMANAGERSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ERC20STAKINGMANAGERSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMCHURNPERCENTAGELIMIT is a free data retrieval call binding the contract method 0xc974d1b6.
+//
+// Solidity: function MAXIMUM_CHURN_PERCENTAGE_LIMIT() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) MAXIMUMCHURNPERCENTAGELIMIT(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "MAXIMUM_CHURN_PERCENTAGE_LIMIT")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// MAXIMUMCHURNPERCENTAGELIMIT is a free data retrieval call binding the contract method 0xc974d1b6.
+//
+// Solidity: function MAXIMUM_CHURN_PERCENTAGE_LIMIT() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) MAXIMUMCHURNPERCENTAGELIMIT() (uint8, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMCHURNPERCENTAGELIMIT(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMCHURNPERCENTAGELIMIT is a free data retrieval call binding the contract method 0xc974d1b6.
+//
+// Solidity: function MAXIMUM_CHURN_PERCENTAGE_LIMIT() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) MAXIMUMCHURNPERCENTAGELIMIT() (uint8, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMCHURNPERCENTAGELIMIT(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMDELEGATIONFEEBIPS is a free data retrieval call binding the contract method 0x35455ded.
+//
+// Solidity: function MAXIMUM_DELEGATION_FEE_BIPS() view returns(uint16)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) MAXIMUMDELEGATIONFEEBIPS(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "MAXIMUM_DELEGATION_FEE_BIPS")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// MAXIMUMDELEGATIONFEEBIPS is a free data retrieval call binding the contract method 0x35455ded.
+//
+// Solidity: function MAXIMUM_DELEGATION_FEE_BIPS() view returns(uint16)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) MAXIMUMDELEGATIONFEEBIPS() (uint16, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMDELEGATIONFEEBIPS(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMDELEGATIONFEEBIPS is a free data retrieval call binding the contract method 0x35455ded.
+//
+// Solidity: function MAXIMUM_DELEGATION_FEE_BIPS() view returns(uint16)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) MAXIMUMDELEGATIONFEEBIPS() (uint16, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMDELEGATIONFEEBIPS(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMREGISTRATIONEXPIRYLENGTH is a free data retrieval call binding the contract method 0xdf93d8de.
+//
+// Solidity: function MAXIMUM_REGISTRATION_EXPIRY_LENGTH() view returns(uint64)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) MAXIMUMREGISTRATIONEXPIRYLENGTH(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "MAXIMUM_REGISTRATION_EXPIRY_LENGTH")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// MAXIMUMREGISTRATIONEXPIRYLENGTH is a free data retrieval call binding the contract method 0xdf93d8de.
+//
+// Solidity: function MAXIMUM_REGISTRATION_EXPIRY_LENGTH() view returns(uint64)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) MAXIMUMREGISTRATIONEXPIRYLENGTH() (uint64, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMREGISTRATIONEXPIRYLENGTH(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMREGISTRATIONEXPIRYLENGTH is a free data retrieval call binding the contract method 0xdf93d8de.
+//
+// Solidity: function MAXIMUM_REGISTRATION_EXPIRY_LENGTH() view returns(uint64)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) MAXIMUMREGISTRATIONEXPIRYLENGTH() (uint64, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMREGISTRATIONEXPIRYLENGTH(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMSTAKEMULTIPLIERLIMIT is a free data retrieval call binding the contract method 0x151d30d1.
+//
+// Solidity: function MAXIMUM_STAKE_MULTIPLIER_LIMIT() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) MAXIMUMSTAKEMULTIPLIERLIMIT(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "MAXIMUM_STAKE_MULTIPLIER_LIMIT")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// MAXIMUMSTAKEMULTIPLIERLIMIT is a free data retrieval call binding the contract method 0x151d30d1.
+//
+// Solidity: function MAXIMUM_STAKE_MULTIPLIER_LIMIT() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) MAXIMUMSTAKEMULTIPLIERLIMIT() (uint8, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMSTAKEMULTIPLIERLIMIT(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXIMUMSTAKEMULTIPLIERLIMIT is a free data retrieval call binding the contract method 0x151d30d1.
+//
+// Solidity: function MAXIMUM_STAKE_MULTIPLIER_LIMIT() view returns(uint8)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) MAXIMUMSTAKEMULTIPLIERLIMIT() (uint8, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXIMUMSTAKEMULTIPLIERLIMIT(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXEPOCHVALIDATORS is a free data retrieval call binding the contract method 0x6edb0d49.
+//
+// Solidity: function MAX_EPOCH_VALIDATORS() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) MAXEPOCHVALIDATORS(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "MAX_EPOCH_VALIDATORS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXEPOCHVALIDATORS is a free data retrieval call binding the contract method 0x6edb0d49.
+//
+// Solidity: function MAX_EPOCH_VALIDATORS() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) MAXEPOCHVALIDATORS() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXEPOCHVALIDATORS(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// MAXEPOCHVALIDATORS is a free data retrieval call binding the contract method 0x6edb0d49.
+//
+// Solidity: function MAX_EPOCH_VALIDATORS() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) MAXEPOCHVALIDATORS() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.MAXEPOCHVALIDATORS(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// POSVALIDATORMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xafb98096.
+//
+// Solidity: function POS_VALIDATOR_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) POSVALIDATORMANAGERSTORAGELOCATION(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "POS_VALIDATOR_MANAGER_STORAGE_LOCATION")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// POSVALIDATORMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xafb98096.
+//
+// Solidity: function POS_VALIDATOR_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) POSVALIDATORMANAGERSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.POSVALIDATORMANAGERSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// POSVALIDATORMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xafb98096.
+//
+// Solidity: function POS_VALIDATOR_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) POSVALIDATORMANAGERSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.POSVALIDATORMANAGERSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// PCHAINBLOCKCHAINID is a free data retrieval call binding the contract method 0x732214f8.
+//
+// Solidity: function P_CHAIN_BLOCKCHAIN_ID() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) PCHAINBLOCKCHAINID(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "P_CHAIN_BLOCKCHAIN_ID")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// PCHAINBLOCKCHAINID is a free data retrieval call binding the contract method 0x732214f8.
+//
+// Solidity: function P_CHAIN_BLOCKCHAIN_ID() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) PCHAINBLOCKCHAINID() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.PCHAINBLOCKCHAINID(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// PCHAINBLOCKCHAINID is a free data retrieval call binding the contract method 0x732214f8.
+//
+// Solidity: function P_CHAIN_BLOCKCHAIN_ID() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) PCHAINBLOCKCHAINID() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.PCHAINBLOCKCHAINID(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// REGISTERROLE is a free data retrieval call binding the contract method 0x74f53317.
+//
+// Solidity: function REGISTER_ROLE() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) REGISTERROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "REGISTER_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// REGISTERROLE is a free data retrieval call binding the contract method 0x74f53317.
+//
+// Solidity: function REGISTER_ROLE() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) REGISTERROLE() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.REGISTERROLE(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// REGISTERROLE is a free data retrieval call binding the contract method 0x74f53317.
+//
+// Solidity: function REGISTER_ROLE() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) REGISTERROLE() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.REGISTERROLE(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// UPTIMETHRESHOLDPERCENTAGE is a free data retrieval call binding the contract method 0x3bb332dd.
+//
+// Solidity: function UPTIME_THRESHOLD_PERCENTAGE() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) UPTIMETHRESHOLDPERCENTAGE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "UPTIME_THRESHOLD_PERCENTAGE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UPTIMETHRESHOLDPERCENTAGE is a free data retrieval call binding the contract method 0x3bb332dd.
+//
+// Solidity: function UPTIME_THRESHOLD_PERCENTAGE() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) UPTIMETHRESHOLDPERCENTAGE() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.UPTIMETHRESHOLDPERCENTAGE(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// UPTIMETHRESHOLDPERCENTAGE is a free data retrieval call binding the contract method 0x3bb332dd.
+//
+// Solidity: function UPTIME_THRESHOLD_PERCENTAGE() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) UPTIMETHRESHOLDPERCENTAGE() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.UPTIMETHRESHOLDPERCENTAGE(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// VALIDATORMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xbc5fbfec.
+//
+// Solidity: function VALIDATOR_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) VALIDATORMANAGERSTORAGELOCATION(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "VALIDATOR_MANAGER_STORAGE_LOCATION")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// VALIDATORMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xbc5fbfec.
+//
+// Solidity: function VALIDATOR_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) VALIDATORMANAGERSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.VALIDATORMANAGERSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// VALIDATORMANAGERSTORAGELOCATION is a free data retrieval call binding the contract method 0xbc5fbfec.
+//
+// Solidity: function VALIDATOR_MANAGER_STORAGE_LOCATION() view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) VALIDATORMANAGERSTORAGELOCATION() ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.VALIDATORMANAGERSTORAGELOCATION(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// WARPMESSENGER is a free data retrieval call binding the contract method 0xb771b3bc.
+//
+// Solidity: function WARP_MESSENGER() view returns(address)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) WARPMESSENGER(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "WARP_MESSENGER")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// WARPMESSENGER is a free data retrieval call binding the contract method 0xb771b3bc.
+//
+// Solidity: function WARP_MESSENGER() view returns(address)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) WARPMESSENGER() (common.Address, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.WARPMESSENGER(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// WARPMESSENGER is a free data retrieval call binding the contract method 0xb771b3bc.
+//
+// Solidity: function WARP_MESSENGER() view returns(address)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) WARPMESSENGER() (common.Address, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.WARPMESSENGER(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// Erc20 is a free data retrieval call binding the contract method 0x785e9e86.
+//
+// Solidity: function erc20() view returns(address)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) Erc20(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "erc20")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Erc20 is a free data retrieval call binding the contract method 0x785e9e86.
+//
+// Solidity: function erc20() view returns(address)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) Erc20() (common.Address, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.Erc20(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// Erc20 is a free data retrieval call binding the contract method 0x785e9e86.
+//
+// Solidity: function erc20() view returns(address)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) Erc20() (common.Address, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.Erc20(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// GetCurrentEpochIndex is a free data retrieval call binding the contract method 0x23381bd2.
+//
+// Solidity: function getCurrentEpochIndex() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) GetCurrentEpochIndex(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "getCurrentEpochIndex")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetCurrentEpochIndex is a free data retrieval call binding the contract method 0x23381bd2.
+//
+// Solidity: function getCurrentEpochIndex() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) GetCurrentEpochIndex() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetCurrentEpochIndex(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// GetCurrentEpochIndex is a free data retrieval call binding the contract method 0x23381bd2.
+//
+// Solidity: function getCurrentEpochIndex() view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) GetCurrentEpochIndex() (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetCurrentEpochIndex(&_CoqnetERC20TokenStakingManager.CallOpts)
+}
+
+// GetLastValidationID is a free data retrieval call binding the contract method 0xa8fa5fab.
+//
+// Solidity: function getLastValidationID(address validator) view returns(bytes32 epoch)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) GetLastValidationID(opts *bind.CallOpts, validator common.Address) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "getLastValidationID", validator)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetLastValidationID is a free data retrieval call binding the contract method 0xa8fa5fab.
+//
+// Solidity: function getLastValidationID(address validator) view returns(bytes32 epoch)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) GetLastValidationID(validator common.Address) ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetLastValidationID(&_CoqnetERC20TokenStakingManager.CallOpts, validator)
+}
+
+// GetLastValidationID is a free data retrieval call binding the contract method 0xa8fa5fab.
+//
+// Solidity: function getLastValidationID(address validator) view returns(bytes32 epoch)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) GetLastValidationID(validator common.Address) ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetLastValidationID(&_CoqnetERC20TokenStakingManager.CallOpts, validator)
+}
+
+// GetPoSValidators is a free data retrieval call binding the contract method 0x9fd2b0e9.
+//
+// Solidity: function getPoSValidators(bytes32[] validationIDs) view returns(address[] owners)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) GetPoSValidators(opts *bind.CallOpts, validationIDs [][32]byte) ([]common.Address, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "getPoSValidators", validationIDs)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetPoSValidators is a free data retrieval call binding the contract method 0x9fd2b0e9.
+//
+// Solidity: function getPoSValidators(bytes32[] validationIDs) view returns(address[] owners)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) GetPoSValidators(validationIDs [][32]byte) ([]common.Address, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetPoSValidators(&_CoqnetERC20TokenStakingManager.CallOpts, validationIDs)
+}
+
+// GetPoSValidators is a free data retrieval call binding the contract method 0x9fd2b0e9.
+//
+// Solidity: function getPoSValidators(bytes32[] validationIDs) view returns(address[] owners)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) GetPoSValidators(validationIDs [][32]byte) ([]common.Address, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetPoSValidators(&_CoqnetERC20TokenStakingManager.CallOpts, validationIDs)
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) GetRoleAdmin(opts *bind.CallOpts, role [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "getRoleAdmin", role)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) GetRoleAdmin(role [32]byte) ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetRoleAdmin(&_CoqnetERC20TokenStakingManager.CallOpts, role)
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) GetRoleAdmin(role [32]byte) ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetRoleAdmin(&_CoqnetERC20TokenStakingManager.CallOpts, role)
+}
+
+// GetValidationEpoch is a free data retrieval call binding the contract method 0xd7df8e67.
+//
+// Solidity: function getValidationEpoch(uint256 epochIdx) view returns((uint256,uint256,uint256,bytes32[]) epoch)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) GetValidationEpoch(opts *bind.CallOpts, epochIdx *big.Int) (CoqnetERC20TokenStakingManagerValidationEpoch, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "getValidationEpoch", epochIdx)
+
+	if err != nil {
+		return *new(CoqnetERC20TokenStakingManagerValidationEpoch), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(CoqnetERC20TokenStakingManagerValidationEpoch)).(*CoqnetERC20TokenStakingManagerValidationEpoch)
+
+	return out0, err
+
+}
+
+// GetValidationEpoch is a free data retrieval call binding the contract method 0xd7df8e67.
+//
+// Solidity: function getValidationEpoch(uint256 epochIdx) view returns((uint256,uint256,uint256,bytes32[]) epoch)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) GetValidationEpoch(epochIdx *big.Int) (CoqnetERC20TokenStakingManagerValidationEpoch, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetValidationEpoch(&_CoqnetERC20TokenStakingManager.CallOpts, epochIdx)
+}
+
+// GetValidationEpoch is a free data retrieval call binding the contract method 0xd7df8e67.
+//
+// Solidity: function getValidationEpoch(uint256 epochIdx) view returns((uint256,uint256,uint256,bytes32[]) epoch)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) GetValidationEpoch(epochIdx *big.Int) (CoqnetERC20TokenStakingManagerValidationEpoch, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetValidationEpoch(&_CoqnetERC20TokenStakingManager.CallOpts, epochIdx)
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0xd5f20ff6.
+//
+// Solidity: function getValidator(bytes32 validationID) view returns((uint8,bytes,uint64,uint64,uint64,uint64,uint64))
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) GetValidator(opts *bind.CallOpts, validationID [32]byte) (Validator, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "getValidator", validationID)
+
+	if err != nil {
+		return *new(Validator), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(Validator)).(*Validator)
+
+	return out0, err
+
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0xd5f20ff6.
+//
+// Solidity: function getValidator(bytes32 validationID) view returns((uint8,bytes,uint64,uint64,uint64,uint64,uint64))
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) GetValidator(validationID [32]byte) (Validator, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetValidator(&_CoqnetERC20TokenStakingManager.CallOpts, validationID)
+}
+
+// GetValidator is a free data retrieval call binding the contract method 0xd5f20ff6.
+//
+// Solidity: function getValidator(bytes32 validationID) view returns((uint8,bytes,uint64,uint64,uint64,uint64,uint64))
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) GetValidator(validationID [32]byte) (Validator, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GetValidator(&_CoqnetERC20TokenStakingManager.CallOpts, validationID)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) HasRole(opts *bind.CallOpts, role [32]byte, account common.Address) (bool, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "hasRole", role, account)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) HasRole(role [32]byte, account common.Address) (bool, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.HasRole(&_CoqnetERC20TokenStakingManager.CallOpts, role, account)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) HasRole(role [32]byte, account common.Address) (bool, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.HasRole(&_CoqnetERC20TokenStakingManager.CallOpts, role, account)
+}
+
+// RegisteredValidators is a free data retrieval call binding the contract method 0xfd7ac5e7.
+//
+// Solidity: function registeredValidators(bytes nodeID) view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) RegisteredValidators(opts *bind.CallOpts, nodeID []byte) ([32]byte, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "registeredValidators", nodeID)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// RegisteredValidators is a free data retrieval call binding the contract method 0xfd7ac5e7.
+//
+// Solidity: function registeredValidators(bytes nodeID) view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) RegisteredValidators(nodeID []byte) ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.RegisteredValidators(&_CoqnetERC20TokenStakingManager.CallOpts, nodeID)
+}
+
+// RegisteredValidators is a free data retrieval call binding the contract method 0xfd7ac5e7.
+//
+// Solidity: function registeredValidators(bytes nodeID) view returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) RegisteredValidators(nodeID []byte) ([32]byte, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.RegisteredValidators(&_CoqnetERC20TokenStakingManager.CallOpts, nodeID)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "supportsInterface", interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SupportsInterface(&_CoqnetERC20TokenStakingManager.CallOpts, interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SupportsInterface(&_CoqnetERC20TokenStakingManager.CallOpts, interfaceId)
+}
+
+// ValueToWeight is a free data retrieval call binding the contract method 0x2e2194d8.
+//
+// Solidity: function valueToWeight(uint256 value) view returns(uint64)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) ValueToWeight(opts *bind.CallOpts, value *big.Int) (uint64, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "valueToWeight", value)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// ValueToWeight is a free data retrieval call binding the contract method 0x2e2194d8.
+//
+// Solidity: function valueToWeight(uint256 value) view returns(uint64)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ValueToWeight(value *big.Int) (uint64, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ValueToWeight(&_CoqnetERC20TokenStakingManager.CallOpts, value)
+}
+
+// ValueToWeight is a free data retrieval call binding the contract method 0x2e2194d8.
+//
+// Solidity: function valueToWeight(uint256 value) view returns(uint64)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) ValueToWeight(value *big.Int) (uint64, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ValueToWeight(&_CoqnetERC20TokenStakingManager.CallOpts, value)
+}
+
+// WeightToValue is a free data retrieval call binding the contract method 0x62065856.
+//
+// Solidity: function weightToValue(uint64 weight) view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCaller) WeightToValue(opts *bind.CallOpts, weight uint64) (*big.Int, error) {
+	var out []interface{}
+	err := _CoqnetERC20TokenStakingManager.contract.Call(opts, &out, "weightToValue", weight)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// WeightToValue is a free data retrieval call binding the contract method 0x62065856.
+//
+// Solidity: function weightToValue(uint64 weight) view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) WeightToValue(weight uint64) (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.WeightToValue(&_CoqnetERC20TokenStakingManager.CallOpts, weight)
+}
+
+// WeightToValue is a free data retrieval call binding the contract method 0x62065856.
+//
+// Solidity: function weightToValue(uint64 weight) view returns(uint256)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerCallerSession) WeightToValue(weight uint64) (*big.Int, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.WeightToValue(&_CoqnetERC20TokenStakingManager.CallOpts, weight)
+}
+
+// ChangeDelegatorRewardRecipient is a paid mutator transaction binding the contract method 0xfb8b11dd.
+//
+// Solidity: function changeDelegatorRewardRecipient(bytes32 delegationID, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ChangeDelegatorRewardRecipient(opts *bind.TransactOpts, delegationID [32]byte, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "changeDelegatorRewardRecipient", delegationID, rewardRecipient)
+}
+
+// ChangeDelegatorRewardRecipient is a paid mutator transaction binding the contract method 0xfb8b11dd.
+//
+// Solidity: function changeDelegatorRewardRecipient(bytes32 delegationID, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ChangeDelegatorRewardRecipient(delegationID [32]byte, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ChangeDelegatorRewardRecipient(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, rewardRecipient)
+}
+
+// ChangeDelegatorRewardRecipient is a paid mutator transaction binding the contract method 0xfb8b11dd.
+//
+// Solidity: function changeDelegatorRewardRecipient(bytes32 delegationID, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ChangeDelegatorRewardRecipient(delegationID [32]byte, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ChangeDelegatorRewardRecipient(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, rewardRecipient)
+}
+
+// ChangeValidatorRewardRecipient is a paid mutator transaction binding the contract method 0x8ef34c98.
+//
+// Solidity: function changeValidatorRewardRecipient(bytes32 validationID, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ChangeValidatorRewardRecipient(opts *bind.TransactOpts, validationID [32]byte, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "changeValidatorRewardRecipient", validationID, rewardRecipient)
+}
+
+// ChangeValidatorRewardRecipient is a paid mutator transaction binding the contract method 0x8ef34c98.
+//
+// Solidity: function changeValidatorRewardRecipient(bytes32 validationID, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ChangeValidatorRewardRecipient(validationID [32]byte, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ChangeValidatorRewardRecipient(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, rewardRecipient)
+}
+
+// ChangeValidatorRewardRecipient is a paid mutator transaction binding the contract method 0x8ef34c98.
+//
+// Solidity: function changeValidatorRewardRecipient(bytes32 validationID, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ChangeValidatorRewardRecipient(validationID [32]byte, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ChangeValidatorRewardRecipient(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, rewardRecipient)
+}
+
+// ClaimDelegationFees is a paid mutator transaction binding the contract method 0x93e24598.
+//
+// Solidity: function claimDelegationFees(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ClaimDelegationFees(opts *bind.TransactOpts, validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "claimDelegationFees", validationID)
+}
+
+// ClaimDelegationFees is a paid mutator transaction binding the contract method 0x93e24598.
+//
+// Solidity: function claimDelegationFees(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ClaimDelegationFees(validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ClaimDelegationFees(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID)
+}
+
+// ClaimDelegationFees is a paid mutator transaction binding the contract method 0x93e24598.
+//
+// Solidity: function claimDelegationFees(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ClaimDelegationFees(validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ClaimDelegationFees(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID)
+}
+
+// CompleteDelegatorRegistration is a paid mutator transaction binding the contract method 0x60ad7784.
+//
+// Solidity: function completeDelegatorRegistration(bytes32 delegationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) CompleteDelegatorRegistration(opts *bind.TransactOpts, delegationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "completeDelegatorRegistration", delegationID, messageIndex)
+}
+
+// CompleteDelegatorRegistration is a paid mutator transaction binding the contract method 0x60ad7784.
+//
+// Solidity: function completeDelegatorRegistration(bytes32 delegationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) CompleteDelegatorRegistration(delegationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteDelegatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, messageIndex)
+}
+
+// CompleteDelegatorRegistration is a paid mutator transaction binding the contract method 0x60ad7784.
+//
+// Solidity: function completeDelegatorRegistration(bytes32 delegationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) CompleteDelegatorRegistration(delegationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteDelegatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, messageIndex)
+}
+
+// CompleteEndDelegation is a paid mutator transaction binding the contract method 0x80dd672f.
+//
+// Solidity: function completeEndDelegation(bytes32 delegationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) CompleteEndDelegation(opts *bind.TransactOpts, delegationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "completeEndDelegation", delegationID, messageIndex)
+}
+
+// CompleteEndDelegation is a paid mutator transaction binding the contract method 0x80dd672f.
+//
+// Solidity: function completeEndDelegation(bytes32 delegationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) CompleteEndDelegation(delegationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteEndDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, messageIndex)
+}
+
+// CompleteEndDelegation is a paid mutator transaction binding the contract method 0x80dd672f.
+//
+// Solidity: function completeEndDelegation(bytes32 delegationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) CompleteEndDelegation(delegationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteEndDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, messageIndex)
+}
+
+// CompleteEndValidation is a paid mutator transaction binding the contract method 0x467ef06f.
+//
+// Solidity: function completeEndValidation(uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) CompleteEndValidation(opts *bind.TransactOpts, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "completeEndValidation", messageIndex)
+}
+
+// CompleteEndValidation is a paid mutator transaction binding the contract method 0x467ef06f.
+//
+// Solidity: function completeEndValidation(uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) CompleteEndValidation(messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteEndValidation(&_CoqnetERC20TokenStakingManager.TransactOpts, messageIndex)
+}
+
+// CompleteEndValidation is a paid mutator transaction binding the contract method 0x467ef06f.
+//
+// Solidity: function completeEndValidation(uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) CompleteEndValidation(messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteEndValidation(&_CoqnetERC20TokenStakingManager.TransactOpts, messageIndex)
+}
+
+// CompleteValidatorRegistration is a paid mutator transaction binding the contract method 0xa3a65e48.
+//
+// Solidity: function completeValidatorRegistration(uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) CompleteValidatorRegistration(opts *bind.TransactOpts, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "completeValidatorRegistration", messageIndex)
+}
+
+// CompleteValidatorRegistration is a paid mutator transaction binding the contract method 0xa3a65e48.
+//
+// Solidity: function completeValidatorRegistration(uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) CompleteValidatorRegistration(messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteValidatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, messageIndex)
+}
+
+// CompleteValidatorRegistration is a paid mutator transaction binding the contract method 0xa3a65e48.
+//
+// Solidity: function completeValidatorRegistration(uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) CompleteValidatorRegistration(messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.CompleteValidatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, messageIndex)
+}
+
+// ForceInitializeEndDelegation is a paid mutator transaction binding the contract method 0x1ec44724.
+//
+// Solidity: function forceInitializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ForceInitializeEndDelegation(opts *bind.TransactOpts, delegationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "forceInitializeEndDelegation", delegationID, includeUptimeProof, messageIndex)
+}
+
+// ForceInitializeEndDelegation is a paid mutator transaction binding the contract method 0x1ec44724.
+//
+// Solidity: function forceInitializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ForceInitializeEndDelegation(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex)
+}
+
+// ForceInitializeEndDelegation is a paid mutator transaction binding the contract method 0x1ec44724.
+//
+// Solidity: function forceInitializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ForceInitializeEndDelegation(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex)
+}
+
+// ForceInitializeEndDelegation0 is a paid mutator transaction binding the contract method 0x37b9be8f.
+//
+// Solidity: function forceInitializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ForceInitializeEndDelegation0(opts *bind.TransactOpts, delegationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "forceInitializeEndDelegation0", delegationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// ForceInitializeEndDelegation0 is a paid mutator transaction binding the contract method 0x37b9be8f.
+//
+// Solidity: function forceInitializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ForceInitializeEndDelegation0(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndDelegation0(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// ForceInitializeEndDelegation0 is a paid mutator transaction binding the contract method 0x37b9be8f.
+//
+// Solidity: function forceInitializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ForceInitializeEndDelegation0(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndDelegation0(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// ForceInitializeEndValidation is a paid mutator transaction binding the contract method 0x3a1cfff6.
+//
+// Solidity: function forceInitializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ForceInitializeEndValidation(opts *bind.TransactOpts, validationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "forceInitializeEndValidation", validationID, includeUptimeProof, messageIndex)
+}
+
+// ForceInitializeEndValidation is a paid mutator transaction binding the contract method 0x3a1cfff6.
+//
+// Solidity: function forceInitializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ForceInitializeEndValidation(validationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndValidation(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex)
+}
+
+// ForceInitializeEndValidation is a paid mutator transaction binding the contract method 0x3a1cfff6.
+//
+// Solidity: function forceInitializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ForceInitializeEndValidation(validationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndValidation(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex)
+}
+
+// ForceInitializeEndValidation0 is a paid mutator transaction binding the contract method 0x7d8d2f77.
+//
+// Solidity: function forceInitializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ForceInitializeEndValidation0(opts *bind.TransactOpts, validationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "forceInitializeEndValidation0", validationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// ForceInitializeEndValidation0 is a paid mutator transaction binding the contract method 0x7d8d2f77.
+//
+// Solidity: function forceInitializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ForceInitializeEndValidation0(validationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndValidation0(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// ForceInitializeEndValidation0 is a paid mutator transaction binding the contract method 0x7d8d2f77.
+//
+// Solidity: function forceInitializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ForceInitializeEndValidation0(validationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ForceInitializeEndValidation0(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) GrantRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "grantRole", role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GrantRole(&_CoqnetERC20TokenStakingManager.TransactOpts, role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.GrantRole(&_CoqnetERC20TokenStakingManager.TransactOpts, role, account)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xe7bc4f2b.
+//
+// Solidity: function initialize(((bytes32,uint64,uint8),uint256,uint256,uint64,uint16,uint8,uint256,address,bytes32) settings, address token, address admin) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) Initialize(opts *bind.TransactOpts, settings PoSValidatorManagerSettings, token common.Address, admin common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initialize", settings, token, admin)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xe7bc4f2b.
+//
+// Solidity: function initialize(((bytes32,uint64,uint8),uint256,uint256,uint64,uint16,uint8,uint256,address,bytes32) settings, address token, address admin) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) Initialize(settings PoSValidatorManagerSettings, token common.Address, admin common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.Initialize(&_CoqnetERC20TokenStakingManager.TransactOpts, settings, token, admin)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xe7bc4f2b.
+//
+// Solidity: function initialize(((bytes32,uint64,uint8),uint256,uint256,uint64,uint16,uint8,uint256,address,bytes32) settings, address token, address admin) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) Initialize(settings PoSValidatorManagerSettings, token common.Address, admin common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.Initialize(&_CoqnetERC20TokenStakingManager.TransactOpts, settings, token, admin)
+}
+
+// InitializeDelegatorRegistration is a paid mutator transaction binding the contract method 0x9e1bc4ef.
+//
+// Solidity: function initializeDelegatorRegistration(bytes32 validationID, uint256 delegationAmount) returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeDelegatorRegistration(opts *bind.TransactOpts, validationID [32]byte, delegationAmount *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeDelegatorRegistration", validationID, delegationAmount)
+}
+
+// InitializeDelegatorRegistration is a paid mutator transaction binding the contract method 0x9e1bc4ef.
+//
+// Solidity: function initializeDelegatorRegistration(bytes32 validationID, uint256 delegationAmount) returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeDelegatorRegistration(validationID [32]byte, delegationAmount *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeDelegatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, delegationAmount)
+}
+
+// InitializeDelegatorRegistration is a paid mutator transaction binding the contract method 0x9e1bc4ef.
+//
+// Solidity: function initializeDelegatorRegistration(bytes32 validationID, uint256 delegationAmount) returns(bytes32)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeDelegatorRegistration(validationID [32]byte, delegationAmount *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeDelegatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, delegationAmount)
+}
+
+// InitializeEndDelegation is a paid mutator transaction binding the contract method 0x0118acc4.
+//
+// Solidity: function initializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeEndDelegation(opts *bind.TransactOpts, delegationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeEndDelegation", delegationID, includeUptimeProof, messageIndex)
+}
+
+// InitializeEndDelegation is a paid mutator transaction binding the contract method 0x0118acc4.
+//
+// Solidity: function initializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeEndDelegation(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex)
+}
+
+// InitializeEndDelegation is a paid mutator transaction binding the contract method 0x0118acc4.
+//
+// Solidity: function initializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeEndDelegation(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex)
+}
+
+// InitializeEndDelegation0 is a paid mutator transaction binding the contract method 0x9ae06447.
+//
+// Solidity: function initializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeEndDelegation0(opts *bind.TransactOpts, delegationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeEndDelegation0", delegationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// InitializeEndDelegation0 is a paid mutator transaction binding the contract method 0x9ae06447.
+//
+// Solidity: function initializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeEndDelegation0(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndDelegation0(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// InitializeEndDelegation0 is a paid mutator transaction binding the contract method 0x9ae06447.
+//
+// Solidity: function initializeEndDelegation(bytes32 delegationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeEndDelegation0(delegationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndDelegation0(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// InitializeEndValidation is a paid mutator transaction binding the contract method 0x5dd6a6cb.
+//
+// Solidity: function initializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeEndValidation(opts *bind.TransactOpts, validationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeEndValidation", validationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// InitializeEndValidation is a paid mutator transaction binding the contract method 0x5dd6a6cb.
+//
+// Solidity: function initializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeEndValidation(validationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndValidation(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// InitializeEndValidation is a paid mutator transaction binding the contract method 0x5dd6a6cb.
+//
+// Solidity: function initializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex, address rewardRecipient) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeEndValidation(validationID [32]byte, includeUptimeProof bool, messageIndex uint32, rewardRecipient common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndValidation(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex, rewardRecipient)
+}
+
+// InitializeEndValidation0 is a paid mutator transaction binding the contract method 0x76f78621.
+//
+// Solidity: function initializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeEndValidation0(opts *bind.TransactOpts, validationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeEndValidation0", validationID, includeUptimeProof, messageIndex)
+}
+
+// InitializeEndValidation0 is a paid mutator transaction binding the contract method 0x76f78621.
+//
+// Solidity: function initializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeEndValidation0(validationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndValidation0(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex)
+}
+
+// InitializeEndValidation0 is a paid mutator transaction binding the contract method 0x76f78621.
+//
+// Solidity: function initializeEndValidation(bytes32 validationID, bool includeUptimeProof, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeEndValidation0(validationID [32]byte, includeUptimeProof bool, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeEndValidation0(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, includeUptimeProof, messageIndex)
+}
+
+// InitializeValidatorRegistration is a paid mutator transaction binding the contract method 0x4bee0040.
+//
+// Solidity: function initializeValidatorRegistration((bytes,bytes,uint64,(uint32,address[]),(uint32,address[])) registrationInput, uint16 delegationFeeBips, uint64 minStakeDuration, uint256 stakeAmount) returns(bytes32 validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeValidatorRegistration(opts *bind.TransactOpts, registrationInput ValidatorRegistrationInput, delegationFeeBips uint16, minStakeDuration uint64, stakeAmount *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeValidatorRegistration", registrationInput, delegationFeeBips, minStakeDuration, stakeAmount)
+}
+
+// InitializeValidatorRegistration is a paid mutator transaction binding the contract method 0x4bee0040.
+//
+// Solidity: function initializeValidatorRegistration((bytes,bytes,uint64,(uint32,address[]),(uint32,address[])) registrationInput, uint16 delegationFeeBips, uint64 minStakeDuration, uint256 stakeAmount) returns(bytes32 validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeValidatorRegistration(registrationInput ValidatorRegistrationInput, delegationFeeBips uint16, minStakeDuration uint64, stakeAmount *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeValidatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, registrationInput, delegationFeeBips, minStakeDuration, stakeAmount)
+}
+
+// InitializeValidatorRegistration is a paid mutator transaction binding the contract method 0x4bee0040.
+//
+// Solidity: function initializeValidatorRegistration((bytes,bytes,uint64,(uint32,address[]),(uint32,address[])) registrationInput, uint16 delegationFeeBips, uint64 minStakeDuration, uint256 stakeAmount) returns(bytes32 validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeValidatorRegistration(registrationInput ValidatorRegistrationInput, delegationFeeBips uint16, minStakeDuration uint64, stakeAmount *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeValidatorRegistration(&_CoqnetERC20TokenStakingManager.TransactOpts, registrationInput, delegationFeeBips, minStakeDuration, stakeAmount)
+}
+
+// InitializeValidatorRegistrationOnBehalfOf is a paid mutator transaction binding the contract method 0xcc6b8c71.
+//
+// Solidity: function initializeValidatorRegistrationOnBehalfOf((bytes,bytes,uint64,(uint32,address[]),(uint32,address[])) registrationInput, uint16 delegationFeeBips, uint64 minStakeDuration, uint256 stakeAmount, address validator) returns(bytes32 validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeValidatorRegistrationOnBehalfOf(opts *bind.TransactOpts, registrationInput ValidatorRegistrationInput, delegationFeeBips uint16, minStakeDuration uint64, stakeAmount *big.Int, validator common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeValidatorRegistrationOnBehalfOf", registrationInput, delegationFeeBips, minStakeDuration, stakeAmount, validator)
+}
+
+// InitializeValidatorRegistrationOnBehalfOf is a paid mutator transaction binding the contract method 0xcc6b8c71.
+//
+// Solidity: function initializeValidatorRegistrationOnBehalfOf((bytes,bytes,uint64,(uint32,address[]),(uint32,address[])) registrationInput, uint16 delegationFeeBips, uint64 minStakeDuration, uint256 stakeAmount, address validator) returns(bytes32 validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeValidatorRegistrationOnBehalfOf(registrationInput ValidatorRegistrationInput, delegationFeeBips uint16, minStakeDuration uint64, stakeAmount *big.Int, validator common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeValidatorRegistrationOnBehalfOf(&_CoqnetERC20TokenStakingManager.TransactOpts, registrationInput, delegationFeeBips, minStakeDuration, stakeAmount, validator)
+}
+
+// InitializeValidatorRegistrationOnBehalfOf is a paid mutator transaction binding the contract method 0xcc6b8c71.
+//
+// Solidity: function initializeValidatorRegistrationOnBehalfOf((bytes,bytes,uint64,(uint32,address[]),(uint32,address[])) registrationInput, uint16 delegationFeeBips, uint64 minStakeDuration, uint256 stakeAmount, address validator) returns(bytes32 validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeValidatorRegistrationOnBehalfOf(registrationInput ValidatorRegistrationInput, delegationFeeBips uint16, minStakeDuration uint64, stakeAmount *big.Int, validator common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeValidatorRegistrationOnBehalfOf(&_CoqnetERC20TokenStakingManager.TransactOpts, registrationInput, delegationFeeBips, minStakeDuration, stakeAmount, validator)
+}
+
+// InitializeValidatorSet is a paid mutator transaction binding the contract method 0x20d91b7a.
+//
+// Solidity: function initializeValidatorSet((bytes32,bytes32,address,(bytes,bytes,uint64)[]) conversionData, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) InitializeValidatorSet(opts *bind.TransactOpts, conversionData ConversionData, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "initializeValidatorSet", conversionData, messageIndex)
+}
+
+// InitializeValidatorSet is a paid mutator transaction binding the contract method 0x20d91b7a.
+//
+// Solidity: function initializeValidatorSet((bytes32,bytes32,address,(bytes,bytes,uint64)[]) conversionData, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) InitializeValidatorSet(conversionData ConversionData, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeValidatorSet(&_CoqnetERC20TokenStakingManager.TransactOpts, conversionData, messageIndex)
+}
+
+// InitializeValidatorSet is a paid mutator transaction binding the contract method 0x20d91b7a.
+//
+// Solidity: function initializeValidatorSet((bytes32,bytes32,address,(bytes,bytes,uint64)[]) conversionData, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) InitializeValidatorSet(conversionData ConversionData, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.InitializeValidatorSet(&_CoqnetERC20TokenStakingManager.TransactOpts, conversionData, messageIndex)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address callerConfirmation) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) RenounceRole(opts *bind.TransactOpts, role [32]byte, callerConfirmation common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "renounceRole", role, callerConfirmation)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address callerConfirmation) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) RenounceRole(role [32]byte, callerConfirmation common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.RenounceRole(&_CoqnetERC20TokenStakingManager.TransactOpts, role, callerConfirmation)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address callerConfirmation) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) RenounceRole(role [32]byte, callerConfirmation common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.RenounceRole(&_CoqnetERC20TokenStakingManager.TransactOpts, role, callerConfirmation)
+}
+
+// ResendEndValidatorMessage is a paid mutator transaction binding the contract method 0x0322ed98.
+//
+// Solidity: function resendEndValidatorMessage(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ResendEndValidatorMessage(opts *bind.TransactOpts, validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "resendEndValidatorMessage", validationID)
+}
+
+// ResendEndValidatorMessage is a paid mutator transaction binding the contract method 0x0322ed98.
+//
+// Solidity: function resendEndValidatorMessage(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ResendEndValidatorMessage(validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ResendEndValidatorMessage(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID)
+}
+
+// ResendEndValidatorMessage is a paid mutator transaction binding the contract method 0x0322ed98.
+//
+// Solidity: function resendEndValidatorMessage(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ResendEndValidatorMessage(validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ResendEndValidatorMessage(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID)
+}
+
+// ResendRegisterValidatorMessage is a paid mutator transaction binding the contract method 0xbee0a03f.
+//
+// Solidity: function resendRegisterValidatorMessage(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ResendRegisterValidatorMessage(opts *bind.TransactOpts, validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "resendRegisterValidatorMessage", validationID)
+}
+
+// ResendRegisterValidatorMessage is a paid mutator transaction binding the contract method 0xbee0a03f.
+//
+// Solidity: function resendRegisterValidatorMessage(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ResendRegisterValidatorMessage(validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ResendRegisterValidatorMessage(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID)
+}
+
+// ResendRegisterValidatorMessage is a paid mutator transaction binding the contract method 0xbee0a03f.
+//
+// Solidity: function resendRegisterValidatorMessage(bytes32 validationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ResendRegisterValidatorMessage(validationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ResendRegisterValidatorMessage(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID)
+}
+
+// ResendUpdateDelegation is a paid mutator transaction binding the contract method 0xba3a4b97.
+//
+// Solidity: function resendUpdateDelegation(bytes32 delegationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) ResendUpdateDelegation(opts *bind.TransactOpts, delegationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "resendUpdateDelegation", delegationID)
+}
+
+// ResendUpdateDelegation is a paid mutator transaction binding the contract method 0xba3a4b97.
+//
+// Solidity: function resendUpdateDelegation(bytes32 delegationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) ResendUpdateDelegation(delegationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ResendUpdateDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID)
+}
+
+// ResendUpdateDelegation is a paid mutator transaction binding the contract method 0xba3a4b97.
+//
+// Solidity: function resendUpdateDelegation(bytes32 delegationID) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) ResendUpdateDelegation(delegationID [32]byte) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.ResendUpdateDelegation(&_CoqnetERC20TokenStakingManager.TransactOpts, delegationID)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) RevokeRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "revokeRole", role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.RevokeRole(&_CoqnetERC20TokenStakingManager.TransactOpts, role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.RevokeRole(&_CoqnetERC20TokenStakingManager.TransactOpts, role, account)
+}
+
+// SetMaxNodesPerValidator is a paid mutator transaction binding the contract method 0xacb931c5.
+//
+// Solidity: function setMaxNodesPerValidator(uint256 maxNodesPerValidator) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) SetMaxNodesPerValidator(opts *bind.TransactOpts, maxNodesPerValidator *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "setMaxNodesPerValidator", maxNodesPerValidator)
+}
+
+// SetMaxNodesPerValidator is a paid mutator transaction binding the contract method 0xacb931c5.
+//
+// Solidity: function setMaxNodesPerValidator(uint256 maxNodesPerValidator) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) SetMaxNodesPerValidator(maxNodesPerValidator *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SetMaxNodesPerValidator(&_CoqnetERC20TokenStakingManager.TransactOpts, maxNodesPerValidator)
+}
+
+// SetMaxNodesPerValidator is a paid mutator transaction binding the contract method 0xacb931c5.
+//
+// Solidity: function setMaxNodesPerValidator(uint256 maxNodesPerValidator) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) SetMaxNodesPerValidator(maxNodesPerValidator *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SetMaxNodesPerValidator(&_CoqnetERC20TokenStakingManager.TransactOpts, maxNodesPerValidator)
+}
+
+// SetMaxValidators is a paid mutator transaction binding the contract method 0x9bb2ea5a.
+//
+// Solidity: function setMaxValidators(uint256 maxValidators) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) SetMaxValidators(opts *bind.TransactOpts, maxValidators *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "setMaxValidators", maxValidators)
+}
+
+// SetMaxValidators is a paid mutator transaction binding the contract method 0x9bb2ea5a.
+//
+// Solidity: function setMaxValidators(uint256 maxValidators) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) SetMaxValidators(maxValidators *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SetMaxValidators(&_CoqnetERC20TokenStakingManager.TransactOpts, maxValidators)
+}
+
+// SetMaxValidators is a paid mutator transaction binding the contract method 0x9bb2ea5a.
+//
+// Solidity: function setMaxValidators(uint256 maxValidators) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) SetMaxValidators(maxValidators *big.Int) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SetMaxValidators(&_CoqnetERC20TokenStakingManager.TransactOpts, maxValidators)
+}
+
+// SubmitUptimeProof is a paid mutator transaction binding the contract method 0x25e1c776.
+//
+// Solidity: function submitUptimeProof(bytes32 validationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactor) SubmitUptimeProof(opts *bind.TransactOpts, validationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.contract.Transact(opts, "submitUptimeProof", validationID, messageIndex)
+}
+
+// SubmitUptimeProof is a paid mutator transaction binding the contract method 0x25e1c776.
+//
+// Solidity: function submitUptimeProof(bytes32 validationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerSession) SubmitUptimeProof(validationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SubmitUptimeProof(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, messageIndex)
+}
+
+// SubmitUptimeProof is a paid mutator transaction binding the contract method 0x25e1c776.
+//
+// Solidity: function submitUptimeProof(bytes32 validationID, uint32 messageIndex) returns()
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerTransactorSession) SubmitUptimeProof(validationID [32]byte, messageIndex uint32) (*types.Transaction, error) {
+	return _CoqnetERC20TokenStakingManager.Contract.SubmitUptimeProof(&_CoqnetERC20TokenStakingManager.TransactOpts, validationID, messageIndex)
+}
+
+// CoqnetERC20TokenStakingManagerDelegationEndedIterator is returned from FilterDelegationEnded and is used to iterate over the raw logs and unpacked data for DelegationEnded events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegationEndedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerDelegationEnded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerDelegationEndedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerDelegationEnded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerDelegationEnded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerDelegationEndedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerDelegationEndedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerDelegationEnded represents a DelegationEnded event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegationEnded struct {
+	DelegationID [32]byte
+	ValidationID [32]byte
+	Rewards      *big.Int
+	Fees         *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterDelegationEnded is a free log retrieval operation binding the contract event 0x8ececf510070c320d9a55323ffabe350e294ae505fc0c509dc5736da6f5cc993.
+//
+// Solidity: event DelegationEnded(bytes32 indexed delegationID, bytes32 indexed validationID, uint256 rewards, uint256 fees)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterDelegationEnded(opts *bind.FilterOpts, delegationID [][32]byte, validationID [][32]byte) (*CoqnetERC20TokenStakingManagerDelegationEndedIterator, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "DelegationEnded", delegationIDRule, validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerDelegationEndedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "DelegationEnded", logs: logs, sub: sub}, nil
+}
+
+// WatchDelegationEnded is a free log subscription operation binding the contract event 0x8ececf510070c320d9a55323ffabe350e294ae505fc0c509dc5736da6f5cc993.
+//
+// Solidity: event DelegationEnded(bytes32 indexed delegationID, bytes32 indexed validationID, uint256 rewards, uint256 fees)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchDelegationEnded(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerDelegationEnded, delegationID [][32]byte, validationID [][32]byte) (event.Subscription, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "DelegationEnded", delegationIDRule, validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerDelegationEnded)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegationEnded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDelegationEnded is a log parse operation binding the contract event 0x8ececf510070c320d9a55323ffabe350e294ae505fc0c509dc5736da6f5cc993.
+//
+// Solidity: event DelegationEnded(bytes32 indexed delegationID, bytes32 indexed validationID, uint256 rewards, uint256 fees)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseDelegationEnded(log types.Log) (*CoqnetERC20TokenStakingManagerDelegationEnded, error) {
+	event := new(CoqnetERC20TokenStakingManagerDelegationEnded)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegationEnded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerDelegatorAddedIterator is returned from FilterDelegatorAdded and is used to iterate over the raw logs and unpacked data for DelegatorAdded events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegatorAddedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerDelegatorAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerDelegatorAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerDelegatorAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerDelegatorAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerDelegatorAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerDelegatorAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerDelegatorAdded represents a DelegatorAdded event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegatorAdded struct {
+	DelegationID       [32]byte
+	ValidationID       [32]byte
+	DelegatorAddress   common.Address
+	Nonce              uint64
+	ValidatorWeight    uint64
+	DelegatorWeight    uint64
+	SetWeightMessageID [32]byte
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterDelegatorAdded is a free log retrieval operation binding the contract event 0xb0024b263bc3a0b728a6edea50a69efa841189f8d32ee8af9d1c2b1a1a223426.
+//
+// Solidity: event DelegatorAdded(bytes32 indexed delegationID, bytes32 indexed validationID, address indexed delegatorAddress, uint64 nonce, uint64 validatorWeight, uint64 delegatorWeight, bytes32 setWeightMessageID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterDelegatorAdded(opts *bind.FilterOpts, delegationID [][32]byte, validationID [][32]byte, delegatorAddress []common.Address) (*CoqnetERC20TokenStakingManagerDelegatorAddedIterator, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var delegatorAddressRule []interface{}
+	for _, delegatorAddressItem := range delegatorAddress {
+		delegatorAddressRule = append(delegatorAddressRule, delegatorAddressItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "DelegatorAdded", delegationIDRule, validationIDRule, delegatorAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerDelegatorAddedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "DelegatorAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchDelegatorAdded is a free log subscription operation binding the contract event 0xb0024b263bc3a0b728a6edea50a69efa841189f8d32ee8af9d1c2b1a1a223426.
+//
+// Solidity: event DelegatorAdded(bytes32 indexed delegationID, bytes32 indexed validationID, address indexed delegatorAddress, uint64 nonce, uint64 validatorWeight, uint64 delegatorWeight, bytes32 setWeightMessageID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchDelegatorAdded(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerDelegatorAdded, delegationID [][32]byte, validationID [][32]byte, delegatorAddress []common.Address) (event.Subscription, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var delegatorAddressRule []interface{}
+	for _, delegatorAddressItem := range delegatorAddress {
+		delegatorAddressRule = append(delegatorAddressRule, delegatorAddressItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "DelegatorAdded", delegationIDRule, validationIDRule, delegatorAddressRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerDelegatorAdded)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegatorAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDelegatorAdded is a log parse operation binding the contract event 0xb0024b263bc3a0b728a6edea50a69efa841189f8d32ee8af9d1c2b1a1a223426.
+//
+// Solidity: event DelegatorAdded(bytes32 indexed delegationID, bytes32 indexed validationID, address indexed delegatorAddress, uint64 nonce, uint64 validatorWeight, uint64 delegatorWeight, bytes32 setWeightMessageID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseDelegatorAdded(log types.Log) (*CoqnetERC20TokenStakingManagerDelegatorAdded, error) {
+	event := new(CoqnetERC20TokenStakingManagerDelegatorAdded)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegatorAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerDelegatorRegisteredIterator is returned from FilterDelegatorRegistered and is used to iterate over the raw logs and unpacked data for DelegatorRegistered events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegatorRegisteredIterator struct {
+	Event *CoqnetERC20TokenStakingManagerDelegatorRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerDelegatorRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerDelegatorRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerDelegatorRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerDelegatorRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerDelegatorRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerDelegatorRegistered represents a DelegatorRegistered event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegatorRegistered struct {
+	DelegationID [32]byte
+	ValidationID [32]byte
+	StartTime    *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterDelegatorRegistered is a free log retrieval operation binding the contract event 0x047059b465069b8b751836b41f9f1d83daff583d2238cc7fbb461437ec23a4f6.
+//
+// Solidity: event DelegatorRegistered(bytes32 indexed delegationID, bytes32 indexed validationID, uint256 startTime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterDelegatorRegistered(opts *bind.FilterOpts, delegationID [][32]byte, validationID [][32]byte) (*CoqnetERC20TokenStakingManagerDelegatorRegisteredIterator, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "DelegatorRegistered", delegationIDRule, validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerDelegatorRegisteredIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "DelegatorRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchDelegatorRegistered is a free log subscription operation binding the contract event 0x047059b465069b8b751836b41f9f1d83daff583d2238cc7fbb461437ec23a4f6.
+//
+// Solidity: event DelegatorRegistered(bytes32 indexed delegationID, bytes32 indexed validationID, uint256 startTime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchDelegatorRegistered(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerDelegatorRegistered, delegationID [][32]byte, validationID [][32]byte) (event.Subscription, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "DelegatorRegistered", delegationIDRule, validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerDelegatorRegistered)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegatorRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDelegatorRegistered is a log parse operation binding the contract event 0x047059b465069b8b751836b41f9f1d83daff583d2238cc7fbb461437ec23a4f6.
+//
+// Solidity: event DelegatorRegistered(bytes32 indexed delegationID, bytes32 indexed validationID, uint256 startTime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseDelegatorRegistered(log types.Log) (*CoqnetERC20TokenStakingManagerDelegatorRegistered, error) {
+	event := new(CoqnetERC20TokenStakingManagerDelegatorRegistered)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegatorRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerDelegatorRemovalInitializedIterator is returned from FilterDelegatorRemovalInitialized and is used to iterate over the raw logs and unpacked data for DelegatorRemovalInitialized events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegatorRemovalInitializedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerDelegatorRemovalInitializedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerDelegatorRemovalInitializedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerDelegatorRemovalInitializedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized represents a DelegatorRemovalInitialized event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized struct {
+	DelegationID [32]byte
+	ValidationID [32]byte
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterDelegatorRemovalInitialized is a free log retrieval operation binding the contract event 0x366d336c0ab380dc799f095a6f82a26326585c52909cc698b09ba4540709ed57.
+//
+// Solidity: event DelegatorRemovalInitialized(bytes32 indexed delegationID, bytes32 indexed validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterDelegatorRemovalInitialized(opts *bind.FilterOpts, delegationID [][32]byte, validationID [][32]byte) (*CoqnetERC20TokenStakingManagerDelegatorRemovalInitializedIterator, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "DelegatorRemovalInitialized", delegationIDRule, validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerDelegatorRemovalInitializedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "DelegatorRemovalInitialized", logs: logs, sub: sub}, nil
+}
+
+// WatchDelegatorRemovalInitialized is a free log subscription operation binding the contract event 0x366d336c0ab380dc799f095a6f82a26326585c52909cc698b09ba4540709ed57.
+//
+// Solidity: event DelegatorRemovalInitialized(bytes32 indexed delegationID, bytes32 indexed validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchDelegatorRemovalInitialized(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized, delegationID [][32]byte, validationID [][32]byte) (event.Subscription, error) {
+
+	var delegationIDRule []interface{}
+	for _, delegationIDItem := range delegationID {
+		delegationIDRule = append(delegationIDRule, delegationIDItem)
+	}
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "DelegatorRemovalInitialized", delegationIDRule, validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegatorRemovalInitialized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDelegatorRemovalInitialized is a log parse operation binding the contract event 0x366d336c0ab380dc799f095a6f82a26326585c52909cc698b09ba4540709ed57.
+//
+// Solidity: event DelegatorRemovalInitialized(bytes32 indexed delegationID, bytes32 indexed validationID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseDelegatorRemovalInitialized(log types.Log) (*CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized, error) {
+	event := new(CoqnetERC20TokenStakingManagerDelegatorRemovalInitialized)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "DelegatorRemovalInitialized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerInitialValidatorCreatedIterator is returned from FilterInitialValidatorCreated and is used to iterate over the raw logs and unpacked data for InitialValidatorCreated events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerInitialValidatorCreatedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerInitialValidatorCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerInitialValidatorCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerInitialValidatorCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerInitialValidatorCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerInitialValidatorCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerInitialValidatorCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerInitialValidatorCreated represents a InitialValidatorCreated event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerInitialValidatorCreated struct {
+	ValidationID [32]byte
+	Weight       uint64
+	NodeID       []byte
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterInitialValidatorCreated is a free log retrieval operation binding the contract event 0xd80a750132c7a4fb67e226bdfecab0d8609bbb4e48cdad62cee218f25b758fff.
+//
+// Solidity: event InitialValidatorCreated(bytes32 indexed validationID, uint64 weight, bytes nodeID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterInitialValidatorCreated(opts *bind.FilterOpts, validationID [][32]byte) (*CoqnetERC20TokenStakingManagerInitialValidatorCreatedIterator, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "InitialValidatorCreated", validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerInitialValidatorCreatedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "InitialValidatorCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchInitialValidatorCreated is a free log subscription operation binding the contract event 0xd80a750132c7a4fb67e226bdfecab0d8609bbb4e48cdad62cee218f25b758fff.
+//
+// Solidity: event InitialValidatorCreated(bytes32 indexed validationID, uint64 weight, bytes nodeID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchInitialValidatorCreated(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerInitialValidatorCreated, validationID [][32]byte) (event.Subscription, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "InitialValidatorCreated", validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerInitialValidatorCreated)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "InitialValidatorCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseInitialValidatorCreated is a log parse operation binding the contract event 0xd80a750132c7a4fb67e226bdfecab0d8609bbb4e48cdad62cee218f25b758fff.
+//
+// Solidity: event InitialValidatorCreated(bytes32 indexed validationID, uint64 weight, bytes nodeID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseInitialValidatorCreated(log types.Log) (*CoqnetERC20TokenStakingManagerInitialValidatorCreated, error) {
+	event := new(CoqnetERC20TokenStakingManagerInitialValidatorCreated)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "InitialValidatorCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerInitializedIterator is returned from FilterInitialized and is used to iterate over the raw logs and unpacked data for Initialized events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerInitializedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerInitialized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerInitializedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerInitialized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerInitialized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerInitializedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerInitializedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerInitialized represents a Initialized event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerInitialized struct {
+	Version uint64
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterInitialized is a free log retrieval operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterInitialized(opts *bind.FilterOpts) (*CoqnetERC20TokenStakingManagerInitializedIterator, error) {
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerInitializedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "Initialized", logs: logs, sub: sub}, nil
+}
+
+// WatchInitialized is a free log subscription operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchInitialized(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerInitialized) (event.Subscription, error) {
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerInitialized)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "Initialized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseInitialized is a log parse operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseInitialized(log types.Log) (*CoqnetERC20TokenStakingManagerInitialized, error) {
+	event := new(CoqnetERC20TokenStakingManagerInitialized)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "Initialized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerRoleAdminChangedIterator is returned from FilterRoleAdminChanged and is used to iterate over the raw logs and unpacked data for RoleAdminChanged events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerRoleAdminChangedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerRoleAdminChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerRoleAdminChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerRoleAdminChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerRoleAdminChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerRoleAdminChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerRoleAdminChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerRoleAdminChanged represents a RoleAdminChanged event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerRoleAdminChanged struct {
+	Role              [32]byte
+	PreviousAdminRole [32]byte
+	NewAdminRole      [32]byte
+	Raw               types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleAdminChanged is a free log retrieval operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterRoleAdminChanged(opts *bind.FilterOpts, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (*CoqnetERC20TokenStakingManagerRoleAdminChangedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var previousAdminRoleRule []interface{}
+	for _, previousAdminRoleItem := range previousAdminRole {
+		previousAdminRoleRule = append(previousAdminRoleRule, previousAdminRoleItem)
+	}
+	var newAdminRoleRule []interface{}
+	for _, newAdminRoleItem := range newAdminRole {
+		newAdminRoleRule = append(newAdminRoleRule, newAdminRoleItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "RoleAdminChanged", roleRule, previousAdminRoleRule, newAdminRoleRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerRoleAdminChangedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "RoleAdminChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleAdminChanged is a free log subscription operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchRoleAdminChanged(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerRoleAdminChanged, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var previousAdminRoleRule []interface{}
+	for _, previousAdminRoleItem := range previousAdminRole {
+		previousAdminRoleRule = append(previousAdminRoleRule, previousAdminRoleItem)
+	}
+	var newAdminRoleRule []interface{}
+	for _, newAdminRoleItem := range newAdminRole {
+		newAdminRoleRule = append(newAdminRoleRule, newAdminRoleItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "RoleAdminChanged", roleRule, previousAdminRoleRule, newAdminRoleRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerRoleAdminChanged)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "RoleAdminChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleAdminChanged is a log parse operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseRoleAdminChanged(log types.Log) (*CoqnetERC20TokenStakingManagerRoleAdminChanged, error) {
+	event := new(CoqnetERC20TokenStakingManagerRoleAdminChanged)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "RoleAdminChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerRoleGrantedIterator is returned from FilterRoleGranted and is used to iterate over the raw logs and unpacked data for RoleGranted events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerRoleGrantedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerRoleGranted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerRoleGrantedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerRoleGranted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerRoleGranted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerRoleGrantedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerRoleGrantedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerRoleGranted represents a RoleGranted event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerRoleGranted struct {
+	Role    [32]byte
+	Account common.Address
+	Sender  common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleGranted is a free log retrieval operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterRoleGranted(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*CoqnetERC20TokenStakingManagerRoleGrantedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "RoleGranted", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerRoleGrantedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "RoleGranted", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleGranted is a free log subscription operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchRoleGranted(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerRoleGranted, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "RoleGranted", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerRoleGranted)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleGranted is a log parse operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseRoleGranted(log types.Log) (*CoqnetERC20TokenStakingManagerRoleGranted, error) {
+	event := new(CoqnetERC20TokenStakingManagerRoleGranted)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerRoleRevokedIterator is returned from FilterRoleRevoked and is used to iterate over the raw logs and unpacked data for RoleRevoked events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerRoleRevokedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerRoleRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerRoleRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerRoleRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerRoleRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerRoleRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerRoleRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerRoleRevoked represents a RoleRevoked event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerRoleRevoked struct {
+	Role    [32]byte
+	Account common.Address
+	Sender  common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleRevoked is a free log retrieval operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterRoleRevoked(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*CoqnetERC20TokenStakingManagerRoleRevokedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "RoleRevoked", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerRoleRevokedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "RoleRevoked", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleRevoked is a free log subscription operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchRoleRevoked(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerRoleRevoked, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "RoleRevoked", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerRoleRevoked)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleRevoked is a log parse operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseRoleRevoked(log types.Log) (*CoqnetERC20TokenStakingManagerRoleRevoked, error) {
+	event := new(CoqnetERC20TokenStakingManagerRoleRevoked)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerUptimeUpdatedIterator is returned from FilterUptimeUpdated and is used to iterate over the raw logs and unpacked data for UptimeUpdated events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerUptimeUpdatedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerUptimeUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerUptimeUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerUptimeUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerUptimeUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerUptimeUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerUptimeUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerUptimeUpdated represents a UptimeUpdated event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerUptimeUpdated struct {
+	ValidationID [32]byte
+	Uptime       uint64
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterUptimeUpdated is a free log retrieval operation binding the contract event 0xec44148e8ff271f2d0bacef1142154abacb0abb3a29eb3eb50e2ca97e86d0435.
+//
+// Solidity: event UptimeUpdated(bytes32 indexed validationID, uint64 uptime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterUptimeUpdated(opts *bind.FilterOpts, validationID [][32]byte) (*CoqnetERC20TokenStakingManagerUptimeUpdatedIterator, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "UptimeUpdated", validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerUptimeUpdatedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "UptimeUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchUptimeUpdated is a free log subscription operation binding the contract event 0xec44148e8ff271f2d0bacef1142154abacb0abb3a29eb3eb50e2ca97e86d0435.
+//
+// Solidity: event UptimeUpdated(bytes32 indexed validationID, uint64 uptime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchUptimeUpdated(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerUptimeUpdated, validationID [][32]byte) (event.Subscription, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "UptimeUpdated", validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerUptimeUpdated)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "UptimeUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUptimeUpdated is a log parse operation binding the contract event 0xec44148e8ff271f2d0bacef1142154abacb0abb3a29eb3eb50e2ca97e86d0435.
+//
+// Solidity: event UptimeUpdated(bytes32 indexed validationID, uint64 uptime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseUptimeUpdated(log types.Log) (*CoqnetERC20TokenStakingManagerUptimeUpdated, error) {
+	event := new(CoqnetERC20TokenStakingManagerUptimeUpdated)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "UptimeUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerValidationPeriodCreatedIterator is returned from FilterValidationPeriodCreated and is used to iterate over the raw logs and unpacked data for ValidationPeriodCreated events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidationPeriodCreatedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerValidationPeriodCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerValidationPeriodCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerValidationPeriodCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerValidationPeriodCreated represents a ValidationPeriodCreated event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidationPeriodCreated struct {
+	ValidationID                [32]byte
+	RegisterValidationMessageID [32]byte
+	Weight                      uint64
+	NodeID                      []byte
+	RegistrationExpiry          uint64
+	Raw                         types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidationPeriodCreated is a free log retrieval operation binding the contract event 0xe093cab27f3fc60439e008f3a2073310d3ac9ac9b7d59e53a06b2588f7498bd6.
+//
+// Solidity: event ValidationPeriodCreated(bytes32 indexed validationID, bytes32 indexed registerValidationMessageID, uint64 weight, bytes nodeID, uint64 registrationExpiry)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterValidationPeriodCreated(opts *bind.FilterOpts, validationID [][32]byte, registerValidationMessageID [][32]byte) (*CoqnetERC20TokenStakingManagerValidationPeriodCreatedIterator, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var registerValidationMessageIDRule []interface{}
+	for _, registerValidationMessageIDItem := range registerValidationMessageID {
+		registerValidationMessageIDRule = append(registerValidationMessageIDRule, registerValidationMessageIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "ValidationPeriodCreated", validationIDRule, registerValidationMessageIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerValidationPeriodCreatedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "ValidationPeriodCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchValidationPeriodCreated is a free log subscription operation binding the contract event 0xe093cab27f3fc60439e008f3a2073310d3ac9ac9b7d59e53a06b2588f7498bd6.
+//
+// Solidity: event ValidationPeriodCreated(bytes32 indexed validationID, bytes32 indexed registerValidationMessageID, uint64 weight, bytes nodeID, uint64 registrationExpiry)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchValidationPeriodCreated(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerValidationPeriodCreated, validationID [][32]byte, registerValidationMessageID [][32]byte) (event.Subscription, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var registerValidationMessageIDRule []interface{}
+	for _, registerValidationMessageIDItem := range registerValidationMessageID {
+		registerValidationMessageIDRule = append(registerValidationMessageIDRule, registerValidationMessageIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "ValidationPeriodCreated", validationIDRule, registerValidationMessageIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerValidationPeriodCreated)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidationPeriodCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidationPeriodCreated is a log parse operation binding the contract event 0xe093cab27f3fc60439e008f3a2073310d3ac9ac9b7d59e53a06b2588f7498bd6.
+//
+// Solidity: event ValidationPeriodCreated(bytes32 indexed validationID, bytes32 indexed registerValidationMessageID, uint64 weight, bytes nodeID, uint64 registrationExpiry)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseValidationPeriodCreated(log types.Log) (*CoqnetERC20TokenStakingManagerValidationPeriodCreated, error) {
+	event := new(CoqnetERC20TokenStakingManagerValidationPeriodCreated)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidationPeriodCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerValidationPeriodEndedIterator is returned from FilterValidationPeriodEnded and is used to iterate over the raw logs and unpacked data for ValidationPeriodEnded events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidationPeriodEndedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerValidationPeriodEnded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodEndedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerValidationPeriodEnded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerValidationPeriodEnded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodEndedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodEndedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerValidationPeriodEnded represents a ValidationPeriodEnded event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidationPeriodEnded struct {
+	ValidationID [32]byte
+	Status       uint8
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidationPeriodEnded is a free log retrieval operation binding the contract event 0x1c08e59656f1a18dc2da76826cdc52805c43e897a17c50faefb8ab3c1526cc16.
+//
+// Solidity: event ValidationPeriodEnded(bytes32 indexed validationID, uint8 indexed status)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterValidationPeriodEnded(opts *bind.FilterOpts, validationID [][32]byte, status []uint8) (*CoqnetERC20TokenStakingManagerValidationPeriodEndedIterator, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var statusRule []interface{}
+	for _, statusItem := range status {
+		statusRule = append(statusRule, statusItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "ValidationPeriodEnded", validationIDRule, statusRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerValidationPeriodEndedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "ValidationPeriodEnded", logs: logs, sub: sub}, nil
+}
+
+// WatchValidationPeriodEnded is a free log subscription operation binding the contract event 0x1c08e59656f1a18dc2da76826cdc52805c43e897a17c50faefb8ab3c1526cc16.
+//
+// Solidity: event ValidationPeriodEnded(bytes32 indexed validationID, uint8 indexed status)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchValidationPeriodEnded(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerValidationPeriodEnded, validationID [][32]byte, status []uint8) (event.Subscription, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var statusRule []interface{}
+	for _, statusItem := range status {
+		statusRule = append(statusRule, statusItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "ValidationPeriodEnded", validationIDRule, statusRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerValidationPeriodEnded)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidationPeriodEnded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidationPeriodEnded is a log parse operation binding the contract event 0x1c08e59656f1a18dc2da76826cdc52805c43e897a17c50faefb8ab3c1526cc16.
+//
+// Solidity: event ValidationPeriodEnded(bytes32 indexed validationID, uint8 indexed status)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseValidationPeriodEnded(log types.Log) (*CoqnetERC20TokenStakingManagerValidationPeriodEnded, error) {
+	event := new(CoqnetERC20TokenStakingManagerValidationPeriodEnded)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidationPeriodEnded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerValidationPeriodRegisteredIterator is returned from FilterValidationPeriodRegistered and is used to iterate over the raw logs and unpacked data for ValidationPeriodRegistered events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidationPeriodRegisteredIterator struct {
+	Event *CoqnetERC20TokenStakingManagerValidationPeriodRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerValidationPeriodRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerValidationPeriodRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerValidationPeriodRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerValidationPeriodRegistered represents a ValidationPeriodRegistered event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidationPeriodRegistered struct {
+	ValidationID [32]byte
+	Weight       uint64
+	Timestamp    *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidationPeriodRegistered is a free log retrieval operation binding the contract event 0x8629ec2bfd8d3b792ba269096bb679e08f20ba2caec0785ef663cf94788e349b.
+//
+// Solidity: event ValidationPeriodRegistered(bytes32 indexed validationID, uint64 weight, uint256 timestamp)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterValidationPeriodRegistered(opts *bind.FilterOpts, validationID [][32]byte) (*CoqnetERC20TokenStakingManagerValidationPeriodRegisteredIterator, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "ValidationPeriodRegistered", validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerValidationPeriodRegisteredIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "ValidationPeriodRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchValidationPeriodRegistered is a free log subscription operation binding the contract event 0x8629ec2bfd8d3b792ba269096bb679e08f20ba2caec0785ef663cf94788e349b.
+//
+// Solidity: event ValidationPeriodRegistered(bytes32 indexed validationID, uint64 weight, uint256 timestamp)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchValidationPeriodRegistered(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerValidationPeriodRegistered, validationID [][32]byte) (event.Subscription, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "ValidationPeriodRegistered", validationIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerValidationPeriodRegistered)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidationPeriodRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidationPeriodRegistered is a log parse operation binding the contract event 0x8629ec2bfd8d3b792ba269096bb679e08f20ba2caec0785ef663cf94788e349b.
+//
+// Solidity: event ValidationPeriodRegistered(bytes32 indexed validationID, uint64 weight, uint256 timestamp)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseValidationPeriodRegistered(log types.Log) (*CoqnetERC20TokenStakingManagerValidationPeriodRegistered, error) {
+	event := new(CoqnetERC20TokenStakingManagerValidationPeriodRegistered)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidationPeriodRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerValidatorRemovalInitializedIterator is returned from FilterValidatorRemovalInitialized and is used to iterate over the raw logs and unpacked data for ValidatorRemovalInitialized events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidatorRemovalInitializedIterator struct {
+	Event *CoqnetERC20TokenStakingManagerValidatorRemovalInitialized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerValidatorRemovalInitializedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerValidatorRemovalInitialized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerValidatorRemovalInitialized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerValidatorRemovalInitializedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerValidatorRemovalInitializedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerValidatorRemovalInitialized represents a ValidatorRemovalInitialized event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidatorRemovalInitialized struct {
+	ValidationID       [32]byte
+	SetWeightMessageID [32]byte
+	Weight             uint64
+	EndTime            *big.Int
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidatorRemovalInitialized is a free log retrieval operation binding the contract event 0xfbfc4c00cddda774e9bce93712e29d12887b46526858a1afb0937cce8c30fa42.
+//
+// Solidity: event ValidatorRemovalInitialized(bytes32 indexed validationID, bytes32 indexed setWeightMessageID, uint64 weight, uint256 endTime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterValidatorRemovalInitialized(opts *bind.FilterOpts, validationID [][32]byte, setWeightMessageID [][32]byte) (*CoqnetERC20TokenStakingManagerValidatorRemovalInitializedIterator, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var setWeightMessageIDRule []interface{}
+	for _, setWeightMessageIDItem := range setWeightMessageID {
+		setWeightMessageIDRule = append(setWeightMessageIDRule, setWeightMessageIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "ValidatorRemovalInitialized", validationIDRule, setWeightMessageIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerValidatorRemovalInitializedIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "ValidatorRemovalInitialized", logs: logs, sub: sub}, nil
+}
+
+// WatchValidatorRemovalInitialized is a free log subscription operation binding the contract event 0xfbfc4c00cddda774e9bce93712e29d12887b46526858a1afb0937cce8c30fa42.
+//
+// Solidity: event ValidatorRemovalInitialized(bytes32 indexed validationID, bytes32 indexed setWeightMessageID, uint64 weight, uint256 endTime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchValidatorRemovalInitialized(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerValidatorRemovalInitialized, validationID [][32]byte, setWeightMessageID [][32]byte) (event.Subscription, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var setWeightMessageIDRule []interface{}
+	for _, setWeightMessageIDItem := range setWeightMessageID {
+		setWeightMessageIDRule = append(setWeightMessageIDRule, setWeightMessageIDItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "ValidatorRemovalInitialized", validationIDRule, setWeightMessageIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerValidatorRemovalInitialized)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidatorRemovalInitialized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidatorRemovalInitialized is a log parse operation binding the contract event 0xfbfc4c00cddda774e9bce93712e29d12887b46526858a1afb0937cce8c30fa42.
+//
+// Solidity: event ValidatorRemovalInitialized(bytes32 indexed validationID, bytes32 indexed setWeightMessageID, uint64 weight, uint256 endTime)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseValidatorRemovalInitialized(log types.Log) (*CoqnetERC20TokenStakingManagerValidatorRemovalInitialized, error) {
+	event := new(CoqnetERC20TokenStakingManagerValidatorRemovalInitialized)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidatorRemovalInitialized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CoqnetERC20TokenStakingManagerValidatorWeightUpdateIterator is returned from FilterValidatorWeightUpdate and is used to iterate over the raw logs and unpacked data for ValidatorWeightUpdate events raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidatorWeightUpdateIterator struct {
+	Event *CoqnetERC20TokenStakingManagerValidatorWeightUpdate // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CoqnetERC20TokenStakingManagerValidatorWeightUpdateIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CoqnetERC20TokenStakingManagerValidatorWeightUpdate)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CoqnetERC20TokenStakingManagerValidatorWeightUpdate)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CoqnetERC20TokenStakingManagerValidatorWeightUpdateIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CoqnetERC20TokenStakingManagerValidatorWeightUpdateIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CoqnetERC20TokenStakingManagerValidatorWeightUpdate represents a ValidatorWeightUpdate event raised by the CoqnetERC20TokenStakingManager contract.
+type CoqnetERC20TokenStakingManagerValidatorWeightUpdate struct {
+	ValidationID       [32]byte
+	Nonce              uint64
+	Weight             uint64
+	SetWeightMessageID [32]byte
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidatorWeightUpdate is a free log retrieval operation binding the contract event 0x07de5ff35a674a8005e661f3333c907ca6333462808762d19dc7b3abb1a8c1df.
+//
+// Solidity: event ValidatorWeightUpdate(bytes32 indexed validationID, uint64 indexed nonce, uint64 weight, bytes32 setWeightMessageID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) FilterValidatorWeightUpdate(opts *bind.FilterOpts, validationID [][32]byte, nonce []uint64) (*CoqnetERC20TokenStakingManagerValidatorWeightUpdateIterator, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.FilterLogs(opts, "ValidatorWeightUpdate", validationIDRule, nonceRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CoqnetERC20TokenStakingManagerValidatorWeightUpdateIterator{contract: _CoqnetERC20TokenStakingManager.contract, event: "ValidatorWeightUpdate", logs: logs, sub: sub}, nil
+}
+
+// WatchValidatorWeightUpdate is a free log subscription operation binding the contract event 0x07de5ff35a674a8005e661f3333c907ca6333462808762d19dc7b3abb1a8c1df.
+//
+// Solidity: event ValidatorWeightUpdate(bytes32 indexed validationID, uint64 indexed nonce, uint64 weight, bytes32 setWeightMessageID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) WatchValidatorWeightUpdate(opts *bind.WatchOpts, sink chan<- *CoqnetERC20TokenStakingManagerValidatorWeightUpdate, validationID [][32]byte, nonce []uint64) (event.Subscription, error) {
+
+	var validationIDRule []interface{}
+	for _, validationIDItem := range validationID {
+		validationIDRule = append(validationIDRule, validationIDItem)
+	}
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+
+	logs, sub, err := _CoqnetERC20TokenStakingManager.contract.WatchLogs(opts, "ValidatorWeightUpdate", validationIDRule, nonceRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CoqnetERC20TokenStakingManagerValidatorWeightUpdate)
+				if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidatorWeightUpdate", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidatorWeightUpdate is a log parse operation binding the contract event 0x07de5ff35a674a8005e661f3333c907ca6333462808762d19dc7b3abb1a8c1df.
+//
+// Solidity: event ValidatorWeightUpdate(bytes32 indexed validationID, uint64 indexed nonce, uint64 weight, bytes32 setWeightMessageID)
+func (_CoqnetERC20TokenStakingManager *CoqnetERC20TokenStakingManagerFilterer) ParseValidatorWeightUpdate(log types.Log) (*CoqnetERC20TokenStakingManagerValidatorWeightUpdate, error) {
+	event := new(CoqnetERC20TokenStakingManagerValidatorWeightUpdate)
+	if err := _CoqnetERC20TokenStakingManager.contract.UnpackLog(event, "ValidatorWeightUpdate", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
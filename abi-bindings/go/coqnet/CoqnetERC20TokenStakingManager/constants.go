@@ -0,0 +1,44 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package coqneterc20tokenstakingmanager
+
+import "time"
+
+// Mirrors of the constants defined in CoqnetERC20TokenStakingManager.sol.
+// These must be kept up-to-date with the contract.
+const (
+	UptimeThresholdPercentage = 80
+	MaxEpochValidators        = 5
+	EpochDuration             = 30 * 24 * time.Hour
+)
+
+// ValidatorStatus mirrors the ValidatorStatus enum defined in IValidatorManager.sol
+type ValidatorStatus uint8
+
+const (
+	Unknown ValidatorStatus = iota
+	PendingAdded
+	Active
+	PendingRemoved
+	Completed
+	Invalidated
+)
+
+// String returns the name of the status as defined in IValidatorManager.sol
+func (s ValidatorStatus) String() string {
+	switch s {
+	case PendingAdded:
+		return "PendingAdded"
+	case Active:
+		return "Active"
+	case PendingRemoved:
+		return "PendingRemoved"
+	case Completed:
+		return "Completed"
+	case Invalidated:
+		return "Invalidated"
+	default:
+		return "Unknown"
+	}
+}
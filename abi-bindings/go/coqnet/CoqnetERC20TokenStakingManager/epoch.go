@@ -0,0 +1,151 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package coqneterc20tokenstakingmanager
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// EpochCaller is the subset of the CoqnetERC20TokenStakingManager read-only methods
+// used to query the validation epoch model. It is satisfied by both
+// *CoqnetERC20TokenStakingManager and *CoqnetERC20TokenStakingManagerCaller.
+type EpochCaller interface {
+	GetValidationEpoch(opts *bind.CallOpts, epochIdx *big.Int) (CoqnetERC20TokenStakingManagerValidationEpoch, error)
+	GetCurrentEpochIndex(opts *bind.CallOpts) (*big.Int, error)
+	GetLastValidationID(opts *bind.CallOpts, validator common.Address) ([32]byte, error)
+	GetPoSValidators(opts *bind.CallOpts, validationIDs [][32]byte) ([]common.Address, error)
+}
+
+// ValidationEpoch is a typed view of the ValidationEpoch struct stored by the contract.
+type ValidationEpoch struct {
+	Index         uint64
+	StartTime     time.Time
+	EndTime       time.Time
+	ValidationIDs []ids.ID
+}
+
+// EpochValidator pairs a validation ID with the address that owns it.
+type EpochValidator struct {
+	ValidationID ids.ID
+	Owner        common.Address
+}
+
+// Started returns true if the epoch has been opened by a validator registration.
+// Epochs that were never opened are returned by the contract with zeroed fields.
+func (e *ValidationEpoch) Started() bool {
+	return e.StartTime.Unix() > 0
+}
+
+// Ended returns true if the epoch is over at time t, in which case the next
+// registration opens a new epoch.
+func (e *ValidationEpoch) Ended(t time.Time) bool {
+	return !e.Started() || !t.Before(e.EndTime)
+}
+
+// Contains returns true if validationID is part of the epoch's active validation IDs.
+func (e *ValidationEpoch) Contains(validationID ids.ID) bool {
+	for _, id := range e.ValidationIDs {
+		if id == validationID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCurrentEpochIndex returns the index of the most recently opened epoch.
+func GetCurrentEpochIndex(opts *bind.CallOpts, caller EpochCaller) (uint64, error) {
+	idx, err := caller.GetCurrentEpochIndex(opts)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get current epoch index")
+	}
+	if !idx.IsUint64() {
+		return 0, errors.Errorf("epoch index %s overflows uint64", idx)
+	}
+	return idx.Uint64(), nil
+}
+
+// GetValidationEpoch returns the epoch stored at epochIdx.
+func GetValidationEpoch(opts *bind.CallOpts, caller EpochCaller, epochIdx uint64) (*ValidationEpoch, error) {
+	raw, err := caller.GetValidationEpoch(opts, new(big.Int).SetUint64(epochIdx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get validation epoch %d", epochIdx)
+	}
+	return toValidationEpoch(raw)
+}
+
+// GetCurrentValidationEpoch returns the most recently opened epoch.
+func GetCurrentValidationEpoch(opts *bind.CallOpts, caller EpochCaller) (*ValidationEpoch, error) {
+	idx, err := GetCurrentEpochIndex(opts, caller)
+	if err != nil {
+		return nil, err
+	}
+	return GetValidationEpoch(opts, caller, idx)
+}
+
+// GetLastValidationID returns the validation ID most recently registered for validator,
+// or ids.Empty if the validator has never registered.
+func GetLastValidationID(opts *bind.CallOpts, caller EpochCaller, validator common.Address) (ids.ID, error) {
+	validationID, err := caller.GetLastValidationID(opts, validator)
+	if err != nil {
+		return ids.Empty, errors.Wrapf(err, "failed to get last validation ID of %s", validator)
+	}
+	return ids.ID(validationID), nil
+}
+
+// GetPoSValidators returns the owner of each of the given validation IDs, in order.
+// Validation IDs that are unknown to the contract have the zero address as owner.
+func GetPoSValidators(opts *bind.CallOpts, caller EpochCaller, validationIDs []ids.ID) ([]EpochValidator, error) {
+	rawIDs := make([][32]byte, len(validationIDs))
+	for i, id := range validationIDs {
+		rawIDs[i] = id
+	}
+	owners, err := caller.GetPoSValidators(opts, rawIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get PoS validators")
+	}
+	if len(owners) != len(validationIDs) {
+		return nil, errors.Errorf("expected %d owners, got %d", len(validationIDs), len(owners))
+	}
+	validators := make([]EpochValidator, len(validationIDs))
+	for i, id := range validationIDs {
+		validators[i] = EpochValidator{
+			ValidationID: id,
+			Owner:        owners[i],
+		}
+	}
+	return validators, nil
+}
+
+// GetEpochValidators returns the validation IDs of the epoch stored at epochIdx along with their owners.
+func GetEpochValidators(opts *bind.CallOpts, caller EpochCaller, epochIdx uint64) ([]EpochValidator, error) {
+	epoch, err := GetValidationEpoch(opts, caller, epochIdx)
+	if err != nil {
+		return nil, err
+	}
+	return GetPoSValidators(opts, caller, epoch.ValidationIDs)
+}
+
+func toValidationEpoch(raw CoqnetERC20TokenStakingManagerValidationEpoch) (*ValidationEpoch, error) {
+	for _, v := range []*big.Int{raw.Epoch, raw.StartTime, raw.EndTime} {
+		if v == nil || !v.IsInt64() {
+			return nil, errors.Errorf("invalid validation epoch field %v", v)
+		}
+	}
+	validationIDs := make([]ids.ID, len(raw.ValidationIDs))
+	for i, id := range raw.ValidationIDs {
+		validationIDs[i] = ids.ID(id)
+	}
+	return &ValidationEpoch{
+		Index:         raw.Epoch.Uint64(),
+		StartTime:     time.Unix(raw.StartTime.Int64(), 0),
+		EndTime:       time.Unix(raw.EndTime.Int64(), 0),
+		ValidationIDs: validationIDs,
+	}, nil
+}
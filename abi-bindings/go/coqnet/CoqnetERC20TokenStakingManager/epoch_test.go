@@ -0,0 +1,118 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package coqneterc20tokenstakingmanager
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var _ EpochCaller = (*CoqnetERC20TokenStakingManager)(nil)
+
+type mockEpochCaller struct {
+	currentEpoch uint64
+	epochs       map[uint64]CoqnetERC20TokenStakingManagerValidationEpoch
+	owners       map[[32]byte]common.Address
+	last         map[common.Address][32]byte
+}
+
+func (m *mockEpochCaller) GetValidationEpoch(
+	_ *bind.CallOpts,
+	epochIdx *big.Int,
+) (CoqnetERC20TokenStakingManagerValidationEpoch, error) {
+	if epoch, ok := m.epochs[epochIdx.Uint64()]; ok {
+		return epoch, nil
+	}
+	return CoqnetERC20TokenStakingManagerValidationEpoch{
+		Epoch:     big.NewInt(0),
+		StartTime: big.NewInt(0),
+		EndTime:   big.NewInt(0),
+	}, nil
+}
+
+func (m *mockEpochCaller) GetCurrentEpochIndex(_ *bind.CallOpts) (*big.Int, error) {
+	return new(big.Int).SetUint64(m.currentEpoch), nil
+}
+
+func (m *mockEpochCaller) GetLastValidationID(_ *bind.CallOpts, validator common.Address) ([32]byte, error) {
+	return m.last[validator], nil
+}
+
+func (m *mockEpochCaller) GetPoSValidators(_ *bind.CallOpts, validationIDs [][32]byte) ([]common.Address, error) {
+	owners := make([]common.Address, len(validationIDs))
+	for i, id := range validationIDs {
+		owners[i] = m.owners[id]
+	}
+	return owners, nil
+}
+
+func TestGetCurrentValidationEpoch(t *testing.T) {
+	validationID1 := ids.GenerateTestID()
+	validationID2 := ids.GenerateTestID()
+	owner1 := common.HexToAddress("0x1")
+	owner2 := common.HexToAddress("0x2")
+	start := time.Unix(1_700_000_000, 0)
+
+	caller := &mockEpochCaller{
+		currentEpoch: 2,
+		epochs: map[uint64]CoqnetERC20TokenStakingManagerValidationEpoch{
+			2: {
+				Epoch:         big.NewInt(2),
+				StartTime:     big.NewInt(start.Unix()),
+				EndTime:       big.NewInt(start.Add(EpochDuration).Unix()),
+				ValidationIDs: [][32]byte{validationID1, validationID2},
+			},
+		},
+		owners: map[[32]byte]common.Address{
+			validationID1: owner1,
+			validationID2: owner2,
+		},
+		last: map[common.Address][32]byte{
+			owner2: validationID2,
+		},
+	}
+
+	epoch, err := GetCurrentValidationEpoch(nil, caller)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), epoch.Index)
+	require.Equal(t, start, epoch.StartTime)
+	require.Equal(t, start.Add(EpochDuration), epoch.EndTime)
+	require.Equal(t, []ids.ID{validationID1, validationID2}, epoch.ValidationIDs)
+	require.True(t, epoch.Started())
+	require.False(t, epoch.Ended(start.Add(time.Hour)))
+	require.True(t, epoch.Ended(epoch.EndTime))
+	require.True(t, epoch.Contains(validationID2))
+	require.False(t, epoch.Contains(ids.GenerateTestID()))
+
+	validators, err := GetEpochValidators(nil, caller, 2)
+	require.NoError(t, err)
+	require.Equal(t, []EpochValidator{
+		{ValidationID: validationID1, Owner: owner1},
+		{ValidationID: validationID2, Owner: owner2},
+	}, validators)
+
+	lastValidationID, err := GetLastValidationID(nil, caller, owner2)
+	require.NoError(t, err)
+	require.Equal(t, validationID2, lastValidationID)
+
+	lastValidationID, err = GetLastValidationID(nil, caller, owner1)
+	require.NoError(t, err)
+	require.Equal(t, ids.Empty, lastValidationID)
+}
+
+func TestUnstartedValidationEpoch(t *testing.T) {
+	caller := &mockEpochCaller{}
+
+	epoch, err := GetValidationEpoch(nil, caller, 7)
+	require.NoError(t, err)
+	require.False(t, epoch.Started())
+	require.True(t, epoch.Ended(time.Now()))
+	require.Empty(t, epoch.ValidationIDs)
+}
@@ -0,0 +1,367 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package rewardscalculator
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = interfaces.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// RewardsCalculatorMetaData contains all meta data concerning the RewardsCalculator contract.
+var RewardsCalculatorMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"rewardBasisPoints_\",\"type\":\"uint64\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"BIPS_CONVERSION_FACTOR\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_UPTIME_SECONS\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SECONDS_IN_YEAR\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"UPTIME_REWARDS_THRESHOLD_PERCENTAGE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"stakeAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"validatorStartTime\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"stakingStartTime\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"stakingEndTime\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"uptimeSeconds\",\"type\":\"uint64\"}],\"name\":\"calculateReward\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rewardBasisPoints\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// RewardsCalculatorABI is the input ABI used to generate the binding from.
+// Deprecated: Use RewardsCalculatorMetaData.ABI instead.
+var RewardsCalculatorABI = RewardsCalculatorMetaData.ABI
+
+// RewardsCalculator is an auto generated Go binding around an Ethereum contract.
+type RewardsCalculator struct {
+	RewardsCalculatorCaller     // Read-only binding to the contract
+	RewardsCalculatorTransactor // Write-only binding to the contract
+	RewardsCalculatorFilterer   // Log filterer for contract events
+}
+
+// RewardsCalculatorCaller is an auto generated read-only Go binding around an Ethereum contract.
+type RewardsCalculatorCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RewardsCalculatorTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type RewardsCalculatorTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RewardsCalculatorFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type RewardsCalculatorFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RewardsCalculatorSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type RewardsCalculatorSession struct {
+	Contract     *RewardsCalculator // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// RewardsCalculatorCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type RewardsCalculatorCallerSession struct {
+	Contract *RewardsCalculatorCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// RewardsCalculatorTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type RewardsCalculatorTransactorSession struct {
+	Contract     *RewardsCalculatorTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// RewardsCalculatorRaw is an auto generated low-level Go binding around an Ethereum contract.
+type RewardsCalculatorRaw struct {
+	Contract *RewardsCalculator // Generic contract binding to access the raw methods on
+}
+
+// RewardsCalculatorCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type RewardsCalculatorCallerRaw struct {
+	Contract *RewardsCalculatorCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// RewardsCalculatorTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type RewardsCalculatorTransactorRaw struct {
+	Contract *RewardsCalculatorTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewRewardsCalculator creates a new instance of RewardsCalculator, bound to a specific deployed contract.
+func NewRewardsCalculator(address common.Address, backend bind.ContractBackend) (*RewardsCalculator, error) {
+	contract, err := bindRewardsCalculator(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &RewardsCalculator{RewardsCalculatorCaller: RewardsCalculatorCaller{contract: contract}, RewardsCalculatorTransactor: RewardsCalculatorTransactor{contract: contract}, RewardsCalculatorFilterer: RewardsCalculatorFilterer{contract: contract}}, nil
+}
+
+// NewRewardsCalculatorCaller creates a new read-only instance of RewardsCalculator, bound to a specific deployed contract.
+func NewRewardsCalculatorCaller(address common.Address, caller bind.ContractCaller) (*RewardsCalculatorCaller, error) {
+	contract, err := bindRewardsCalculator(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RewardsCalculatorCaller{contract: contract}, nil
+}
+
+// NewRewardsCalculatorTransactor creates a new write-only instance of RewardsCalculator, bound to a specific deployed contract.
+func NewRewardsCalculatorTransactor(address common.Address, transactor bind.ContractTransactor) (*RewardsCalculatorTransactor, error) {
+	contract, err := bindRewardsCalculator(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RewardsCalculatorTransactor{contract: contract}, nil
+}
+
+// NewRewardsCalculatorFilterer creates a new log filterer instance of RewardsCalculator, bound to a specific deployed contract.
+func NewRewardsCalculatorFilterer(address common.Address, filterer bind.ContractFilterer) (*RewardsCalculatorFilterer, error) {
+	contract, err := bindRewardsCalculator(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &RewardsCalculatorFilterer{contract: contract}, nil
+}
+
+// bindRewardsCalculator binds a generic wrapper to an already deployed contract.
+func bindRewardsCalculator(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := RewardsCalculatorMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_RewardsCalculator *RewardsCalculatorRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _RewardsCalculator.Contract.RewardsCalculatorCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_RewardsCalculator *RewardsCalculatorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _RewardsCalculator.Contract.RewardsCalculatorTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_RewardsCalculator *RewardsCalculatorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _RewardsCalculator.Contract.RewardsCalculatorTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_RewardsCalculator *RewardsCalculatorCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _RewardsCalculator.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_RewardsCalculator *RewardsCalculatorTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _RewardsCalculator.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_RewardsCalculator *RewardsCalculatorTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _RewardsCalculator.Contract.contract.Transact(opts, method, params...)
+}
+
+// BIPSCONVERSIONFACTOR is a free data retrieval call binding the contract method 0xa9778a7a.
+//
+// Solidity: function BIPS_CONVERSION_FACTOR() view returns(uint16)
+func (_RewardsCalculator *RewardsCalculatorCaller) BIPSCONVERSIONFACTOR(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _RewardsCalculator.contract.Call(opts, &out, "BIPS_CONVERSION_FACTOR")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// BIPSCONVERSIONFACTOR is a free data retrieval call binding the contract method 0xa9778a7a.
+//
+// Solidity: function BIPS_CONVERSION_FACTOR() view returns(uint16)
+func (_RewardsCalculator *RewardsCalculatorSession) BIPSCONVERSIONFACTOR() (uint16, error) {
+	return _RewardsCalculator.Contract.BIPSCONVERSIONFACTOR(&_RewardsCalculator.CallOpts)
+}
+
+// BIPSCONVERSIONFACTOR is a free data retrieval call binding the contract method 0xa9778a7a.
+//
+// Solidity: function BIPS_CONVERSION_FACTOR() view returns(uint16)
+func (_RewardsCalculator *RewardsCalculatorCallerSession) BIPSCONVERSIONFACTOR() (uint16, error) {
+	return _RewardsCalculator.Contract.BIPSCONVERSIONFACTOR(&_RewardsCalculator.CallOpts)
+}
+
+// MAXUPTIMESECONS is a free data retrieval call binding the contract method 0xb27a724b.
+//
+// Solidity: function MAX_UPTIME_SECONS() view returns(uint64)
+func (_RewardsCalculator *RewardsCalculatorCaller) MAXUPTIMESECONS(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _RewardsCalculator.contract.Call(opts, &out, "MAX_UPTIME_SECONS")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// MAXUPTIMESECONS is a free data retrieval call binding the contract method 0xb27a724b.
+//
+// Solidity: function MAX_UPTIME_SECONS() view returns(uint64)
+func (_RewardsCalculator *RewardsCalculatorSession) MAXUPTIMESECONS() (uint64, error) {
+	return _RewardsCalculator.Contract.MAXUPTIMESECONS(&_RewardsCalculator.CallOpts)
+}
+
+// MAXUPTIMESECONS is a free data retrieval call binding the contract method 0xb27a724b.
+//
+// Solidity: function MAX_UPTIME_SECONS() view returns(uint64)
+func (_RewardsCalculator *RewardsCalculatorCallerSession) MAXUPTIMESECONS() (uint64, error) {
+	return _RewardsCalculator.Contract.MAXUPTIMESECONS(&_RewardsCalculator.CallOpts)
+}
+
+// SECONDSINYEAR is a free data retrieval call binding the contract method 0x5dcc9391.
+//
+// Solidity: function SECONDS_IN_YEAR() view returns(uint256)
+func (_RewardsCalculator *RewardsCalculatorCaller) SECONDSINYEAR(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _RewardsCalculator.contract.Call(opts, &out, "SECONDS_IN_YEAR")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SECONDSINYEAR is a free data retrieval call binding the contract method 0x5dcc9391.
+//
+// Solidity: function SECONDS_IN_YEAR() view returns(uint256)
+func (_RewardsCalculator *RewardsCalculatorSession) SECONDSINYEAR() (*big.Int, error) {
+	return _RewardsCalculator.Contract.SECONDSINYEAR(&_RewardsCalculator.CallOpts)
+}
+
+// SECONDSINYEAR is a free data retrieval call binding the contract method 0x5dcc9391.
+//
+// Solidity: function SECONDS_IN_YEAR() view returns(uint256)
+func (_RewardsCalculator *RewardsCalculatorCallerSession) SECONDSINYEAR() (*big.Int, error) {
+	return _RewardsCalculator.Contract.SECONDSINYEAR(&_RewardsCalculator.CallOpts)
+}
+
+// UPTIMEREWARDSTHRESHOLDPERCENTAGE is a free data retrieval call binding the contract method 0xafba878a.
+//
+// Solidity: function UPTIME_REWARDS_THRESHOLD_PERCENTAGE() view returns(uint8)
+func (_RewardsCalculator *RewardsCalculatorCaller) UPTIMEREWARDSTHRESHOLDPERCENTAGE(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _RewardsCalculator.contract.Call(opts, &out, "UPTIME_REWARDS_THRESHOLD_PERCENTAGE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// UPTIMEREWARDSTHRESHOLDPERCENTAGE is a free data retrieval call binding the contract method 0xafba878a.
+//
+// Solidity: function UPTIME_REWARDS_THRESHOLD_PERCENTAGE() view returns(uint8)
+func (_RewardsCalculator *RewardsCalculatorSession) UPTIMEREWARDSTHRESHOLDPERCENTAGE() (uint8, error) {
+	return _RewardsCalculator.Contract.UPTIMEREWARDSTHRESHOLDPERCENTAGE(&_RewardsCalculator.CallOpts)
+}
+
+// UPTIMEREWARDSTHRESHOLDPERCENTAGE is a free data retrieval call binding the contract method 0xafba878a.
+//
+// Solidity: function UPTIME_REWARDS_THRESHOLD_PERCENTAGE() view returns(uint8)
+func (_RewardsCalculator *RewardsCalculatorCallerSession) UPTIMEREWARDSTHRESHOLDPERCENTAGE() (uint8, error) {
+	return _RewardsCalculator.Contract.UPTIMEREWARDSTHRESHOLDPERCENTAGE(&_RewardsCalculator.CallOpts)
+}
+
+// CalculateReward is a free data retrieval call binding the contract method 0x4f22429f.
+//
+// Solidity: function calculateReward(uint256 stakeAmount, uint64 validatorStartTime, uint64 stakingStartTime, uint64 stakingEndTime, uint64 uptimeSeconds) view returns(uint256)
+func (_RewardsCalculator *RewardsCalculatorCaller) CalculateReward(opts *bind.CallOpts, stakeAmount *big.Int, validatorStartTime uint64, stakingStartTime uint64, stakingEndTime uint64, uptimeSeconds uint64) (*big.Int, error) {
+	var out []interface{}
+	err := _RewardsCalculator.contract.Call(opts, &out, "calculateReward", stakeAmount, validatorStartTime, stakingStartTime, stakingEndTime, uptimeSeconds)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CalculateReward is a free data retrieval call binding the contract method 0x4f22429f.
+//
+// Solidity: function calculateReward(uint256 stakeAmount, uint64 validatorStartTime, uint64 stakingStartTime, uint64 stakingEndTime, uint64 uptimeSeconds) view returns(uint256)
+func (_RewardsCalculator *RewardsCalculatorSession) CalculateReward(stakeAmount *big.Int, validatorStartTime uint64, stakingStartTime uint64, stakingEndTime uint64, uptimeSeconds uint64) (*big.Int, error) {
+	return _RewardsCalculator.Contract.CalculateReward(&_RewardsCalculator.CallOpts, stakeAmount, validatorStartTime, stakingStartTime, stakingEndTime, uptimeSeconds)
+}
+
+// CalculateReward is a free data retrieval call binding the contract method 0x4f22429f.
+//
+// Solidity: function calculateReward(uint256 stakeAmount, uint64 validatorStartTime, uint64 stakingStartTime, uint64 stakingEndTime, uint64 uptimeSeconds) view returns(uint256)
+func (_RewardsCalculator *RewardsCalculatorCallerSession) CalculateReward(stakeAmount *big.Int, validatorStartTime uint64, stakingStartTime uint64, stakingEndTime uint64, uptimeSeconds uint64) (*big.Int, error) {
+	return _RewardsCalculator.Contract.CalculateReward(&_RewardsCalculator.CallOpts, stakeAmount, validatorStartTime, stakingStartTime, stakingEndTime, uptimeSeconds)
+}
+
+// RewardBasisPoints is a free data retrieval call binding the contract method 0xbb65b242.
+//
+// Solidity: function rewardBasisPoints() view returns(uint64)
+func (_RewardsCalculator *RewardsCalculatorCaller) RewardBasisPoints(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _RewardsCalculator.contract.Call(opts, &out, "rewardBasisPoints")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// RewardBasisPoints is a free data retrieval call binding the contract method 0xbb65b242.
+//
+// Solidity: function rewardBasisPoints() view returns(uint64)
+func (_RewardsCalculator *RewardsCalculatorSession) RewardBasisPoints() (uint64, error) {
+	return _RewardsCalculator.Contract.RewardBasisPoints(&_RewardsCalculator.CallOpts)
+}
+
+// RewardBasisPoints is a free data retrieval call binding the contract method 0xbb65b242.
+//
+// Solidity: function rewardBasisPoints() view returns(uint64)
+func (_RewardsCalculator *RewardsCalculatorCallerSession) RewardBasisPoints() (uint64, error) {
+	return _RewardsCalculator.Contract.RewardBasisPoints(&_RewardsCalculator.CallOpts)
+}
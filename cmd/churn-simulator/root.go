@@ -0,0 +1,179 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	validatorManagerUtils "github.com/ava-labs/icm-contracts/utils/validator-manager-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+// errDelayed is returned when a planned change would revert at its planned time, so that the
+// simulator exits with a non-zero status.
+var errDelayed = errors.New("planned changes exceed the churn limit")
+
+var (
+	nodeURI                 string
+	blockchainIDStr         string
+	validatorManagerAddress string
+	planPath                string
+	jsonOutput              bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "churn-simulator --node-uri NODE_URI --blockchain-id BLOCKCHAIN_ID --validator-manager-address CONTRACT_ADDRESS --plan PLAN_FILE",
+	Short: "Predicts whether planned validator set changes pass the churn limit of a validator manager",
+	Long: `Predicts whether planned validator set changes pass the churn limit of a validator manager.
+The churn period and total weight of the validator manager are read from its storage, and the
+registrations, removals and delegations of the plan are simulated in order against the churn check
+of the validator manager. For each change, the simulator reports whether it would succeed at its
+planned time, and the earliest time at which it would. It exits with a non-zero status if any
+change would revert at its planned time.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          rootRunE,
+}
+
+func rootRunE(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(validatorManagerAddress) {
+		return fmt.Errorf("invalid validator manager address %s", validatorManagerAddress)
+	}
+	blockchainID, err := ids.FromString(blockchainIDStr)
+	if err != nil {
+		return err
+	}
+	changes, err := loadPlan(planPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client, err := ethclient.Dial(fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURI, blockchainID))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	state, err := validatorManagerUtils.ReadChurnState(ctx, client, common.HexToAddress(validatorManagerAddress))
+	if err != nil {
+		return err
+	}
+	predictions, err := validatorManagerUtils.SimulateChurn(state, changes)
+	if err != nil {
+		return err
+	}
+	if err := printPredictions(cmd, state, predictions); err != nil {
+		return err
+	}
+	for _, prediction := range predictions {
+		if !prediction.Succeeds {
+			return errDelayed
+		}
+	}
+	return nil
+}
+
+// loadPlan reads the planned changes from a JSON array of PlannedChange.
+func loadPlan(path string) ([]validatorManagerUtils.PlannedChange, error) {
+	planJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	var changes []validatorManagerUtils.PlannedChange
+	if err := json.Unmarshal(planJSON, &changes); err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %w", path, err)
+	}
+	return changes, nil
+}
+
+func printPredictions(
+	cmd *cobra.Command,
+	state *validatorManagerUtils.ChurnState,
+	predictions []validatorManagerUtils.ChurnPrediction,
+) error {
+	if jsonOutput {
+		out, err := json.MarshalIndent(struct {
+			State       *validatorManagerUtils.ChurnState       `json:"state"`
+			Predictions []validatorManagerUtils.ChurnPrediction `json:"predictions"`
+		}{state, predictions}, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(out))
+		return nil
+	}
+	cmd.Printf("Validator manager %s at %s: total weight %d, maximum churn %d%% per %s\n",
+		state.Manager, formatTime(state.Timestamp), state.TotalWeight, state.MaximumChurnPercentage,
+		time.Duration(state.ChurnPeriodSeconds)*time.Second,
+	)
+	if state.StartedAt != 0 {
+		cmd.Printf("Churn period started at %s: churn %d of initial weight %d\n",
+			formatTime(state.StartedAt), state.ChurnAmount, state.InitialWeight)
+	}
+	for i, prediction := range predictions {
+		change := prediction.Change
+		name := fmt.Sprintf("%d. %s of weight %d", i+1, change.Kind, change.Weight)
+		if change.Label != "" {
+			name += " (" + change.Label + ")"
+		}
+		switch {
+		case prediction.Error != "":
+			cmd.Printf("%s: never succeeds: %s\n", name, prediction.Error)
+		case prediction.Succeeds:
+			cmd.Printf("%s: succeeds at %s, total weight %d\n", name, formatTime(prediction.Time), prediction.TotalWeight)
+		default:
+			cmd.Printf("%s: reverts at %s, succeeds from %s, total weight %d\n",
+				name, formatTime(prediction.Time), formatTime(prediction.EarliestTime), prediction.TotalWeight)
+		}
+	}
+	return nil
+}
+
+func formatTime(timestamp uint64) string {
+	return time.Unix(int64(timestamp), 0).UTC().Format(time.RFC3339)
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if errors.Is(err, errDelayed) {
+		os.Exit(2)
+	}
+	if err != nil {
+		rootCmd.PrintErrln("Error:", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.Flags().StringVar(&nodeURI, "node-uri", "", "URI of an L1 node, e.g. http://127.0.0.1:9650")
+	rootCmd.Flags().StringVar(&blockchainIDStr, "blockchain-id", "", "Blockchain ID of the L1")
+	rootCmd.Flags().StringVar(&validatorManagerAddress, "validator-manager-address", "", "Validator manager contract address")
+	rootCmd.Flags().StringVar(&planPath, "plan", "", "JSON file of the planned changes")
+	for _, flag := range []string{"node-uri", "blockchain-id", "validator-manager-address", "plan"} {
+		err := rootCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+	rootCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the predictions as JSON")
+}
+
+func main() {
+	Execute()
+}
@@ -0,0 +1,111 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	validatorManagerUtils "github.com/ava-labs/icm-contracts/utils/validator-manager-utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTestCmd(t *testing.T, c *cobra.Command, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	c.SetArgs(args)
+
+	err := c.Execute()
+	return strings.TrimSpace(buf.String()), err
+}
+
+func TestRootCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "missing flags",
+			args: []string{},
+			err:  fmt.Errorf(`required flag(s) "blockchain-id", "node-uri", "plan", "validator-manager-address" not set`),
+		},
+		{
+			name: "unexpected args",
+			args: []string{"invalid"},
+			err:  fmt.Errorf(`unknown command "invalid"`),
+		},
+		{
+			name: "invalid address",
+			args: []string{"--node-uri", "http://127.0.0.1:9650", "--blockchain-id", "invalid", "--validator-manager-address", "0x1", "--plan", "plan.json"},
+			err:  fmt.Errorf("invalid validator manager address 0x1"),
+		},
+		// Run last, since cobra does not reset the help flag between executions
+		{
+			name: "help",
+			args: []string{"--help"},
+			err:  nil,
+			out:  "Predicts whether planned validator set changes pass the churn limit of a validator manager",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestLoadPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"kind": "registration", "label": "node-1", "weight": 100},
+		{"kind": "removal", "weight": 50, "notBefore": 1000}
+	]`), 0o600))
+	changes, err := loadPlan(path)
+	require.NoError(t, err)
+	require.Equal(t, []validatorManagerUtils.PlannedChange{
+		{Kind: validatorManagerUtils.ChurnRegistration, Label: "node-1", Weight: 100},
+		{Kind: validatorManagerUtils.ChurnRemoval, Weight: 50, NotBefore: 1000},
+	}, changes)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{}`), 0o600))
+	_, err = loadPlan(path)
+	require.ErrorContains(t, err, "failed to parse plan")
+}
+
+func TestPrintPredictions(t *testing.T) {
+	state := &validatorManagerUtils.ChurnState{
+		Timestamp:              1700000000,
+		ChurnPeriodSeconds:     3600,
+		MaximumChurnPercentage: 20,
+		TotalWeight:            1000,
+	}
+	predictions, err := validatorManagerUtils.SimulateChurn(state, []validatorManagerUtils.PlannedChange{
+		{Kind: validatorManagerUtils.ChurnRegistration, Label: "node-1", Weight: 150},
+		{Kind: validatorManagerUtils.ChurnRegistration, Weight: 150},
+		{Kind: validatorManagerUtils.ChurnRegistration, Weight: 1000},
+	})
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	require.NoError(t, printPredictions(rootCmd, state, predictions))
+	out := buf.String()
+	require.Contains(t, out, "1. registration of weight 150 (node-1): succeeds at 2023-11-14T22:13:20Z, total weight 1150")
+	require.Contains(t, out, "2. registration of weight 150: reverts at 2023-11-14T22:13:20Z, succeeds from 2023-11-14T23:13:20Z, total weight 1300")
+	require.Contains(t, out, "3. registration of weight 1000: never succeeds: churn amount 1000 exceeds 20% of initial weight 1300")
+}
@@ -0,0 +1,285 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type config struct {
+	minStakeDuration time.Duration
+	windowWarning    time.Duration
+	pollInterval     time.Duration
+	startBlock       uint64
+	maxBlockRange    uint64
+	reportDir        string
+}
+
+// chainClient is the subset of an EVM client used by the monitor besides the staking manager
+// bindings. It is implemented by the subnet-evm ethclient.Client.
+type chainClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+type alertKey struct {
+	kind         AlertKind
+	epoch        uint64
+	validationID ids.ID
+}
+
+type monitor struct {
+	logger  logging.Logger
+	client  chainClient
+	manager *coqnetstakingmanager.CoqnetERC20TokenStakingManager
+	config  config
+
+	// Latest uptime reported by UptimeUpdated for each validation ID
+	uptimes map[ids.ID]uint64
+	// Validation IDs whose UptimeUpdated events before the start block were searched
+	seeded    set.Set[ids.ID]
+	nextBlock uint64
+	alerted   set.Set[alertKey]
+	// Most recent report of the current epoch, finalized when the next epoch starts
+	report *EpochReport
+}
+
+func newMonitor(
+	logger logging.Logger,
+	client chainClient,
+	manager *coqnetstakingmanager.CoqnetERC20TokenStakingManager,
+	config config,
+) *monitor {
+	return &monitor{
+		logger:    logger,
+		client:    client,
+		manager:   manager,
+		config:    config,
+		uptimes:   make(map[ids.ID]uint64),
+		seeded:    set.NewSet[ids.ID](0),
+		nextBlock: config.startBlock,
+		alerted:   set.NewSet[alertKey](0),
+	}
+}
+
+// run polls the staking manager every poll interval until ctx is cancelled.
+func (m *monitor) run(ctx context.Context) error {
+	ticker := time.NewTicker(m.config.pollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.poll(ctx); err != nil {
+			m.logger.Error("Failed to poll staking manager", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			if m.report != nil {
+				return m.writeReport(m.report)
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll syncs uptime updates, evaluates the current epoch and emits any new alerts.
+func (m *monitor) poll(ctx context.Context) (*EpochReport, error) {
+	if err := m.syncUptimes(ctx); err != nil {
+		return nil, err
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	epoch, err := coqnetstakingmanager.GetCurrentValidationEpoch(opts, m.manager)
+	if err != nil {
+		return nil, err
+	}
+	if !epoch.Started() {
+		m.logger.Info("No validation epoch has started yet")
+		return nil, nil
+	}
+	if err := m.seedUptimes(ctx, epoch.ValidationIDs); err != nil {
+		return nil, err
+	}
+	owners, err := coqnetstakingmanager.GetPoSValidators(opts, m.manager, epoch.ValidationIDs)
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]validatorState, len(owners))
+	for i, owner := range owners {
+		validator, err := m.manager.GetValidator(opts, owner.ValidationID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get validator %s", owner.ValidationID)
+		}
+		validators[i] = validatorState{
+			ValidationID:  owner.ValidationID,
+			Owner:         owner.Owner,
+			Validator:     validator,
+			UptimeSeconds: m.uptimes[owner.ValidationID],
+		}
+	}
+
+	report, alerts := evaluateEpoch(
+		time.Now(),
+		epoch,
+		validators,
+		m.config.minStakeDuration,
+		m.config.windowWarning,
+	)
+	if m.report != nil && m.report.Epoch != report.Epoch {
+		m.logger.Info("Validation epoch ended", zap.Uint64("epoch", m.report.Epoch))
+		if err := m.writeReport(m.report); err != nil {
+			return nil, err
+		}
+	}
+	m.report = report
+
+	for _, alert := range alerts {
+		key := alertKey{kind: alert.Kind, epoch: alert.Epoch, validationID: alert.ValidationID}
+		if m.alerted.Contains(key) {
+			continue
+		}
+		m.alerted.Add(key)
+		m.logAlert(alert)
+	}
+	m.logger.Debug(
+		"Evaluated validation epoch",
+		zap.Uint64("epoch", report.Epoch),
+		zap.Int("validators", len(report.Validators)),
+		zap.Int("openSlots", report.OpenSlots),
+	)
+	return report, nil
+}
+
+// syncUptimes processes UptimeUpdated events up to the latest block,
+// in chunks of at most maxBlockRange blocks.
+func (m *monitor) syncUptimes(ctx context.Context) error {
+	latest, err := m.client.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest block number")
+	}
+	for m.nextBlock <= latest {
+		end := min(m.nextBlock+m.config.maxBlockRange-1, latest)
+		it, err := m.manager.FilterUptimeUpdated(&bind.FilterOpts{
+			Start:   m.nextBlock,
+			End:     &end,
+			Context: ctx,
+		}, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to filter UptimeUpdated events in blocks [%d, %d]", m.nextBlock, end)
+		}
+		for it.Next() {
+			m.uptimes[ids.ID(it.Event.ValidationID)] = it.Event.Uptime
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return errors.Wrap(err, "failed to iterate UptimeUpdated events")
+		}
+		m.nextBlock = end + 1
+	}
+	return nil
+}
+
+// seedUptimes sets the uptime of each of validationIDs without an UptimeUpdated event since the
+// start block to the one of its last event before the start block. Blocks are searched backwards
+// from the start block, in chunks of at most maxBlockRange blocks, until the last event of each
+// validation ID is found or the genesis block is reached. Each validation ID is searched once.
+func (m *monitor) seedUptimes(ctx context.Context, validationIDs []ids.ID) error {
+	var pending [][32]byte
+	for _, validationID := range validationIDs {
+		if _, ok := m.uptimes[validationID]; ok || m.seeded.Contains(validationID) {
+			continue
+		}
+		pending = append(pending, validationID)
+	}
+	for end := m.config.startBlock; len(pending) > 0 && end > 0; {
+		start := end - min(end, m.config.maxBlockRange)
+		last := end - 1
+		it, err := m.manager.FilterUptimeUpdated(&bind.FilterOpts{
+			Start:   start,
+			End:     &last,
+			Context: ctx,
+		}, pending)
+		if err != nil {
+			return errors.Wrapf(err, "failed to filter UptimeUpdated events in blocks [%d, %d]", start, last)
+		}
+		found := set.NewSet[ids.ID](0)
+		for it.Next() {
+			// Events are in block order, so the last one of each validation ID is kept.
+			m.uptimes[ids.ID(it.Event.ValidationID)] = it.Event.Uptime
+			found.Add(ids.ID(it.Event.ValidationID))
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return errors.Wrap(err, "failed to iterate UptimeUpdated events")
+		}
+		pending = slices.DeleteFunc(pending, func(validationID [32]byte) bool {
+			return found.Contains(validationID)
+		})
+		end = start
+	}
+	m.seeded.Add(validationIDs...)
+	return nil
+}
+
+func (m *monitor) logAlert(alert Alert) {
+	fields := []zap.Field{
+		zap.String("kind", string(alert.Kind)),
+		zap.Uint64("epoch", alert.Epoch),
+		zap.Time("replacementWindowClosesAt", alert.ReplacementWindowClosesAt),
+	}
+	switch alert.Kind {
+	case BelowUptimeThreshold:
+		m.logger.Warn("Validator uptime is below the rewards threshold", append(fields,
+			zap.Stringer("validationID", alert.ValidationID),
+			zap.Stringer("owner", alert.Owner),
+			zap.Float64("uptimePercentage", alert.UptimePercentage),
+		)...)
+	case ReplacementWindowClosing:
+		m.logger.Warn("Replacement window is closing with open slots", append(fields,
+			zap.Int("openSlots", alert.OpenSlots),
+		)...)
+	case ReplacementWindowMissed:
+		m.logger.Error("Replacement window closed with open slots", append(fields,
+			zap.Int("openSlots", alert.OpenSlots),
+		)...)
+	}
+}
+
+// writeReport writes the report to the report directory, if one is configured.
+func (m *monitor) writeReport(report *EpochReport) error {
+	m.logger.Info(
+		"Validation epoch report",
+		zap.Uint64("epoch", report.Epoch),
+		zap.Time("startTime", report.StartTime),
+		zap.Time("endTime", report.EndTime),
+		zap.Int("validators", len(report.Validators)),
+		zap.Int("openSlots", report.OpenSlots),
+	)
+	if m.config.reportDir == "" {
+		return nil
+	}
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(m.config.reportDir, fmt.Sprintf("epoch-%d.json", report.Epoch))
+	if err := os.WriteFile(path, reportJSON, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write report to %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	testUtils "github.com/ava-labs/icm-contracts/utils/test-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedUptimes(t *testing.T) {
+	ctx := context.Background()
+	managerABI, err := coqnetstakingmanager.CoqnetERC20TokenStakingManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	managerAddress := common.Address{3}
+	uptimeUpdated := func(validationID ids.ID, uptime uint64) *types.Log {
+		return testUtils.EventLog(t, managerABI, managerAddress, "UptimeUpdated", []common.Hash{common.Hash(validationID)}, uptime)
+	}
+
+	client := testUtils.NewFakeClient()
+	client.MaxFilterRange = 2
+	updatedBefore, updatedSince, notUpdated := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+	client.Accept()
+	client.Accept(uptimeUpdated(updatedBefore, 100))
+	client.Accept(uptimeUpdated(updatedSince, 50))
+	client.Accept()
+	client.Accept(uptimeUpdated(updatedBefore, 200))
+	client.Accept()
+	// The monitor starts at block 7.
+	client.Accept()
+	client.Accept(uptimeUpdated(updatedSince, 300))
+
+	manager, err := coqnetstakingmanager.NewCoqnetERC20TokenStakingManager(managerAddress, client)
+	require.NoError(t, err)
+	m := newMonitor(logging.NoLog{}, client, manager, config{
+		pollInterval:  time.Minute,
+		startBlock:    7,
+		maxBlockRange: 2,
+	})
+	require.NoError(t, m.syncUptimes(ctx))
+	require.Equal(t, map[ids.ID]uint64{updatedSince: 300}, m.uptimes)
+
+	// The uptimes recorded before the start block are known, and the ones since are kept.
+	validationIDs := []ids.ID{updatedBefore, updatedSince, notUpdated}
+	require.NoError(t, m.seedUptimes(ctx, validationIDs))
+	require.Equal(t, map[ids.ID]uint64{updatedBefore: 200, updatedSince: 300}, m.uptimes)
+
+	// Validation IDs are only searched once.
+	client.MaxFilterRange = 0
+	client.Receipts = nil
+	require.NoError(t, m.seedUptimes(ctx, validationIDs))
+	require.Equal(t, map[ids.ID]uint64{updatedBefore: 200, updatedSince: 300}, m.uptimes)
+}
@@ -0,0 +1,158 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AlertKind identifies the condition that raised an Alert
+type AlertKind string
+
+const (
+	// BelowUptimeThreshold is raised for each active validator whose uptime is below
+	// UPTIME_THRESHOLD_PERCENTAGE while the replacement window is still open.
+	// The validator can be replaced, in which case it earns no rewards for the epoch.
+	BelowUptimeThreshold AlertKind = "BelowUptimeThreshold"
+	// ReplacementWindowClosing is raised once per epoch when there are replaceable
+	// slots and the replacement window closes within the configured warning period.
+	ReplacementWindowClosing AlertKind = "ReplacementWindowClosing"
+	// ReplacementWindowMissed is raised once per epoch when the replacement window
+	// closed while there were still replaceable slots.
+	ReplacementWindowMissed AlertKind = "ReplacementWindowMissed"
+)
+
+// Alert is a structured notification about a validation epoch
+type Alert struct {
+	Kind                      AlertKind
+	Epoch                     uint64
+	ValidationID              ids.ID
+	Owner                     common.Address
+	UptimePercentage          float64
+	OpenSlots                 int
+	ReplacementWindowClosesAt time.Time
+}
+
+// ValidatorReport summarizes a single validation within an epoch
+type ValidatorReport struct {
+	ValidationID     ids.ID
+	Owner            common.Address
+	Status           string
+	StartedAt        time.Time
+	UptimeSeconds    uint64
+	UptimePercentage float64
+	BelowThreshold   bool
+}
+
+// EpochReport summarizes the state of a validation epoch at GeneratedAt
+type EpochReport struct {
+	Epoch                     uint64
+	StartTime                 time.Time
+	EndTime                   time.Time
+	ReplacementWindowClosesAt time.Time
+	OpenSlots                 int
+	Validators                []ValidatorReport
+	GeneratedAt               time.Time
+}
+
+// validatorState is the on-chain state of a validation gathered by the monitor
+type validatorState struct {
+	ValidationID  ids.ID
+	Owner         common.Address
+	Validator     coqnetstakingmanager.Validator
+	UptimeSeconds uint64
+}
+
+// evaluateEpoch builds the report for epoch at time now and returns the alerts raised by it.
+// A validation is replaceable if its status is Unknown or Invalidated, or if it is active and
+// its uptime is below the threshold, using the same integer comparison as the contract.
+// New validations must be registered at least minStakeDuration before the epoch ends, which
+// closes the replacement window at EndTime - minStakeDuration.
+func evaluateEpoch(
+	now time.Time,
+	epoch *coqnetstakingmanager.ValidationEpoch,
+	validators []validatorState,
+	minStakeDuration time.Duration,
+	windowWarning time.Duration,
+) (*EpochReport, []Alert) {
+	windowClosesAt := epoch.EndTime.Add(-minStakeDuration)
+	reference := now
+	if reference.After(epoch.EndTime) {
+		reference = epoch.EndTime
+	}
+
+	report := &EpochReport{
+		Epoch:                     epoch.Index,
+		StartTime:                 epoch.StartTime,
+		EndTime:                   epoch.EndTime,
+		ReplacementWindowClosesAt: windowClosesAt,
+		OpenSlots:                 coqnetstakingmanager.MaxEpochValidators - len(validators),
+		GeneratedAt:               now,
+	}
+	var alerts []Alert
+	for _, v := range validators {
+		status := coqnetstakingmanager.ValidatorStatus(v.Validator.Status)
+		startedAt := time.Unix(int64(v.Validator.StartedAt), 0)
+		validatorReport := ValidatorReport{
+			ValidationID:  v.ValidationID,
+			Owner:         v.Owner,
+			Status:        status.String(),
+			StartedAt:     startedAt,
+			UptimeSeconds: v.UptimeSeconds,
+		}
+		switch status {
+		case coqnetstakingmanager.Unknown, coqnetstakingmanager.Invalidated:
+			report.OpenSlots++
+		case coqnetstakingmanager.Active:
+			var elapsed uint64
+			if reference.After(startedAt) {
+				elapsed = uint64(reference.Sub(startedAt) / time.Second)
+			}
+			validatorReport.UptimePercentage = 100
+			if elapsed > 0 {
+				validatorReport.UptimePercentage = float64(v.UptimeSeconds) * 100 / float64(elapsed)
+			}
+			validatorReport.BelowThreshold = v.UptimeSeconds*100 < elapsed*coqnetstakingmanager.UptimeThresholdPercentage
+			if !validatorReport.BelowThreshold {
+				break
+			}
+			report.OpenSlots++
+			if now.Before(windowClosesAt) {
+				alerts = append(alerts, Alert{
+					Kind:                      BelowUptimeThreshold,
+					Epoch:                     epoch.Index,
+					ValidationID:              v.ValidationID,
+					Owner:                     v.Owner,
+					UptimePercentage:          validatorReport.UptimePercentage,
+					ReplacementWindowClosesAt: windowClosesAt,
+				})
+			}
+		}
+		report.Validators = append(report.Validators, validatorReport)
+	}
+
+	if report.OpenSlots > 0 && now.Before(epoch.EndTime) {
+		switch {
+		case !now.Before(windowClosesAt):
+			alerts = append(alerts, Alert{
+				Kind:                      ReplacementWindowMissed,
+				Epoch:                     epoch.Index,
+				OpenSlots:                 report.OpenSlots,
+				ReplacementWindowClosesAt: windowClosesAt,
+			})
+		case windowClosesAt.Sub(now) <= windowWarning:
+			alerts = append(alerts, Alert{
+				Kind:                      ReplacementWindowClosing,
+				Epoch:                     epoch.Index,
+				OpenSlots:                 report.OpenSlots,
+				ReplacementWindowClosesAt: windowClosesAt,
+			})
+		}
+	}
+	return report, alerts
+}
@@ -0,0 +1,145 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	day              = 24 * time.Hour
+	minStakeDuration = 20 * day
+	windowWarning    = day
+)
+
+func newTestEpoch(start time.Time, validators []validatorState) *coqnetstakingmanager.ValidationEpoch {
+	epoch := &coqnetstakingmanager.ValidationEpoch{
+		Index:     3,
+		StartTime: start,
+		EndTime:   start.Add(coqnetstakingmanager.EpochDuration),
+	}
+	for _, v := range validators {
+		epoch.ValidationIDs = append(epoch.ValidationIDs, v.ValidationID)
+	}
+	return epoch
+}
+
+func newActiveValidator(startedAt time.Time, uptime time.Duration) validatorState {
+	return validatorState{
+		ValidationID: ids.GenerateTestID(),
+		Owner:        common.Address(ids.GenerateTestShortID()),
+		Validator: coqnetstakingmanager.Validator{
+			Status:    uint8(coqnetstakingmanager.Active),
+			StartedAt: uint64(startedAt.Unix()),
+		},
+		UptimeSeconds: uint64(uptime / time.Second),
+	}
+}
+
+func TestEvaluateEpoch(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	now := start.Add(5 * day)
+
+	healthy := newActiveValidator(start, 5*day)
+	atThreshold := newActiveValidator(start, 4*day)
+	belowThreshold := newActiveValidator(start, 4*day-time.Second)
+	invalidated := validatorState{
+		ValidationID: ids.GenerateTestID(),
+		Validator: coqnetstakingmanager.Validator{
+			Status: uint8(coqnetstakingmanager.Invalidated),
+		},
+	}
+	validators := []validatorState{healthy, atThreshold, belowThreshold, invalidated}
+	epoch := newTestEpoch(start, validators)
+
+	report, alerts := evaluateEpoch(now, epoch, validators, minStakeDuration, windowWarning)
+	require.Equal(t, uint64(3), report.Epoch)
+	require.Equal(t, start.Add(10*day), report.ReplacementWindowClosesAt)
+	// One empty slot, the invalidated validation and the validation below threshold
+	require.Equal(t, 3, report.OpenSlots)
+	require.Len(t, report.Validators, 4)
+	require.False(t, report.Validators[0].BelowThreshold)
+	require.InDelta(t, 100, report.Validators[0].UptimePercentage, 0.001)
+	require.False(t, report.Validators[1].BelowThreshold)
+	require.InDelta(t, 80, report.Validators[1].UptimePercentage, 0.001)
+	require.True(t, report.Validators[2].BelowThreshold)
+	require.Equal(t, "Invalidated", report.Validators[3].Status)
+
+	require.Len(t, alerts, 1)
+	require.Equal(t, BelowUptimeThreshold, alerts[0].Kind)
+	require.Equal(t, belowThreshold.ValidationID, alerts[0].ValidationID)
+	require.Equal(t, belowThreshold.Owner, alerts[0].Owner)
+}
+
+func TestEvaluateEpochReplacementWindow(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	windowClosesAt := start.Add(10 * day)
+
+	full := make([]validatorState, coqnetstakingmanager.MaxEpochValidators)
+	for i := range full {
+		full[i] = newActiveValidator(start, 10*day)
+	}
+	belowThreshold := append([]validatorState{}, full...)
+	belowThreshold[0] = newActiveValidator(start, 0)
+
+	testCases := []struct {
+		name          string
+		now           time.Time
+		validators    []validatorState
+		expectedKinds []AlertKind
+	}{
+		{
+			name:       "full epoch",
+			now:        windowClosesAt.Add(-time.Hour),
+			validators: full,
+		},
+		{
+			name:          "open slot before warning",
+			now:           windowClosesAt.Add(-2 * windowWarning),
+			validators:    full[1:],
+			expectedKinds: nil,
+		},
+		{
+			name:          "open slot within warning",
+			now:           windowClosesAt.Add(-time.Hour),
+			validators:    full[1:],
+			expectedKinds: []AlertKind{ReplacementWindowClosing},
+		},
+		{
+			name:          "below threshold within warning",
+			now:           windowClosesAt.Add(-time.Hour),
+			validators:    belowThreshold,
+			expectedKinds: []AlertKind{BelowUptimeThreshold, ReplacementWindowClosing},
+		},
+		{
+			name:          "below threshold after window",
+			now:           windowClosesAt.Add(time.Hour),
+			validators:    belowThreshold,
+			expectedKinds: []AlertKind{ReplacementWindowMissed},
+		},
+		{
+			name:          "epoch ended",
+			now:           start.Add(coqnetstakingmanager.EpochDuration),
+			validators:    belowThreshold,
+			expectedKinds: nil,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			epoch := newTestEpoch(start, test.validators)
+			_, alerts := evaluateEpoch(test.now, epoch, test.validators, minStakeDuration, windowWarning)
+			var kinds []AlertKind
+			for _, alert := range alerts {
+				kinds = append(kinds, alert.Kind)
+			}
+			require.Equal(t, test.expectedKinds, kinds)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logger logging.Logger
+
+	rpcEndpoint           string
+	stakingManagerAddress string
+	once                  bool
+	monitorConfig         config
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "coqnet-epoch-monitor --rpc RPC_URL --staking-manager-address CONTRACT_ADDRESS --min-stake-duration DURATION",
+	Short: "Monitors Coqnet validation epochs and alerts on validators at risk",
+	Long: `Monitors the validation epochs of a CoqnetERC20TokenStakingManager. Each poll tracks
+the current epoch, the uptime recorded by UptimeUpdated events for each of its validations,
+and raises structured alerts for validators below the uptime rewards threshold while the
+replacement window (epoch end time minus the minimum stake duration) is still open.
+A report is written for each epoch when it ends. Pass --once to print the report of the
+current epoch and exit.`,
+	Args: cobra.NoArgs,
+	Run:  rootRun,
+}
+
+func rootRun(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(stakingManagerAddress) {
+		cobra.CheckErr("invalid staking manager address " + stakingManagerAddress)
+	}
+	if monitorConfig.maxBlockRange == 0 || monitorConfig.pollInterval <= 0 {
+		cobra.CheckErr("--max-block-range and --poll-interval must be positive")
+	}
+	client, err := ethclient.Dial(rpcEndpoint)
+	cobra.CheckErr(err)
+	defer client.Close()
+
+	manager, err := coqnetstakingmanager.NewCoqnetERC20TokenStakingManager(
+		common.HexToAddress(stakingManagerAddress),
+		client,
+	)
+	cobra.CheckErr(err)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	m := newMonitor(logger, client, manager, monitorConfig)
+	if once {
+		report, err := m.poll(ctx)
+		cobra.CheckErr(err)
+		if report == nil {
+			return
+		}
+		reportJSON, err := json.MarshalIndent(report, "", "  ")
+		cobra.CheckErr(err)
+		fmt.Fprintln(cmd.OutOrStdout(), string(reportJSON))
+		return
+	}
+	cobra.CheckErr(m.run(ctx))
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	logLevelArg := rootCmd.PersistentFlags().StringP("log", "l", "", "Log level i.e. debug, info...")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return rootPreRunE(logLevelArg)
+	}
+
+	rootCmd.Flags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to connect to the node")
+	rootCmd.Flags().StringVar(&stakingManagerAddress, "staking-manager-address", "", "CoqnetERC20TokenStakingManager contract address")
+	rootCmd.Flags().DurationVar(&monitorConfig.minStakeDuration, "min-stake-duration", 0, "Minimum stake duration the staking manager was initialized with")
+	rootCmd.Flags().DurationVar(&monitorConfig.windowWarning, "window-warning", 24*time.Hour, "Alert when the replacement window closes within this duration and slots are open")
+	rootCmd.Flags().DurationVar(&monitorConfig.pollInterval, "poll-interval", time.Minute, "Interval between polls of the staking manager")
+	rootCmd.Flags().Uint64Var(&monitorConfig.startBlock, "start-block", 0, "Block to start processing UptimeUpdated events from, and to search the earlier uptimes of the epoch validators backwards from")
+	rootCmd.Flags().Uint64Var(&monitorConfig.maxBlockRange, "max-block-range", 2048, "Maximum number of blocks per log query")
+	rootCmd.Flags().StringVar(&monitorConfig.reportDir, "report-dir", "", "Directory to write per-epoch JSON reports to")
+	rootCmd.Flags().BoolVar(&once, "once", false, "Print the report of the current epoch and exit")
+
+	for _, flag := range []string{"rpc", "staking-manager-address", "min-stake-duration"} {
+		err := rootCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+}
+
+func rootPreRunE(logLevelArg *string) error {
+	if *logLevelArg == "" {
+		*logLevelArg = logging.Info.LowerString()
+	}
+
+	logLevel, err := logging.ToLevel(*logLevelArg)
+	if err != nil {
+		return err
+	}
+	logger = logging.NewLogger(
+		"coqnet-epoch-monitor",
+		logging.NewWrappedCore(
+			logLevel,
+			os.Stdout,
+			logging.JSON.ConsoleEncoder(),
+		),
+	)
+	return nil
+}
+
+func main() {
+	Execute()
+}
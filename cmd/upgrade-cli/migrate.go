@@ -0,0 +1,563 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	wcoq "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/WCOQ"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	proxyUtils "github.com/ava-labs/icm-contracts/utils/proxy-utils"
+	validatorManagerUtils "github.com/ava-labs/icm-contracts/utils/validator-manager-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	preMigrationFileName  = "pre-migration.json"
+	postMigrationFileName = "post-migration.json"
+)
+
+var (
+	fromBlock                uint64
+	migrationConfig          string
+	stateOutput              string
+	validatorMessagesAddress string
+)
+
+// migrationTx is a transaction of the migration. Transactions that the sender is not allowed to
+// send, such as granting a role of the token, are marked as manual and must be sent by the
+// account in From.
+type migrationTx struct {
+	Description string          `json:"description"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to,omitempty"`
+	Data        hexutil.Bytes   `json:"data"`
+	Manual      bool            `json:"manual,omitempty"`
+	TxHash      *common.Hash    `json:"txHash,omitempty"`
+}
+
+// migrationReport is the output of migrate-pos run, to be reviewed and signed off.
+type migrationReport struct {
+	Pre          *validatorManagerUtils.ManagerState     `json:"pre"`
+	Post         *validatorManagerUtils.ManagerState     `json:"post,omitempty"`
+	Changes      []validatorManagerUtils.ValidatorChange `json:"changes,omitempty"`
+	Transactions []*migrationTx                          `json:"transactions"`
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate-pos",
+	Short: "Migrates a PoAValidatorManager proxy to a PoS validator manager",
+	Long: `Migrates a PoAValidatorManager behind a TransparentUpgradeableProxy to a
+CoqnetERC20TokenStakingManager, as done by PoaToPos.s.sol. The validators, owner
+and proxy of the validator manager are read before and after the migration, and
+the validator sets are compared, so that the migration can be reviewed and
+signed off. Validators are read from the events of the validator manager, since
+--from-block.`,
+}
+
+var migrateInspectCmd = &cobra.Command{
+	Use:   "inspect --rpc RPC_URL --address PROXY_ADDRESS",
+	Short: "Prints the state of a validator manager",
+	Long: `Prints the proxy implementation and admin, the owner, the subnet ID, the
+initialized version and the validators of the validator manager behind the proxy.
+With --output, the state is also written to a file, which can be compared with
+"migrate-pos diff".`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		client, proxy := dialProxy()
+		defer client.Close()
+
+		state, err := validatorManagerUtils.ReadManagerState(ctx, client, proxy, fromBlock)
+		cobra.CheckErr(err)
+		if stateOutput != "" {
+			cobra.CheckErr(writeJSON(stateOutput, state))
+		}
+		printJSON(cmd, state)
+	},
+}
+
+var migrateRunCmd = &cobra.Command{
+	Use:   "run --rpc RPC_URL --address PROXY_ADDRESS --config CONFIG (--implementation ADDRESS | --artifact ARTIFACT)",
+	Short: "Migrates the validator manager to PoS",
+	Long: `Migrates the validator manager behind the proxy to a
+CoqnetERC20TokenStakingManager configured by the JSON file --config:
+  1. reads the state of the validator manager, and checks that the subnet ID of
+     the config matches, and that it has not been migrated already
+  2. deploys the implementation in the foundry artifact --artifact, unless an
+     implementation deployed at --implementation is given. If both are given, the
+     deployed code is checked against the artifact. The ValidatorMessages library
+     linked by the implementation is deployed from the same foundry output, unless
+     one deployed at --validator-messages-address is given.
+  3. upgrades the proxy and calls initialize through it, granting the
+     DEFAULT_ADMIN_ROLE to the admin of the config, or to the current owner
+  4. grants the ISSUER_ROLE of the token to the proxy, and the REGISTER_ROLE to
+     the registrars of the config
+  5. reads the state again, and compares the validator sets
+
+Transactions are sent by the owner of the ProxyAdmin, whose private key is read
+from the PRIVATE_KEY environment variable. Role grants that it is not allowed to
+send are reported as manual transactions. With --dry-run, the transactions are
+printed instead of being sent. The state before and after the migration is
+written to --output-dir. The command fails if the validator set changed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if implementationAddress == "" && artifactPath == "" {
+			cobra.CheckErr("one of --implementation or --artifact is required")
+		}
+		if implementationAddress != "" && !common.IsHexAddress(implementationAddress) {
+			cobra.CheckErr("invalid implementation address " + implementationAddress)
+		}
+		if validatorMessagesAddress != "" && !common.IsHexAddress(validatorMessagesAddress) {
+			cobra.CheckErr("invalid ValidatorMessages address " + validatorMessagesAddress)
+		}
+		config, err := validatorManagerUtils.LoadPoSMigrationConfig(migrationConfig)
+		cobra.CheckErr(err)
+
+		ctx := context.Background()
+		client, proxy := dialProxy()
+		defer client.Close()
+
+		report, err := migrateToPoS(ctx, client, proxy, config)
+		if report != nil {
+			printJSON(cmd, report)
+		}
+		cobra.CheckErr(err)
+		if len(report.Changes) > 0 {
+			for _, change := range report.Changes {
+				logger.Error("Validator set changed", zap.Stringer("change", change))
+			}
+			cobra.CheckErr(fmt.Sprintf("validator set changed during the migration with %d changes", len(report.Changes)))
+		}
+	},
+}
+
+var migrateDiffCmd = &cobra.Command{
+	Use:   "diff PRE POST",
+	Short: "Compares the validator sets of two states",
+	Long: `Compares the validator sets of two states written by "migrate-pos inspect
+--output" or "migrate-pos run --output-dir", matching validators by node ID.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		pre, err := readManagerState(args[0])
+		cobra.CheckErr(err)
+		post, err := readManagerState(args[1])
+		cobra.CheckErr(err)
+		printJSON(cmd, validatorManagerUtils.DiffValidatorSets(pre.Validators, post.Validators))
+	},
+}
+
+// migrateToPoS runs the migration, or plans it with --dry-run. The report is returned with the
+// transactions sent so far if the migration fails.
+func migrateToPoS(
+	ctx context.Context,
+	client ethclient.Client,
+	proxy common.Address,
+	config *validatorManagerUtils.PoSMigrationConfig,
+) (*migrationReport, error) {
+	pre, err := validatorManagerUtils.ReadManagerState(ctx, client, proxy, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(
+		"Read validator manager",
+		zap.Stringer("proxy", proxy),
+		zap.Stringer("implementation", pre.Implementation),
+		zap.Stringer("owner", pre.Owner),
+		zap.Int("validators", len(pre.Validators.Validators)),
+		zap.Uint64("totalWeight", pre.Validators.TotalWeight),
+	)
+	if err := writeStateFile(preMigrationFileName, pre); err != nil {
+		return nil, err
+	}
+	report := &migrationReport{Pre: pre, Transactions: []*migrationTx{}}
+	if pre.SubnetID != config.SubnetID {
+		return report, fmt.Errorf("validator manager has subnet ID %s, config has %s", pre.SubnetID, config.SubnetID)
+	}
+	if pre.InitializedVersion >= validatorManagerUtils.PoSInitializerVersion {
+		return report, fmt.Errorf("validator manager is already initialized to version %d", pre.InitializedVersion)
+	}
+	admin := config.Admin
+	if admin == (common.Address{}) {
+		admin = pre.Owner
+	}
+	if admin == (common.Address{}) {
+		return report, errors.New("validator manager has no owner, so the admin must be set in the config")
+	}
+
+	// The migration is sent by the ProxyAdmin owner. With --dry-run, no key is needed.
+	var key *ecdsa.PrivateKey
+	sender := pre.ProxyAdminOwner
+	if !dryRun {
+		key = readPrivateKey()
+		if crypto.PubkeyToAddress(key.PublicKey) != sender {
+			return report, fmt.Errorf(
+				"sender %s is not the owner %s of ProxyAdmin %s",
+				crypto.PubkeyToAddress(key.PublicKey), sender, pre.ProxyAdmin,
+			)
+		}
+	}
+	send := func(tx *migrationTx) (*types.Receipt, error) {
+		report.Transactions = append(report.Transactions, tx)
+		if dryRun || tx.Manual {
+			return nil, nil
+		}
+		receipt, err := sendContractTx(ctx, client, key, tx.To, tx.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to %s", tx.Description)
+		}
+		tx.TxHash = &receipt.TxHash
+		logger.Info("Sent transaction", zap.String("description", tx.Description), zap.Stringer("txID", receipt.TxHash))
+		return receipt, nil
+	}
+
+	implementation, err := posImplementation(ctx, client, sender, send)
+	if err != nil {
+		return report, err
+	}
+
+	initCalldata, err := config.PackPoSInitialize(admin)
+	if err != nil {
+		return report, err
+	}
+	upgradeCalldata, err := packUpgradeAndCall(proxy, implementation, initCalldata)
+	if err != nil {
+		return report, err
+	}
+	_, err = send(&migrationTx{
+		Description: "upgrade the proxy and initialize the PoS validator manager",
+		From:        sender,
+		To:          &pre.ProxyAdmin,
+		Data:        upgradeCalldata,
+	})
+	if err != nil {
+		return report, err
+	}
+
+	if err := grantRoles(ctx, client, proxy, admin, sender, config, send); err != nil {
+		return report, err
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	report.Post, err = validatorManagerUtils.ReadManagerState(ctx, client, proxy, fromBlock)
+	if err != nil {
+		return report, err
+	}
+	if err := writeStateFile(postMigrationFileName, report.Post); err != nil {
+		return report, err
+	}
+	report.Changes = validatorManagerUtils.DiffValidatorSets(pre.Validators, report.Post.Validators)
+	if report.Post.Implementation != implementation {
+		return report, fmt.Errorf("proxy implementation is %s after the migration, expected %s", report.Post.Implementation, implementation)
+	}
+	if report.Post.InitializedVersion != validatorManagerUtils.PoSInitializerVersion {
+		return report, fmt.Errorf("validator manager is initialized to version %d after the migration", report.Post.InitializedVersion)
+	}
+	logger.Info(
+		"Migrated validator manager",
+		zap.Stringer("proxy", proxy),
+		zap.Stringer("implementation", implementation),
+		zap.Stringer("admin", admin),
+	)
+	return report, nil
+}
+
+// posImplementation returns the address of the PoS implementation, deploying it from the artifact
+// if no implementation is given. With --dry-run, the address it would be deployed to is returned.
+func posImplementation(
+	ctx context.Context,
+	client ethclient.Client,
+	sender common.Address,
+	send func(*migrationTx) (*types.Receipt, error),
+) (common.Address, error) {
+	if implementationAddress != "" {
+		implementation := common.HexToAddress(implementationAddress)
+		if artifactPath != "" {
+			err := proxyUtils.VerifyDeployedBytecode(ctx, client, implementation, artifactPath, ignoreMetadata)
+			if err != nil {
+				return common.Address{}, err
+			}
+			logger.Info("Verified implementation bytecode", zap.String("artifact", artifactPath))
+			return implementation, nil
+		}
+		if err := checkCode(ctx, client, implementation, "implementation"); err != nil {
+			return common.Address{}, err
+		}
+		return implementation, nil
+	}
+
+	nonce, err := client.NonceAt(ctx, sender, nil)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to get nonce")
+	}
+	deploy := func(description string, creationCode []byte) (common.Address, error) {
+		receipt, err := send(&migrationTx{
+			Description: description,
+			From:        sender,
+			Data:        creationCode,
+		})
+		if err != nil {
+			return common.Address{}, err
+		}
+		deployed := crypto.CreateAddress(sender, nonce)
+		nonce++
+		if receipt == nil {
+			return deployed, nil
+		}
+		return receipt.ContractAddress, nil
+	}
+
+	constructorArgs := validatorManagerUtils.PoSImplementationConstructorArgs()
+	linked, err := deploymentUtils.HasLibraryPlaceholders(artifactPath)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !linked {
+		creationCode, err := deploymentUtils.LoadCreationCode(artifactPath, constructorArgs)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return deploy("deploy the PoS validator manager implementation", creationCode)
+	}
+
+	// The implementation links the ValidatorMessages library, which is deployed from the same
+	// foundry output as the implementation unless it is already deployed.
+	var library common.Address
+	if validatorMessagesAddress != "" {
+		library = common.HexToAddress(validatorMessagesAddress)
+		if err := checkCode(ctx, client, library, "ValidatorMessages library"); err != nil {
+			return common.Address{}, err
+		}
+	} else {
+		libraryCode, err := deploymentUtils.LoadCreationCode(validatorMessagesArtifactPath(artifactPath), nil)
+		if err != nil {
+			return common.Address{}, errors.Wrap(err, "failed to load the ValidatorMessages library, pass --validator-messages-address to use a deployed one")
+		}
+		library, err = deploy("deploy the ValidatorMessages library", libraryCode)
+		if err != nil {
+			return common.Address{}, err
+		}
+	}
+	creationCode, err := deploymentUtils.LoadLinkedCreationCode(artifactPath, library, constructorArgs)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return deploy("deploy the PoS validator manager implementation", creationCode)
+}
+
+// validatorMessagesArtifactPath returns the path of the artifact of the ValidatorMessages library
+// in the foundry output directory of the implementation artifact, e.g.
+// out/ValidatorMessages.sol/ValidatorMessages.json for
+// out/CoqnetERC20TokenStakingManager.sol/CoqnetERC20TokenStakingManager.json.
+func validatorMessagesArtifactPath(implementationArtifact string) string {
+	return filepath.Join(filepath.Dir(filepath.Dir(implementationArtifact)), "ValidatorMessages.sol", "ValidatorMessages.json")
+}
+
+// checkCode checks that a contract is deployed at address.
+func checkCode(ctx context.Context, client ethclient.Client, address common.Address, name string) error {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %s code", name)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no code deployed at %s %s", name, address)
+	}
+	return nil
+}
+
+// grantRoles grants the ISSUER_ROLE of the token to the proxy, so that it can mint rewards, and the
+// REGISTER_ROLE of the validator manager to the registrars. Grants the sender is not allowed to
+// send are added to the report as manual transactions.
+func grantRoles(
+	ctx context.Context,
+	client ethclient.Client,
+	proxy common.Address,
+	admin common.Address,
+	sender common.Address,
+	config *validatorManagerUtils.PoSMigrationConfig,
+	send func(*migrationTx) (*types.Receipt, error),
+) error {
+	opts := &bind.CallOpts{Context: ctx}
+	token, err := wcoq.NewWCOQCaller(config.Token, client)
+	if err != nil {
+		return err
+	}
+	issuerRole, err := token.ISSUERROLE(opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to get token ISSUER_ROLE")
+	}
+	hasRole, err := token.HasRole(opts, issuerRole, proxy)
+	if err != nil {
+		return errors.Wrap(err, "failed to check token ISSUER_ROLE")
+	}
+	if !hasRole {
+		issuerAdminRole, err := token.GetRoleAdmin(opts, issuerRole)
+		if err != nil {
+			return errors.Wrap(err, "failed to get admin of token ISSUER_ROLE")
+		}
+		senderIsAdmin, err := token.HasRole(opts, issuerAdminRole, sender)
+		if err != nil {
+			return errors.Wrap(err, "failed to check token role admin")
+		}
+		tokenABI, err := wcoq.WCOQMetaData.GetAbi()
+		if err != nil {
+			return err
+		}
+		calldata, err := tokenABI.Pack("grantRole", issuerRole, proxy)
+		if err != nil {
+			return err
+		}
+		from := sender
+		if !senderIsAdmin {
+			// Any account with the role admin can grant it, but the owner is the most likely one
+			if from, err = token.OWNER(opts); err != nil {
+				return errors.Wrap(err, "failed to get token owner")
+			}
+		}
+		_, err = send(&migrationTx{
+			Description: "grant the token ISSUER_ROLE to the validator manager",
+			From:        from,
+			To:          &config.Token,
+			Data:        calldata,
+			Manual:      !senderIsAdmin,
+		})
+		if err != nil {
+			return err
+		}
+		if !senderIsAdmin {
+			logger.Warn("Sender cannot grant the token ISSUER_ROLE, it must be granted manually", zap.Stringer("token", config.Token))
+		}
+	}
+
+	managerABI, err := coqnetstakingmanager.CoqnetERC20TokenStakingManagerMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	registerRole := crypto.Keccak256Hash([]byte("REGISTER_ROLE"))
+	for _, registrar := range config.Registrars {
+		calldata, err := managerABI.Pack("grantRole", registerRole, registrar)
+		if err != nil {
+			return err
+		}
+		_, err = send(&migrationTx{
+			Description: fmt.Sprintf("grant the REGISTER_ROLE to %s", registrar),
+			From:        admin,
+			To:          &proxy,
+			Data:        calldata,
+			Manual:      admin != sender,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sendContractTx(
+	ctx context.Context,
+	client ethclient.Client,
+	key *ecdsa.PrivateKey,
+	to *common.Address,
+	data []byte,
+) (*types.Receipt, error) {
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain ID")
+	}
+	gasFeeCap, gasTipCap, nonce, err := txParams(ctx, client, sender)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := client.EstimateGas(ctx, interfaces.CallMsg{From: sender, To: to, Data: data})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to estimate gas")
+	}
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        to,
+		Gas:       gas,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Data:      data,
+	}), types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction")
+	}
+	return sendAndWait(ctx, client, tx)
+}
+
+func writeStateFile(name string, state *validatorManagerUtils.ManagerState) error {
+	if outputDir == "" {
+		return nil
+	}
+	return writeJSON(filepath.Join(outputDir, name), state)
+}
+
+func writeJSON(path string, value interface{}) error {
+	valueJSON, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, valueJSON, 0o644)
+}
+
+func readManagerState(path string) (*validatorManagerUtils.ManagerState, error) {
+	stateJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	var state validatorManagerUtils.ManagerState
+	if err := json.Unmarshal(stateJSON, &state); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	if state.Validators == nil {
+		return nil, fmt.Errorf("%s has no validators", path)
+	}
+	return &state, nil
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateInspectCmd, migrateRunCmd, migrateDiffCmd)
+
+	for _, cmd := range []*cobra.Command{migrateInspectCmd, migrateRunCmd} {
+		cmd.Flags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to connect to the node")
+		cmd.Flags().StringVar(&contractAddress, "address", "", "Address of the validator manager proxy")
+		cmd.Flags().Uint64Var(&fromBlock, "from-block", 0, "Block from which the events of the validator manager are read")
+		for _, flag := range []string{"rpc", "address"} {
+			err := cmd.MarkFlagRequired(flag)
+			cobra.CheckErr(err)
+		}
+	}
+	migrateInspectCmd.Flags().StringVar(&stateOutput, "output", "", "File the state is written to")
+
+	migrateRunCmd.Flags().StringVar(&migrationConfig, "config", "", "JSON file with the settings of the PoS validator manager")
+	migrateRunCmd.Flags().StringVar(&implementationAddress, "implementation", "", "Address of a deployed PoS validator manager implementation")
+	migrateRunCmd.Flags().StringVar(&artifactPath, "artifact", "", "Path to the foundry artifact of the PoS validator manager implementation")
+	migrateRunCmd.Flags().StringVar(&validatorMessagesAddress, "validator-messages-address", "", "Address of a deployed ValidatorMessages library to link the implementation in --artifact with")
+	migrateRunCmd.Flags().BoolVar(&ignoreMetadata, "ignore-metadata", false, "Ignore the metadata appended to the bytecode by solc when verifying it")
+	migrateRunCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the migration transactions instead of sending them")
+	migrateRunCmd.Flags().StringVar(&outputDir, "output-dir", "", "Directory the state before and after the migration is written to")
+	err := migrateRunCmd.MarkFlagRequired("config")
+	cobra.CheckErr(err)
+}
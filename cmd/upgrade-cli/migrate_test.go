@@ -0,0 +1,100 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	validatorManagerUtils "github.com/ava-labs/icm-contracts/utils/validator-manager-utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "inspect missing flags",
+			args: []string{"migrate-pos", "inspect"},
+			err:  fmt.Errorf(`required flag(s) "address", "rpc" not set`),
+		},
+		{
+			name: "run missing flags",
+			args: []string{"migrate-pos", "run", "--rpc", "http://127.0.0.1:9650", "--address", "0x0000000000000000000000000000000000001234"},
+			err:  fmt.Errorf(`required flag(s) "config" not set`),
+		},
+		{
+			name: "diff missing files",
+			args: []string{"migrate-pos", "diff", "pre.json"},
+			err:  fmt.Errorf("accepts 2 arg(s), received 1"),
+		},
+		{
+			name: "help",
+			args: []string{"migrate-pos", "--help"},
+			err:  nil,
+			out:  "Migrates a PoAValidatorManager behind a TransparentUpgradeableProxy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestMigrateDiff(t *testing.T) {
+	validator := validatorManagerUtils.Validator{
+		ValidationID: ids.ID{1},
+		NodeID:       ids.BuildTestNodeID([]byte{1}),
+		Status:       validatorManagerUtils.Active,
+		Weight:       100,
+	}
+	pre := &validatorManagerUtils.ManagerState{
+		Validators: &validatorManagerUtils.ValidatorSet{Validators: []validatorManagerUtils.Validator{validator}},
+	}
+	post := &validatorManagerUtils.ManagerState{
+		Validators: &validatorManagerUtils.ValidatorSet{Validators: []validatorManagerUtils.Validator{}},
+	}
+	dir := t.TempDir()
+	prePath := filepath.Join(dir, preMigrationFileName)
+	postPath := filepath.Join(dir, postMigrationFileName)
+	require.NoError(t, writeJSON(prePath, pre))
+	require.NoError(t, writeJSON(postPath, post))
+
+	out, err := executeTestCmd(t, rootCmd, "migrate-pos", "diff", prePath, postPath)
+	require.NoError(t, err)
+	var changes []validatorManagerUtils.ValidatorChange
+	require.NoError(t, json.Unmarshal([]byte(out), &changes))
+	require.Equal(t, []validatorManagerUtils.ValidatorChange{{
+		Kind:         validatorManagerUtils.ValidatorRemoved,
+		NodeID:       validator.NodeID,
+		ValidationID: validator.ValidationID,
+		OldWeight:    100,
+	}}, changes)
+
+	_, err = readManagerState(filepath.Join(dir, "missing.json"))
+	require.ErrorContains(t, err, "failed to read")
+}
+
+func TestValidatorMessagesArtifactPath(t *testing.T) {
+	require.Equal(
+		t,
+		filepath.Join("out", "ValidatorMessages.sol", "ValidatorMessages.json"),
+		validatorMessagesArtifactPath(filepath.Join("out", "CoqnetERC20TokenStakingManager.sol", "CoqnetERC20TokenStakingManager.json")),
+	)
+}
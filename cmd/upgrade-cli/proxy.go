@@ -0,0 +1,256 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	proxyadmin "github.com/ava-labs/icm-contracts/abi-bindings/go/ProxyAdmin"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	proxyUtils "github.com/ava-labs/icm-contracts/utils/proxy-utils"
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const privateKeyEnvVar = "PRIVATE_KEY"
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Inspects and upgrades transparent upgradeable proxies",
+	Long: `Inspects and upgrades TransparentUpgradeableProxy contracts, whose
+implementation and ProxyAdmin are stored in the EIP-1967 slots.`,
+}
+
+var proxyInfoCmd = &cobra.Command{
+	Use:   "info --rpc RPC_URL --address PROXY_ADDRESS",
+	Short: "Prints the implementation and admin of a proxy",
+	Long: `Prints the implementation and ProxyAdmin of a proxy, read from the EIP-1967
+slots, and the owner of the ProxyAdmin, which is allowed to upgrade the proxy.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		client, proxy := dialProxy()
+		defer client.Close()
+
+		implementation, err := proxyUtils.GetImplementation(ctx, client, proxy)
+		cobra.CheckErr(err)
+		admin, err := proxyUtils.GetAdmin(ctx, client, proxy)
+		cobra.CheckErr(err)
+		proxyAdmin, err := proxyadmin.NewProxyAdmin(admin, client)
+		cobra.CheckErr(err)
+		owner, err := proxyAdmin.Owner(&bind.CallOpts{Context: ctx})
+		cobra.CheckErr(err)
+
+		printJSON(cmd, struct {
+			Implementation common.Address `json:"implementation"`
+			Admin          common.Address `json:"admin"`
+			AdminOwner     common.Address `json:"adminOwner"`
+		}{
+			Implementation: implementation,
+			Admin:          admin,
+			AdminOwner:     owner,
+		})
+	},
+}
+
+var (
+	implementationAddress string
+	artifactPath          string
+	ignoreMetadata        bool
+	oldLayoutPath         string
+	newLayoutPath         string
+	skipStorageCheck      bool
+	initCalldata          string
+	dryRun                bool
+)
+
+var proxyUpgradeCmd = &cobra.Command{
+	Use:   "upgrade --rpc RPC_URL --address PROXY_ADDRESS --implementation IMPLEMENTATION_ADDRESS --artifact ARTIFACT",
+	Short: "Upgrades a proxy to a new implementation",
+	Long: `Upgrades a proxy to a new implementation by calling upgradeAndCall on its
+ProxyAdmin, after checking that:
+  - the code deployed at --implementation matches the deployedBytecode of the
+    foundry artifact --artifact, ignoring immutable variables
+  - the storage layout --new-layout of the new implementation is compatible with
+    the layout --old-layout of the current one. Layouts are given as for
+    "storage compare", with --contract naming the contract in foundry output
+    directories. Pass --skip-storage-check to skip this check.
+  - the sender, whose private key is read from the PRIVATE_KEY environment
+    variable, owns the ProxyAdmin
+
+If --init-calldata is set, the new implementation is called with it in the same
+transaction, e.g. to call a reinitializer. With --dry-run, the checks are run and
+the upgradeAndCall calldata is printed instead of being sent, e.g. for a multisig
+owner.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !common.IsHexAddress(implementationAddress) {
+			cobra.CheckErr("invalid implementation address " + implementationAddress)
+		}
+		implementation := common.HexToAddress(implementationAddress)
+		calldata, err := hexutil.Decode(initCalldata)
+		if initCalldata != "" && err != nil {
+			cobra.CheckErr(fmt.Sprintf("invalid init calldata: %s", err))
+		}
+		if !skipStorageCheck && (oldLayoutPath == "" || newLayoutPath == "") {
+			cobra.CheckErr("--old-layout and --new-layout are required unless --skip-storage-check is set")
+		}
+
+		ctx := context.Background()
+		client, proxy := dialProxy()
+		defer client.Close()
+
+		currentImplementation, err := proxyUtils.GetImplementation(ctx, client, proxy)
+		cobra.CheckErr(err)
+		admin, err := proxyUtils.GetAdmin(ctx, client, proxy)
+		cobra.CheckErr(err)
+		logger.Info(
+			"Read proxy",
+			zap.Stringer("proxy", proxy),
+			zap.Stringer("implementation", currentImplementation),
+			zap.Stringer("admin", admin),
+		)
+		if currentImplementation == implementation {
+			cobra.CheckErr(fmt.Sprintf("proxy %s is already using implementation %s", proxy, implementation))
+		}
+
+		err = proxyUtils.VerifyDeployedBytecode(ctx, client, implementation, artifactPath, ignoreMetadata)
+		cobra.CheckErr(err)
+		logger.Info("Verified implementation bytecode", zap.String("artifact", artifactPath))
+
+		if skipStorageCheck {
+			logger.Warn("Skipping storage layout check")
+		} else {
+			checkStorageLayouts(cmd)
+		}
+
+		upgradeCalldata, err := packUpgradeAndCall(proxy, implementation, calldata)
+		cobra.CheckErr(err)
+		if dryRun {
+			printJSON(cmd, struct {
+				To   common.Address `json:"to"`
+				Data hexutil.Bytes  `json:"data"`
+			}{
+				To:   admin,
+				Data: upgradeCalldata,
+			})
+			return
+		}
+
+		key, err := crypto.HexToECDSA(os.Getenv(privateKeyEnvVar))
+		if err != nil {
+			cobra.CheckErr(fmt.Sprintf("invalid private key in %s: %s", privateKeyEnvVar, err))
+		}
+		proxyAdmin, err := proxyadmin.NewProxyAdmin(admin, client)
+		cobra.CheckErr(err)
+		owner, err := proxyAdmin.Owner(&bind.CallOpts{Context: ctx})
+		cobra.CheckErr(err)
+		sender := crypto.PubkeyToAddress(key.PublicKey)
+		if owner != sender {
+			cobra.CheckErr(fmt.Sprintf("sender %s is not the owner %s of ProxyAdmin %s", sender, owner, admin))
+		}
+
+		chainID, err := client.ChainID(ctx)
+		cobra.CheckErr(err)
+		opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+		cobra.CheckErr(err)
+		opts.Context = ctx
+		baseFee, err := client.EstimateBaseFee(ctx)
+		cobra.CheckErr(err)
+		opts.GasFeeCap = gasUtils.GasFeeCap(baseFee)
+		opts.GasTipCap = big.NewInt(gasUtils.MaxPriorityFeePerGas)
+
+		tx, err := proxyAdmin.UpgradeAndCall(opts, proxy, implementation, calldata)
+		cobra.CheckErr(err)
+		logger.Info("Sent upgrade transaction", zap.Stringer("txID", tx.Hash()))
+		receipt, err := bind.WaitMined(ctx, client, tx)
+		cobra.CheckErr(err)
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			cobra.CheckErr(fmt.Sprintf("upgrade transaction %s reverted", tx.Hash()))
+		}
+
+		newImplementation, err := proxyUtils.GetImplementation(ctx, client, proxy)
+		cobra.CheckErr(err)
+		if newImplementation != implementation {
+			cobra.CheckErr(fmt.Sprintf("proxy implementation is %s after upgrade, expected %s", newImplementation, implementation))
+		}
+		logger.Info(
+			"Upgraded proxy",
+			zap.Stringer("proxy", proxy),
+			zap.Stringer("implementation", implementation),
+			zap.Stringer("txID", tx.Hash()),
+		)
+	},
+}
+
+func dialProxy() (ethclient.Client, common.Address) {
+	if !common.IsHexAddress(contractAddress) {
+		cobra.CheckErr("invalid proxy address " + contractAddress)
+	}
+	client, err := ethclient.Dial(rpcEndpoint)
+	cobra.CheckErr(err)
+	return client, common.HexToAddress(contractAddress)
+}
+
+func checkStorageLayouts(cmd *cobra.Command) {
+	oldLayout, err := loadComparedLayout(oldLayoutPath)
+	cobra.CheckErr(err)
+	newLayout, err := loadComparedLayout(newLayoutPath)
+	cobra.CheckErr(err)
+	changes := storageUtils.CompareLayouts(oldLayout, newLayout)
+	for _, change := range changes {
+		cmd.Printf("%s %s\n", change.Kind, change)
+	}
+	if len(changes) > 0 {
+		cobra.CheckErr(fmt.Sprintf("found %d incompatible storage layout changes", len(changes)))
+	}
+	logger.Info("Storage layouts are compatible")
+}
+
+func packUpgradeAndCall(proxy common.Address, implementation common.Address, calldata []byte) ([]byte, error) {
+	proxyAdminABI, err := proxyadmin.ProxyAdminMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if calldata == nil {
+		calldata = []byte{}
+	}
+	return proxyAdminABI.Pack("upgradeAndCall", proxy, implementation, calldata)
+}
+
+func init() {
+	rootCmd.AddCommand(proxyCmd)
+	proxyCmd.AddCommand(proxyInfoCmd, proxyUpgradeCmd)
+
+	proxyCmd.PersistentFlags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to connect to the node")
+	proxyCmd.PersistentFlags().StringVar(&contractAddress, "address", "", "Address of the proxy")
+	for _, flag := range []string{"rpc", "address"} {
+		err := proxyCmd.MarkPersistentFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+
+	proxyUpgradeCmd.Flags().StringVar(&implementationAddress, "implementation", "", "Address of the new implementation")
+	proxyUpgradeCmd.Flags().StringVar(&artifactPath, "artifact", "", "Path to the foundry artifact of the new implementation")
+	proxyUpgradeCmd.Flags().BoolVar(&ignoreMetadata, "ignore-metadata", false, "Ignore the metadata appended to the bytecode by solc when verifying it")
+	proxyUpgradeCmd.Flags().StringVar(&oldLayoutPath, "old-layout", "", "Storage layout of the current implementation")
+	proxyUpgradeCmd.Flags().StringVar(&newLayoutPath, "new-layout", "", "Storage layout of the new implementation")
+	proxyUpgradeCmd.Flags().StringVar(&contractName, "contract", "", "Name of the contract, used when a layout is a foundry output directory")
+	proxyUpgradeCmd.Flags().BoolVar(&skipStorageCheck, "skip-storage-check", false, "Skip the storage layout compatibility check")
+	proxyUpgradeCmd.Flags().StringVar(&initCalldata, "init-calldata", "", "Hex encoded calldata to call the new implementation with, e.g. a reinitializer")
+	proxyUpgradeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Run the checks and print the upgradeAndCall transaction instead of sending it")
+	for _, flag := range []string{"implementation", "artifact"} {
+		err := proxyUpgradeCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+}
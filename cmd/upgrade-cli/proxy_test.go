@@ -0,0 +1,68 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "info missing flags",
+			args: []string{"proxy", "info"},
+			err:  fmt.Errorf(`required flag(s) "address", "rpc" not set`),
+		},
+		{
+			name: "upgrade missing flags",
+			args: []string{"proxy", "upgrade", "--rpc", "http://localhost:9650", "--address", common.Address{}.Hex()},
+			err:  fmt.Errorf(`required flag(s) "artifact", "implementation" not set`),
+		},
+		{
+			name: "help",
+			args: []string{"proxy", "--help"},
+			err:  nil,
+			out:  "Inspects and upgrades TransparentUpgradeableProxy contracts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestPackUpgradeAndCall(t *testing.T) {
+	proxy := common.HexToAddress("0x0000000000000000000000000000000000001234")
+	implementation := common.HexToAddress("0x0000000000000000000000000000000000005678")
+
+	// upgradeAndCall(address,address,bytes) with empty calldata
+	data, err := packUpgradeAndCall(proxy, implementation, nil)
+	require.NoError(t, err)
+	require.Equal(t, "0x9623609d", hexutil.Encode(data[:4]))
+	require.Len(t, data, 4+4*32)
+
+	initializer := hexutil.MustDecode("0x8129fc1c")
+	data, err = packUpgradeAndCall(proxy, implementation, initializer)
+	require.NoError(t, err)
+	require.Len(t, data, 4+5*32)
+	require.Equal(t, initializer, data[4+4*32:4+4*32+len(initializer)])
+}
@@ -0,0 +1,63 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"os"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+var logger logging.Logger
+
+var rootCmd = &cobra.Command{
+	Use:   "upgrade-cli",
+	Short: "A CLI for inspecting and upgrading upgradeable ICM contracts",
+	Long: `A CLI for inspecting and upgrading upgradeable ICM contracts. The CLI can
+read and decode the ERC-7201 namespaced storage of validator managers and
+other upgradeable contracts, check that storage layouts remain compatible
+across upgrades, and upgrade transparent upgradeable proxies.`,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	logLevelArg := rootCmd.PersistentFlags().StringP("log", "l", "", "Log level i.e. debug, info...")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return rootPreRunE(logLevelArg)
+	}
+}
+
+func rootPreRunE(logLevelArg *string) error {
+	if *logLevelArg == "" {
+		*logLevelArg = logging.Info.LowerString()
+	}
+
+	logLevel, err := logging.ToLevel(*logLevelArg)
+	if err != nil {
+		return err
+	}
+	logger = logging.NewLogger(
+		"upgrade-cli",
+		logging.NewWrappedCore(
+			logLevel,
+			os.Stdout,
+			logging.Plain.ConsoleEncoder(),
+		),
+	)
+	return nil
+}
+
+func main() {
+	Execute()
+}
@@ -0,0 +1,185 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	layoutPath    string
+	builtinLayout string
+	namespace     string
+)
+
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Inspects the storage of upgradeable contracts",
+	Long: `Inspects the storage of upgradeable contracts. Storage is described by a
+storage layout, in the format of the storageLayout output of solc and foundry,
+with an additional "namespaces" object mapping each ERC-7201 namespace ID to the
+members of its storage struct. Pass --layout to use a layout file or foundry artifact,
+or --builtin-layout to use a layout bundled with the CLI.`,
+}
+
+var storageSlotCmd = &cobra.Command{
+	Use:   "slot [--namespace NAMESPACE] PATH",
+	Short: "Computes the storage slot of a value",
+	Long: `Computes the storage slot, byte offset and type of the value at PATH,
+e.g. _validationPeriods[0x1234...].status or _epochs[3].validationIDs[0].
+PATH is resolved within the ERC-7201 namespace given by --namespace, or within
+the contract's state variables if no namespace is given.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		layout := loadLayout()
+		loc, err := layout.Resolve(namespace, args[0])
+		cobra.CheckErr(err)
+		printJSON(cmd, struct {
+			Slot   common.Hash `json:"slot"`
+			Offset uint64      `json:"offset"`
+			Type   string      `json:"type"`
+		}{
+			Slot:   loc.Slot,
+			Offset: loc.Offset,
+			Type:   loc.Type,
+		})
+	},
+}
+
+var (
+	rpcEndpoint     string
+	contractAddress string
+	blockNumber     int64
+)
+
+var storageReadCmd = &cobra.Command{
+	Use:   "read --rpc RPC_URL --address CONTRACT_ADDRESS [--namespace NAMESPACE] [PATH]",
+	Short: "Reads and decodes a value from contract storage",
+	Long: `Reads the value at PATH from the storage of a contract via eth_getStorageAt,
+and prints it decoded as JSON. If PATH is omitted, all members of the namespace
+(or all state variables) are read. Mappings cannot be enumerated, so they must be
+indexed with a key, and are omitted when reading the structs containing them.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !common.IsHexAddress(contractAddress) {
+			cobra.CheckErr("invalid contract address " + contractAddress)
+		}
+		layout := loadLayout()
+		path := ""
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		client, err := ethclient.Dial(rpcEndpoint)
+		cobra.CheckErr(err)
+		defer client.Close()
+
+		var block *big.Int
+		if blockNumber >= 0 {
+			block = big.NewInt(blockNumber)
+		}
+		reader := storageUtils.NewReader(client, common.HexToAddress(contractAddress), layout, block)
+		value, err := reader.Read(context.Background(), namespace, path)
+		cobra.CheckErr(err)
+		printJSON(cmd, value)
+	},
+}
+
+var contractName string
+
+var storageCompareCmd = &cobra.Command{
+	Use:   "compare [--contract CONTRACT] OLD NEW",
+	Short: "Checks that a new storage layout is compatible with an old one",
+	Long: `Checks that upgrading a contract from the OLD storage layout to the NEW one
+preserves its storage. OLD and NEW are either storage layout files or foundry
+artifacts, or foundry output directories containing the artifact of --contract.
+Output directories must be built with --ast --extra-output storageLayout, and the
+ERC-7201 namespaces are computed from the structs annotated with
+@custom:storage-location in the contract and its base contracts.
+
+Variables and struct members may be appended, but removing, moving, renaming,
+resizing or retyping existing ones, or removing a namespace, is reported as an
+incompatible change, and the command fails.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldLayout, err := loadComparedLayout(args[0])
+		if err != nil {
+			return err
+		}
+		newLayout, err := loadComparedLayout(args[1])
+		if err != nil {
+			return err
+		}
+		changes := storageUtils.CompareLayouts(oldLayout, newLayout)
+		if len(changes) == 0 {
+			cmd.Println("Storage layouts are compatible")
+			return nil
+		}
+		for _, change := range changes {
+			cmd.Printf("%s %s\n", change.Kind, change)
+		}
+		return fmt.Errorf("found %d incompatible storage layout changes", len(changes))
+	},
+}
+
+// loadComparedLayout loads a layout from a file, or from the artifact of --contract
+// in a foundry output directory.
+func loadComparedLayout(path string) (*storageUtils.StorageLayout, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return storageUtils.LoadLayout(path)
+	}
+	if contractName == "" {
+		return nil, fmt.Errorf("--contract is required to load a layout from directory %s", path)
+	}
+	return storageUtils.LoadFoundryLayout(path, contractName)
+}
+
+func loadLayout() *storageUtils.StorageLayout {
+	if layoutPath != "" {
+		layout, err := storageUtils.LoadLayout(layoutPath)
+		cobra.CheckErr(err)
+		return layout
+	}
+	layout, err := storageUtils.LoadBuiltinLayout(builtinLayout)
+	cobra.CheckErr(err)
+	return layout
+}
+
+func printJSON(cmd *cobra.Command, value interface{}) {
+	valueJSON, err := json.MarshalIndent(value, "", "  ")
+	cobra.CheckErr(err)
+	cmd.Println(string(valueJSON))
+}
+
+func init() {
+	rootCmd.AddCommand(storageCmd)
+	storageCmd.AddCommand(storageSlotCmd, storageReadCmd, storageCompareCmd)
+
+	storageCmd.PersistentFlags().StringVar(&layoutPath, "layout", "", "Path to a storage layout JSON file or foundry artifact")
+	storageCmd.PersistentFlags().StringVar(&builtinLayout, "builtin-layout", storageUtils.ValidatorManagerLayout, "Name of a built-in storage layout, used if --layout is not set")
+	storageCmd.PersistentFlags().StringVar(&namespace, "namespace", "", "ERC-7201 namespace ID, e.g. "+storageUtils.ValidatorManagerNamespace)
+
+	storageReadCmd.Flags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to connect to the node")
+	storageReadCmd.Flags().StringVar(&contractAddress, "address", "", "Address of the contract, or of the proxy in front of it")
+	storageReadCmd.Flags().Int64Var(&blockNumber, "block", -1, "Block number to read storage at. Defaults to the latest block")
+	for _, flag := range []string{"rpc", "address"} {
+		err := storageReadCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+
+	storageCompareCmd.Flags().StringVar(&contractName, "contract", "", "Name of the contract, used when OLD or NEW is a foundry output directory")
+}
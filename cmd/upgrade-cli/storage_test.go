@@ -0,0 +1,78 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testdataV1 = "../../utils/storage-utils/testdata/v1"
+	testdataV2 = "../../utils/storage-utils/testdata/v2"
+)
+
+func TestStorageCmd(t *testing.T) {
+	currentEpochSlot := storageUtils.AddSlot(storageUtils.NamespaceSlot(storageUtils.CoqnetMetricsNamespace), 7)
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "slot no args",
+			args: []string{"storage", "slot"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "slot",
+			args: []string{"storage", "slot", "--namespace", storageUtils.CoqnetMetricsNamespace, "_currentEpoch"},
+			err:  nil,
+			out:  fmt.Sprintf(`"slot": "%s"`, currentEpochSlot.Hex()),
+		},
+		{
+			name: "read missing flags",
+			args: []string{"storage", "read", "_currentEpoch"},
+			err:  fmt.Errorf(`required flag(s) "address", "rpc" not set`),
+		},
+		{
+			name: "compare directory without contract",
+			args: []string{"storage", "compare", testdataV1, testdataV2},
+			err:  fmt.Errorf("--contract is required"),
+		},
+		{
+			name: "compare compatible",
+			args: []string{"storage", "compare", "--contract", "ExampleManager", testdataV1, testdataV1},
+			err:  nil,
+			out:  "Storage layouts are compatible",
+		},
+		{
+			name: "compare incompatible",
+			args: []string{"storage", "compare", "--contract", "ExampleManager", testdataV1, testdataV2},
+			err:  fmt.Errorf("found 4 incompatible storage layout changes"),
+		},
+		{
+			name: "help",
+			args: []string{"storage", "--help"},
+			err:  nil,
+			out:  "Inspects the storage of upgradeable contracts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,576 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	sigAggUtils "github.com/ava-labs/icm-contracts/utils/signature-aggregator-utils"
+	teleporterUtils "github.com/ava-labs/icm-contracts/utils/teleporter-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// Name of the upgrade plan written by teleporter prepare
+	upgradePlanFileName = "upgrade.json"
+	// Name of the chain config files, in directories named after the blockchain IDs
+	chainConfigFileName = "config.json"
+)
+
+// Status of a chain reported by teleporter deploy and teleporter register
+const (
+	statusDeployed   = "deployed"
+	statusRegistered = "registered"
+	statusSkipped    = "already-done"
+	statusFailed     = "failed"
+)
+
+var (
+	chainsFile          string
+	outputDir           string
+	gasPrice            string
+	upgradePlanFile     string
+	signatureAggregator string
+	quorumPercentage    uint64
+	teleporterGasLimit  uint64
+)
+
+// upgradeChain is an entry of the chains file.
+type upgradeChain struct {
+	Name string `json:"name"`
+	RPC  string `json:"rpc"`
+	// NetworkID is the Avalanche network ID, e.g. 1 for Mainnet and 5 for Fuji
+	NetworkID uint32 `json:"networkID"`
+	// SubnetID is the ID of the subnet validating the chain, whose validators sign the
+	// off-chain registry message
+	SubnetID ids.ID `json:"subnetID"`
+	// BlockchainID defaults to the blockchain ID of the TeleporterRegistry
+	BlockchainID    ids.ID         `json:"blockchainID"`
+	RegistryAddress common.Address `json:"registryAddress"`
+	// Version to register the new TeleporterMessenger as. Defaults to the latest version of the
+	// TeleporterRegistry plus one.
+	Version uint64 `json:"version,omitempty"`
+	// ChainConfig is the path of the chain's current chain config, to which the off-chain
+	// registry message is added
+	ChainConfig string `json:"chainConfig,omitempty"`
+}
+
+// upgradePlan is written by teleporter prepare, and read by teleporter deploy and register.
+type upgradePlan struct {
+	TeleporterAddress   common.Address `json:"teleporterAddress"`
+	DeployerAddress     common.Address `json:"deployerAddress"`
+	DeployerTransaction hexutil.Bytes  `json:"deployerTransaction"`
+	RequiredFunding     *hexutil.Big   `json:"requiredFunding"`
+	Chains              []chainUpgrade `json:"chains"`
+}
+
+type chainUpgrade struct {
+	upgradeChain
+	// OffChainMessage is the unsigned Warp message registering the new version
+	OffChainMessage hexutil.Bytes `json:"offChainMessage"`
+	MessageID       ids.ID        `json:"messageID"`
+	// NewChainConfig is the path of the chain config to install on the chain's nodes
+	NewChainConfig string `json:"newChainConfig"`
+}
+
+type chainResult struct {
+	Name   string      `json:"name"`
+	Status string      `json:"status"`
+	TxHash common.Hash `json:"txHash,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+var teleporterCmd = &cobra.Command{
+	Use:   "teleporter",
+	Short: "Upgrades TeleporterMessenger across multiple chains",
+	Long: `Upgrades TeleporterMessenger on a list of chains, by deploying the new version
+with a keyless transaction and registering it with each chain's
+TeleporterRegistry using an off-chain Warp message. An upgrade is run in steps:
+  1. prepare: writes the upgrade plan and the chain config of each chain, which
+     adds the off-chain registry message to the messages its validators sign
+  2. deploy: deploys the new TeleporterMessenger on each chain
+  3. install the chain configs on the chains' validators and restart them
+  4. register: aggregates the signatures of the off-chain registry messages, and
+     calls addProtocolVersion on each chain's TeleporterRegistry
+Each step skips the chains it has already been run on, so it can be retried.`,
+}
+
+var teleporterPrepareCmd = &cobra.Command{
+	Use:   "prepare ARTIFACT --chains CHAINS_FILE --output-dir OUTPUT_DIR",
+	Short: "Prepares the upgrade plan and chain configs",
+	Long: `Constructs the keyless transaction deploying the TeleporterMessenger in the
+foundry artifact ARTIFACT, and the off-chain Warp message registering it with the
+TeleporterRegistry of each chain in the JSON file --chains, which contains a list
+of objects with the fields:
+  - name: name of the chain
+  - rpc: RPC endpoint of the chain
+  - networkID: Avalanche network ID, e.g. 1 for Mainnet and 5 for Fuji
+  - subnetID: ID of the subnet validating the chain
+  - blockchainID: blockchain ID of the chain. Defaults to the blockchain ID of
+    the TeleporterRegistry.
+  - registryAddress: address of the TeleporterRegistry
+  - version: optional version to register. Defaults to the latest version of the
+    TeleporterRegistry plus one.
+  - chainConfig: optional path of the chain's current chain config
+
+The upgrade plan is written to upgrade.json in --output-dir, and the chain config
+of each chain, with the off-chain message added, to <BLOCKCHAIN_ID>/config.json,
+matching the layout of avalanchego's chain config directory.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := deploymentUtils.KeylessTransactionOptions{GasLimit: teleporterGasLimit}
+		if gasPrice != "" {
+			price, ok := new(big.Int).SetString(gasPrice, 10)
+			if !ok {
+				cobra.CheckErr("invalid gas price " + gasPrice)
+			}
+			opts.GasPrice = price
+		}
+		keylessTx, err := deploymentUtils.ConstructKeylessTransactionWithOptions(args[0], opts)
+		cobra.CheckErr(err)
+
+		chains, err := readUpgradeChains(chainsFile)
+		cobra.CheckErr(err)
+		plan := upgradePlan{
+			TeleporterAddress:   keylessTx.ContractAddress,
+			DeployerAddress:     keylessTx.DeployerAddress,
+			DeployerTransaction: keylessTx.Tx,
+			RequiredFunding:     (*hexutil.Big)(keylessTx.RequiredFunding),
+		}
+		for _, chain := range chains {
+			if chain.Version == 0 || chain.BlockchainID == ids.Empty {
+				cobra.CheckErr(readRegistry(context.Background(), &chain))
+			}
+			upgrade, chainConfig, err := newChainUpgrade(chain, keylessTx.ContractAddress, outputDir)
+			cobra.CheckErr(err)
+			cobra.CheckErr(os.MkdirAll(filepath.Dir(upgrade.NewChainConfig), 0o755))
+			cobra.CheckErr(os.WriteFile(upgrade.NewChainConfig, chainConfig, 0o644))
+			logger.Info(
+				"Prepared chain upgrade",
+				zap.String("chain", chain.Name),
+				zap.Uint64("version", chain.Version),
+				zap.Stringer("messageID", upgrade.MessageID),
+				zap.String("chainConfig", upgrade.NewChainConfig),
+			)
+			plan.Chains = append(plan.Chains, upgrade)
+		}
+
+		planJSON, err := json.MarshalIndent(plan, "", "  ")
+		cobra.CheckErr(err)
+		cobra.CheckErr(os.WriteFile(filepath.Join(outputDir, upgradePlanFileName), planJSON, 0o644))
+		printJSON(cmd, plan)
+	},
+}
+
+var teleporterDeployCmd = &cobra.Command{
+	Use:   "deploy --plan UPGRADE_PLAN",
+	Short: "Deploys the new TeleporterMessenger on each chain",
+	Long: `Deploys the new TeleporterMessenger of the upgrade plan --plan on each chain by
+sending its keyless transaction. The keyless deployer is first funded by the
+account whose private key is read from the PRIVATE_KEY environment variable, if
+its balance is below the required funding. Chains on which the TeleporterMessenger
+is already deployed are skipped. The results are printed as JSON.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plan, err := readUpgradePlan(upgradePlanFile)
+		cobra.CheckErr(err)
+		key := readPrivateKey()
+		results := forEachChain(plan, func(ctx context.Context, client ethclient.Client, chain chainUpgrade) (string, common.Hash, error) {
+			return deployTeleporter(ctx, client, plan, key)
+		})
+		printResults(cmd, results)
+	},
+}
+
+var teleporterRegisterCmd = &cobra.Command{
+	Use:   "register --plan UPGRADE_PLAN --signature-aggregator-url URL",
+	Short: "Registers the new TeleporterMessenger with each chain's TeleporterRegistry",
+	Long: `Registers the new TeleporterMessenger of the upgrade plan --plan with the
+TeleporterRegistry of each chain, once its validators have been restarted with
+the chain configs written by prepare. The signatures of the off-chain registry
+message are aggregated by the signature aggregator at --signature-aggregator-url,
+and addProtocolVersion is called with the signed message by the account whose
+private key is read from the PRIVATE_KEY environment variable. The transaction
+must emit AddProtocolVersion and LatestVersionUpdated events for the new version.
+Chains on which the new version is already registered are skipped. The results
+are printed as JSON.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plan, err := readUpgradePlan(upgradePlanFile)
+		cobra.CheckErr(err)
+		key := readPrivateKey()
+		aggregator := sigAggUtils.NewClient(signatureAggregator)
+		results := forEachChain(plan, func(ctx context.Context, client ethclient.Client, chain chainUpgrade) (string, common.Hash, error) {
+			return registerTeleporter(ctx, client, aggregator, plan, chain, key)
+		})
+		printResults(cmd, results)
+	},
+}
+
+func readUpgradeChains(path string) ([]upgradeChain, error) {
+	chainsJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read chains file")
+	}
+	var chains []upgradeChain
+	if err := json.Unmarshal(chainsJSON, &chains); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse chains file %s", path)
+	}
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("no chains in %s", path)
+	}
+	for i, chain := range chains {
+		if chain.Name == "" {
+			return nil, fmt.Errorf("chain %d has no name", i)
+		}
+		if chain.RPC == "" {
+			return nil, fmt.Errorf("chain %s has no RPC endpoint", chain.Name)
+		}
+		if chain.NetworkID == 0 {
+			return nil, fmt.Errorf("chain %s has no network ID", chain.Name)
+		}
+		if chain.RegistryAddress == (common.Address{}) {
+			return nil, fmt.Errorf("chain %s has no registry address", chain.Name)
+		}
+	}
+	return chains, nil
+}
+
+func readUpgradePlan(path string) (*upgradePlan, error) {
+	planJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read upgrade plan")
+	}
+	var plan upgradePlan
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse upgrade plan %s", path)
+	}
+	return &plan, nil
+}
+
+// readRegistry sets the version and blockchain ID of the chain that are not set from its
+// TeleporterRegistry.
+func readRegistry(ctx context.Context, chain *upgradeChain) error {
+	client, err := ethclient.Dial(chain.RPC)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dial chain %s", chain.Name)
+	}
+	defer client.Close()
+	registry, err := teleporterregistry.NewTeleporterRegistryCaller(chain.RegistryAddress, client)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	if chain.Version == 0 {
+		latestVersion, err := registry.LatestVersion(opts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get latest version on chain %s", chain.Name)
+		}
+		chain.Version = latestVersion.Uint64() + 1
+	}
+	if chain.BlockchainID == ids.Empty {
+		blockchainID, err := registry.BlockchainID(opts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get blockchain ID on chain %s", chain.Name)
+		}
+		chain.BlockchainID = blockchainID
+	}
+	return nil
+}
+
+// newChainUpgrade returns the upgrade of a chain to the TeleporterMessenger at teleporterAddress,
+// and the chain config to be written to its NewChainConfig path under outputDir.
+func newChainUpgrade(
+	chain upgradeChain,
+	teleporterAddress common.Address,
+	outputDir string,
+) (chainUpgrade, []byte, error) {
+	message, err := teleporterUtils.NewOffChainRegistryMessage(
+		chain.NetworkID,
+		chain.BlockchainID,
+		chain.RegistryAddress,
+		new(big.Int).SetUint64(chain.Version),
+		teleporterAddress,
+	)
+	if err != nil {
+		return chainUpgrade{}, nil, err
+	}
+	var currentConfig []byte
+	if chain.ChainConfig != "" {
+		currentConfig, err = os.ReadFile(chain.ChainConfig)
+		if err != nil {
+			return chainUpgrade{}, nil, errors.Wrapf(err, "failed to read chain config of chain %s", chain.Name)
+		}
+	}
+	chainConfig, err := teleporterUtils.AddOffChainMessages(currentConfig, message)
+	if err != nil {
+		return chainUpgrade{}, nil, errors.Wrapf(err, "failed to add off-chain message to chain config of chain %s", chain.Name)
+	}
+	return chainUpgrade{
+		upgradeChain:    chain,
+		OffChainMessage: message.Bytes(),
+		MessageID:       message.ID(),
+		NewChainConfig:  filepath.Join(outputDir, chain.BlockchainID.String(), chainConfigFileName),
+	}, chainConfig, nil
+}
+
+func readPrivateKey() *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(os.Getenv(privateKeyEnvVar))
+	if err != nil {
+		cobra.CheckErr(fmt.Sprintf("invalid private key in %s: %s", privateKeyEnvVar, err))
+	}
+	return key
+}
+
+// forEachChain runs step on each chain of the plan, and returns the result of each chain.
+// Failures are reported in the results rather than stopping the upgrade of the other chains.
+func forEachChain(
+	plan *upgradePlan,
+	step func(context.Context, ethclient.Client, chainUpgrade) (string, common.Hash, error),
+) []chainResult {
+	ctx := context.Background()
+	results := make([]chainResult, len(plan.Chains))
+	for i, chain := range plan.Chains {
+		results[i] = chainResult{Name: chain.Name}
+		client, err := ethclient.Dial(chain.RPC)
+		if err == nil {
+			results[i].Status, results[i].TxHash, err = step(ctx, client, chain)
+			client.Close()
+		}
+		if err != nil {
+			logger.Error("Failed to upgrade chain", zap.String("chain", chain.Name), zap.Error(err))
+			results[i].Status = statusFailed
+			results[i].Error = err.Error()
+			continue
+		}
+		logger.Info("Upgraded chain", zap.String("chain", chain.Name), zap.String("status", results[i].Status))
+	}
+	return results
+}
+
+func printResults(cmd *cobra.Command, results []chainResult) {
+	printJSON(cmd, results)
+	var failed int
+	for _, result := range results {
+		if result.Status == statusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		cobra.CheckErr(fmt.Sprintf("failed to upgrade %d chains", failed))
+	}
+}
+
+// deployTeleporter funds the keyless deployer of the plan if needed, and sends its keyless
+// transaction, unless the TeleporterMessenger is already deployed.
+func deployTeleporter(
+	ctx context.Context,
+	client ethclient.Client,
+	plan *upgradePlan,
+	key *ecdsa.PrivateKey,
+) (string, common.Hash, error) {
+	code, err := client.CodeAt(ctx, plan.TeleporterAddress, nil)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to get code")
+	}
+	if len(code) > 0 {
+		return statusSkipped, common.Hash{}, nil
+	}
+	nonce, err := client.NonceAt(ctx, plan.DeployerAddress, nil)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to get deployer nonce")
+	}
+	if nonce != 0 {
+		return "", common.Hash{}, fmt.Errorf("deployer %s has nonce %d, so the keyless transaction can no longer be sent", plan.DeployerAddress, nonce)
+	}
+
+	balance, err := client.BalanceAt(ctx, plan.DeployerAddress, nil)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to get deployer balance")
+	}
+	requiredFunding := plan.RequiredFunding.ToInt()
+	if balance.Cmp(requiredFunding) < 0 {
+		fundingTx, err := newTransferTx(ctx, client, key, plan.DeployerAddress, new(big.Int).Sub(requiredFunding, balance))
+		if err != nil {
+			return "", common.Hash{}, err
+		}
+		if _, err := sendAndWait(ctx, client, fundingTx); err != nil {
+			return "", common.Hash{}, errors.Wrap(err, "failed to fund deployer")
+		}
+	}
+
+	deployerTx := new(types.Transaction)
+	if err := deployerTx.UnmarshalBinary(plan.DeployerTransaction); err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to parse keyless transaction")
+	}
+	if _, err := sendAndWait(ctx, client, deployerTx); err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to deploy TeleporterMessenger")
+	}
+	code, err = client.CodeAt(ctx, plan.TeleporterAddress, nil)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to get code")
+	}
+	if len(code) == 0 {
+		return "", common.Hash{}, fmt.Errorf("no code at %s after deployment", plan.TeleporterAddress)
+	}
+	return statusDeployed, deployerTx.Hash(), nil
+}
+
+// registerTeleporter aggregates the signatures of the chain's off-chain registry message, and
+// calls addProtocolVersion with it, unless the TeleporterMessenger is already registered.
+func registerTeleporter(
+	ctx context.Context,
+	client ethclient.Client,
+	aggregator *sigAggUtils.Client,
+	plan *upgradePlan,
+	chain chainUpgrade,
+	key *ecdsa.PrivateKey,
+) (string, common.Hash, error) {
+	registry, err := teleporterregistry.NewTeleporterRegistryCaller(chain.RegistryAddress, client)
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	version := new(big.Int).SetUint64(chain.Version)
+	registeredVersion, err := registry.GetVersionFromAddress(&bind.CallOpts{Context: ctx}, plan.TeleporterAddress)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to get registered version")
+	}
+	if registeredVersion.Sign() != 0 {
+		if registeredVersion.Cmp(version) != 0 {
+			return "", common.Hash{}, fmt.Errorf("%s is registered as version %s, expected %s", plan.TeleporterAddress, registeredVersion, version)
+		}
+		return statusSkipped, common.Hash{}, nil
+	}
+
+	unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(chain.OffChainMessage)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to parse off-chain message")
+	}
+	signedMessage, err := aggregator.CreateSignedMessage(unsignedMessage, nil, chain.SubnetID, quorumPercentage)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to aggregate signatures")
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to get chain ID")
+	}
+	gasFeeCap, gasTipCap, nonce, err := txParams(ctx, client, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	tx, err := teleporterUtils.NewAddProtocolVersionTx(chainID, nonce, chain.RegistryAddress, gasFeeCap, gasTipCap, signedMessage)
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to sign transaction")
+	}
+	receipt, err := sendAndWait(ctx, client, signedTx)
+	if err != nil {
+		return "", common.Hash{}, errors.Wrap(err, "failed to call addProtocolVersion")
+	}
+	err = teleporterUtils.CheckProtocolVersionAdded(receipt, chain.RegistryAddress, version, plan.TeleporterAddress)
+	if err != nil {
+		return "", signedTx.Hash(), err
+	}
+	return statusRegistered, signedTx.Hash(), nil
+}
+
+func txParams(ctx context.Context, client ethclient.Client, sender common.Address) (*big.Int, *big.Int, uint64, error) {
+	baseFee, err := client.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "failed to estimate base fee")
+	}
+	nonce, err := client.NonceAt(ctx, sender, nil)
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "failed to get nonce")
+	}
+	return gasUtils.GasFeeCap(baseFee), big.NewInt(gasUtils.MaxPriorityFeePerGas), nonce, nil
+}
+
+func newTransferTx(
+	ctx context.Context,
+	client ethclient.Client,
+	key *ecdsa.PrivateKey,
+	recipient common.Address,
+	amount *big.Int,
+) (*types.Transaction, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain ID")
+	}
+	gasFeeCap, gasTipCap, nonce, err := txParams(ctx, client, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        &recipient,
+		Gas:       params.TxGas,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Value:     amount,
+	})
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+}
+
+func sendAndWait(ctx context.Context, client ethclient.Client, tx *types.Transaction) (*types.Receipt, error) {
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", tx.Hash())
+	}
+	return receipt, nil
+}
+
+func init() {
+	rootCmd.AddCommand(teleporterCmd)
+	teleporterCmd.AddCommand(teleporterPrepareCmd, teleporterDeployCmd, teleporterRegisterCmd)
+
+	teleporterPrepareCmd.Flags().StringVar(&chainsFile, "chains", "", "JSON file listing the chains to upgrade")
+	teleporterPrepareCmd.Flags().StringVar(&outputDir, "output-dir", "", "Directory the upgrade plan and chain configs are written to")
+	teleporterPrepareCmd.Flags().StringVar(&gasPrice, "gas-price", "", "Gas price in wei of the keyless transaction (default 2500 gwei)")
+	teleporterPrepareCmd.Flags().Uint64Var(&teleporterGasLimit, "gas-limit", 0, "Gas limit of the keyless transaction (default 4000000)")
+	for _, flag := range []string{"chains", "output-dir"} {
+		err := teleporterPrepareCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+
+	for _, c := range []*cobra.Command{teleporterDeployCmd, teleporterRegisterCmd} {
+		c.Flags().StringVar(&upgradePlanFile, "plan", "", "Upgrade plan written by teleporter prepare")
+		cobra.CheckErr(c.MarkFlagRequired("plan"))
+	}
+	teleporterRegisterCmd.Flags().StringVar(&signatureAggregator, "signature-aggregator-url", "", "Base URL of the signature aggregator API, e.g. http://localhost:8080")
+	teleporterRegisterCmd.Flags().Uint64Var(&quorumPercentage, "quorum-percentage", warp.WarpDefaultQuorumNumerator, "Percentage of the subnet's weight that must sign the registry message")
+	cobra.CheckErr(teleporterRegisterCmd.MarkFlagRequired("signature-aggregator-url"))
+}
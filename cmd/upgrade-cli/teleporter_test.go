@@ -0,0 +1,167 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	teleporterUtils "github.com/ava-labs/icm-contracts/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleArtifact = "../../utils/deployment-utils/testdata/Example.json"
+
+var testRegistryAddress = common.HexToAddress("0x0000000000000000000000000000000000001234")
+
+func TestTeleporterCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "prepare missing flags",
+			args: []string{"teleporter", "prepare", exampleArtifact},
+			err:  fmt.Errorf(`required flag(s) "chains", "output-dir" not set`),
+		},
+		{
+			name: "deploy missing flags",
+			args: []string{"teleporter", "deploy"},
+			err:  fmt.Errorf(`required flag(s) "plan" not set`),
+		},
+		{
+			name: "register missing flags",
+			args: []string{"teleporter", "register", "--plan", "upgrade.json"},
+			err:  fmt.Errorf(`required flag(s) "signature-aggregator-url" not set`),
+		},
+		{
+			name: "help",
+			args: []string{"teleporter", "--help"},
+			err:  nil,
+			out:  "Upgrades TeleporterMessenger on a list of chains",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestTeleporterPrepare(t *testing.T) {
+	dir := t.TempDir()
+	currentConfig := filepath.Join(dir, "current.json")
+	require.NoError(t, os.WriteFile(currentConfig, []byte(`{"log-level":"info"}`), 0o644))
+	chains := []upgradeChain{
+		{
+			Name:            "a",
+			RPC:             "http://127.0.0.1:9650/ext/bc/a/rpc",
+			NetworkID:       5,
+			BlockchainID:    ids.ID{1},
+			RegistryAddress: testRegistryAddress,
+			Version:         2,
+			ChainConfig:     currentConfig,
+		},
+		{
+			Name:            "b",
+			RPC:             "http://127.0.0.1:9650/ext/bc/b/rpc",
+			NetworkID:       5,
+			BlockchainID:    ids.ID{2},
+			RegistryAddress: testRegistryAddress,
+			Version:         4,
+		},
+	}
+	chainsJSON, err := json.Marshal(chains)
+	require.NoError(t, err)
+	chainsPath := filepath.Join(dir, "chains.json")
+	require.NoError(t, os.WriteFile(chainsPath, chainsJSON, 0o644))
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, os.Mkdir(outputDir, 0o755))
+	_, err = executeTestCmd(t, rootCmd, "teleporter", "prepare", exampleArtifact, "--chains", chainsPath, "--output-dir", outputDir)
+	require.NoError(t, err)
+
+	plan, err := readUpgradePlan(filepath.Join(outputDir, upgradePlanFileName))
+	require.NoError(t, err)
+	require.Len(t, plan.Chains, 2)
+	require.Equal(t, "10000000000000000000", plan.RequiredFunding.ToInt().String())
+	for i, chain := range plan.Chains {
+		require.Equal(t, chains[i], chain.upgradeChain)
+		message, err := avalancheWarp.ParseUnsignedMessage(chain.OffChainMessage)
+		require.NoError(t, err)
+		require.Equal(t, chain.MessageID, message.ID())
+		require.Equal(t, chains[i].BlockchainID, message.SourceChainID)
+		require.Equal(t, filepath.Join(outputDir, chains[i].BlockchainID.String(), chainConfigFileName), chain.NewChainConfig)
+
+		configJSON, err := os.ReadFile(chain.NewChainConfig)
+		require.NoError(t, err)
+		var config map[string]interface{}
+		require.NoError(t, json.Unmarshal(configJSON, &config))
+		require.Equal(t, []interface{}{hexutil.Encode(chain.OffChainMessage)}, config[teleporterUtils.OffChainMessagesConfigKey])
+	}
+	configJSON, err := os.ReadFile(plan.Chains[0].NewChainConfig)
+	require.NoError(t, err)
+	require.Contains(t, string(configJSON), `"log-level": "info"`)
+}
+
+func TestReadUpgradeChains(t *testing.T) {
+	testCases := []struct {
+		name   string
+		chains string
+		err    string
+	}{
+		{
+			name:   "valid",
+			chains: `[{"name":"a","rpc":"http://localhost","networkID":5,"registryAddress":"0x0000000000000000000000000000000000001234"}]`,
+		},
+		{
+			name:   "empty",
+			chains: `[]`,
+			err:    "no chains",
+		},
+		{
+			name:   "missing rpc",
+			chains: `[{"name":"a","networkID":5,"registryAddress":"0x0000000000000000000000000000000000001234"}]`,
+			err:    "chain a has no RPC endpoint",
+		},
+		{
+			name:   "missing network ID",
+			chains: `[{"name":"a","rpc":"http://localhost","registryAddress":"0x0000000000000000000000000000000000001234"}]`,
+			err:    "chain a has no network ID",
+		},
+		{
+			name:   "missing registry",
+			chains: `[{"name":"a","rpc":"http://localhost","networkID":5}]`,
+			err:    "chain a has no registry address",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "chains.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.chains), 0o644))
+			_, err := readUpgradeChains(path)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	sigAggUtils "github.com/ava-labs/icm-contracts/utils/signature-aggregator-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const privateKeyEnvVar = "PRIVATE_KEY"
+
+var (
+	logger logging.Logger
+
+	nodeURI                 string
+	blockchainIDStr         string
+	validatorManagerAddress string
+	signatureAggregatorURL  string
+	validationIDStrs        []string
+	interval                time.Duration
+	once                    bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "uptime-proof-submitter --node-uri NODE_URI --blockchain-id BLOCKCHAIN_ID --validator-manager-address CONTRACT_ADDRESS",
+	Short: "Submits validator uptime proofs to a PoS validator manager",
+	Long: `Submits validator uptime proofs to a PoS validator manager. Every interval, the uptime
+of each active L1 validator is read from the L1's validators API, a signed
+ValidationUptimeMessage is requested from the signature aggregator, and the proof is
+delivered with submitUptimeProof. Rewards are computed from the latest recorded uptime,
+so proofs should be submitted regularly and before a validation ends.
+The sender's private key is read from the ` + privateKeyEnvVar + ` environment variable.`,
+	Args: cobra.NoArgs,
+	Run:  rootRun,
+}
+
+var endValidationCmd = &cobra.Command{
+	Use:   "end-validation VALIDATION_ID",
+	Short: "Submits an uptime proof for a validation and initializes its end",
+	Long: `Submits an uptime proof for the latest uptime of a validation, then calls
+initializeEndValidation so that the validation's rewards account for it.
+The sender must be the owner of the validation.`,
+	Args: cobra.ExactArgs(1),
+	Run:  endValidationRun,
+}
+
+func rootRun(cmd *cobra.Command, args []string) {
+	validationIDs := set.NewSet[ids.ID](len(validationIDStrs))
+	for _, validationIDStr := range validationIDStrs {
+		validationID, err := ids.FromString(validationIDStr)
+		cobra.CheckErr(err)
+		validationIDs.Add(validationID)
+	}
+	if interval <= 0 {
+		cobra.CheckErr("--interval must be positive")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeClient := newSubmitter(ctx, config{
+		interval:      interval,
+		validationIDs: validationIDs,
+	})
+	defer closeClient()
+
+	if once {
+		cobra.CheckErr(s.submitAll(ctx))
+		return
+	}
+	cobra.CheckErr(s.run(ctx))
+}
+
+func endValidationRun(cmd *cobra.Command, args []string) {
+	validationID, err := ids.FromString(args[0])
+	cobra.CheckErr(err)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeClient := newSubmitter(ctx, config{})
+	defer closeClient()
+
+	receipt, err := s.endValidation(ctx, validationID)
+	cobra.CheckErr(err)
+	fmt.Fprintf(cmd.OutOrStdout(), "Initialized end of validation %s in transaction %s\n", validationID, receipt.TxHash)
+}
+
+// newSubmitter connects to the L1 and looks up the network and subnet of the blockchain.
+func newSubmitter(ctx context.Context, cfg config) (*submitter, func()) {
+	if !common.IsHexAddress(validatorManagerAddress) {
+		cobra.CheckErr("invalid validator manager address " + validatorManagerAddress)
+	}
+	blockchainID, err := ids.FromString(blockchainIDStr)
+	cobra.CheckErr(err)
+	key, err := crypto.HexToECDSA(os.Getenv(privateKeyEnvVar))
+	if err != nil {
+		cobra.CheckErr(fmt.Sprintf("invalid private key in %s: %s", privateKeyEnvVar, err))
+	}
+
+	networkID, err := info.NewClient(nodeURI).GetNetworkID(ctx)
+	cobra.CheckErr(err)
+	subnetID, err := platformvm.NewClient(nodeURI).ValidatedBy(ctx, blockchainID)
+	cobra.CheckErr(err)
+
+	client, err := ethclient.Dial(fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURI, blockchainID))
+	cobra.CheckErr(err)
+	chainID, err := client.ChainID(ctx)
+	cobra.CheckErr(err)
+	managerAddress := common.HexToAddress(validatorManagerAddress)
+	manager, err := nativetokenstakingmanager.NewNativeTokenStakingManagerCaller(managerAddress, client)
+	cobra.CheckErr(err)
+
+	return &submitter{
+		logger:         logger,
+		client:         client,
+		validators:     evm.NewClient(nodeURI, blockchainID.String()),
+		aggregator:     sigAggUtils.NewClient(signatureAggregatorURL),
+		manager:        manager,
+		managerAddress: managerAddress,
+		key:            key,
+		chainID:        chainID,
+		networkID:      networkID,
+		blockchainID:   blockchainID,
+		subnetID:       subnetID,
+		config:         cfg,
+		submitted:      make(map[ids.ID]uint64),
+	}, client.Close
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	logLevelArg := rootCmd.PersistentFlags().StringP("log", "l", "", "Log level i.e. debug, info...")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return rootPreRunE(logLevelArg)
+	}
+
+	rootCmd.PersistentFlags().StringVar(&nodeURI, "node-uri", "", "URI of an L1 node, e.g. http://127.0.0.1:9650")
+	rootCmd.PersistentFlags().StringVar(&blockchainIDStr, "blockchain-id", "", "Blockchain ID of the L1")
+	rootCmd.PersistentFlags().StringVar(&validatorManagerAddress, "validator-manager-address", "", "PoS validator manager contract address")
+	rootCmd.PersistentFlags().StringVar(&signatureAggregatorURL, "signature-aggregator-url", "http://localhost:8080", "Base URL of the signature aggregator API")
+	for _, flag := range []string{"node-uri", "blockchain-id", "validator-manager-address"} {
+		err := rootCmd.MarkPersistentFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+
+	rootCmd.Flags().DurationVar(&interval, "interval", time.Hour, "Interval between uptime proof submissions")
+	rootCmd.Flags().StringSliceVar(&validationIDStrs, "validation-ids", nil, "Validation IDs to submit uptime proofs for. Defaults to all active L1 validators")
+	rootCmd.Flags().BoolVar(&once, "once", false, "Submit uptime proofs once and exit")
+
+	rootCmd.AddCommand(endValidationCmd)
+}
+
+func rootPreRunE(logLevelArg *string) error {
+	if *logLevelArg == "" {
+		*logLevelArg = logging.Info.LowerString()
+	}
+
+	logLevel, err := logging.ToLevel(*logLevelArg)
+	if err != nil {
+		return err
+	}
+	logger = logging.NewLogger(
+		"uptime-proof-submitter",
+		logging.NewWrappedCore(
+			logLevel,
+			os.Stdout,
+			logging.JSON.ConsoleEncoder(),
+		),
+	)
+	return nil
+}
+
+func main() {
+	Execute()
+}
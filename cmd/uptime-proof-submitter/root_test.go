@@ -0,0 +1,68 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTestCmd(t *testing.T, c *cobra.Command, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	c.SetArgs(args)
+
+	err := c.Execute()
+	return strings.TrimSpace(buf.String()), err
+}
+
+func TestRootCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "missing flags",
+			args: []string{},
+			err:  fmt.Errorf(`required flag(s) "blockchain-id", "node-uri", "validator-manager-address" not set`),
+		},
+		{
+			name: "end validation missing args",
+			args: []string{"end-validation"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "invalid",
+			args: []string{"invalid"},
+			err:  fmt.Errorf("unknown command"),
+		},
+		// Run last, since cobra does not reset the help flag between executions
+		{
+			name: "help",
+			args: []string{"--help"},
+			err:  nil,
+			out:  "Submits validator uptime proofs to a PoS validator manager",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	uptimeUtils "github.com/ava-labs/icm-contracts/utils/uptime-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Status of an active validation in the ValidatorManager contract
+const activeValidatorStatus uint8 = 2
+
+type config struct {
+	interval time.Duration
+	// Validation IDs to submit uptime proofs for. If empty, proofs are submitted for all
+	// active L1 validators.
+	validationIDs set.Set[ids.ID]
+}
+
+// uptimeProof is the uptime to prove for a single validation.
+type uptimeProof struct {
+	ValidationID ids.ID
+	NodeID       ids.NodeID
+	Uptime       uint64
+}
+
+type submitter struct {
+	logger     logging.Logger
+	client     ethclient.Client
+	validators evm.Client
+	aggregator uptimeUtils.SignatureAggregator
+	// getValidator is implemented by every ValidatorManager, so the caller binding of
+	// any PoS validator manager can be used to read validator statuses.
+	manager        *nativetokenstakingmanager.NativeTokenStakingManagerCaller
+	managerAddress common.Address
+	key            *ecdsa.PrivateKey
+	chainID        *big.Int
+	networkID      uint32
+	blockchainID   ids.ID
+	subnetID       ids.ID
+	config         config
+
+	// Latest uptime proven for each validation ID
+	submitted map[ids.ID]uint64
+}
+
+// run submits uptime proofs every interval until ctx is cancelled.
+func (s *submitter) run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.submitAll(ctx); err != nil {
+			s.logger.Error("Failed to submit uptime proofs", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// submitAll submits an uptime proof for each active validation whose uptime
+// has increased since its last proof.
+func (s *submitter) submitAll(ctx context.Context) error {
+	validators, err := s.validators.GetCurrentValidators(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current validators")
+	}
+	proofs := selectUptimeProofs(validators, s.config.validationIDs, s.submitted)
+	s.logger.Debug("Selected uptime proofs", zap.Int("count", len(proofs)))
+	for _, proof := range proofs {
+		fields := []zap.Field{
+			zap.Stringer("validationID", proof.ValidationID),
+			zap.Stringer("nodeID", proof.NodeID),
+			zap.Uint64("uptime", proof.Uptime),
+		}
+		active, err := s.isActive(ctx, proof.ValidationID)
+		if err != nil {
+			s.logger.Error("Failed to get validator status", append(fields, zap.Error(err))...)
+			continue
+		}
+		if !active {
+			s.logger.Debug("Validation is not active in the validator manager, skipping", fields...)
+			continue
+		}
+		receipt, err := s.submitUptimeProof(ctx, proof.ValidationID, proof.Uptime)
+		if err != nil {
+			s.logger.Error("Failed to submit uptime proof", append(fields, zap.Error(err))...)
+			continue
+		}
+		s.logger.Info("Submitted uptime proof", append(fields, zap.Stringer("txHash", receipt.TxHash))...)
+	}
+	return nil
+}
+
+// endValidation submits an uptime proof for validationID, so that its rewards account
+// for its latest uptime, and then initializes the end of the validation.
+func (s *submitter) endValidation(ctx context.Context, validationID ids.ID) (*types.Receipt, error) {
+	validators, err := s.validators.GetCurrentValidators(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current validators")
+	}
+	var uptime uint64
+	for _, validator := range validators {
+		if validator.ValidationID == validationID {
+			uptime = validator.UptimeSeconds
+			break
+		}
+	}
+	if uptime == 0 {
+		return nil, fmt.Errorf("no uptime reported for validation %s", validationID)
+	}
+	receipt, err := s.submitUptimeProof(ctx, validationID, uptime)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info(
+		"Submitted uptime proof",
+		zap.Stringer("validationID", validationID),
+		zap.Uint64("uptime", uptime),
+		zap.Stringer("txHash", receipt.TxHash),
+	)
+
+	callData, err := uptimeUtils.PackInitializeEndValidation(validationID, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendTransaction(ctx, func(nonce uint64, gasFeeCap, gasTipCap *big.Int) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   s.chainID,
+			Nonce:     nonce,
+			To:        &s.managerAddress,
+			Gas:       uptimeUtils.WarpMessageTxGasLimit,
+			GasFeeCap: gasFeeCap,
+			GasTipCap: gasTipCap,
+			Value:     big.NewInt(0),
+			Data:      callData,
+		})
+	})
+}
+
+func (s *submitter) isActive(ctx context.Context, validationID ids.ID) (bool, error) {
+	validator, err := s.manager.GetValidator(&bind.CallOpts{Context: ctx}, validationID)
+	if err != nil {
+		return false, err
+	}
+	return validator.Status == activeValidatorStatus, nil
+}
+
+// submitUptimeProof aggregates signatures over the uptime of validationID and
+// delivers them to the validator manager with submitUptimeProof.
+func (s *submitter) submitUptimeProof(ctx context.Context, validationID ids.ID, uptime uint64) (*types.Receipt, error) {
+	signedMessage, err := uptimeUtils.ConstructUptimeProofMessage(
+		s.aggregator,
+		s.networkID,
+		s.blockchainID,
+		s.subnetID,
+		validationID,
+		uptime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	callData, err := uptimeUtils.PackSubmitUptimeProof(validationID)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := s.sendWarpMessageTx(ctx, callData, signedMessage)
+	if err != nil {
+		return nil, err
+	}
+	s.submitted[validationID] = uptime
+	return receipt, nil
+}
+
+func (s *submitter) sendWarpMessageTx(
+	ctx context.Context,
+	callData []byte,
+	signedMessage *avalancheWarp.Message,
+) (*types.Receipt, error) {
+	return s.sendTransaction(ctx, func(nonce uint64, gasFeeCap, gasTipCap *big.Int) *types.Transaction {
+		return uptimeUtils.NewWarpMessageTx(
+			s.chainID,
+			nonce,
+			s.managerAddress,
+			gasFeeCap,
+			gasTipCap,
+			callData,
+			signedMessage,
+		)
+	})
+}
+
+// sendTransaction signs and sends the transaction built by newTx with the current
+// fee parameters, and waits for it to be accepted.
+func (s *submitter) sendTransaction(
+	ctx context.Context,
+	newTx func(nonce uint64, gasFeeCap, gasTipCap *big.Int) *types.Transaction,
+) (*types.Receipt, error) {
+	baseFee, err := s.client.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to estimate base fee")
+	}
+	gasTipCap, err := s.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to suggest gas tip cap")
+	}
+	nonce, err := s.client.NonceAt(ctx, crypto.PubkeyToAddress(s.key.PublicKey), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get nonce")
+	}
+	gasFeeCap := gasUtils.GasFeeCap(baseFee)
+
+	tx, err := types.SignTx(newTx(nonce, gasFeeCap, gasTipCap), types.LatestSignerForChainID(s.chainID), s.key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction")
+	}
+	if err := s.client.SendTransaction(ctx, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to send transaction")
+	}
+	receipt, err := bind.WaitMined(ctx, s.client, tx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to wait for transaction %s", tx.Hash())
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", tx.Hash())
+	}
+	return receipt, nil
+}
+
+// selectUptimeProofs returns the uptime proofs to submit for the given L1 validators.
+// Only active L1 validators in validationIDs (if non-empty) whose uptime has increased
+// since their last submitted proof are selected, since the validator manager ignores
+// proofs that do not increase the recorded uptime.
+func selectUptimeProofs(
+	validators []evm.CurrentValidator,
+	validationIDs set.Set[ids.ID],
+	submitted map[ids.ID]uint64,
+) []uptimeProof {
+	var proofs []uptimeProof
+	for _, validator := range validators {
+		if !validator.IsActive || !validator.IsL1Validator {
+			continue
+		}
+		if validationIDs.Len() > 0 && !validationIDs.Contains(validator.ValidationID) {
+			continue
+		}
+		if validator.UptimeSeconds == 0 || validator.UptimeSeconds <= submitted[validator.ValidationID] {
+			continue
+		}
+		proofs = append(proofs, uptimeProof{
+			ValidationID: validator.ValidationID,
+			NodeID:       validator.NodeID,
+			Uptime:       validator.UptimeSeconds,
+		})
+	}
+	return proofs
+}
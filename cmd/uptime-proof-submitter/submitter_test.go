@@ -0,0 +1,70 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/subnet-evm/plugin/evm"
+	"github.com/stretchr/testify/require"
+)
+
+func newL1Validator(uptime uint64) evm.CurrentValidator {
+	return evm.CurrentValidator{
+		ValidationID:  ids.GenerateTestID(),
+		NodeID:        ids.GenerateTestNodeID(),
+		IsActive:      true,
+		IsL1Validator: true,
+		UptimeSeconds: uptime,
+	}
+}
+
+func TestSelectUptimeProofs(t *testing.T) {
+	active := newL1Validator(3600)
+	inactive := newL1Validator(3600)
+	inactive.IsActive = false
+	notL1 := newL1Validator(3600)
+	notL1.IsL1Validator = false
+	noUptime := newL1Validator(0)
+	alreadySubmitted := newL1Validator(3600)
+	increased := newL1Validator(7200)
+	validators := []evm.CurrentValidator{active, inactive, notL1, noUptime, alreadySubmitted, increased}
+	submitted := map[ids.ID]uint64{
+		alreadySubmitted.ValidationID: 3600,
+		increased.ValidationID:        3600,
+	}
+
+	testCases := []struct {
+		name          string
+		validationIDs set.Set[ids.ID]
+		expected      []uptimeProof
+	}{
+		{
+			name: "all validators",
+			expected: []uptimeProof{
+				{ValidationID: active.ValidationID, NodeID: active.NodeID, Uptime: 3600},
+				{ValidationID: increased.ValidationID, NodeID: increased.NodeID, Uptime: 7200},
+			},
+		},
+		{
+			name:          "filtered",
+			validationIDs: set.Of(increased.ValidationID, inactive.ValidationID),
+			expected: []uptimeProof{
+				{ValidationID: increased.ValidationID, NodeID: increased.NodeID, Uptime: 7200},
+			},
+		},
+		{
+			name:          "filtered without proofs",
+			validationIDs: set.Of(alreadySubmitted.ValidationID),
+			expected:      nil,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, selectUptimeProofs(validators, test.validationIDs, submitted))
+		})
+	}
+}
@@ -0,0 +1,447 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	"github.com/ava-labs/icm-contracts/sdk/validatormanager"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type config struct {
+	interval      time.Duration
+	startBlock    uint64
+	maxBlockRange uint64
+	// Directory of the flows and of the next block to process
+	stateDir string
+	// Directory of the info.getNodeID replies of validators to register, named <nodeID>.json
+	proofsDir string
+	// Balance in nAVAX of the validators registered on the P-Chain
+	balance uint64
+	// A registration not issued to the P-Chain has its message resent when it expires within
+	// expiryMargin, and other operations when they are not issued after stuckAfter.
+	expiryMargin   time.Duration
+	stuckAfter     time.Duration
+	resendInterval time.Duration
+	// Maximum time spent on a flow per poll
+	flowTimeout time.Duration
+}
+
+// action is what the keeper does with a flow on a poll.
+type action string
+
+const (
+	// actionRun runs the flow until it is done.
+	actionRun action = "run"
+	// actionWait waits for the proof of possession of a validator to register.
+	actionWait action = "wait"
+	// actionInvalidate delivers the P-Chain's acknowledgement that an expired registration was
+	// never registered, which unlocks its stake.
+	actionInvalidate action = "invalidate"
+)
+
+// openingEvents are the events of operations initialized on the validator manager.
+var openingEvents = map[string]validatormanager.Operation{
+	"ValidationPeriodCreated":     validatormanager.RegisterValidator,
+	"ValidatorRemovalInitialized": validatormanager.EndValidation,
+	"DelegatorAdded":              validatormanager.RegisterDelegator,
+	"DelegatorRemovalInitialized": validatormanager.EndDelegation,
+}
+
+// closingEvents are the events of completed operations, and the prefixes of the IDs of the flows
+// they complete, as returned by validatormanager.NewInitializedFlow. The first topic of every
+// event is the validation or delegation ID of the flows.
+var closingEvents = map[string][]string{
+	"ValidationPeriodRegistered": {"register-validator"},
+	"ValidationPeriodEnded":      {"register-validator", "end-validation"},
+	"DelegatorRegistered":        {"register-delegator"},
+	"DelegationEnded":            {"register-delegator", "end-delegation"},
+}
+
+type keeper struct {
+	logger logging.Logger
+	// manager runs the flows of the keeper, and stores them in the state directory.
+	manager *validatormanager.Manager
+	key     *ecdsa.PrivateKey
+	config  config
+
+	// Names of the events watched by the keeper, by their ID
+	events    map[common.Hash]string
+	nextBlock uint64
+	// Last time the Warp message of each flow was resent
+	resent map[string]time.Time
+}
+
+func newKeeper(
+	logger logging.Logger,
+	manager *validatormanager.Manager,
+	key *ecdsa.PrivateKey,
+	config config,
+) (*keeper, error) {
+	// The events of every validator manager are part of the ABI of the PoS validator managers.
+	managerABI, err := nativetokenstakingmanager.NativeTokenStakingManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	events := make(map[common.Hash]string, len(openingEvents)+len(closingEvents))
+	for name := range openingEvents {
+		events[managerABI.Events[name].ID] = name
+	}
+	for name := range closingEvents {
+		events[managerABI.Events[name].ID] = name
+	}
+	k := &keeper{
+		logger:    logger,
+		manager:   manager,
+		key:       key,
+		config:    config,
+		events:    events,
+		nextBlock: config.startBlock,
+		resent:    make(map[string]time.Time),
+	}
+	nextBlock, err := k.loadNextBlock()
+	if err != nil {
+		return nil, err
+	}
+	k.nextBlock = max(k.nextBlock, nextBlock)
+	return k, nil
+}
+
+// run polls the validator manager every interval until ctx is cancelled.
+func (k *keeper) run(ctx context.Context) error {
+	ticker := time.NewTicker(k.config.interval)
+	defer ticker.Stop()
+	for {
+		if err := k.poll(ctx); err != nil {
+			k.logger.Error("Failed to poll validator manager", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll tracks the operations initialized and completed since the last poll, and advances the
+// flows of the operations that are not complete.
+func (k *keeper) poll(ctx context.Context) error {
+	if err := k.syncEvents(ctx); err != nil {
+		return err
+	}
+	flows, err := k.manager.Store.List()
+	if err != nil {
+		return err
+	}
+	for _, flow := range flows {
+		if flow.Done() {
+			continue
+		}
+		if err := k.process(ctx, flow); err != nil {
+			k.logger.Error("Failed to advance flow", append(flowFields(flow), zap.Error(err))...)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// syncEvents processes the events of the validator manager up to the latest block, in chunks of
+// at most maxBlockRange blocks.
+func (k *keeper) syncEvents(ctx context.Context) error {
+	latest, err := k.manager.Client.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest block number")
+	}
+	eventIDs := make([]common.Hash, 0, len(k.events))
+	for id := range k.events {
+		eventIDs = append(eventIDs, id)
+	}
+	for k.nextBlock <= latest {
+		end := min(k.nextBlock+k.config.maxBlockRange-1, latest)
+		logs, err := k.manager.Client.FilterLogs(ctx, interfaces.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(k.nextBlock),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{k.manager.Address},
+			Topics:    [][]common.Hash{eventIDs},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get logs in blocks [%d, %d]", k.nextBlock, end)
+		}
+		for _, log := range logs {
+			if err := k.processEvent(ctx, log); err != nil {
+				return err
+			}
+		}
+		k.nextBlock = end + 1
+		if err := k.saveNextBlock(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processEvent starts tracking the operation initialized by log, or marks the flows of the
+// operation completed by log as done. Events are processed again after an interruption, so
+// processing an event twice has no effect.
+func (k *keeper) processEvent(ctx context.Context, log types.Log) error {
+	if len(log.Topics) < 2 {
+		return nil
+	}
+	name := k.events[log.Topics[0]]
+	id := ids.ID(log.Topics[1])
+	store := k.manager.Store
+	if operation, ok := openingEvents[name]; ok {
+		var notFound *validatormanager.FlowNotFoundError
+		receipt, err := k.manager.Client.TransactionReceipt(ctx, log.TxHash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get receipt of transaction %s", log.TxHash)
+		}
+		flow, err := validatormanager.NewInitializedFlow(k.manager.Address, operation, receipt)
+		if err != nil {
+			// The event cannot be tracked again, so it is skipped instead of blocking the keeper.
+			k.logger.Error(
+				"Failed to track operation",
+				zap.String("event", name),
+				zap.Stringer("id", id),
+				zap.Stringer("txHash", log.TxHash),
+				zap.Error(err),
+			)
+			return nil
+		}
+		if _, err := store.Load(flow.ID); !errors.As(err, &notFound) {
+			return err
+		}
+		if operation == validatormanager.EndValidation {
+			// The registration of a tracked validator justifies the end of its validation.
+			registration, err := store.Load(fmt.Sprintf("register-validator-%s", flow.ValidationID))
+			if err == nil {
+				flow.RegistrationMessage = registration.RegistrationMessage
+			}
+		}
+		if operation == validatormanager.RegisterValidator {
+			flow.Validator.Balance = k.config.balance
+		}
+		k.logger.Info("Tracking operation", flowFields(flow)...)
+		return store.Save(flow)
+	}
+	for _, prefix := range closingEvents[name] {
+		flow, err := store.Load(fmt.Sprintf("%s-%s", prefix, id))
+		var notFound *validatormanager.FlowNotFoundError
+		if errors.As(err, &notFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if flow.Done() {
+			continue
+		}
+		k.logger.Info("Operation completed", append(flowFields(flow), zap.String("event", name))...)
+		flow.Step = validatormanager.StepDone
+		flow.UpdatedAt = time.Now().UTC()
+		if err := store.Save(flow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process advances flow, resending its Warp message if it cannot be issued to the P-Chain.
+func (k *keeper) process(ctx context.Context, flow *validatormanager.Flow) error {
+	ctx, cancel := context.WithTimeout(ctx, k.config.flowTimeout)
+	defer cancel()
+
+	registered, hasProof := false, false
+	if flow.Operation == validatormanager.RegisterValidator && flow.Step == validatormanager.StepPChain {
+		_, _, err := k.manager.PChainState.GetL1Validator(ctx, flow.ValidationID)
+		registered = err == nil
+		hasProof = len(flow.Validator.ProofOfPossession) > 0
+		if !registered && !hasProof {
+			proofOfPossession, err := loadProofOfPossession(k.config.proofsDir, flow.Validator)
+			if err != nil {
+				k.logger.Warn("Invalid proof of possession", append(flowFields(flow), zap.Error(err))...)
+			}
+			if proofOfPossession != nil {
+				flow.Validator.ProofOfPossession = proofOfPossession
+				if err := k.manager.Store.Save(flow); err != nil {
+					return err
+				}
+				hasProof = true
+			}
+		}
+	}
+
+	now := time.Now()
+	switch nextAction(flow, now, registered, hasProof) {
+	case actionWait:
+		expiry := time.Unix(int64(flow.Validator.RegistrationExpiry), 0)
+		fields := append(flowFields(flow), zap.Stringer("nodeID", flow.Validator.NodeID), zap.Time("expiry", expiry))
+		if expiry.Sub(now) <= k.config.expiryMargin {
+			k.logger.Warn("Registration expires soon without a proof of possession", fields...)
+		} else {
+			k.logger.Debug("Waiting for proof of possession", fields...)
+		}
+		return nil
+
+	case actionInvalidate:
+		invalidation, err := validatormanager.NewExpiredRegistrationFlow(k.manager.Address, flow.RegistrationMessage)
+		if err != nil {
+			return err
+		}
+		k.logger.Info("Registration expired, unlocking its stake", flowFields(invalidation)...)
+		if _, err := k.manager.Run(ctx, invalidation, k.key); err != nil {
+			return err
+		}
+		// The register flow is done once ValidationPeriodEnded is processed.
+		return nil
+	}
+
+	flow, err := k.manager.Run(ctx, flow, k.key)
+	if err == nil {
+		k.logger.Info("Completed operation", flowFields(flow)...)
+		return nil
+	}
+	if flow != nil && k.shouldResend(flow, now, k.resent[flow.ID]) {
+		k.logger.Info("Resending Warp message", flowFields(flow)...)
+		// The flow's context may be done, and the message is resent regardless.
+		resendCtx, cancel := context.WithTimeout(context.Background(), k.config.flowTimeout)
+		defer cancel()
+		receipt, resendErr := k.manager.ResendWarpMessage(resendCtx, flow, k.key)
+		if resendErr != nil {
+			k.logger.Error("Failed to resend Warp message", append(flowFields(flow), zap.Error(resendErr))...)
+		} else {
+			k.resent[flow.ID] = now
+			k.logger.Info("Resent Warp message", append(flowFields(flow), zap.Stringer("txHash", receipt.TxHash))...)
+		}
+	}
+	return err
+}
+
+// nextAction returns the action to take for flow at time now. registered is true if the P-Chain
+// registered the validation of a RegisterValidator flow, and hasProof if the proof of possession
+// of its validator is known.
+func nextAction(flow *validatormanager.Flow, now time.Time, registered bool, hasProof bool) action {
+	if flow.Operation != validatormanager.RegisterValidator || flow.Step != validatormanager.StepPChain || registered {
+		return actionRun
+	}
+	// The P-Chain only registers validators before their registration expires.
+	if now.Unix() >= int64(flow.Validator.RegistrationExpiry) {
+		return actionInvalidate
+	}
+	if hasProof {
+		return actionRun
+	}
+	return actionWait
+}
+
+// shouldResend returns true if the Warp message of flow should be emitted again at time now,
+// because the flow is not issued to the P-Chain and its registration expires soon, or it was
+// initialized long ago. Messages are resent at most once per resend interval.
+func (k *keeper) shouldResend(flow *validatormanager.Flow, now time.Time, lastResent time.Time) bool {
+	if flow.Step != validatormanager.StepPChain || now.Sub(lastResent) < k.config.resendInterval {
+		return false
+	}
+	if flow.Operation == validatormanager.RegisterValidator {
+		expiry := time.Unix(int64(flow.Validator.RegistrationExpiry), 0)
+		return now.Before(expiry) && expiry.Sub(now) <= k.config.expiryMargin
+	}
+	return now.Sub(flow.UpdatedAt) >= k.config.stuckAfter
+}
+
+// loadProofOfPossession returns the proof of possession of validator in dir, written as the
+// info.getNodeID reply of the validator's node to <nodeID>.json. It returns nil if dir has no
+// proof of possession for the validator.
+func loadProofOfPossession(dir string, validator *validatormanager.Validator) ([]byte, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	replyJSON, err := os.ReadFile(filepath.Join(dir, validator.NodeID.String()+".json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var reply info.GetNodeIDReply
+	if err := json.Unmarshal(replyJSON, &reply); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse proof of possession of %s", validator.NodeID)
+	}
+	if reply.NodeID != validator.NodeID {
+		return nil, fmt.Errorf("proof of possession of %s is for node %s", validator.NodeID, reply.NodeID)
+	}
+	if reply.NodePOP == nil {
+		return nil, fmt.Errorf("no proof of possession for %s", validator.NodeID)
+	}
+	if string(reply.NodePOP.PublicKey[:]) != string(validator.BLSPublicKey) {
+		return nil, fmt.Errorf("proof of possession of %s is for another BLS public key", validator.NodeID)
+	}
+	return reply.NodePOP.ProofOfPossession[:], nil
+}
+
+type cursor struct {
+	NextBlock uint64 `json:"nextBlock"`
+}
+
+func (k *keeper) cursorPath() string {
+	return filepath.Join(k.config.stateDir, "cursor.json")
+}
+
+func (k *keeper) loadNextBlock() (uint64, error) {
+	cursorJSON, err := os.ReadFile(k.cursorPath())
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read cursor")
+	}
+	var c cursor
+	if err := json.Unmarshal(cursorJSON, &c); err != nil {
+		return 0, errors.Wrap(err, "failed to parse cursor")
+	}
+	return c.NextBlock, nil
+}
+
+// saveNextBlock writes the next block to process to a temporary file that replaces the cursor, so
+// that an interrupted write does not corrupt it.
+func (k *keeper) saveNextBlock() error {
+	cursorJSON, err := json.Marshal(cursor{NextBlock: k.nextBlock})
+	if err != nil {
+		return err
+	}
+	tmp := k.cursorPath() + ".tmp"
+	if err := os.WriteFile(tmp, cursorJSON, 0o644); err != nil {
+		return errors.Wrap(err, "failed to save cursor")
+	}
+	return errors.Wrap(os.Rename(tmp, k.cursorPath()), "failed to save cursor")
+}
+
+func flowFields(flow *validatormanager.Flow) []zap.Field {
+	fields := []zap.Field{
+		zap.String("flow", flow.ID),
+		zap.String("operation", string(flow.Operation)),
+		zap.String("step", string(flow.Step)),
+	}
+	if flow.Operation == validatormanager.RegisterDelegator || flow.Operation == validatormanager.EndDelegation {
+		fields = append(fields, zap.Stringer("delegationID", flow.DelegationID))
+	}
+	return append(fields, zap.Stringer("validationID", flow.ValidationID))
+}
@@ -0,0 +1,354 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	"github.com/ava-labs/icm-contracts/sdk/validatormanager"
+	testUtils "github.com/ava-labs/icm-contracts/utils/test-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var testManagerAddress = common.Address{3}
+
+// fakeL1 serves the events of the validator manager, one transaction per block.
+type fakeL1 struct {
+	*testUtils.FakeClient
+	t   *testing.T
+	abi *abi.ABI
+}
+
+func newFakeL1(t *testing.T) *fakeL1 {
+	managerABI, err := nativetokenstakingmanager.NativeTokenStakingManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	client := testUtils.NewFakeClient()
+	// The keeper syncs events one block at a time.
+	client.MaxFilterRange = 1
+	return &fakeL1{FakeClient: client, t: t, abi: managerABI}
+}
+
+func (c *fakeL1) event(name string, topics ...common.Hash) *types.Log {
+	event := c.abi.Events[name]
+	// The keeper reads the IDs of the topics, so the other arguments are zero.
+	args := make([]interface{}, 0, len(event.Inputs))
+	for _, input := range event.Inputs.NonIndexed() {
+		typ := input.Type.GetType()
+		if typ.Kind() == reflect.Ptr {
+			args = append(args, reflect.New(typ.Elem()).Interface())
+		} else {
+			args = append(args, reflect.Zero(typ).Interface())
+		}
+	}
+	return testUtils.EventLog(c.t, c.abi, testManagerAddress, name, topics, args...)
+}
+
+func (c *fakeL1) warpLog(payload []byte) *types.Log {
+	addressedCall, err := warpPayload.NewAddressedCall(testManagerAddress[:], payload)
+	require.NoError(c.t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.ID{1}, addressedCall.Bytes())
+	require.NoError(c.t, err)
+	return testUtils.WarpLog(c.t, testManagerAddress, unsignedMessage)
+}
+
+func newRegistration(t *testing.T, expiry uint64) *warpMessage.RegisterL1Validator {
+	registration, err := warpMessage.NewRegisterL1Validator(
+		ids.ID{2},
+		ids.GenerateTestNodeID(),
+		[bls.PublicKeyLen]byte{4},
+		expiry,
+		warpMessage.PChainOwner{Addresses: []ids.ShortID{}},
+		warpMessage.PChainOwner{Addresses: []ids.ShortID{}},
+		100,
+	)
+	require.NoError(t, err)
+	return registration
+}
+
+func newWeightUpdate(t *testing.T, validationID ids.ID, nonce uint64, weight uint64) []byte {
+	message, err := warpMessage.NewL1ValidatorWeight(validationID, nonce, weight)
+	require.NoError(t, err)
+	return message.Bytes()
+}
+
+func newTestKeeper(t *testing.T, l1 *fakeL1, stateDir string) *keeper {
+	store, err := validatormanager.NewFileStore(filepath.Join(stateDir, "flows"))
+	require.NoError(t, err)
+	k, err := newKeeper(logging.NoLog{}, &validatormanager.Manager{
+		Client:  l1,
+		Address: testManagerAddress,
+		Store:   store,
+	}, nil, config{
+		stateDir:      stateDir,
+		maxBlockRange: 2,
+		startBlock:    1,
+		balance:       7,
+	})
+	require.NoError(t, err)
+	return k
+}
+
+func TestSyncEvents(t *testing.T) {
+	l1 := newFakeL1(t)
+	registration := newRegistration(t, 1000)
+	validationID := registration.ValidationID()
+	otherValidationID := ids.ID{5}
+	delegationID := ids.ID{6}
+	l1.Accept(
+		l1.event("ValidationPeriodCreated", common.Hash(validationID), common.Hash{}),
+		l1.warpLog(registration.Bytes()),
+	)
+	l1.Accept(
+		l1.event("ValidatorRemovalInitialized", common.Hash(otherValidationID), common.Hash{}),
+		l1.warpLog(newWeightUpdate(t, otherValidationID, 3, 0)),
+	)
+	l1.Accept(l1.event("ValidationPeriodRegistered", common.Hash(validationID)))
+	l1.Accept(
+		l1.warpLog(newWeightUpdate(t, validationID, 1, 150)),
+		l1.event("DelegatorAdded", common.Hash(delegationID), common.Hash(validationID), common.Hash{}),
+	)
+	l1.Accept(
+		l1.event("ValidatorRemovalInitialized", common.Hash(validationID), common.Hash{}),
+		l1.warpLog(newWeightUpdate(t, validationID, 2, 0)),
+	)
+
+	stateDir := t.TempDir()
+	k := newTestKeeper(t, l1, stateDir)
+	require.NoError(t, k.syncEvents(context.Background()))
+	require.Equal(t, uint64(6), k.nextBlock)
+
+	// The registration is tracked with the configured balance, and done once it is completed
+	registrationFlow, err := k.manager.Store.Load("register-validator-" + validationID.String())
+	require.NoError(t, err)
+	require.True(t, registrationFlow.Done())
+	require.Equal(t, uint64(7), registrationFlow.Validator.Balance)
+	require.Equal(t, registration.Bytes(), []byte(registrationFlow.RegistrationMessage))
+
+	// The removal of an untracked validator is issued to the P-Chain next
+	otherRemoval, err := k.manager.Store.Load("end-validation-" + otherValidationID.String())
+	require.NoError(t, err)
+	require.Equal(t, validatormanager.StepPChain, otherRemoval.Step)
+	require.Equal(t, uint64(3), otherRemoval.Nonce)
+	require.Empty(t, otherRemoval.RegistrationMessage)
+
+	// The removal of a tracked validator is justified by its registration
+	removal, err := k.manager.Store.Load("end-validation-" + validationID.String())
+	require.NoError(t, err)
+	require.Equal(t, registrationFlow.RegistrationMessage, removal.RegistrationMessage)
+
+	delegation, err := k.manager.Store.Load("register-delegator-" + delegationID.String())
+	require.NoError(t, err)
+	require.Equal(t, validatormanager.RegisterDelegator, delegation.Operation)
+	require.Equal(t, validationID, delegation.ValidationID)
+	require.Equal(t, uint64(150), delegation.Weight)
+
+	// The keeper resumes from the persisted cursor, and tracks new events only
+	l1.Accept(l1.event("DelegationEnded", common.Hash(delegationID), common.Hash(validationID)))
+	k = newTestKeeper(t, l1, stateDir)
+	require.Equal(t, uint64(6), k.nextBlock)
+	require.NoError(t, k.syncEvents(context.Background()))
+	delegation, err = k.manager.Store.Load(delegation.ID)
+	require.NoError(t, err)
+	require.True(t, delegation.Done())
+
+	// Processing the events again has no effect
+	flows, err := k.manager.Store.List()
+	require.NoError(t, err)
+	k.nextBlock = 1
+	require.NoError(t, k.syncEvents(context.Background()))
+	reprocessed, err := k.manager.Store.List()
+	require.NoError(t, err)
+	require.Equal(t, flows, reprocessed)
+}
+
+func TestNextAction(t *testing.T) {
+	now := time.Unix(1000, 0)
+	registration := func(step validatormanager.Step) *validatormanager.Flow {
+		return &validatormanager.Flow{
+			Operation: validatormanager.RegisterValidator,
+			Step:      step,
+			Validator: &validatormanager.Validator{RegistrationExpiry: 2000},
+		}
+	}
+	expired := registration(validatormanager.StepPChain)
+	expired.Validator.RegistrationExpiry = 1000
+
+	testCases := []struct {
+		name       string
+		flow       *validatormanager.Flow
+		registered bool
+		hasProof   bool
+		expected   action
+	}{
+		{
+			name:     "removal",
+			flow:     &validatormanager.Flow{Operation: validatormanager.EndValidation, Step: validatormanager.StepPChain},
+			expected: actionRun,
+		},
+		{
+			name:     "registration issued to the P-Chain",
+			flow:     registration(validatormanager.StepComplete),
+			expected: actionRun,
+		},
+		{
+			name:       "registration registered by the P-Chain",
+			flow:       expired,
+			registered: true,
+			expected:   actionRun,
+		},
+		{
+			name:     "registration with proof",
+			flow:     registration(validatormanager.StepPChain),
+			hasProof: true,
+			expected: actionRun,
+		},
+		{
+			name:     "registration without proof",
+			flow:     registration(validatormanager.StepPChain),
+			expected: actionWait,
+		},
+		{
+			name:     "expired registration",
+			flow:     expired,
+			hasProof: true,
+			expected: actionInvalidate,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, nextAction(test.flow, now, test.registered, test.hasProof))
+		})
+	}
+}
+
+func TestShouldResend(t *testing.T) {
+	now := time.Unix(10_000, 0)
+	k := &keeper{config: config{
+		expiryMargin:   time.Hour,
+		stuckAfter:     2 * time.Hour,
+		resendInterval: 10 * time.Minute,
+	}}
+	registration := func(expiresIn time.Duration) *validatormanager.Flow {
+		return &validatormanager.Flow{
+			Operation: validatormanager.RegisterValidator,
+			Step:      validatormanager.StepPChain,
+			Validator: &validatormanager.Validator{RegistrationExpiry: uint64(now.Add(expiresIn).Unix())},
+		}
+	}
+	removal := func(step validatormanager.Step, age time.Duration) *validatormanager.Flow {
+		return &validatormanager.Flow{
+			Operation: validatormanager.EndValidation,
+			Step:      step,
+			UpdatedAt: now.Add(-age),
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		flow       *validatormanager.Flow
+		lastResent time.Time
+		expected   bool
+	}{
+		{
+			name:     "registration expiring soon",
+			flow:     registration(30 * time.Minute),
+			expected: true,
+		},
+		{
+			name:     "registration expiring later",
+			flow:     registration(2 * time.Hour),
+			expected: false,
+		},
+		{
+			name:     "expired registration",
+			flow:     registration(-time.Minute),
+			expected: false,
+		},
+		{
+			name:       "registration resent recently",
+			flow:       registration(30 * time.Minute),
+			lastResent: now.Add(-5 * time.Minute),
+			expected:   false,
+		},
+		{
+			name:       "registration resent before",
+			flow:       registration(30 * time.Minute),
+			lastResent: now.Add(-15 * time.Minute),
+			expected:   true,
+		},
+		{
+			name:     "stuck removal",
+			flow:     removal(validatormanager.StepPChain, 3*time.Hour),
+			expected: true,
+		},
+		{
+			name:     "recent removal",
+			flow:     removal(validatormanager.StepPChain, time.Hour),
+			expected: false,
+		},
+		{
+			name:     "removal issued to the P-Chain",
+			flow:     removal(validatormanager.StepComplete, 3*time.Hour),
+			expected: false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, k.shouldResend(test.flow, now, test.lastResent))
+		})
+	}
+}
+
+func TestLoadProofOfPossession(t *testing.T) {
+	secretKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	proofOfPossession := signer.NewProofOfPossession(secretKey)
+	validator := &validatormanager.Validator{
+		NodeID:       ids.GenerateTestNodeID(),
+		BLSPublicKey: proofOfPossession.PublicKey[:],
+	}
+	dir := t.TempDir()
+	writeReply := func(reply info.GetNodeIDReply) {
+		replyJSON, err := json.Marshal(reply)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, validator.NodeID.String()+".json"), replyJSON, 0o644))
+	}
+
+	loaded, err := loadProofOfPossession(dir, validator)
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+
+	writeReply(info.GetNodeIDReply{NodeID: validator.NodeID, NodePOP: proofOfPossession})
+	loaded, err = loadProofOfPossession(dir, validator)
+	require.NoError(t, err)
+	require.Equal(t, proofOfPossession.ProofOfPossession[:], loaded)
+
+	writeReply(info.GetNodeIDReply{NodeID: ids.GenerateTestNodeID(), NodePOP: proofOfPossession})
+	_, err = loadProofOfPossession(dir, validator)
+	require.ErrorContains(t, err, "is for node")
+
+	otherKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	writeReply(info.GetNodeIDReply{NodeID: validator.NodeID, NodePOP: signer.NewProofOfPossession(otherKey)})
+	_, err = loadProofOfPossession(dir, validator)
+	require.ErrorContains(t, err, "another BLS public key")
+}
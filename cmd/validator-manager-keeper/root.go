@@ -0,0 +1,206 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/icm-contracts/sdk/validatormanager"
+	sigAggUtils "github.com/ava-labs/icm-contracts/utils/signature-aggregator-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	privateKeyEnvVar       = "PRIVATE_KEY"
+	pChainPrivateKeyEnvVar = "P_CHAIN_PRIVATE_KEY"
+)
+
+var managerTypes = map[string]validatormanager.Type{
+	"poa":    validatormanager.PoA,
+	"native": validatormanager.NativeTokenStaking,
+	"erc20":  validatormanager.ERC20TokenStaking,
+}
+
+var (
+	logger logging.Logger
+
+	nodeURI                 string
+	blockchainIDStr         string
+	validatorManagerAddress string
+	managerType             string
+	signatureAggregatorURL  string
+	keeperConfig            config
+	once                    bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "validator-manager-keeper --node-uri NODE_URI --blockchain-id BLOCKCHAIN_ID --validator-manager-address CONTRACT_ADDRESS --type TYPE",
+	Short: "Completes the validator set changes initialized on a validator manager",
+	Long: `Completes the validator set changes initialized on a validator manager. The keeper
+watches the ValidationPeriodCreated, ValidatorRemovalInitialized, DelegatorAdded and
+DelegatorRemovalInitialized events of the validator manager, issues their Warp messages to the
+P-Chain, and delivers the P-Chain's acknowledgements with completeValidatorRegistration,
+completeEndValidation, completeDelegatorRegistration and completeEndDelegation.
+Registrations that expire before they are issued to the P-Chain are completed with
+completeEndValidation, which unlocks their stake.
+The L1 sender's private key is read from the ` + privateKeyEnvVar + ` environment variable, and the
+key paying the P-Chain fees and balances from ` + pChainPrivateKeyEnvVar + `, which defaults to
+the L1 sender's key.`,
+	Args: cobra.NoArgs,
+	Run:  rootRun,
+}
+
+func rootRun(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(validatorManagerAddress) {
+		cobra.CheckErr("invalid validator manager address " + validatorManagerAddress)
+	}
+	typ, ok := managerTypes[managerType]
+	if !ok {
+		cobra.CheckErr("invalid validator manager type " + managerType)
+	}
+	if keeperConfig.interval <= 0 || keeperConfig.flowTimeout <= 0 {
+		cobra.CheckErr("--interval and --flow-timeout must be positive")
+	}
+	if keeperConfig.maxBlockRange == 0 {
+		cobra.CheckErr("--max-block-range must be positive")
+	}
+	blockchainID, err := ids.FromString(blockchainIDStr)
+	cobra.CheckErr(err)
+	key, err := crypto.HexToECDSA(os.Getenv(privateKeyEnvVar))
+	if err != nil {
+		cobra.CheckErr(fmt.Sprintf("invalid private key in %s: %s", privateKeyEnvVar, err))
+	}
+	pChainKeyHex, ok := os.LookupEnv(pChainPrivateKeyEnvVar)
+	if !ok {
+		pChainKeyHex = os.Getenv(privateKeyEnvVar)
+	}
+	pChainKeyECDSA, err := crypto.HexToECDSA(pChainKeyHex)
+	if err != nil {
+		cobra.CheckErr(fmt.Sprintf("invalid private key in %s: %s", pChainPrivateKeyEnvVar, err))
+	}
+	pChainKey, err := secp256k1.ToPrivateKey(crypto.FromECDSA(pChainKeyECDSA))
+	cobra.CheckErr(err)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	networkID, err := info.NewClient(nodeURI).GetNetworkID(ctx)
+	cobra.CheckErr(err)
+	pChainClient := platformvm.NewClient(nodeURI)
+	subnetID, err := pChainClient.ValidatedBy(ctx, blockchainID)
+	cobra.CheckErr(err)
+	client, err := ethclient.Dial(fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURI, blockchainID))
+	cobra.CheckErr(err)
+	defer client.Close()
+	evmChainID, err := client.ChainID(ctx)
+	cobra.CheckErr(err)
+	kc := secp256k1fx.NewKeychain(pChainKey)
+	wallet, err := primary.MakeWallet(ctx, nodeURI, kc, kc, primary.WalletConfig{})
+	cobra.CheckErr(err)
+	store, err := validatormanager.NewFileStore(filepath.Join(keeperConfig.stateDir, "flows"))
+	cobra.CheckErr(err)
+
+	manager := &validatormanager.Manager{
+		Client:              client,
+		EVMChainID:          evmChainID,
+		NetworkID:           networkID,
+		BlockchainID:        blockchainID,
+		SubnetID:            subnetID,
+		Address:             common.HexToAddress(validatorManagerAddress),
+		Type:                typ,
+		FromBlock:           keeperConfig.startBlock,
+		PChain:              wallet.P(),
+		PChainState:         pChainClient,
+		SignatureAggregator: sigAggUtils.NewClient(signatureAggregatorURL),
+		Store:               store,
+	}
+	k, err := newKeeper(logger, manager, key, keeperConfig)
+	cobra.CheckErr(err)
+
+	if once {
+		cobra.CheckErr(k.poll(ctx))
+		return
+	}
+	cobra.CheckErr(k.run(ctx))
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	logLevelArg := rootCmd.PersistentFlags().StringP("log", "l", "", "Log level i.e. debug, info...")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return rootPreRunE(logLevelArg)
+	}
+
+	rootCmd.Flags().StringVar(&nodeURI, "node-uri", "", "URI of an L1 node, e.g. http://127.0.0.1:9650")
+	rootCmd.Flags().StringVar(&blockchainIDStr, "blockchain-id", "", "Blockchain ID of the L1")
+	rootCmd.Flags().StringVar(&validatorManagerAddress, "validator-manager-address", "", "Validator manager contract address")
+	rootCmd.Flags().StringVar(&managerType, "type", "", "Type of the validator manager: poa, native or erc20")
+	rootCmd.Flags().StringVar(&signatureAggregatorURL, "signature-aggregator-url", "http://localhost:8080", "Base URL of the signature aggregator API")
+	for _, flag := range []string{"node-uri", "blockchain-id", "validator-manager-address", "type"} {
+		err := rootCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+
+	rootCmd.Flags().StringVar(&keeperConfig.stateDir, "state-dir", "keeper-state", "Directory to persist the progress of the keeper to")
+	rootCmd.Flags().StringVar(&keeperConfig.proofsDir, "proofs-dir", "", "Directory of the info.getNodeID replies of validators to register, named <NODE_ID>.json")
+	rootCmd.Flags().Uint64Var(&keeperConfig.balance, "balance", units.Avax, "Balance in nAVAX of the validators registered on the P-Chain")
+	rootCmd.Flags().DurationVar(&keeperConfig.interval, "interval", time.Minute, "Interval between polls of the validator manager")
+	rootCmd.Flags().Uint64Var(&keeperConfig.startBlock, "start-block", 0, "Block to start processing events from, and to search validator registrations from")
+	rootCmd.Flags().Uint64Var(&keeperConfig.maxBlockRange, "max-block-range", validatormanager.MaxLogBlockRange, "Maximum number of blocks per log query")
+	rootCmd.Flags().DurationVar(&keeperConfig.expiryMargin, "expiry-margin", time.Hour, "Resend the message of a registration not issued to the P-Chain when it expires within this duration")
+	rootCmd.Flags().DurationVar(&keeperConfig.stuckAfter, "stuck-after", time.Hour, "Resend the message of a removal or delegation not issued to the P-Chain after this duration")
+	rootCmd.Flags().DurationVar(&keeperConfig.resendInterval, "resend-interval", 10*time.Minute, "Minimum interval between resends of the message of an operation")
+	rootCmd.Flags().DurationVar(&keeperConfig.flowTimeout, "flow-timeout", 2*time.Minute, "Maximum time spent on an operation per poll")
+	rootCmd.Flags().BoolVar(&once, "once", false, "Poll the validator manager once and exit")
+}
+
+func rootPreRunE(logLevelArg *string) error {
+	if *logLevelArg == "" {
+		*logLevelArg = logging.Info.LowerString()
+	}
+
+	logLevel, err := logging.ToLevel(*logLevelArg)
+	if err != nil {
+		return err
+	}
+	logger = logging.NewLogger(
+		"validator-manager-keeper",
+		logging.NewWrappedCore(
+			logLevel,
+			os.Stdout,
+			logging.JSON.ConsoleEncoder(),
+		),
+	)
+	return nil
+}
+
+func main() {
+	Execute()
+}
@@ -0,0 +1,145 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	validatorManagerUtils "github.com/ava-labs/icm-contracts/utils/validator-manager-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+// errDiscrepancies is returned when the validator sets differ, so that the reconciler exits with
+// a non-zero status.
+var errDiscrepancies = errors.New("validator sets differ")
+
+var (
+	nodeURI                 string
+	blockchainIDStr         string
+	validatorManagerAddress string
+	fromBlock               uint64
+	jsonOutput              bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "validator-set-reconciler --node-uri NODE_URI --blockchain-id BLOCKCHAIN_ID --validator-manager-address CONTRACT_ADDRESS",
+	Short: "Compares the validator set of a validator manager with the P-Chain",
+	Long: `Compares the validator set of a validator manager with the P-Chain. The validator set of
+the validator manager is read from its InitialValidatorCreated and ValidationPeriodCreated events
+and getValidator, and compared with the validators of its L1 on the P-Chain. The reconciler
+reports the validators missing from or extra on the P-Chain, the validators with different
+weights, and the weight updates not applied by the P-Chain, and exits with a non-zero status if
+there are any.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          rootRunE,
+}
+
+func rootRunE(cmd *cobra.Command, args []string) error {
+	if !common.IsHexAddress(validatorManagerAddress) {
+		return fmt.Errorf("invalid validator manager address %s", validatorManagerAddress)
+	}
+	blockchainID, err := ids.FromString(blockchainIDStr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	pChainClient := platformvm.NewClient(nodeURI)
+	subnetID, err := pChainClient.ValidatedBy(ctx, blockchainID)
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.Dial(fmt.Sprintf("%s/ext/bc/%s/rpc", nodeURI, blockchainID))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	reconciliation, err := validatorManagerUtils.ReconcileValidatorSet(
+		ctx,
+		client,
+		pChainClient,
+		common.HexToAddress(validatorManagerAddress),
+		subnetID,
+		fromBlock,
+	)
+	if err != nil {
+		return err
+	}
+	if err := printReconciliation(cmd, reconciliation); err != nil {
+		return err
+	}
+	if len(reconciliation.Discrepancies) > 0 {
+		return errDiscrepancies
+	}
+	return nil
+}
+
+func printReconciliation(cmd *cobra.Command, reconciliation *validatorManagerUtils.Reconciliation) error {
+	if jsonOutput {
+		out, err := json.MarshalIndent(reconciliation, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(out))
+		return nil
+	}
+	cmd.Printf("Validator manager %s at block %d: total weight %d\n",
+		reconciliation.Manager, reconciliation.BlockNumber, reconciliation.ManagerWeight)
+	cmd.Printf("L1 %s at P-Chain height %d: total weight %d\n",
+		reconciliation.SubnetID, reconciliation.PChainHeight, reconciliation.PChainWeight)
+	if len(reconciliation.Discrepancies) == 0 {
+		cmd.Println("The validator sets are in sync")
+		return nil
+	}
+	cmd.Printf("%d discrepancies:\n", len(reconciliation.Discrepancies))
+	for _, discrepancy := range reconciliation.Discrepancies {
+		cmd.Printf("  %s: %s\n", discrepancy.Kind, discrepancy)
+	}
+	return nil
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if errors.Is(err, errDiscrepancies) {
+		os.Exit(2)
+	}
+	if err != nil {
+		rootCmd.PrintErrln("Error:", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.Flags().StringVar(&nodeURI, "node-uri", "", "URI of an L1 node, e.g. http://127.0.0.1:9650")
+	rootCmd.Flags().StringVar(&blockchainIDStr, "blockchain-id", "", "Blockchain ID of the L1")
+	rootCmd.Flags().StringVar(&validatorManagerAddress, "validator-manager-address", "", "Validator manager contract address")
+	for _, flag := range []string{"node-uri", "blockchain-id", "validator-manager-address"} {
+		err := rootCmd.MarkFlagRequired(flag)
+		cobra.CheckErr(err)
+	}
+	rootCmd.Flags().Uint64Var(&fromBlock, "from-block", 0, "Block to search validator creation events from, e.g. the deployment block of the validator manager")
+	rootCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the report as JSON")
+}
+
+func main() {
+	Execute()
+}
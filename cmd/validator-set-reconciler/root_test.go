@@ -0,0 +1,91 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	validatorManagerUtils "github.com/ava-labs/icm-contracts/utils/validator-manager-utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func executeTestCmd(t *testing.T, c *cobra.Command, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	c.SetArgs(args)
+
+	err := c.Execute()
+	return strings.TrimSpace(buf.String()), err
+}
+
+func TestRootCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "missing flags",
+			args: []string{},
+			err:  fmt.Errorf(`required flag(s) "blockchain-id", "node-uri", "validator-manager-address" not set`),
+		},
+		{
+			name: "unexpected args",
+			args: []string{"invalid"},
+			err:  fmt.Errorf(`unknown command "invalid"`),
+		},
+		{
+			name: "invalid address",
+			args: []string{"--node-uri", "http://127.0.0.1:9650", "--blockchain-id", "invalid", "--validator-manager-address", "0x1"},
+			err:  fmt.Errorf("invalid validator manager address 0x1"),
+		},
+		// Run last, since cobra does not reset the help flag between executions
+		{
+			name: "help",
+			args: []string{"--help"},
+			err:  nil,
+			out:  "Compares the validator set of a validator manager with the P-Chain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestPrintReconciliation(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	reconciliation := &validatorManagerUtils.Reconciliation{
+		ManagerWeight: 100,
+		PChainWeight:  150,
+		Discrepancies: []validatorManagerUtils.Discrepancy{
+			{Kind: validatorManagerUtils.ExtraOnPChain, NodeID: nodeID, PChainWeight: 50},
+		},
+	}
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	require.NoError(t, printReconciliation(rootCmd, reconciliation))
+	require.Contains(t, buf.String(), "total weight 150")
+	require.Contains(t, buf.String(), "1 discrepancies:\n  extra: "+nodeID.String())
+
+	buf.Reset()
+	reconciliation.Discrepancies = nil
+	require.NoError(t, printReconciliation(rootCmd, reconciliation))
+	require.Contains(t, buf.String(), "The validator sets are in sync")
+}
@@ -23,7 +23,7 @@ import {SafeERC20} from "@openzeppelin/contracts@5.0.2/token/ERC20/utils/SafeERC
 import {AccessControlUpgradeable} from
     "@openzeppelin/contracts-upgradeable@5.0.2/access/AccessControlUpgradeable.sol";
 
-import "forge-std/console.sol";
+import {console} from "@forge-std/console.sol";
 /**
  * @dev Implementation of the {IERC20TokenStakingManager} interface.
  *
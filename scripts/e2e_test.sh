@@ -22,12 +22,15 @@ Arguments:
                                                   $(echo $valid_components | tr ' ' '\n' | sort | tr '\n' ' ')
                                                   (default: all)
 Options:
+    --local-sig-agg                               Aggregate signatures in-process instead of with the
+                                                  signature-aggregator binary of icm-services
     --help                                        Print this help message
 EOF
 }
 
 valid_components=$(ls -d $ICM_CONTRACTS_PATH/tests/suites/*/ | xargs -n 1 basename)
 components=
+local_sig_agg=false
 
 while [ $# -gt 0 ]; do
     case "$1" in
@@ -38,6 +41,8 @@ while [ $# -gt 0 ]; do
                 echo "Invalid components $2" && printHelp && exit 1
             fi 
             shift;;
+        --local-sig-agg)
+            local_sig_agg=true ;;
         --help) 
             printHelp && exit 0 ;;
         *) 
@@ -77,9 +82,13 @@ export AVALANCHEGO_BUILD_PATH=$BASEDIR/avalanchego
 ICM_SERVICES_BUILD_PATH=$BASEDIR/icm-services
 
 cd $ICM_CONTRACTS_PATH
-# Install signature-aggregator binary
-BASEDIR=$BASEDIR ICM_SERVICES_BUILD_PATH=$ICM_SERVICES_BUILD_PATH "${ICM_CONTRACTS_PATH}/scripts/install_sig_agg_release.sh"
-echo "Installed signature-aggregator from icm-services release ${ICM_SERVICES_VERSION}"
+# Install signature-aggregator binary, unless signatures are aggregated in-process
+SIG_AGG_PATH=
+if [ "$local_sig_agg" = false ]; then
+  BASEDIR=$BASEDIR ICM_SERVICES_BUILD_PATH=$ICM_SERVICES_BUILD_PATH "${ICM_CONTRACTS_PATH}/scripts/install_sig_agg_release.sh"
+  echo "Installed signature-aggregator from icm-services release ${ICM_SERVICES_VERSION}"
+  SIG_AGG_PATH=$ICM_SERVICES_BUILD_PATH/signature-aggregator
+fi
 
 cd $ICM_CONTRACTS_PATH
 if command -v forge &> /dev/null; then
@@ -100,7 +109,7 @@ for component in $(echo $components | tr ',' ' '); do
 
     echo "Running e2e tests for $component"
 
-    RUN_E2E=true SIG_AGG_PATH=$ICM_SERVICES_BUILD_PATH/signature-aggregator ./tests/suites/$component/$component.test \
+    RUN_E2E=true SIG_AGG_PATH=$SIG_AGG_PATH ./tests/suites/$component/$component.test \
     --ginkgo.vv \
     --ginkgo.label-filter=${GINKGO_LABEL_FILTER:-""} \
     --ginkgo.focus=${GINKGO_FOCUS:-""} \
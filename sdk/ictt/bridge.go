@@ -0,0 +1,166 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package ictt sends tokens between a TokenHome and its TokenRemote instances, and tracks the
+// transfers until they are delivered on their destination. It handles the token scaling, the
+// approvals and fee deposits, and the routing of multi-hop transfers through the home.
+package ictt
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/TokenHome"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Client is the subset of an EVM client used to send and track token transfers. It is
+// implemented by the subnet-evm ethclient.Client.
+type Client interface {
+	bind.ContractBackend
+	teleporterSDK.Client
+}
+
+// TokenType is the type of token a transferrer holds or mints.
+type TokenType int
+
+const (
+	// ERC20 transferrers are ERC20TokenHome, which holds an ERC20 token, and ERC20TokenRemote,
+	// which is itself an ERC20 token.
+	ERC20 TokenType = iota
+	// Native transferrers are NativeTokenHome, which holds the wrapped native token, and
+	// NativeTokenRemote, which mints the native token of its chain.
+	Native
+)
+
+func (t TokenType) String() string {
+	switch t {
+	case ERC20:
+		return "ERC20"
+	case Native:
+		return "Native"
+	default:
+		return fmt.Sprintf("TokenType(%d)", int(t))
+	}
+}
+
+// Transferrer is a TokenHome or TokenRemote contract deployed on a chain.
+type Transferrer struct {
+	Client       Client
+	EVMChainID   *big.Int
+	BlockchainID ids.ID
+	Address      common.Address
+	TokenType    TokenType
+	// TeleporterAddress is the TeleporterMessenger that delivers the messages sent to the transferrer.
+	TeleporterAddress common.Address
+
+	// feeToken is the token the primary fee of transfers sent from the transferrer is paid in
+	feeToken common.Address
+	// settings of a remote, as registered with the home
+	settings *tokenhome.RemoteTokenTransferrerSettings
+}
+
+// FeeToken returns the token the primary fee of the transfers sent from t is paid in. It is the
+// token held by a TokenHome, or the TokenRemote itself.
+func (t *Transferrer) FeeToken() common.Address {
+	return t.feeToken
+}
+
+func (t *Transferrer) String() string {
+	return fmt.Sprintf("%s transferrer %s on %s", t.TokenType, t.Address, t.BlockchainID)
+}
+
+// Bridge is a TokenHome and the TokenRemote instances registered with it.
+type Bridge struct {
+	Home    *Transferrer
+	Remotes []*Transferrer
+}
+
+// NewBridge returns a bridge between home and remotes. It reads the token of home, and the
+// scaling of each remote, which must be registered with home.
+func NewBridge(ctx context.Context, home *Transferrer, remotes ...*Transferrer) (*Bridge, error) {
+	for _, t := range append([]*Transferrer{home}, remotes...) {
+		if t.Client == nil || t.EVMChainID == nil {
+			return nil, fmt.Errorf("client of %s is not set", t)
+		}
+		if t.TeleporterAddress == (common.Address{}) {
+			return nil, fmt.Errorf("Teleporter address of %s is not set", t)
+		}
+	}
+	homeCaller, err := tokenhome.NewTokenHomeCaller(home.Address, home.Client)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	home.feeToken, err = homeCaller.GetTokenAddress(opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get token of %s", home)
+	}
+	for _, remote := range remotes {
+		settings, err := homeCaller.GetRemoteTokenTransferrerSettings(opts, remote.BlockchainID, remote.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get settings of %s", remote)
+		}
+		if !settings.Registered {
+			return nil, fmt.Errorf("%s is not registered with %s", remote, home)
+		}
+		remote.settings = &settings
+		remote.feeToken = remote.Address
+	}
+	return &Bridge{
+		Home:    home,
+		Remotes: remotes,
+	}, nil
+}
+
+// Remote returns the remote with the given blockchain ID and address.
+func (b *Bridge) Remote(blockchainID ids.ID, address common.Address) (*Transferrer, error) {
+	for _, remote := range b.Remotes {
+		if remote.BlockchainID == blockchainID && remote.Address == address {
+			return remote, nil
+		}
+	}
+	return nil, fmt.Errorf("no remote %s on %s in the bridge", address, blockchainID)
+}
+
+// ScaleToRemote returns the amount of remote tokens transferred to remote for homeAmount tokens.
+func (b *Bridge) ScaleToRemote(remote *Transferrer, homeAmount *big.Int) (*big.Int, error) {
+	if remote.settings == nil {
+		return nil, fmt.Errorf("%s is not a remote of the bridge", remote)
+	}
+	return ApplyTokenScaling(remote.settings.TokenMultiplier, remote.settings.MultiplyOnRemote, homeAmount), nil
+}
+
+// ScaleToHome returns the amount of home tokens transferred to the home for remoteAmount tokens
+// of remote.
+func (b *Bridge) ScaleToHome(remote *Transferrer, remoteAmount *big.Int) (*big.Int, error) {
+	if remote.settings == nil {
+		return nil, fmt.Errorf("%s is not a remote of the bridge", remote)
+	}
+	return RemoveTokenScaling(remote.settings.TokenMultiplier, remote.settings.MultiplyOnRemote, remoteAmount), nil
+}
+
+// ApplyTokenScaling applies token scaling to the given amount of home tokens.
+// Token scaling is applied when sending tokens from the home to the TokenRemote instances.
+func ApplyTokenScaling(tokenMultiplier *big.Int, multiplyOnRemote bool, homeTokenAmount *big.Int) *big.Int {
+	return scaleTokens(tokenMultiplier, multiplyOnRemote, homeTokenAmount, true)
+}
+
+// RemoveTokenScaling removes token scaling from the given amount of remote tokens.
+// Token scaling is removed when sending tokens from the remote back to the TokenHome instance.
+func RemoveTokenScaling(tokenMultiplier *big.Int, multiplyOnRemote bool, remoteTokenAmount *big.Int) *big.Int {
+	return scaleTokens(tokenMultiplier, multiplyOnRemote, remoteTokenAmount, false)
+}
+
+func scaleTokens(tokenMultiplier *big.Int, multiplyOnRemote bool, amount *big.Int, isSendToRemote bool) *big.Int {
+	// Multiply when multiplyOnRemote and isSendToRemote are both true or both false.
+	if multiplyOnRemote == isSendToRemote {
+		return new(big.Int).Mul(amount, tokenMultiplier)
+	}
+	return new(big.Int).Div(amount, tokenMultiplier)
+}
@@ -0,0 +1,331 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ictt
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/TokenHome"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	testUtils "github.com/ava-labs/icm-contracts/utils/test-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var testTeleporterAddress = common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+
+// deliver adds to chain a receipt delivering messageID in blockNumber, with the given events of
+// the transferrer.
+func deliver(t *testing.T, chain *testUtils.FakeClient, blockNumber uint64, messageID ids.ID, executed bool, logs ...*types.Log) {
+	messengerABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	message := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		RequiredGasLimit:        big.NewInt(1),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 []byte{},
+	}
+	messageTopics := []common.Hash{common.Hash(messageID), {1}}
+	receiptLogs := []*types.Log{
+		testUtils.EventLog(t, messengerABI, testTeleporterAddress, "ReceiveCrossChainMessage",
+			append(messageTopics, common.Hash{}), common.Address{}, message),
+	}
+	if executed {
+		receiptLogs = append(receiptLogs, logs...)
+		receiptLogs = append(receiptLogs, testUtils.EventLog(t, messengerABI, testTeleporterAddress, "MessageExecuted", messageTopics))
+	} else {
+		receiptLogs = append(receiptLogs, testUtils.EventLog(t, messengerABI, testTeleporterAddress, "MessageExecutionFailed", messageTopics, message))
+	}
+	chain.AddReceipt(&types.Receipt{
+		TxHash:      common.Hash{byte(len(chain.Receipts) + 1), byte(blockNumber)},
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+		Logs:        receiptLogs,
+	})
+}
+
+type testBridge struct {
+	*Bridge
+	homeChain, remoteChainA, remoteChainB *testUtils.FakeClient
+}
+
+func newTestBridge() *testBridge {
+	b := &testBridge{
+		homeChain:    testUtils.NewFakeClient(),
+		remoteChainA: testUtils.NewFakeClient(),
+		remoteChainB: testUtils.NewFakeClient(),
+	}
+	transferrer := func(chain *testUtils.FakeClient, blockchainID ids.ID, tokenType TokenType, settings *tokenhome.RemoteTokenTransferrerSettings) *Transferrer {
+		address := common.Address{blockchainID[0]}
+		return &Transferrer{
+			Client:            chain,
+			EVMChainID:        big.NewInt(int64(blockchainID[0])),
+			BlockchainID:      blockchainID,
+			Address:           address,
+			TokenType:         tokenType,
+			TeleporterAddress: testTeleporterAddress,
+			feeToken:          address,
+			settings:          settings,
+		}
+	}
+	b.Bridge = &Bridge{
+		Home: transferrer(b.homeChain, ids.ID{1}, ERC20, nil),
+		Remotes: []*Transferrer{
+			transferrer(b.remoteChainA, ids.ID{2}, ERC20, &tokenhome.RemoteTokenTransferrerSettings{
+				Registered: true, TokenMultiplier: big.NewInt(100), MultiplyOnRemote: true,
+			}),
+			transferrer(b.remoteChainB, ids.ID{3}, Native, &tokenhome.RemoteTokenTransferrerSettings{
+				Registered: true, TokenMultiplier: big.NewInt(1000), MultiplyOnRemote: false,
+			}),
+		},
+	}
+	return b
+}
+
+func TestTokenScaling(t *testing.T) {
+	b := newTestBridge()
+	remoteA, remoteB := b.Remotes[0], b.Remotes[1]
+
+	scaled, err := b.ScaleToRemote(remoteA, big.NewInt(7))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(700), scaled)
+	scaled, err = b.ScaleToHome(remoteA, big.NewInt(750))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(7), scaled)
+
+	scaled, err = b.ScaleToRemote(remoteB, big.NewInt(7500))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(7), scaled)
+	scaled, err = b.ScaleToHome(remoteB, big.NewInt(7))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(7000), scaled)
+
+	_, err = b.ScaleToRemote(b.Home, big.NewInt(1))
+	require.ErrorContains(t, err, "is not a remote of the bridge")
+
+	remote, err := b.Remote(ids.ID{3}, common.Address{3})
+	require.NoError(t, err)
+	require.Equal(t, remoteB, remote)
+	_, err = b.Remote(ids.ID{3}, common.Address{2})
+	require.Error(t, err)
+}
+
+func TestSendInput(t *testing.T) {
+	b := newTestBridge()
+	home, remoteA, remoteB := b.Home, b.Remotes[0], b.Remotes[1]
+	recipient := common.Address{0xaa}
+	fallback := common.Address{0xbb}
+
+	testCases := []struct {
+		name     string
+		from, to *Transferrer
+		transfer Transfer
+		expected tokenhome.SendTokensInput
+		err      string
+	}{
+		{
+			name:     "home to remote",
+			from:     home,
+			to:       remoteB,
+			transfer: Transfer{Recipient: recipient, Amount: big.NewInt(10), PrimaryFee: big.NewInt(1)},
+			expected: tokenhome.SendTokensInput{
+				DestinationBlockchainID:            remoteB.BlockchainID,
+				DestinationTokenTransferrerAddress: remoteB.Address,
+				Recipient:                          recipient,
+				PrimaryFeeTokenAddress:             home.feeToken,
+				PrimaryFee:                         big.NewInt(1),
+				SecondaryFee:                       big.NewInt(0),
+				RequiredGasLimit:                   DefaultNativeRequiredGas,
+			},
+		},
+		{
+			name: "multi-hop",
+			from: remoteB,
+			to:   remoteA,
+			transfer: Transfer{
+				Recipient:        recipient,
+				Amount:           big.NewInt(10),
+				SecondaryFee:     big.NewInt(2),
+				RequiredGasLimit: big.NewInt(5),
+			},
+			expected: tokenhome.SendTokensInput{
+				DestinationBlockchainID:            remoteA.BlockchainID,
+				DestinationTokenTransferrerAddress: remoteA.Address,
+				Recipient:                          recipient,
+				PrimaryFeeTokenAddress:             remoteB.Address,
+				PrimaryFee:                         big.NewInt(0),
+				SecondaryFee:                       big.NewInt(2),
+				RequiredGasLimit:                   big.NewInt(5),
+				MultiHopFallback:                   recipient,
+			},
+		},
+		{
+			name: "multi-hop fallback",
+			from: remoteA,
+			to:   remoteB,
+			transfer: Transfer{
+				Recipient:        recipient,
+				Amount:           big.NewInt(10),
+				MultiHopFallback: fallback,
+			},
+			expected: tokenhome.SendTokensInput{
+				DestinationBlockchainID:            remoteB.BlockchainID,
+				DestinationTokenTransferrerAddress: remoteB.Address,
+				Recipient:                          recipient,
+				PrimaryFeeTokenAddress:             remoteA.Address,
+				PrimaryFee:                         big.NewInt(0),
+				SecondaryFee:                       big.NewInt(0),
+				RequiredGasLimit:                   DefaultNativeRequiredGas,
+				MultiHopFallback:                   fallback,
+			},
+		},
+		{
+			name:     "secondary fee of single hop",
+			from:     remoteA,
+			to:       home,
+			transfer: Transfer{Recipient: recipient, Amount: big.NewInt(10), SecondaryFee: big.NewInt(1)},
+			err:      "secondary fee is only paid by multi-hop transfers",
+		},
+		{
+			name:     "missing recipient",
+			from:     home,
+			to:       remoteA,
+			transfer: Transfer{Amount: big.NewInt(10)},
+			err:      "recipient is not set",
+		},
+		{
+			name:     "zero amount",
+			from:     home,
+			to:       remoteA,
+			transfer: Transfer{Recipient: recipient, Amount: big.NewInt(0)},
+			err:      "transfer amount must be positive",
+		},
+		{
+			name:     "to itself",
+			from:     remoteA,
+			to:       remoteA,
+			transfer: Transfer{Recipient: recipient, Amount: big.NewInt(10)},
+			err:      "to itself",
+		},
+		{
+			name:     "unknown transferrer",
+			from:     home,
+			to:       &Transferrer{Address: common.Address{9}},
+			transfer: Transfer{Recipient: recipient, Amount: big.NewInt(10)},
+			err:      "is not part of the bridge",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := b.checkRoute(tc.from, tc.to)
+			var transfer Transfer
+			if err == nil {
+				transfer, err = withDefaults(tc.transfer, tc.from, tc.to)
+			}
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, sendTokensInput(tc.from, tc.to, transfer))
+		})
+	}
+}
+
+func TestWaitForDelivery(t *testing.T) {
+	PollInterval = time.Millisecond
+	homeABI, err := tokenhome.TokenHomeMetaData.GetAbi()
+	require.NoError(t, err)
+	recipient := common.Address{0xaa}
+
+	t.Run("single hop", func(t *testing.T) {
+		b := newTestBridge()
+		remote := b.Remotes[0]
+		messageID := ids.ID{0x10}
+		deliver(t, b.remoteChainA, 5000, messageID, true,
+			testUtils.EventLog(t, homeABI, remote.Address, "TokensWithdrawn", []common.Hash{common.BytesToHash(recipient[:])}, big.NewInt(700)),
+		)
+		// A message delivered before the transfer was sent is not searched
+		deliver(t, b.remoteChainA, 10, ids.ID{0x11}, true)
+
+		delivery, err := b.WaitForDelivery(context.Background(), &SentTransfer{
+			Source:           b.Home,
+			Destination:      remote,
+			MessageID:        messageID,
+			destinationBlock: 100,
+		})
+		require.NoError(t, err)
+		require.Len(t, delivery.Hops, 1)
+		require.Equal(t, messageID, delivery.Hops[0].MessageID)
+		require.Equal(t, recipient, delivery.Recipient)
+		require.Equal(t, big.NewInt(700), delivery.Amount)
+	})
+
+	t.Run("multi-hop call", func(t *testing.T) {
+		b := newTestBridge()
+		remoteA, remoteB := b.Remotes[0], b.Remotes[1]
+		messageID, routedID := ids.ID{0x10}, ids.ID{0x20}
+		contract := common.Address{0xcc}
+		routedInput := tokenhome.SendAndCallInput{
+			RecipientPayload:  []byte{},
+			RequiredGasLimit:  big.NewInt(1),
+			RecipientGasLimit: big.NewInt(1),
+			PrimaryFee:        big.NewInt(0),
+			SecondaryFee:      big.NewInt(0),
+		}
+		deliver(t, b.homeChain, 20, messageID, true,
+			testUtils.EventLog(t, homeABI, b.Home.Address, "TokensAndCallRouted", []common.Hash{common.Hash(routedID)}, routedInput, big.NewInt(7)),
+		)
+		deliver(t, b.remoteChainB, 30, routedID, true,
+			testUtils.EventLog(t, homeABI, remoteB.Address, "CallSucceeded", []common.Hash{common.BytesToHash(contract[:])}, big.NewInt(7)),
+		)
+
+		delivery, err := b.WaitForDelivery(context.Background(), &SentTransfer{
+			Source:      remoteA,
+			Destination: remoteB,
+			MessageID:   messageID,
+			call:        true,
+		})
+		require.NoError(t, err)
+		require.Len(t, delivery.Hops, 2)
+		require.Equal(t, routedID, delivery.Hops[1].MessageID)
+		require.True(t, delivery.CallSucceeded)
+		require.Equal(t, contract, delivery.Recipient)
+		require.Equal(t, big.NewInt(7), delivery.Amount)
+	})
+
+	t.Run("execution failed", func(t *testing.T) {
+		b := newTestBridge()
+		messageID := ids.ID{0x10}
+		deliver(t, b.homeChain, 20, messageID, false)
+
+		_, err := b.WaitForDelivery(context.Background(), &SentTransfer{
+			Source:      b.Remotes[0],
+			Destination: b.Home,
+			MessageID:   messageID,
+		})
+		var failed *ExecutionFailedError
+		require.ErrorAs(t, err, &failed)
+		require.Equal(t, messageID, failed.Message.MessageID)
+	})
+
+	t.Run("not delivered", func(t *testing.T) {
+		b := newTestBridge()
+		b.remoteChainA.Head = 3 * MaxLogBlockRange
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := b.WaitForDelivery(ctx, &SentTransfer{
+			Source:      b.Home,
+			Destination: b.Remotes[0],
+			MessageID:   ids.ID{0x10},
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,201 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ictt
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/TokenHome"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// MaxLogBlockRange is the number of blocks requested per eth_getLogs call when searching for the
+// delivery of a transfer.
+const MaxLogBlockRange uint64 = 2048
+
+// PollInterval is the interval at which the destination of a transfer is polled for its delivery.
+var PollInterval = time.Second
+
+// Delivery is a transfer delivered on its destination.
+type Delivery struct {
+	// Hops are the Teleporter messages delivered for the transfer, one per hop.
+	Hops []*teleporterSDK.ReceivedMessage
+	// Receipt is the receipt of the transaction that delivered the transfer on its destination.
+	Receipt *types.Receipt
+	// Recipient received Amount tokens of the destination. For sendAndCall transfers, Recipient
+	// is the called contract, or the fallback recipient if the call failed.
+	Recipient common.Address
+	Amount    *big.Int
+	// CallSucceeded is true if the contract called by a sendAndCall transfer succeeded.
+	CallSucceeded bool
+}
+
+// ExecutionFailedError is returned when a Teleporter message of a transfer was received, but its
+// execution failed. It can be retried with teleporter.RetryMessageExecution.
+type ExecutionFailedError struct {
+	Transferrer *Transferrer
+	Message     *teleporterSDK.ReceivedMessage
+}
+
+func (e *ExecutionFailedError) Error() string {
+	return fmt.Sprintf("message %s was received by %s, but its execution failed", e.Message.MessageID, e.Transferrer)
+}
+
+// WaitForDelivery waits until sent is delivered on its destination, and returns the tokens
+// withdrawn or the result of the call. The transfer is delivered by relayers, so WaitForDelivery
+// only observes the destination, and the home for multi-hop transfers. It stops waiting when ctx
+// is done.
+func (b *Bridge) WaitForDelivery(ctx context.Context, sent *SentTransfer) (*Delivery, error) {
+	delivery := &Delivery{}
+	messageID := sent.MessageID
+	if sent.MultiHop() {
+		hop, err := waitForMessage(ctx, b.Home, messageID, sent.homeBlock)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Hops = append(delivery.Hops, hop)
+		messageID, err = routedMessageID(b.Home, hop.Receipt, sent.call)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hop, err := waitForMessage(ctx, sent.Destination, messageID, sent.destinationBlock)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Hops = append(delivery.Hops, hop)
+	delivery.Receipt = hop.Receipt
+
+	filterer, err := tokenhome.NewTokenHomeFilterer(sent.Destination.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	logs := transferrerLogs(hop.Receipt, sent.Destination.Address)
+	if !sent.call {
+		event, err := teleporterSDK.GetEventFromLogs(logs, filterer.ParseTokensWithdrawn)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Recipient, delivery.Amount = event.Recipient, event.Amount
+		return delivery, nil
+	}
+	if event, err := teleporterSDK.GetEventFromLogs(logs, filterer.ParseCallSucceeded); err == nil {
+		delivery.Recipient, delivery.Amount = event.RecipientContract, event.Amount
+		delivery.CallSucceeded = true
+		return delivery, nil
+	}
+	event, err := teleporterSDK.GetEventFromLogs(logs, filterer.ParseCallFailed)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Recipient, delivery.Amount = sent.fallbackRecipient, event.Amount
+	return delivery, nil
+}
+
+// routedMessageID returns the ID of the message sent by home to route a multi-hop transfer.
+func routedMessageID(home *Transferrer, receipt *types.Receipt, call bool) (ids.ID, error) {
+	filterer, err := tokenhome.NewTokenHomeFilterer(home.Address, nil)
+	if err != nil {
+		return ids.Empty, err
+	}
+	logs := transferrerLogs(receipt, home.Address)
+	if call {
+		event, err := teleporterSDK.GetEventFromLogs(logs, filterer.ParseTokensAndCallRouted)
+		if err != nil {
+			return ids.Empty, errors.Wrapf(err, "%s did not route the transfer", home)
+		}
+		return event.TeleporterMessageID, nil
+	}
+	event, err := teleporterSDK.GetEventFromLogs(logs, filterer.ParseTokensRouted)
+	if err != nil {
+		return ids.Empty, errors.Wrapf(err, "%s did not route the transfer", home)
+	}
+	return event.TeleporterMessageID, nil
+}
+
+// waitForMessage waits until the Teleporter message messageID is received on the chain of t, by
+// searching the ReceiveCrossChainMessage events emitted since fromBlock.
+func waitForMessage(
+	ctx context.Context,
+	t *Transferrer,
+	messageID ids.ID,
+	fromBlock uint64,
+) (*teleporterSDK.ReceivedMessage, error) {
+	messengerABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	topics := [][]common.Hash{
+		{messengerABI.Events["ReceiveCrossChainMessage"].ID},
+		{common.Hash(messageID)},
+	}
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+	for {
+		latest, err := t.Client.BlockNumber(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get block number of %s", t.BlockchainID)
+		}
+		for start := fromBlock; start <= latest; start += MaxLogBlockRange {
+			end := min(start+MaxLogBlockRange-1, latest)
+			logs, err := t.Client.FilterLogs(ctx, interfaces.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(start),
+				ToBlock:   new(big.Int).SetUint64(end),
+				Addresses: []common.Address{t.TeleporterAddress},
+				Topics:    topics,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get logs of %s from block %d to %d", t.BlockchainID, start, end)
+			}
+			if len(logs) > 0 {
+				return receivedMessage(ctx, t, messageID, logs[0].TxHash)
+			}
+			fromBlock = end + 1
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "stopped waiting for message %s on %s", messageID, t.BlockchainID)
+		case <-ticker.C:
+		}
+	}
+}
+
+func receivedMessage(
+	ctx context.Context,
+	t *Transferrer,
+	messageID ids.ID,
+	txHash common.Hash,
+) (*teleporterSDK.ReceivedMessage, error) {
+	receipt, err := t.Client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipt of transaction %s", txHash)
+	}
+	// The transaction may deliver several messages, so only the Teleporter events of messageID
+	// are parsed. The token events of the transfer are read from the full receipt.
+	messageReceipt := *receipt
+	messageReceipt.Logs = nil
+	for _, log := range receipt.Logs {
+		if log.Address == t.TeleporterAddress && len(log.Topics) > 1 && log.Topics[1] == common.Hash(messageID) {
+			messageReceipt.Logs = append(messageReceipt.Logs, log)
+		}
+	}
+	message, err := teleporterSDK.ParseReceivedMessage(&messageReceipt)
+	if err != nil {
+		return nil, err
+	}
+	message.Receipt = receipt
+	if !message.Executed {
+		return nil, &ExecutionFailedError{Transferrer: t, Message: message}
+	}
+	return message, nil
+}
@@ -0,0 +1,376 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ictt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	erc20tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHome"
+	nativetokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/NativeTokenHome"
+	tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/TokenHome"
+	erc20tokenremote "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/ERC20TokenRemote"
+	nativetokenremote "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/NativeTokenRemote"
+	wrappednativetoken "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/WrappedNativeToken"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Gas limits required to receive a transfer on the destination, used when Transfer.RequiredGasLimit
+// is not set
+var (
+	DefaultERC20RequiredGas  = big.NewInt(100_000)
+	DefaultNativeRequiredGas = big.NewInt(135_000)
+)
+
+// Transfer is a transfer of tokens between two transferrers of a bridge.
+type Transfer struct {
+	// Recipient of the tokens on the destination. Ignored if Call is set.
+	Recipient common.Address
+	// Amount of source tokens sent, excluding the primary fee
+	Amount *big.Int
+	// PrimaryFee is paid in the fee token of the source to the relayer of the first hop
+	PrimaryFee *big.Int
+	// SecondaryFee is deducted from the transferred amount to pay the relayer of the second hop
+	// of a multi-hop transfer
+	SecondaryFee *big.Int
+	// RequiredGasLimit is the gas limit required to receive the transfer on the destination. It
+	// defaults to DefaultERC20RequiredGas or DefaultNativeRequiredGas.
+	RequiredGasLimit *big.Int
+	// MultiHopFallback receives the tokens on the home if the second hop of a multi-hop transfer
+	// cannot be sent. It defaults to Recipient, or Call.FallbackRecipient.
+	MultiHopFallback common.Address
+	// Call makes the transfer a sendAndCall, which calls a contract with the tokens.
+	Call *Call
+}
+
+// Call is the contract call made with the tokens of a transfer on the destination.
+type Call struct {
+	RecipientContract common.Address
+	RecipientPayload  []byte
+	RecipientGasLimit *big.Int
+	// FallbackRecipient receives the tokens if the call fails
+	FallbackRecipient common.Address
+}
+
+// SentTransfer is a transfer sent by a transaction on the source.
+type SentTransfer struct {
+	Source      *Transferrer
+	Destination *Transferrer
+	Receipt     *types.Receipt
+	// MessageID is the ID of the Teleporter message of the first hop
+	MessageID ids.ID
+	// Amount is the amount transferred, as emitted by the source. It is scaled to the
+	// denomination of the remote for transfers sent from the home.
+	Amount *big.Int
+
+	call              bool
+	fallbackRecipient common.Address
+	// Blocks of the home and the destination before the transfer was sent, from which its
+	// delivery is searched
+	homeBlock        uint64
+	destinationBlock uint64
+}
+
+// MultiHop returns true if the transfer is routed through the home.
+func (s *SentTransfer) MultiHop() bool {
+	return s.Source.settings != nil && s.Destination.settings != nil
+}
+
+// Send sends transfer from the transferrer from to the transferrer to, and waits for the
+// transaction to be mined successfully. Transfers between two remotes are routed through the
+// home. The ERC20 tokens and the primary fee are approved, and the native primary fee is
+// deposited, before sending.
+func (b *Bridge) Send(
+	ctx context.Context,
+	from *Transferrer,
+	to *Transferrer,
+	transfer Transfer,
+	senderKey *ecdsa.PrivateKey,
+) (*SentTransfer, error) {
+	if err := b.checkRoute(from, to); err != nil {
+		return nil, err
+	}
+	transfer, err := withDefaults(transfer, from, to)
+	if err != nil {
+		return nil, err
+	}
+	sent := &SentTransfer{
+		Source:      from,
+		Destination: to,
+		call:        transfer.Call != nil,
+	}
+	if transfer.Call != nil {
+		sent.fallbackRecipient = transfer.Call.FallbackRecipient
+	}
+	if sent.homeBlock, err = b.Home.Client.BlockNumber(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to get block number of the home")
+	}
+	if sent.destinationBlock, err = to.Client.BlockNumber(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to get block number of the destination")
+	}
+
+	if err := approveTransfer(ctx, from, transfer, senderKey); err != nil {
+		return nil, err
+	}
+	receipt, err := transact(ctx, from, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		if from.TokenType == Native {
+			opts.Value = transfer.Amount
+		}
+		return sendTransfer(opts, from, to, transfer)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to send transfer from %s", from)
+	}
+
+	filterer, err := tokenhome.NewTokenHomeFilterer(from.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	logs := transferrerLogs(receipt, from.Address)
+	if sent.call {
+		event, err := teleporterSDK.GetEventFromLogs(logs, filterer.ParseTokensAndCallSent)
+		if err != nil {
+			return nil, err
+		}
+		sent.MessageID, sent.Amount = event.TeleporterMessageID, event.Amount
+	} else {
+		event, err := teleporterSDK.GetEventFromLogs(logs, filterer.ParseTokensSent)
+		if err != nil {
+			return nil, err
+		}
+		sent.MessageID, sent.Amount = event.TeleporterMessageID, event.Amount
+	}
+	sent.Receipt = receipt
+	return sent, nil
+}
+
+func (b *Bridge) checkRoute(from *Transferrer, to *Transferrer) error {
+	if from == to {
+		return fmt.Errorf("cannot send from %s to itself", from)
+	}
+	for _, t := range []*Transferrer{from, to} {
+		if t != b.Home && t.settings == nil {
+			return fmt.Errorf("%s is not part of the bridge", t)
+		}
+	}
+	return nil
+}
+
+func withDefaults(transfer Transfer, from *Transferrer, to *Transferrer) (Transfer, error) {
+	if transfer.Amount == nil || transfer.Amount.Sign() <= 0 {
+		return transfer, errors.New("transfer amount must be positive")
+	}
+	if transfer.PrimaryFee == nil {
+		transfer.PrimaryFee = big.NewInt(0)
+	}
+	if transfer.SecondaryFee == nil {
+		transfer.SecondaryFee = big.NewInt(0)
+	}
+	multiHop := from.settings != nil && to.settings != nil
+	if !multiHop {
+		if transfer.SecondaryFee.Sign() != 0 {
+			return transfer, errors.New("secondary fee is only paid by multi-hop transfers")
+		}
+		transfer.MultiHopFallback = common.Address{}
+	} else if transfer.MultiHopFallback == (common.Address{}) {
+		transfer.MultiHopFallback = transfer.Recipient
+		if transfer.Call != nil {
+			transfer.MultiHopFallback = transfer.Call.FallbackRecipient
+		}
+	}
+	if transfer.RequiredGasLimit == nil {
+		transfer.RequiredGasLimit = DefaultERC20RequiredGas
+		if to.TokenType == Native {
+			transfer.RequiredGasLimit = DefaultNativeRequiredGas
+		}
+	}
+	if transfer.Call != nil {
+		if transfer.Call.RecipientGasLimit == nil {
+			return transfer, errors.New("recipient gas limit of the call is not set")
+		}
+		if transfer.Call.FallbackRecipient == (common.Address{}) {
+			return transfer, errors.New("fallback recipient of the call is not set")
+		}
+	} else if transfer.Recipient == (common.Address{}) {
+		return transfer, errors.New("recipient is not set")
+	}
+	return transfer, nil
+}
+
+// approveTransfer approves the ERC20 tokens and primary fee of transfer to be spent by from, or
+// deposits and approves the primary fee of a native transfer.
+func approveTransfer(ctx context.Context, from *Transferrer, transfer Transfer, senderKey *ecdsa.PrivateKey) error {
+	amount := new(big.Int).Set(transfer.PrimaryFee)
+	if from.TokenType == ERC20 {
+		amount.Add(amount, transfer.Amount)
+	}
+	if amount.Sign() == 0 {
+		return nil
+	}
+	if from.TokenType == Native {
+		return DepositAndApproveWrappedTokenForFees(ctx, from.Client, from.EVMChainID, from.feeToken, amount, from.Address, senderKey)
+	}
+	// WrappedNativeToken is an ERC20, so its binding approves any ERC20 token.
+	token, err := wrappednativetoken.NewWrappedNativeTokenTransactor(from.feeToken, from.Client)
+	if err != nil {
+		return err
+	}
+	_, err = transact(ctx, from, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return token.Approve(opts, from.Address, amount)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to approve %s to spend %s", from.Address, amount)
+	}
+	return nil
+}
+
+// DepositAndApproveWrappedTokenForFees deposits amount native tokens into the wrapped native
+// token, which may be a NativeTokenRemote, and approves spender to spend them.
+func DepositAndApproveWrappedTokenForFees(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	wrappedToken common.Address,
+	amount *big.Int,
+	spender common.Address,
+	senderKey *ecdsa.PrivateKey,
+) error {
+	if amount.Sign() == 0 {
+		return nil
+	}
+	token, err := wrappednativetoken.NewWrappedNativeTokenTransactor(wrappedToken, client)
+	if err != nil {
+		return err
+	}
+	t := &Transferrer{Client: client, EVMChainID: chainID}
+	_, err = transact(ctx, t, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		opts.Value = amount
+		return token.Deposit(opts)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to deposit %s into %s", amount, wrappedToken)
+	}
+	_, err = transact(ctx, t, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return token.Approve(opts, spender, amount)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to approve %s to spend %s", spender, amount)
+	}
+	return nil
+}
+
+// sendTransfer calls send or sendAndCall on from, which is a home if it has no settings.
+func sendTransfer(opts *bind.TransactOpts, from *Transferrer, to *Transferrer, transfer Transfer) (*types.Transaction, error) {
+	input := sendTokensInput(from, to, transfer)
+	callInput := sendAndCallInput(from, to, transfer)
+	isHome := from.settings == nil
+	switch {
+	case isHome && from.TokenType == ERC20:
+		home, err := erc20tokenhome.NewERC20TokenHomeTransactor(from.Address, from.Client)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Call != nil {
+			return home.SendAndCall(opts, erc20tokenhome.SendAndCallInput(callInput), transfer.Amount)
+		}
+		return home.Send(opts, erc20tokenhome.SendTokensInput(input), transfer.Amount)
+	case isHome && from.TokenType == Native:
+		home, err := nativetokenhome.NewNativeTokenHomeTransactor(from.Address, from.Client)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Call != nil {
+			return home.SendAndCall(opts, nativetokenhome.SendAndCallInput(callInput))
+		}
+		return home.Send(opts, nativetokenhome.SendTokensInput(input))
+	case from.TokenType == ERC20:
+		remote, err := erc20tokenremote.NewERC20TokenRemoteTransactor(from.Address, from.Client)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Call != nil {
+			return remote.SendAndCall(opts, erc20tokenremote.SendAndCallInput(callInput), transfer.Amount)
+		}
+		return remote.Send(opts, erc20tokenremote.SendTokensInput(input), transfer.Amount)
+	default:
+		remote, err := nativetokenremote.NewNativeTokenRemoteTransactor(from.Address, from.Client)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Call != nil {
+			return remote.SendAndCall(opts, nativetokenremote.SendAndCallInput(callInput))
+		}
+		return remote.Send(opts, nativetokenremote.SendTokensInput(input))
+	}
+}
+
+func sendTokensInput(from *Transferrer, to *Transferrer, transfer Transfer) tokenhome.SendTokensInput {
+	return tokenhome.SendTokensInput{
+		DestinationBlockchainID:            to.BlockchainID,
+		DestinationTokenTransferrerAddress: to.Address,
+		Recipient:                          transfer.Recipient,
+		PrimaryFeeTokenAddress:             from.feeToken,
+		PrimaryFee:                         transfer.PrimaryFee,
+		SecondaryFee:                       transfer.SecondaryFee,
+		RequiredGasLimit:                   transfer.RequiredGasLimit,
+		MultiHopFallback:                   transfer.MultiHopFallback,
+	}
+}
+
+func sendAndCallInput(from *Transferrer, to *Transferrer, transfer Transfer) tokenhome.SendAndCallInput {
+	input := tokenhome.SendAndCallInput{
+		DestinationBlockchainID:            to.BlockchainID,
+		DestinationTokenTransferrerAddress: to.Address,
+		RequiredGasLimit:                   transfer.RequiredGasLimit,
+		MultiHopFallback:                   transfer.MultiHopFallback,
+		PrimaryFeeTokenAddress:             from.feeToken,
+		PrimaryFee:                         transfer.PrimaryFee,
+		SecondaryFee:                       transfer.SecondaryFee,
+	}
+	if transfer.Call != nil {
+		input.RecipientContract = transfer.Call.RecipientContract
+		input.RecipientPayload = transfer.Call.RecipientPayload
+		input.RecipientGasLimit = transfer.Call.RecipientGasLimit
+		input.FallbackRecipient = transfer.Call.FallbackRecipient
+	}
+	return input
+}
+
+// transact sends the transaction built by send on the chain of t with a transactor of key, and
+// waits for it to be mined successfully.
+func transact(
+	ctx context.Context,
+	t *Transferrer,
+	key *ecdsa.PrivateKey,
+	send func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*types.Receipt, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, t.EVMChainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	tx, err := send(opts)
+	if err != nil {
+		return nil, err
+	}
+	return teleporterSDK.WaitForTransactionSuccess(ctx, t.Client, tx.Hash())
+}
+
+// transferrerLogs returns the logs of receipt emitted by address.
+func transferrerLogs(receipt *types.Receipt, address common.Address) []*types.Log {
+	var logs []*types.Log
+	for _, log := range receipt.Logs {
+		if log.Address == address {
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
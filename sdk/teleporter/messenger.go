@@ -0,0 +1,339 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+var (
+	// DefaultSendCrossChainMessageGas is the gas limit of the transactions built by
+	// CreateSendCrossChainMessageTransaction.
+	DefaultSendCrossChainMessageGas uint64 = 300_000
+)
+
+// SentMessage is a Teleporter message sent by a transaction.
+type SentMessage struct {
+	Receipt   *types.Receipt
+	MessageID ids.ID
+	Event     *teleportermessenger.TeleporterMessengerSendCrossChainMessage
+}
+
+// ReceivedMessage is a Teleporter message delivered by a transaction. Executed is false if the
+// message was received but its execution failed, in which case it can be retried with
+// RetryMessageExecution.
+type ReceivedMessage struct {
+	Receipt   *types.Receipt
+	MessageID ids.ID
+	Event     *teleportermessenger.TeleporterMessengerReceiveCrossChainMessage
+	Executed  bool
+}
+
+// ParseTeleporterMessage parses the Teleporter message in the addressed call payload of a Warp message.
+func ParseTeleporterMessage(unsignedMessage avalancheWarp.UnsignedMessage) (*teleportermessenger.TeleporterMessage, error) {
+	addressedPayload, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse addressed call payload")
+	}
+	var teleporterMessage teleportermessenger.TeleporterMessage
+	if err := teleporterMessage.Unpack(addressedPayload.Payload); err != nil {
+		return nil, errors.Wrap(err, "failed to unpack Teleporter message")
+	}
+	return &teleporterMessage, nil
+}
+
+// SendCrossChainMessage calls sendCrossChainMessage on messenger, and waits for the transaction
+// to be mined successfully.
+func SendCrossChainMessage(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	messenger *teleportermessenger.TeleporterMessenger,
+	input teleportermessenger.TeleporterMessageInput,
+	senderKey *ecdsa.PrivateKey,
+) (*SentMessage, error) {
+	receipt, err := transact(ctx, client, chainID, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return messenger.SendCrossChainMessage(opts, input)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send cross chain message")
+	}
+	return sentMessage(receipt, messenger)
+}
+
+// SendSpecifiedReceipts calls sendSpecifiedReceipts on messenger, which sends a message with the
+// receipts of the given messages received from destinationBlockchainID, and waits for the
+// transaction to be mined successfully.
+func SendSpecifiedReceipts(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	messenger *teleportermessenger.TeleporterMessenger,
+	destinationBlockchainID ids.ID,
+	messageIDs [][32]byte,
+	feeInfo teleportermessenger.TeleporterFeeInfo,
+	allowedRelayerAddresses []common.Address,
+	senderKey *ecdsa.PrivateKey,
+) (*SentMessage, error) {
+	receipt, err := transact(ctx, client, chainID, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return messenger.SendSpecifiedReceipts(opts, destinationBlockchainID, messageIDs, feeInfo, allowedRelayerAddresses)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send specified receipts")
+	}
+	return sentMessage(receipt, messenger)
+}
+
+func sentMessage(receipt *types.Receipt, messenger *teleportermessenger.TeleporterMessenger) (*SentMessage, error) {
+	event, err := GetEventFromLogs(receipt.Logs, messenger.ParseSendCrossChainMessage)
+	if err != nil {
+		return nil, err
+	}
+	return &SentMessage{
+		Receipt:   receipt,
+		MessageID: event.MessageID,
+		Event:     event,
+	}, nil
+}
+
+// AddFeeAmount calls addFeeAmount on messenger, adding amount of feeTokenAddress to the fee of
+// the message messageID, and waits for the transaction to be mined successfully. The sender must
+// have approved the transfer of the fee by messenger.
+func AddFeeAmount(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	messenger *teleportermessenger.TeleporterMessenger,
+	messageID ids.ID,
+	feeTokenAddress common.Address,
+	amount *big.Int,
+	senderKey *ecdsa.PrivateKey,
+) (*types.Receipt, error) {
+	receipt, err := transact(ctx, client, chainID, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return messenger.AddFeeAmount(opts, messageID, feeTokenAddress, amount)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to add fee amount to message %s", messageID)
+	}
+	event, err := GetEventFromLogs(receipt.Logs, messenger.ParseAddFeeAmount)
+	if err != nil {
+		return nil, err
+	}
+	if ids.ID(event.MessageID) != messageID {
+		return nil, fmt.Errorf("fee amount added to message %s instead of %s", ids.ID(event.MessageID), messageID)
+	}
+	return receipt, nil
+}
+
+// RetryMessageExecution calls retryMessageExecution on messenger, executing again a message
+// from sourceBlockchainID whose execution failed, and waits for the transaction to be mined
+// successfully.
+func RetryMessageExecution(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	messenger *teleportermessenger.TeleporterMessenger,
+	sourceBlockchainID ids.ID,
+	message teleportermessenger.TeleporterMessage,
+	senderKey *ecdsa.PrivateKey,
+) (*types.Receipt, error) {
+	receipt, err := transact(ctx, client, chainID, senderKey, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return messenger.RetryMessageExecution(opts, sourceBlockchainID, message)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retry message execution")
+	}
+	return receipt, nil
+}
+
+// ReceiveCrossChainMessage delivers signedMessage to the messenger at teleporterAddress, crediting
+// the relayer rewards to the sender, and waits for the transaction to be mined successfully.
+func ReceiveCrossChainMessage(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	teleporterAddress common.Address,
+	signedMessage *avalancheWarp.Message,
+	requiredGasLimit *big.Int,
+	senderKey *ecdsa.PrivateKey,
+) (*ReceivedMessage, error) {
+	tx, err := CreateReceiveCrossChainMessageTransaction(
+		ctx,
+		client,
+		chainID,
+		teleporterAddress,
+		signedMessage,
+		requiredGasLimit,
+		senderKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := SendTransactionAndWaitForSuccess(ctx, client, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to receive cross chain message")
+	}
+	return ParseReceivedMessage(receipt)
+}
+
+// ParseReceivedMessage returns the message delivered by the receiveCrossChainMessage transaction
+// of receipt.
+func ParseReceivedMessage(receipt *types.Receipt) (*ReceivedMessage, error) {
+	filterer, err := teleportermessenger.NewTeleporterMessengerFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	event, err := GetEventFromLogs(receipt.Logs, filterer.ParseReceiveCrossChainMessage)
+	if err != nil {
+		return nil, err
+	}
+	executed, err := GetEventFromLogs(receipt.Logs, filterer.ParseMessageExecuted)
+	return &ReceivedMessage{
+		Receipt:   receipt,
+		MessageID: event.MessageID,
+		Event:     event,
+		Executed:  err == nil && executed.MessageID == event.MessageID,
+	}, nil
+}
+
+// ReceiptReceived returns true if receipt contains a ReceiptReceived event for messageID.
+func ReceiptReceived(receipt *types.Receipt, messageID ids.ID) bool {
+	filterer, err := teleportermessenger.NewTeleporterMessengerFilterer(common.Address{}, nil)
+	if err != nil {
+		return false
+	}
+	for _, log := range receipt.Logs {
+		event, err := filterer.ParseReceiptReceived(*log)
+		if err == nil && bytes.Equal(event.MessageID[:], messageID[:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateSendCrossChainMessageTransaction builds and signs a transaction calling
+// sendCrossChainMessage on the messenger at teleporterAddress, with a gas limit of
+// DefaultSendCrossChainMessageGas.
+func CreateSendCrossChainMessageTransaction(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	teleporterAddress common.Address,
+	input teleportermessenger.TeleporterMessageInput,
+	senderKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	data, err := teleportermessenger.PackSendCrossChainMessage(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack sendCrossChainMessage")
+	}
+	params, err := CalculateTxParams(ctx, client, PrivateKeyToAddress(senderKey))
+	if err != nil {
+		return nil, err
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     params.Nonce,
+		To:        &teleporterAddress,
+		Gas:       DefaultSendCrossChainMessageGas,
+		GasFeeCap: params.GasFeeCap,
+		GasTipCap: params.GasTipCap,
+		Value:     common.Big0,
+		Data:      data,
+	})
+	return SignTransaction(tx, senderKey, chainID)
+}
+
+// CreateReceiveCrossChainMessageTransaction builds and signs a transaction calling
+// receiveCrossChainMessage on the messenger at teleporterAddress, with signedMessage as the Warp
+// predicate. The gas limit covers requiredGasLimit, the verification of the signature and the
+// receipts of the message. Relayer rewards are credited to the sender.
+func CreateReceiveCrossChainMessageTransaction(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	teleporterAddress common.Address,
+	signedMessage *avalancheWarp.Message,
+	requiredGasLimit *big.Int,
+	senderKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	numSigners, err := signedMessage.Signature.NumSigners()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get number of signers")
+	}
+	teleporterMessage, err := ParseTeleporterMessage(signedMessage.UnsignedMessage)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(
+		numSigners,
+		requiredGasLimit,
+		len(signedMessage.Bytes()),
+		len(signedMessage.Payload),
+		len(teleporterMessage.Receipts),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate gas limit")
+	}
+
+	senderAddress := PrivateKeyToAddress(senderKey)
+	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, senderAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack receiveCrossChainMessage")
+	}
+	params, err := CalculateTxParams(ctx, client, senderAddress)
+	if err != nil {
+		return nil, err
+	}
+	tx := predicateutils.NewPredicateTx(
+		chainID,
+		params.Nonce,
+		&teleporterAddress,
+		gasLimit,
+		params.GasFeeCap,
+		params.GasTipCap,
+		big.NewInt(0),
+		callData,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedMessage.Bytes(),
+	)
+	return SignTransaction(tx, senderKey, chainID)
+}
+
+// transact sends the transaction built by send with a transactor of key, and waits for it to be
+// mined successfully.
+func transact(
+	ctx context.Context,
+	client Client,
+	chainID *big.Int,
+	key *ecdsa.PrivateKey,
+	send func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*types.Receipt, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	tx, err := send(opts)
+	if err != nil {
+		return nil, err
+	}
+	return WaitForTransactionSuccess(ctx, client, tx.Hash())
+}
@@ -0,0 +1,195 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	testUtils "github.com/ava-labs/icm-contracts/utils/test-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTeleporterAddress = common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+	testChainID           = big.NewInt(43112)
+)
+
+func testMessage() teleportermessenger.TeleporterMessage {
+	return teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     common.Address{1},
+		DestinationBlockchainID: ids.ID{2},
+		DestinationAddress:      common.Address{3},
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts: []teleportermessenger.TeleporterMessageReceipt{
+			{ReceivedMessageNonce: big.NewInt(4), RelayerRewardAddress: common.Address{5}},
+		},
+		Message: []byte("hello"),
+	}
+}
+
+func testSignedMessage(t *testing.T, numSigners int) *avalancheWarp.Message {
+	message := testMessage()
+	messageBytes, err := message.Pack()
+	require.NoError(t, err)
+	addressedCall, err := payload.NewAddressedCall(testTeleporterAddress.Bytes(), messageBytes)
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.ID{6}, addressedCall.Bytes())
+	require.NoError(t, err)
+	signers := set.NewBits()
+	for i := 0; i < numSigners; i++ {
+		signers.Add(i)
+	}
+	signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{
+		Signers: signers.Bytes(),
+	})
+	require.NoError(t, err)
+	return signedMessage
+}
+
+func TestParseTeleporterMessage(t *testing.T) {
+	signedMessage := testSignedMessage(t, 1)
+	message, err := ParseTeleporterMessage(signedMessage.UnsignedMessage)
+	require.NoError(t, err)
+	require.Equal(t, testMessage(), *message)
+
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.ID{6}, []byte{1, 2, 3})
+	require.NoError(t, err)
+	_, err = ParseTeleporterMessage(*unsignedMessage)
+	require.ErrorContains(t, err, "failed to parse addressed call payload")
+}
+
+func TestCreateReceiveCrossChainMessageTransaction(t *testing.T) {
+	client := newFakeClient()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signedMessage := testSignedMessage(t, 5)
+	requiredGasLimit := big.NewInt(100_000)
+
+	tx, err := CreateReceiveCrossChainMessageTransaction(
+		context.Background(),
+		client,
+		testChainID,
+		testTeleporterAddress,
+		signedMessage,
+		requiredGasLimit,
+		key,
+	)
+	require.NoError(t, err)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(testChainID), tx)
+	require.NoError(t, err)
+	require.Equal(t, PrivateKeyToAddress(key), sender)
+	require.Equal(t, testTeleporterAddress, *tx.To())
+	require.Equal(t, client.Nonce, tx.Nonce())
+	require.Equal(t, gasUtils.GasFeeCap(client.BaseFee), tx.GasFeeCap())
+
+	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(
+		5, requiredGasLimit, len(signedMessage.Bytes()), len(signedMessage.Payload), 1,
+	)
+	require.NoError(t, err)
+	require.Equal(t, gasLimit, tx.Gas())
+
+	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, sender)
+	require.NoError(t, err)
+	require.Equal(t, callData, tx.Data())
+
+	accessList := tx.AccessList()
+	require.Len(t, accessList, 1)
+	require.Equal(t, warp.ContractAddress, accessList[0].Address)
+	var predicateBytes []byte
+	for _, key := range accessList[0].StorageKeys {
+		predicateBytes = append(predicateBytes, key[:]...)
+	}
+	predicate, err := predicateutils.UnpackPredicate(predicateBytes)
+	require.NoError(t, err)
+	require.Equal(t, signedMessage.Bytes(), predicate)
+}
+
+func TestCreateSendCrossChainMessageTransaction(t *testing.T) {
+	client := newFakeClient()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	input := teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: ids.ID{2},
+		DestinationAddress:      common.Address{3},
+		FeeInfo:                 teleportermessenger.TeleporterFeeInfo{FeeTokenAddress: common.Address{}, Amount: big.NewInt(0)},
+		RequiredGasLimit:        big.NewInt(100_000),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte("hello"),
+	}
+
+	tx, err := CreateSendCrossChainMessageTransaction(
+		context.Background(),
+		client,
+		testChainID,
+		testTeleporterAddress,
+		input,
+		key,
+	)
+	require.NoError(t, err)
+	require.Equal(t, DefaultSendCrossChainMessageGas, tx.Gas())
+	require.Equal(t, testChainID, tx.ChainId())
+	data, err := teleportermessenger.PackSendCrossChainMessage(input)
+	require.NoError(t, err)
+	require.Equal(t, data, tx.Data())
+}
+
+// eventLog returns a log of the TeleporterMessenger event with the given indexed topics and
+// non-indexed arguments.
+func eventLog(t *testing.T, name string, topics []common.Hash, args ...interface{}) *types.Log {
+	messengerABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	return testUtils.EventLog(t, messengerABI, testTeleporterAddress, name, topics, args...)
+}
+
+func TestParseReceivedMessage(t *testing.T) {
+	messageID := ids.ID{7}
+	sourceBlockchainID := ids.ID{6}
+	deliverer := common.Address{8}
+	received := eventLog(t, "ReceiveCrossChainMessage",
+		[]common.Hash{common.Hash(messageID), common.Hash(sourceBlockchainID), common.BytesToHash(deliverer[:])},
+		deliverer, testMessage(),
+	)
+	executed := eventLog(t, "MessageExecuted", []common.Hash{common.Hash(messageID), common.Hash(sourceBlockchainID)})
+	failed := eventLog(t, "MessageExecutionFailed",
+		[]common.Hash{common.Hash(messageID), common.Hash(sourceBlockchainID)},
+		testMessage(),
+	)
+	receiptReceived := eventLog(t, "ReceiptReceived",
+		[]common.Hash{{4}, common.Hash(sourceBlockchainID), common.BytesToHash(common.Address{5}.Bytes())},
+		teleportermessenger.TeleporterFeeInfo{FeeTokenAddress: common.Address{}, Amount: big.NewInt(0)},
+	)
+
+	message, err := ParseReceivedMessage(&types.Receipt{Logs: []*types.Log{receiptReceived, received, executed}})
+	require.NoError(t, err)
+	require.Equal(t, messageID, message.MessageID)
+	require.Equal(t, deliverer, message.Event.Deliverer)
+	require.Equal(t, testMessage(), message.Event.Message)
+	require.True(t, message.Executed)
+
+	message, err = ParseReceivedMessage(&types.Receipt{Logs: []*types.Log{received, failed}})
+	require.NoError(t, err)
+	require.False(t, message.Executed)
+
+	_, err = ParseReceivedMessage(&types.Receipt{Logs: []*types.Log{executed}})
+	require.ErrorContains(t, err, "failed to find *teleportermessenger.TeleporterMessengerReceiveCrossChainMessage event")
+
+	require.True(t, ReceiptReceived(&types.Receipt{Logs: []*types.Log{receiptReceived}}, ids.ID{4}))
+	require.False(t, ReceiptReceived(&types.Receipt{Logs: []*types.Log{receiptReceived}}, messageID))
+}
@@ -0,0 +1,174 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package teleporter sends, receives and tracks Teleporter messages. Unlike the helpers of the
+// e2e tests, every function returns its errors and respects the deadline of its context, so the
+// package can be used by relayers, services and tools.
+package teleporter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// ReceiptPollInterval is the interval at which transaction receipts and block heights are polled.
+var ReceiptPollInterval = 200 * time.Millisecond
+
+// Client is the subset of an EVM client used to send transactions and wait for their receipts.
+// It is implemented by the subnet-evm ethclient.Client.
+type Client interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	EstimateBaseFee(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// TransactionRevertedError is returned when a transaction was mined but reverted.
+type TransactionRevertedError struct {
+	Receipt *types.Receipt
+}
+
+func (e *TransactionRevertedError) Error() string {
+	return fmt.Sprintf("transaction %s reverted in block %s", e.Receipt.TxHash, e.Receipt.BlockNumber)
+}
+
+// TxParams are the fees and nonce of a transaction sent by an account.
+type TxParams struct {
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+	Nonce     uint64
+}
+
+// CalculateTxParams returns the fees and the next nonce of a transaction sent by sender.
+func CalculateTxParams(ctx context.Context, client Client, sender common.Address) (*TxParams, error) {
+	baseFee, err := client.EstimateBaseFee(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to estimate base fee")
+	}
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to suggest gas tip cap")
+	}
+	nonce, err := client.NonceAt(ctx, sender, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get nonce of %s", sender)
+	}
+	return &TxParams{
+		GasFeeCap: gasUtils.GasFeeCap(baseFee),
+		GasTipCap: gasTipCap,
+		Nonce:     nonce,
+	}, nil
+}
+
+// SignTransaction signs tx with key for the given EVM chain ID.
+func SignTransaction(tx *types.Transaction, key *ecdsa.PrivateKey, chainID *big.Int) (*types.Transaction, error) {
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction")
+	}
+	return signedTx, nil
+}
+
+// PrivateKeyToAddress returns the address of the account of k.
+func PrivateKeyToAddress(k *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(k.PublicKey)
+}
+
+// WaitMined waits until the transaction with txHash is mined, and returns its receipt whatever
+// its status. It then waits for the block number returned by the client to reach the block of
+// the transaction, since the nodes behind a public RPC endpoint may see blocks at different times.
+// It stops waiting when ctx is done.
+func WaitMined(ctx context.Context, client Client, txHash common.Hash) (*types.Receipt, error) {
+	ticker := time.NewTicker(ReceiptPollInterval)
+	defer ticker.Stop()
+
+	var receipt *types.Receipt
+	for receipt == nil {
+		var err error
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		if err != nil && !errors.Is(err, interfaces.NotFound) {
+			return nil, errors.Wrapf(err, "failed to get receipt of transaction %s", txHash)
+		}
+		if receipt == nil {
+			if err := wait(ctx, ticker, txHash); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for {
+		blockNumber, err := client.BlockNumber(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get block number")
+		}
+		if blockNumber >= receipt.BlockNumber.Uint64() {
+			return receipt, nil
+		}
+		if err := wait(ctx, ticker, txHash); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func wait(ctx context.Context, ticker *time.Ticker, txHash common.Hash) error {
+	select {
+	case <-ctx.Done():
+		return errors.Wrapf(ctx.Err(), "stopped waiting for transaction %s", txHash)
+	case <-ticker.C:
+		return nil
+	}
+}
+
+// WaitForTransactionSuccess waits until the transaction with txHash is mined. If it reverted, the
+// receipt is returned with a *TransactionRevertedError.
+func WaitForTransactionSuccess(ctx context.Context, client Client, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := WaitMined(ctx, client, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, &TransactionRevertedError{Receipt: receipt}
+	}
+	return receipt, nil
+}
+
+// SendTransactionAndWaitForSuccess sends the signed tx, and waits until it is mined successfully.
+func SendTransactionAndWaitForSuccess(ctx context.Context, client Client, tx *types.Transaction) (*types.Receipt, error) {
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, errors.Wrapf(err, "failed to send transaction %s", tx.Hash())
+	}
+	return WaitForTransactionSuccess(ctx, client, tx.Hash())
+}
+
+// EventNotFoundError is returned by GetEventFromLogs when none of the logs is the expected event.
+type EventNotFoundError struct {
+	Event string
+}
+
+func (e *EventNotFoundError) Error() string {
+	return fmt.Sprintf("failed to find %s event in receipt logs", e.Event)
+}
+
+// GetEventFromLogs returns the first log in logs that is successfully parsed by parser, or an
+// *EventNotFoundError.
+func GetEventFromLogs[T any](logs []*types.Log, parser func(log types.Log) (T, error)) (T, error) {
+	for _, log := range logs {
+		event, err := parser(*log)
+		if err == nil {
+			return event, nil
+		}
+	}
+	var event T
+	return event, &EventNotFoundError{Event: fmt.Sprintf("%T", event)}
+}
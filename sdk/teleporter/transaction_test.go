@@ -0,0 +1,135 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	testUtils "github.com/ava-labs/icm-contracts/utils/test-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeClient returns a client at block 10, whose next nonce is 3.
+func newFakeClient() *testUtils.FakeClient {
+	client := testUtils.NewFakeClient()
+	client.Head = 10
+	client.Nonce = 3
+	return client
+}
+
+func init() {
+	ReceiptPollInterval = time.Millisecond
+}
+
+func TestWaitForTransactionSuccess(t *testing.T) {
+	txHash := common.Hash{1}
+	testCases := []struct {
+		name         string
+		status       uint64
+		blockNumber  uint64
+		pendingPolls int
+		receiptErr   error
+		err          string
+	}{
+		{
+			name:         "success",
+			status:       types.ReceiptStatusSuccessful,
+			blockNumber:  10,
+			pendingPolls: 3,
+		},
+		{
+			name:        "reverted",
+			status:      types.ReceiptStatusFailed,
+			blockNumber: 10,
+			err:         "reverted in block 10",
+		},
+		{
+			name:       "receipt error",
+			receiptErr: errors.New("connection refused"),
+			err:        "failed to get receipt of transaction",
+		},
+		{
+			name:        "block height behind receipt",
+			status:      types.ReceiptStatusSuccessful,
+			blockNumber: 11,
+			err:         "stopped waiting for transaction",
+		},
+		{
+			name:         "never mined",
+			pendingPolls: 1 << 30,
+			err:          "context deadline exceeded",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newFakeClient()
+			client.PendingPolls = tc.pendingPolls
+			client.ReceiptErr = tc.receiptErr
+			// The receipt is added without moving the head, which may be behind it.
+			client.Receipts = append(client.Receipts, &types.Receipt{
+				TxHash:      txHash,
+				Status:      tc.status,
+				BlockNumber: new(big.Int).SetUint64(tc.blockNumber),
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			receipt, err := WaitForTransactionSuccess(ctx, client, txHash)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, txHash, receipt.TxHash)
+			require.Equal(t, tc.pendingPolls+1, client.ReceiptPolls)
+		})
+	}
+}
+
+func TestWaitForTransactionSuccessReverted(t *testing.T) {
+	client := newFakeClient()
+	txHash := common.Hash{1}
+	client.AddReceipt(&types.Receipt{
+		TxHash:      txHash,
+		Status:      types.ReceiptStatusFailed,
+		BlockNumber: big.NewInt(1),
+	})
+	receipt, err := WaitForTransactionSuccess(context.Background(), client, txHash)
+	var reverted *TransactionRevertedError
+	require.ErrorAs(t, err, &reverted)
+	require.Equal(t, receipt, reverted.Receipt)
+}
+
+func TestCalculateTxParams(t *testing.T) {
+	client := newFakeClient()
+	params, err := CalculateTxParams(context.Background(), client, common.Address{})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(50_000_000_000+2_500_000_000), params.GasFeeCap)
+	require.Equal(t, client.GasTipCap, params.GasTipCap)
+	require.Equal(t, client.Nonce, params.Nonce)
+}
+
+func TestGetEventFromLogs(t *testing.T) {
+	logs := []*types.Log{{Index: 1}, {Index: 2, Data: []byte{1}}}
+	parser := func(log types.Log) (uint, error) {
+		if len(log.Data) == 0 {
+			return 0, errors.New("not the event")
+		}
+		return log.Index, nil
+	}
+	index, err := GetEventFromLogs(logs, parser)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), index)
+
+	_, err = GetEventFromLogs(logs[:1], parser)
+	var notFound *EventNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	require.EqualError(t, err, "failed to find uint event in receipt logs")
+}
@@ -0,0 +1,185 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Operation is the change to the validator set made by a flow.
+type Operation string
+
+const (
+	RegisterValidator Operation = "registerValidator"
+	EndValidation     Operation = "endValidation"
+	RegisterDelegator Operation = "registerDelegator"
+	EndDelegation     Operation = "endDelegation"
+)
+
+// Step is the next step of a flow.
+type Step string
+
+const (
+	// StepInitialize initializes the operation on the validator manager, which emits a Warp
+	// message to the P-Chain.
+	StepInitialize Step = "initialize"
+	// StepPChain signs the Warp message of the validator manager and issues it to the P-Chain.
+	StepPChain Step = "pChain"
+	// StepComplete signs the acknowledgement of the P-Chain and delivers it to the validator
+	// manager.
+	StepComplete Step = "complete"
+	// StepDone is the step of a completed flow.
+	StepDone Step = "done"
+)
+
+// PChainOwner is the P-Chain owner of the remaining balance of a validator, or of the right to
+// disable it.
+type PChainOwner struct {
+	Threshold uint32           `json:"threshold"`
+	Addresses []common.Address `json:"addresses"`
+}
+
+// Validator is a validator to register.
+type Validator struct {
+	NodeID            ids.NodeID    `json:"nodeID"`
+	BLSPublicKey      hexutil.Bytes `json:"blsPublicKey"`
+	ProofOfPossession hexutil.Bytes `json:"proofOfPossession"`
+	// RegistrationExpiry is the time after which the registration is rejected by the P-Chain
+	RegistrationExpiry    uint64      `json:"registrationExpiry"`
+	RemainingBalanceOwner PChainOwner `json:"remainingBalanceOwner"`
+	DisableOwner          PChainOwner `json:"disableOwner"`
+	// Balance is the amount of nAVAX allocated to the continuous fee of the validator on the P-Chain
+	Balance uint64 `json:"balance"`
+
+	// Weight of a PoA validator
+	Weight uint64 `json:"weight,omitempty"`
+	// StakeAmount of a PoS validator
+	StakeAmount       *big.Int `json:"stakeAmount,omitempty"`
+	DelegationFeeBips uint16   `json:"delegationFeeBips,omitempty"`
+	MinStakeDuration  uint64   `json:"minStakeDuration,omitempty"`
+}
+
+// Flow is an operation on a validator manager, and its progress.
+type Flow struct {
+	ID        string         `json:"id"`
+	Operation Operation      `json:"operation"`
+	Manager   common.Address `json:"manager"`
+	Step      Step           `json:"step"`
+
+	// Validator is the validator registered by a RegisterValidator flow.
+	Validator *Validator `json:"validator,omitempty"`
+	// ValidationID is the validation registered by a RegisterValidator flow, ended by an
+	// EndValidation flow, or delegated to by a RegisterDelegator flow.
+	ValidationID ids.ID `json:"validationID"`
+	// DelegationID is the delegation registered by a RegisterDelegator flow, or ended by an
+	// EndDelegation flow.
+	DelegationID ids.ID `json:"delegationID"`
+	// DelegationAmount is the amount of tokens staked by a RegisterDelegator flow.
+	DelegationAmount *big.Int `json:"delegationAmount,omitempty"`
+
+	// Force ends a PoS validation or delegation even if it is not eligible for rewards.
+	Force bool `json:"force,omitempty"`
+	// Uptime is the uptime in seconds proven when ending a PoS validation. If nil, the uptime last
+	// submitted to the validator manager is used.
+	Uptime *uint64 `json:"uptime,omitempty"`
+	// InitialValidatorIndex is the index of an initial validator in the conversion of its subnet
+	// to an L1, for EndValidation flows of initial validators.
+	InitialValidatorIndex *uint32 `json:"initialValidatorIndex,omitempty"`
+	// RegistrationMessage is the RegisterL1ValidatorMessage of a validator registered by the
+	// validator manager. It is read from the L1 if not set.
+	RegistrationMessage hexutil.Bytes `json:"registrationMessage,omitempty"`
+
+	// WarpMessage is the unsigned Warp message emitted by the validator manager to the P-Chain.
+	WarpMessage hexutil.Bytes `json:"warpMessage,omitempty"`
+	// Nonce and Weight are the weight update of the validation sent to the P-Chain.
+	Nonce  uint64 `json:"nonce"`
+	Weight uint64 `json:"weight"`
+	// PChainHadValidator records that the P-Chain had the validator of an EndValidation flow
+	// before its removal was issued, so that its absence afterwards means the removal was applied.
+	PChainHadValidator bool `json:"pChainHadValidator,omitempty"`
+	// PendingTx is a signed L1 transaction that was sent, but not yet observed to be accepted.
+	// It is sent again when the flow is resumed.
+	PendingTx hexutil.Bytes `json:"pendingTx,omitempty"`
+	// Transactions are the accepted L1 transactions of the flow.
+	Transactions []common.Hash `json:"transactions,omitempty"`
+	PChainTxID   ids.ID        `json:"pChainTxID"`
+	UpdatedAt    time.Time     `json:"updatedAt"`
+}
+
+// Done returns true if the operation of the flow is complete.
+func (f *Flow) Done() bool {
+	return f.Step == StepDone
+}
+
+func (f *Flow) String() string {
+	return fmt.Sprintf("%s flow %s", f.Operation, f.ID)
+}
+
+// NewRegisterValidatorFlow returns a flow registering validator with the validator manager at
+// manager. Its ID is derived from the node ID of validator, as the validation ID is only known once
+// the registration is initialized. A completed flow of the same node is archived under its
+// validation ID when the node is registered again.
+func NewRegisterValidatorFlow(manager common.Address, validator Validator) (*Flow, error) {
+	if len(validator.BLSPublicKey) != bls.PublicKeyLen {
+		return nil, fmt.Errorf("invalid BLS public key length %d", len(validator.BLSPublicKey))
+	}
+	if len(validator.ProofOfPossession) != bls.SignatureLen {
+		return nil, fmt.Errorf("invalid proof of possession length %d", len(validator.ProofOfPossession))
+	}
+	return &Flow{
+		ID:        fmt.Sprintf("register-validator-%s", validator.NodeID),
+		Operation: RegisterValidator,
+		Manager:   manager,
+		Step:      StepInitialize,
+		Validator: &validator,
+	}, nil
+}
+
+// registrationFlowID returns the ID of the RegisterValidator flow of validationID, for flows
+// continuing an initialized registration and archived registrations.
+func registrationFlowID(validationID ids.ID) string {
+	return fmt.Sprintf("register-validator-%s", validationID)
+}
+
+// NewEndValidationFlow returns a flow ending validationID.
+func NewEndValidationFlow(manager common.Address, validationID ids.ID) *Flow {
+	return &Flow{
+		ID:           fmt.Sprintf("end-validation-%s", validationID),
+		Operation:    EndValidation,
+		Manager:      manager,
+		Step:         StepInitialize,
+		ValidationID: validationID,
+	}
+}
+
+// NewRegisterDelegatorFlow returns a flow delegating amount tokens to validationID. Each
+// delegation is a new flow, so its ID includes the current time.
+func NewRegisterDelegatorFlow(manager common.Address, validationID ids.ID, amount *big.Int) *Flow {
+	return &Flow{
+		ID:               fmt.Sprintf("register-delegator-%s-%d", validationID, time.Now().UnixNano()),
+		Operation:        RegisterDelegator,
+		Manager:          manager,
+		Step:             StepInitialize,
+		ValidationID:     validationID,
+		DelegationAmount: amount,
+	}
+}
+
+// NewEndDelegationFlow returns a flow ending delegationID.
+func NewEndDelegationFlow(manager common.Address, delegationID ids.ID) *Flow {
+	return &Flow{
+		ID:           fmt.Sprintf("end-delegation-%s", delegationID),
+		Operation:    EndDelegation,
+		Manager:      manager,
+		Step:         StepInitialize,
+		DelegationID: delegationID,
+	}
+}
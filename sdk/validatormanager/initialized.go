@@ -0,0 +1,193 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// NewInitializedFlow returns a flow continuing an operation that was initialized on the validator
+// manager at manager by the transaction of receipt, which may have been sent by anyone. The flow
+// starts at StepPChain.
+//
+// The proof of possession and balance of the validator of a RegisterValidator flow are not
+// recorded by the validator manager, so they must be set before the flow is run, unless the
+// registration was already issued to the P-Chain.
+func NewInitializedFlow(manager common.Address, operation Operation, receipt *types.Receipt) (*Flow, error) {
+	flow := &Flow{
+		Operation: operation,
+		Manager:   manager,
+		Step:      StepPChain,
+	}
+	if err := recordInitialization(manager, flow, receipt); err != nil {
+		return nil, err
+	}
+	switch operation {
+	case RegisterValidator:
+		registration, err := parseRegistration(flow.RegistrationMessage)
+		if err != nil {
+			return nil, err
+		}
+		nodeID, err := ids.ToNodeID(registration.NodeID)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid node ID in registration message")
+		}
+		flow.Validator = &Validator{
+			NodeID:                nodeID,
+			BLSPublicKey:          registration.BLSPublicKey[:],
+			RegistrationExpiry:    registration.Expiry,
+			RemainingBalanceOwner: newPChainOwner(registration.RemainingBalanceOwner),
+			DisableOwner:          newPChainOwner(registration.DisableOwner),
+			Weight:                registration.Weight,
+		}
+		// A node may be registered again after its previous validation ends, so registrations are
+		// identified by their validation ID.
+		flow.ID = registrationFlowID(flow.ValidationID)
+	case EndValidation:
+		flow.ID = fmt.Sprintf("end-validation-%s", flow.ValidationID)
+	case RegisterDelegator:
+		flow.ID = fmt.Sprintf("register-delegator-%s", flow.DelegationID)
+	case EndDelegation:
+		flow.ID = fmt.Sprintf("end-delegation-%s", flow.DelegationID)
+	default:
+		return nil, fmt.Errorf("invalid operation %q", operation)
+	}
+	return flow, nil
+}
+
+// NewExpiredRegistrationFlow returns an EndValidation flow that delivers the P-Chain's
+// acknowledgement that the validator of registrationMessage was never registered, which unlocks
+// the stake of a registration that expired before it was issued to the P-Chain. The flow starts
+// at StepComplete, and the L1's validators only sign the acknowledgement after the expiry of the
+// registration.
+func NewExpiredRegistrationFlow(manager common.Address, registrationMessage []byte) (*Flow, error) {
+	registration, err := parseRegistration(registrationMessage)
+	if err != nil {
+		return nil, err
+	}
+	return &Flow{
+		ID:                  fmt.Sprintf("end-validation-%s", registration.ValidationID()),
+		Operation:           EndValidation,
+		Manager:             manager,
+		Step:                StepComplete,
+		ValidationID:        registration.ValidationID(),
+		RegistrationMessage: registration.Bytes(),
+	}, nil
+}
+
+// ResendWarpMessage calls the validator manager to emit the Warp message of flow again, so that
+// the L1's validators sign it even if they no longer serve the original message. The transaction
+// is not recorded in the flow.
+func (m *Manager) ResendWarpMessage(ctx context.Context, flow *Flow, senderKey *ecdsa.PrivateKey) (*types.Receipt, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	var (
+		method string
+		arg    ids.ID
+	)
+	switch flow.Operation {
+	case RegisterValidator:
+		method, arg = "resendRegisterValidatorMessage", flow.ValidationID
+	case EndValidation:
+		method, arg = "resendEndValidatorMessage", flow.ValidationID
+	case RegisterDelegator, EndDelegation:
+		method, arg = "resendUpdateDelegation", flow.DelegationID
+	default:
+		return nil, fmt.Errorf("invalid operation %q", flow.Operation)
+	}
+	tx, err := m.callTx(ctx, senderKey, nil, method, arg)()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Client.SendTransaction(ctx, tx); err != nil {
+		return nil, errors.Wrapf(err, "failed to send transaction %s", tx.Hash())
+	}
+	return teleporterSDK.WaitForTransactionSuccess(ctx, m.Client, tx.Hash())
+}
+
+// recordInitialization records in flow the Warp message sent by the transaction of receipt, which
+// initialized the operation of flow on the validator manager at manager.
+func recordInitialization(manager common.Address, flow *Flow, receipt *types.Receipt) error {
+	unsignedMessage, err := WarpMessageFromReceipt(receipt)
+	if err != nil {
+		return err
+	}
+	payload, err := ParsePayload(unsignedMessage)
+	if err != nil {
+		return err
+	}
+	switch p := payload.(type) {
+	case *warpMessage.RegisterL1Validator:
+		if flow.Operation != RegisterValidator {
+			return fmt.Errorf("unexpected RegisterL1ValidatorMessage sent by %s", flow)
+		}
+		flow.ValidationID = p.ValidationID()
+		flow.Weight = p.Weight
+		flow.RegistrationMessage = p.Bytes()
+	case *warpMessage.L1ValidatorWeight:
+		if flow.Operation == RegisterValidator {
+			return fmt.Errorf("unexpected L1ValidatorWeightMessage sent by %s", flow)
+		}
+		if flow.Operation == EndValidation && flow.ValidationID != ids.Empty && p.ValidationID != flow.ValidationID {
+			return fmt.Errorf("validator manager ended validation %s instead of %s", p.ValidationID, flow.ValidationID)
+		}
+		flow.ValidationID = p.ValidationID
+		flow.Nonce = p.Nonce
+		flow.Weight = p.Weight
+	default:
+		return fmt.Errorf("unexpected Warp message payload %T sent by %s", payload, flow)
+	}
+	// The delegation events have the same signature in every PoS validator manager.
+	filterer, err := nativetokenstakingmanager.NewNativeTokenStakingManagerFilterer(manager, nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case flow.Operation == RegisterDelegator:
+		event, err := teleporterSDK.GetEventFromLogs(receipt.Logs, filterer.ParseDelegatorAdded)
+		if err != nil {
+			return err
+		}
+		flow.DelegationID = event.DelegationID
+	case flow.Operation == EndDelegation && flow.DelegationID == ids.Empty:
+		event, err := teleporterSDK.GetEventFromLogs(receipt.Logs, filterer.ParseDelegatorRemovalInitialized)
+		if err != nil {
+			return err
+		}
+		flow.DelegationID = event.DelegationID
+	}
+	flow.WarpMessage = unsignedMessage.Bytes()
+	return nil
+}
+
+func parseRegistration(registrationMessage []byte) (*warpMessage.RegisterL1Validator, error) {
+	payload, err := warpMessage.Parse(registrationMessage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse registration message")
+	}
+	registration, ok := payload.(*warpMessage.RegisterL1Validator)
+	if !ok {
+		return nil, fmt.Errorf("unexpected registration message payload %T", payload)
+	}
+	return registration, nil
+}
+
+func newPChainOwner(owner warpMessage.PChainOwner) PChainOwner {
+	addresses := make([]common.Address, len(owner.Addresses))
+	for i, address := range owner.Addresses {
+		addresses[i] = common.Address(address)
+	}
+	return PChainOwner{Threshold: owner.Threshold, Addresses: addresses}
+}
@@ -0,0 +1,176 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package validatormanager registers validators and delegators with a validator manager and ends
+// their validations and delegations. Each operation spans the L1, the P-Chain and back: the
+// validator manager emits a Warp message that is signed and issued to the P-Chain, and the
+// P-Chain's acknowledgement is signed and delivered to the validator manager. Operations are run
+// as flows whose progress is persisted after each step, so an interrupted flow can be resumed.
+package validatormanager
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	walletCommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	erc20tokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ERC20TokenStakingManager"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
+	uptimeUtils "github.com/ava-labs/icm-contracts/utils/uptime-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// QuorumPercentage is the percentage of the L1's weight that must sign the messages exchanged
+// between the validator manager and the P-Chain.
+const QuorumPercentage uint64 = 67
+
+// Client is the subset of an EVM client used to call and transact with a validator manager. It is
+// implemented by the subnet-evm ethclient.Client.
+type Client interface {
+	bind.ContractBackend
+	teleporterSDK.Client
+}
+
+// PChain issues the transactions that register L1 validators and update their weights. It is
+// implemented by the P-Chain wallet of avalanchego.
+type PChain interface {
+	IssueRegisterL1ValidatorTx(
+		balance uint64,
+		proofOfPossession [bls.SignatureLen]byte,
+		message []byte,
+		options ...walletCommon.Option,
+	) (*txs.Tx, error)
+	IssueSetL1ValidatorWeightTx(
+		message []byte,
+		options ...walletCommon.Option,
+	) (*txs.Tx, error)
+}
+
+// PChainState reads the L1 validators of the P-Chain. It is implemented by the platformvm client
+// of avalanchego.
+type PChainState interface {
+	GetL1Validator(ctx context.Context, validationID ids.ID, options ...rpc.Option) (platformvm.L1Validator, uint64, error)
+}
+
+// Type is the type of a validator manager contract.
+type Type int
+
+const (
+	PoA Type = iota
+	NativeTokenStaking
+	ERC20TokenStaking
+)
+
+func (t Type) String() string {
+	switch t {
+	case PoA:
+		return "PoA"
+	case NativeTokenStaking:
+		return "NativeTokenStaking"
+	case ERC20TokenStaking:
+		return "ERC20TokenStaking"
+	default:
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+}
+
+// IsPoS returns true if validators of the manager stake tokens, and can be delegated to.
+func (t Type) IsPoS() bool {
+	return t == NativeTokenStaking || t == ERC20TokenStaking
+}
+
+func (t Type) abi() (*abi.ABI, error) {
+	switch t {
+	case PoA:
+		return poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	case NativeTokenStaking:
+		return nativetokenstakingmanager.NativeTokenStakingManagerMetaData.GetAbi()
+	case ERC20TokenStaking:
+		return erc20tokenstakingmanager.ERC20TokenStakingManagerMetaData.GetAbi()
+	default:
+		return nil, fmt.Errorf("invalid validator manager type %s", t)
+	}
+}
+
+// Manager is a validator manager deployed on an L1, and the P-Chain the L1 is validated by.
+type Manager struct {
+	Client       Client
+	EVMChainID   *big.Int
+	NetworkID    uint32
+	BlockchainID ids.ID
+	SubnetID     ids.ID
+	Address      common.Address
+	Type         Type
+	// FromBlock is the block the validator manager was deployed in. The registration messages of
+	// validators ending their validation are searched from it.
+	FromBlock uint64
+
+	PChain PChain
+	// PChainState is optional. If set, a flow interrupted after issuing its P-Chain transaction is
+	// not issued again on resumption.
+	PChainState         PChainState
+	SignatureAggregator uptimeUtils.SignatureAggregator
+	// Store persists the progress of flows. If nil, flows are kept in memory.
+	Store Store
+}
+
+func (m *Manager) validate() error {
+	if m.Client == nil || m.EVMChainID == nil {
+		return fmt.Errorf("client of validator manager %s is not set", m.Address)
+	}
+	if m.PChain == nil {
+		return fmt.Errorf("P-Chain wallet of validator manager %s is not set", m.Address)
+	}
+	if m.SignatureAggregator == nil {
+		return fmt.Errorf("signature aggregator of validator manager %s is not set", m.Address)
+	}
+	if m.Store == nil {
+		m.Store = NewMemoryStore()
+	}
+	return nil
+}
+
+// contract returns the validator manager bound with the ABI of its type.
+func (m *Manager) contract() (*bind.BoundContract, *abi.ABI, error) {
+	contractABI, err := m.Type.abi()
+	if err != nil {
+		return nil, nil, err
+	}
+	return bind.NewBoundContract(m.Address, *contractABI, m.Client, m.Client, m.Client), contractABI, nil
+}
+
+// method returns the name of the method rawName of contractABI taking numInputs arguments, as
+// overloaded methods are named rawName, rawName0, etc.
+func method(contractABI *abi.ABI, rawName string, numInputs int) (string, error) {
+	for name, m := range contractABI.Methods {
+		if m.RawName == rawName && len(m.Inputs) == numInputs {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no method %s with %d inputs", rawName, numInputs)
+}
+
+// WeightToValue returns the amount of tokens staked for weight by a PoS validator manager.
+func (m *Manager) WeightToValue(ctx context.Context, weight uint64) (*big.Int, error) {
+	if !m.Type.IsPoS() {
+		return nil, fmt.Errorf("%s validator manager %s does not stake tokens", m.Type, m.Address)
+	}
+	contract, _, err := m.contract()
+	if err != nil {
+		return nil, err
+	}
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, "weightToValue", weight); err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
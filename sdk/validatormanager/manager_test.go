@@ -0,0 +1,769 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	walletCommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
+	testUtils "github.com/ava-labs/icm-contracts/utils/test-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNetworkID      uint32 = 12345
+	testBlockchainID          = ids.ID{1}
+	testSubnetID              = ids.ID{2}
+	testManagerAddress        = common.Address{3}
+	testChainID               = big.NewInt(99)
+)
+
+func init() {
+	teleporterSDK.ReceiptPollInterval = time.Millisecond
+	SignatureRetryInterval = time.Millisecond
+}
+
+// fakeL1 executes the transactions sent to a validator manager, emitting the Warp messages a
+// validator manager would.
+type fakeL1 struct {
+	*testUtils.FakeClient
+	t    *testing.T
+	abis []*abi.ABI
+	// weights and nonces of the validations of the manager
+	weights map[ids.ID]uint64
+	nonces  map[ids.ID]uint64
+	// calls are the methods called by the accepted transactions
+	calls []string
+	// completions are the Warp messages delivered to complete operations
+	completions []*avalancheWarp.Message
+	// failSends fails the next sends after accepting their transaction
+	failSends int
+}
+
+func newFakeL1(t *testing.T) *fakeL1 {
+	stakingABI, err := nativetokenstakingmanager.NativeTokenStakingManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	poaABI, err := poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	return &fakeL1{
+		FakeClient: testUtils.NewFakeClient(),
+		t:          t,
+		abis:       []*abi.ABI{stakingABI, poaABI},
+		weights:    make(map[ids.ID]uint64),
+		nonces:     make(map[ids.ID]uint64),
+	}
+}
+
+// receipt returns the receipt of the accepted transaction txHash.
+func (c *fakeL1) receipt(txHash common.Hash) *types.Receipt {
+	receipt, err := c.TransactionReceipt(context.Background(), txHash)
+	require.NoError(c.t, err)
+	return receipt
+}
+
+func (c *fakeL1) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	if _, err := c.TransactionReceipt(context.Background(), tx.Hash()); err == nil {
+		return errors.New("already known")
+	}
+	require.Equal(c.t, testManagerAddress, *tx.To())
+	require.Equal(c.t, c.Nonce, tx.Nonce())
+	var method *abi.Method
+	for _, contractABI := range c.abis {
+		if m, err := contractABI.MethodById(tx.Data()[:4]); err == nil {
+			method = m
+		}
+	}
+	require.NotNil(c.t, method)
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	require.NoError(c.t, err)
+
+	var logs []*types.Log
+	switch method.RawName {
+	case "initializeValidatorRegistration":
+		input := *abi.ConvertType(args[0], new(poavalidatormanager.ValidatorRegistrationInput)).(*poavalidatormanager.ValidatorRegistrationInput)
+		nodeID, err := ids.ToNodeID(input.NodeID)
+		require.NoError(c.t, err)
+		var publicKey [bls.PublicKeyLen]byte
+		copy(publicKey[:], input.BlsPublicKey)
+		// The weight of a PoA validator is set by its owner, and a native stake of 1 weighs 1
+		weight := tx.Value().Uint64()
+		if len(args) == 2 {
+			weight = args[1].(uint64)
+		}
+		message, err := warpMessage.NewRegisterL1Validator(
+			testSubnetID, nodeID, publicKey, input.RegistrationExpiry,
+			warpMessage.PChainOwner{Addresses: []ids.ShortID{}},
+			warpMessage.PChainOwner{Addresses: []ids.ShortID{}},
+			weight,
+		)
+		require.NoError(c.t, err)
+		c.weights[message.ValidationID()] = weight
+		logs = append(logs, c.validationPeriodCreated(message.ValidationID()), c.warpLog(message.Bytes()))
+	case "initializeEndValidation", "forceInitializeEndValidation":
+		validationID := ids.ID(args[0].([32]byte))
+		logs = append(logs, c.weightUpdate(validationID, 0))
+	case "initializeDelegatorRegistration":
+		validationID := ids.ID(args[0].([32]byte))
+		weight := c.weights[validationID] + tx.Value().Uint64()
+		logs = append(logs, c.weightUpdate(validationID, weight), c.delegatorAdded(validationID, c.nonces[validationID]))
+	case "resendRegisterValidatorMessage", "resendEndValidatorMessage":
+	case "completeValidatorRegistration", "completeEndValidation", "completeDelegatorRegistration", "completeEndDelegation":
+		c.completions = append(c.completions, predicateMessage(c.t, tx))
+	default:
+		c.t.Fatalf("unexpected call of %s", method.RawName)
+	}
+
+	c.Nonce++
+	c.calls = append(c.calls, method.RawName)
+	c.AddReceipt(&types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      tx.Hash(),
+		BlockNumber: new(big.Int).SetUint64(c.Head + 1),
+		Logs:        logs,
+	})
+	if c.failSends > 0 {
+		c.failSends--
+		return errors.New("connection reset")
+	}
+	return nil
+}
+
+func (c *fakeL1) warpLog(payload []byte) *types.Log {
+	return testUtils.WarpLog(c.t, testManagerAddress, newAddressedCallMessage(c.t, testBlockchainID, payload))
+}
+
+func (c *fakeL1) weightUpdate(validationID ids.ID, weight uint64) *types.Log {
+	c.nonces[validationID]++
+	c.weights[validationID] = weight
+	message, err := warpMessage.NewL1ValidatorWeight(validationID, c.nonces[validationID], weight)
+	require.NoError(c.t, err)
+	return c.warpLog(message.Bytes())
+}
+
+func (c *fakeL1) eventLog(name string, topics []common.Hash, args ...interface{}) *types.Log {
+	return testUtils.EventLog(c.t, c.abis[0], testManagerAddress, name, topics, args...)
+}
+
+func (c *fakeL1) validationPeriodCreated(validationID ids.ID) *types.Log {
+	return c.eventLog("ValidationPeriodCreated", []common.Hash{common.Hash(validationID), {}}, uint64(0), []byte{}, uint64(0))
+}
+
+func (c *fakeL1) delegatorAdded(validationID ids.ID, nonce uint64) *types.Log {
+	delegationID := ids.ID{byte(nonce), 0xde}
+	return c.eventLog(
+		"DelegatorAdded",
+		[]common.Hash{common.Hash(delegationID), common.Hash(validationID), {}},
+		nonce, uint64(0), uint64(0), [32]byte{},
+	)
+}
+
+func newAddressedCallMessage(t *testing.T, sourceChainID ids.ID, payload []byte) *avalancheWarp.UnsignedMessage {
+	addressedCall, err := warpPayload.NewAddressedCall(nil, payload)
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(testNetworkID, sourceChainID, addressedCall.Bytes())
+	require.NoError(t, err)
+	return unsignedMessage
+}
+
+func predicateMessage(t *testing.T, tx *types.Transaction) *avalancheWarp.Message {
+	accessList := tx.AccessList()
+	require.Len(t, accessList, 1)
+	require.Equal(t, warp.ContractAddress, accessList[0].Address)
+	var predicateBytes []byte
+	for _, key := range accessList[0].StorageKeys {
+		predicateBytes = append(predicateBytes, key[:]...)
+	}
+	predicate, err := predicateutils.UnpackPredicate(predicateBytes)
+	require.NoError(t, err)
+	message, err := avalancheWarp.ParseMessage(predicate)
+	require.NoError(t, err)
+	return message
+}
+
+type signatureRequest struct {
+	payload       warpMessage.Payload
+	justification []byte
+}
+
+// fakeAggregator signs every message with an empty signature, after failing the first failures
+// requests. If limit is set, requests fail once limit messages are signed.
+type fakeAggregator struct {
+	t        *testing.T
+	failures int
+	limit    int
+	requests []signatureRequest
+}
+
+func (a *fakeAggregator) CreateSignedMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+	signingSubnetID ids.ID,
+	quorumPercentage uint64,
+) (*avalancheWarp.Message, error) {
+	require.Equal(a.t, testSubnetID, signingSubnetID)
+	require.Equal(a.t, QuorumPercentage, quorumPercentage)
+	if a.failures > 0 {
+		a.failures--
+		return nil, errors.New("not enough signatures")
+	}
+	if a.limit > 0 && len(a.requests) >= a.limit {
+		return nil, errors.New("not enough signatures")
+	}
+	payload, err := ParsePayload(unsignedMessage)
+	require.NoError(a.t, err)
+	a.requests = append(a.requests, signatureRequest{payload: payload, justification: justification})
+	return avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{})
+}
+
+type issuedTx struct {
+	balance           uint64
+	proofOfPossession [bls.SignatureLen]byte
+	payload           warpMessage.Payload
+}
+
+// fakePChain records the issued transactions, and the L1 validators they register.
+type fakePChain struct {
+	t          *testing.T
+	err        error
+	issued     []issuedTx
+	validators map[ids.ID]platformvm.L1Validator
+}
+
+func newFakePChain(t *testing.T) *fakePChain {
+	return &fakePChain{t: t, validators: make(map[ids.ID]platformvm.L1Validator)}
+}
+
+func (p *fakePChain) issue(balance uint64, proofOfPossession [bls.SignatureLen]byte, message []byte) (*txs.Tx, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	signedMessage, err := avalancheWarp.ParseMessage(message)
+	require.NoError(p.t, err)
+	payload, err := ParsePayload(&signedMessage.UnsignedMessage)
+	require.NoError(p.t, err)
+	switch payload := payload.(type) {
+	case *warpMessage.RegisterL1Validator:
+		p.validators[payload.ValidationID()] = platformvm.L1Validator{Weight: payload.Weight}
+	case *warpMessage.L1ValidatorWeight:
+		validator, ok := p.validators[payload.ValidationID]
+		if !ok {
+			return nil, fmt.Errorf("validation %s not found", payload.ValidationID)
+		}
+		// A weight of zero removes the validator.
+		if payload.Weight == 0 {
+			delete(p.validators, payload.ValidationID)
+			break
+		}
+		validator.Weight = payload.Weight
+		validator.MinNonce = payload.Nonce + 1
+		p.validators[payload.ValidationID] = validator
+	}
+	p.issued = append(p.issued, issuedTx{balance: balance, proofOfPossession: proofOfPossession, payload: payload})
+	return &txs.Tx{TxID: ids.ID{byte(len(p.issued))}}, nil
+}
+
+func (p *fakePChain) IssueRegisterL1ValidatorTx(
+	balance uint64,
+	proofOfPossession [bls.SignatureLen]byte,
+	message []byte,
+	_ ...walletCommon.Option,
+) (*txs.Tx, error) {
+	return p.issue(balance, proofOfPossession, message)
+}
+
+func (p *fakePChain) IssueSetL1ValidatorWeightTx(message []byte, _ ...walletCommon.Option) (*txs.Tx, error) {
+	return p.issue(0, [bls.SignatureLen]byte{}, message)
+}
+
+func (p *fakePChain) GetL1Validator(_ context.Context, validationID ids.ID, _ ...rpc.Option) (platformvm.L1Validator, uint64, error) {
+	validator, ok := p.validators[validationID]
+	if !ok {
+		return platformvm.L1Validator{}, 0, errors.New("not found")
+	}
+	return validator, 0, nil
+}
+
+type testManager struct {
+	*Manager
+	l1         *fakeL1
+	pChain     *fakePChain
+	aggregator *fakeAggregator
+	key        *ecdsa.PrivateKey
+}
+
+func newTestManager(t *testing.T, managerType Type) *testManager {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	m := &testManager{
+		l1:         newFakeL1(t),
+		pChain:     newFakePChain(t),
+		aggregator: &fakeAggregator{t: t},
+		key:        key,
+	}
+	m.Manager = &Manager{
+		Client:              m.l1,
+		EVMChainID:          testChainID,
+		NetworkID:           testNetworkID,
+		BlockchainID:        testBlockchainID,
+		SubnetID:            testSubnetID,
+		Address:             testManagerAddress,
+		Type:                managerType,
+		PChain:              m.pChain,
+		PChainState:         m.pChain,
+		SignatureAggregator: m.aggregator,
+		Store:               NewMemoryStore(),
+	}
+	return m
+}
+
+func testValidator() Validator {
+	return Validator{
+		NodeID:             ids.GenerateTestNodeID(),
+		BLSPublicKey:       make([]byte, bls.PublicKeyLen),
+		ProofOfPossession:  append([]byte{7}, make([]byte, bls.SignatureLen-1)...),
+		RegistrationExpiry: 1000,
+		Balance:            5,
+		StakeAmount:        big.NewInt(100),
+		DelegationFeeBips:  10,
+		MinStakeDuration:   60,
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	m := newTestManager(t, NativeTokenStaking)
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, testValidator())
+	require.NoError(t, err)
+
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	require.Equal(t, []string{"initializeValidatorRegistration", "completeValidatorRegistration"}, m.l1.calls)
+	require.Len(t, flow.Transactions, 2)
+	require.Equal(t, uint64(100), flow.Weight)
+
+	// The registration is issued to the P-Chain with the validator's balance and proof of possession
+	require.Len(t, m.pChain.issued, 1)
+	issued := m.pChain.issued[0]
+	require.Equal(t, uint64(5), issued.balance)
+	require.Equal(t, byte(7), issued.proofOfPossession[0])
+	registration := issued.payload.(*warpMessage.RegisterL1Validator)
+	require.Equal(t, flow.ValidationID, registration.ValidationID())
+	require.Equal(t, registration.Bytes(), []byte(flow.RegistrationMessage))
+
+	// The P-Chain's acknowledgement is justified by the registration, and delivered to the manager
+	require.Len(t, m.aggregator.requests, 2)
+	acknowledgement := m.aggregator.requests[1]
+	require.Equal(t, &warpMessage.L1ValidatorRegistration{ValidationID: flow.ValidationID, Registered: true}, withoutBytes(acknowledgement.payload))
+	justification, err := RegistrationJustification(registration.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, justification, acknowledgement.justification)
+	require.Len(t, m.l1.completions, 1)
+	require.Equal(t, ids.Empty, m.l1.completions[0].SourceChainID)
+
+	stored, err := m.Store.Load(flow.ID)
+	require.NoError(t, err)
+	require.True(t, stored.Done())
+	require.Equal(t, flow.ValidationID, stored.ValidationID)
+}
+
+// withoutBytes returns the L1ValidatorRegistration payload with its fields only, for comparisons.
+func withoutBytes(payload warpMessage.Payload) *warpMessage.L1ValidatorRegistration {
+	registration := payload.(*warpMessage.L1ValidatorRegistration)
+	return &warpMessage.L1ValidatorRegistration{
+		ValidationID: registration.ValidationID,
+		Registered:   registration.Registered,
+	}
+}
+
+func TestResumeAfterPChainFailure(t *testing.T) {
+	m := newTestManager(t, PoA)
+	validator := testValidator()
+	validator.Weight = 20
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+
+	m.pChain.err = errors.New("P-Chain unavailable")
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.ErrorContains(t, err, "P-Chain unavailable")
+	require.Equal(t, StepPChain, flow.Step)
+
+	m.pChain.err = nil
+	flow, err = m.Resume(context.Background(), flow.ID, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	// The validator manager is only called once per step
+	require.Equal(t, []string{"initializeValidatorRegistration", "completeValidatorRegistration"}, m.l1.calls)
+	require.Len(t, m.pChain.issued, 1)
+}
+
+func TestResumeAfterPChainAccepted(t *testing.T) {
+	m := newTestManager(t, PoA)
+	validator := testValidator()
+	validator.Weight = 20
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+
+	// The flow is interrupted after issuing the registration to the P-Chain, before recording it
+	m.aggregator.limit = 1
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	flow, err = m.Run(ctx, flow, m.key)
+	require.Error(t, err)
+	require.Len(t, m.pChain.issued, 1)
+	flow.Step = StepPChain
+	require.NoError(t, m.Store.Save(flow))
+
+	m.aggregator.limit = 0
+	flow, err = m.Resume(context.Background(), flow.ID, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	require.Len(t, m.pChain.issued, 1)
+}
+
+func TestResumePendingTransaction(t *testing.T) {
+	m := newTestManager(t, NativeTokenStaking)
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, testValidator())
+	require.NoError(t, err)
+
+	// The transaction is accepted, but its sender sees an error
+	m.l1.failSends = 1
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.ErrorContains(t, err, "connection reset")
+	require.Equal(t, StepInitialize, flow.Step)
+	stored, err := m.Store.Load(flow.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, stored.PendingTx)
+
+	flow, err = m.Resume(context.Background(), flow.ID, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	require.Empty(t, flow.PendingTx)
+	require.Equal(t, []string{"initializeValidatorRegistration", "completeValidatorRegistration"}, m.l1.calls)
+}
+
+// savesStore records every flow saved to a MemoryStore.
+type savesStore struct {
+	*MemoryStore
+	saves []Flow
+}
+
+func (s *savesStore) Save(flow *Flow) error {
+	s.saves = append(s.saves, *flow)
+	return s.MemoryStore.Save(flow)
+}
+
+func TestAcceptedTransactionSavedWithResult(t *testing.T) {
+	m := newTestManager(t, PoA)
+	store := &savesStore{MemoryStore: NewMemoryStore()}
+	m.Store = store
+	validator := testValidator()
+	validator.Weight = 20
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	// The pending transaction is only cleared by the save that records its result, so that a flow
+	// interrupted in between sends no transaction again on resumption.
+	for _, saved := range store.saves {
+		if len(saved.PendingTx) > 0 {
+			continue
+		}
+		switch len(saved.Transactions) {
+		case 0:
+			require.Equal(t, StepInitialize, saved.Step)
+		case 1:
+			require.NotEqual(t, StepInitialize, saved.Step)
+			require.Equal(t, flow.ValidationID, saved.ValidationID)
+		case 2:
+			require.Equal(t, StepDone, saved.Step)
+		}
+	}
+}
+
+func TestRegisterValidatorAgain(t *testing.T) {
+	m := newTestManager(t, PoA)
+	validator := testValidator()
+	validator.Weight = 20
+	first, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+	first, err = m.Run(context.Background(), first, m.key)
+	require.NoError(t, err)
+	require.True(t, first.Done())
+
+	// The node is registered again after its validation ends, with a new validation ID
+	validator.RegistrationExpiry++
+	second, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+	second, err = m.Run(context.Background(), second, m.key)
+	require.NoError(t, err)
+	require.True(t, second.Done())
+	require.NotEqual(t, first.ValidationID, second.ValidationID)
+	require.Equal(t, []string{
+		"initializeValidatorRegistration", "completeValidatorRegistration",
+		"initializeValidatorRegistration", "completeValidatorRegistration",
+	}, m.l1.calls)
+
+	// The completed registration is archived under the ID of its validation
+	archived, err := m.Store.Load(registrationFlowID(first.ValidationID))
+	require.NoError(t, err)
+	require.True(t, archived.Done())
+	require.Equal(t, first.Transactions, archived.Transactions)
+	stored, err := m.Store.Load(second.ID)
+	require.NoError(t, err)
+	require.Equal(t, second.ValidationID, stored.ValidationID)
+
+	// Resuming a completed flow does not register the node again
+	resumed, err := m.Resume(context.Background(), second.ID, m.key)
+	require.NoError(t, err)
+	require.Equal(t, second.ValidationID, resumed.ValidationID)
+	require.Len(t, m.l1.calls, 4)
+}
+
+func TestSignatureRetries(t *testing.T) {
+	m := newTestManager(t, PoA)
+	validator := testValidator()
+	validator.Weight = 20
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+
+	m.aggregator.failures = 3
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+
+	// Signatures are requested until the context is done
+	flow = NewEndValidationFlow(testManagerAddress, flow.ValidationID)
+	m.aggregator.failures = 1 << 30
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	flow, err = m.Run(ctx, flow, m.key)
+	require.ErrorContains(t, err, "not enough signatures")
+	require.Equal(t, StepPChain, flow.Step)
+}
+
+func TestEndValidation(t *testing.T) {
+	m := newTestManager(t, PoA)
+	validator := testValidator()
+	validator.Weight = 20
+	registration, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+	registration, err = m.Run(context.Background(), registration, m.key)
+	require.NoError(t, err)
+
+	t.Run("registered validator", func(t *testing.T) {
+		flow := NewEndValidationFlow(testManagerAddress, registration.ValidationID)
+		flow, err := m.Run(context.Background(), flow, m.key)
+		require.NoError(t, err)
+		require.True(t, flow.Done())
+		require.Equal(t, uint64(0), flow.Weight)
+
+		// The registration message is read from the L1 to justify the end of the validation
+		require.Equal(t, registration.RegistrationMessage, flow.RegistrationMessage)
+		acknowledgement := m.aggregator.requests[len(m.aggregator.requests)-1]
+		require.Equal(t, &warpMessage.L1ValidatorRegistration{ValidationID: registration.ValidationID}, withoutBytes(acknowledgement.payload))
+		justification, err := RegistrationJustification(registration.RegistrationMessage)
+		require.NoError(t, err)
+		require.Equal(t, justification, acknowledgement.justification)
+	})
+
+	t.Run("initial validator", func(t *testing.T) {
+		validationID := ids.ID{0x11}
+		index := uint32(2)
+		m.pChain.validators[validationID] = platformvm.L1Validator{Weight: 20}
+		flow := NewEndValidationFlow(testManagerAddress, validationID)
+		flow.InitialValidatorIndex = &index
+		flow, err := m.Run(context.Background(), flow, m.key)
+		require.NoError(t, err)
+		require.True(t, flow.Done())
+
+		acknowledgement := m.aggregator.requests[len(m.aggregator.requests)-1]
+		justification, err := InitialValidatorJustification(testSubnetID, index)
+		require.NoError(t, err)
+		require.Equal(t, justification, acknowledgement.justification)
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		m.pChain.validators[ids.ID{0x12}] = platformvm.L1Validator{Weight: 20}
+		flow := NewEndValidationFlow(testManagerAddress, ids.ID{0x12})
+		_, err := m.Run(context.Background(), flow, m.key)
+		require.ErrorContains(t, err, "registration of")
+	})
+}
+
+func TestResumeAfterPChainRemovedValidator(t *testing.T) {
+	m := newTestManager(t, PoA)
+	validator := testValidator()
+	validator.Weight = 20
+	registration, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+	registration, err = m.Run(context.Background(), registration, m.key)
+	require.NoError(t, err)
+
+	// The flow is interrupted after issuing the removal to the P-Chain, before recording it
+	m.aggregator.limit = len(m.aggregator.requests) + 1
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	flow, err := m.Run(ctx, NewEndValidationFlow(testManagerAddress, registration.ValidationID), m.key)
+	require.Error(t, err)
+	require.Len(t, m.pChain.issued, 2)
+	require.NotContains(t, m.pChain.validators, registration.ValidationID)
+	flow.Step = StepPChain
+	require.NoError(t, m.Store.Save(flow))
+
+	// The missing validator is the removal applied, which is not issued again
+	m.aggregator.limit = 0
+	flow, err = m.Resume(context.Background(), flow.ID, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	require.Len(t, m.pChain.issued, 2)
+}
+
+func TestDelegation(t *testing.T) {
+	m := newTestManager(t, NativeTokenStaking)
+	registration, err := NewRegisterValidatorFlow(testManagerAddress, testValidator())
+	require.NoError(t, err)
+	registration, err = m.Run(context.Background(), registration, m.key)
+	require.NoError(t, err)
+
+	flow := NewRegisterDelegatorFlow(testManagerAddress, registration.ValidationID, big.NewInt(50))
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	require.Equal(t, ids.ID{1, 0xde}, flow.DelegationID)
+	require.Equal(t, uint64(1), flow.Nonce)
+	require.Equal(t, uint64(150), flow.Weight)
+
+	// The P-Chain acknowledges the weight update with the same nonce
+	acknowledgement := m.aggregator.requests[len(m.aggregator.requests)-1]
+	weight := acknowledgement.payload.(*warpMessage.L1ValidatorWeight)
+	require.Equal(t, registration.ValidationID, weight.ValidationID)
+	require.Equal(t, uint64(1), weight.Nonce)
+	require.Equal(t, uint64(150), weight.Weight)
+	require.Nil(t, acknowledgement.justification)
+	require.Equal(t, uint64(150), m.pChain.validators[registration.ValidationID].Weight)
+
+	// A weight update already applied by the P-Chain is not issued again
+	issued := len(m.pChain.issued)
+	flow.Step = StepPChain
+	require.NoError(t, m.Store.Save(flow))
+	flow, err = m.Resume(context.Background(), flow.ID, m.key)
+	require.NoError(t, err)
+	require.Len(t, m.pChain.issued, issued)
+
+	_, err = m.Run(context.Background(), NewRegisterDelegatorFlow(testManagerAddress, registration.ValidationID, nil), m.key)
+	require.ErrorContains(t, err, "delegation amount must be positive")
+}
+
+func TestRunValidation(t *testing.T) {
+	m := newTestManager(t, PoA)
+	_, err := m.Run(context.Background(), NewEndValidationFlow(common.Address{9}, ids.ID{1}), m.key)
+	require.ErrorContains(t, err, "is for validator manager")
+
+	_, err = m.Run(context.Background(), NewRegisterDelegatorFlow(testManagerAddress, ids.ID{1}, big.NewInt(1)), m.key)
+	require.ErrorContains(t, err, "does not support delegation")
+
+	validator := testValidator()
+	validator.BLSPublicKey = validator.BLSPublicKey[1:]
+	_, err = NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.ErrorContains(t, err, "invalid BLS public key length")
+
+	m.PChain = nil
+	_, err = m.Run(context.Background(), NewEndValidationFlow(testManagerAddress, ids.ID{1}), m.key)
+	require.ErrorContains(t, err, "P-Chain wallet")
+}
+
+func TestInitializedFlow(t *testing.T) {
+	m := newTestManager(t, PoA)
+	validator := testValidator()
+	validator.Weight = 20
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+	m.pChain.err = errors.New("P-Chain unavailable")
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.ErrorContains(t, err, "P-Chain unavailable")
+	receipt := m.l1.receipt(flow.Transactions[0])
+
+	initialized, err := NewInitializedFlow(testManagerAddress, RegisterValidator, receipt)
+	require.NoError(t, err)
+	require.Equal(t, StepPChain, initialized.Step)
+	require.Equal(t, fmt.Sprintf("register-validator-%s", flow.ValidationID), initialized.ID)
+	require.Equal(t, flow.ValidationID, initialized.ValidationID)
+	require.Equal(t, flow.RegistrationMessage, initialized.RegistrationMessage)
+	require.Equal(t, flow.WarpMessage, initialized.WarpMessage)
+	require.Equal(t, validator.NodeID, initialized.Validator.NodeID)
+	require.Equal(t, validator.RegistrationExpiry, initialized.Validator.RegistrationExpiry)
+	require.Equal(t, uint64(20), initialized.Validator.Weight)
+
+	_, err = NewInitializedFlow(testManagerAddress, EndValidation, receipt)
+	require.ErrorContains(t, err, "unexpected RegisterL1ValidatorMessage")
+
+	// The message is emitted again without changing the progress of the flow
+	_, err = m.ResendWarpMessage(context.Background(), initialized, m.key)
+	require.NoError(t, err)
+	require.Equal(t, "resendRegisterValidatorMessage", m.l1.calls[len(m.l1.calls)-1])
+	require.Empty(t, initialized.Transactions)
+
+	// The proof of possession and balance are set by the caller
+	initialized.Validator.ProofOfPossession = validator.ProofOfPossession
+	initialized.Validator.Balance = 3
+	m.pChain.err = nil
+	initialized, err = m.Run(context.Background(), initialized, m.key)
+	require.NoError(t, err)
+	require.True(t, initialized.Done())
+	require.Equal(t, uint64(3), m.pChain.issued[len(m.pChain.issued)-1].balance)
+	require.Equal(t, "completeValidatorRegistration", m.l1.calls[len(m.l1.calls)-1])
+}
+
+func TestExpiredRegistrationFlow(t *testing.T) {
+	m := newTestManager(t, NativeTokenStaking)
+	flow, err := NewRegisterValidatorFlow(testManagerAddress, testValidator())
+	require.NoError(t, err)
+	m.pChain.err = errors.New("registration expired")
+	flow, err = m.Run(context.Background(), flow, m.key)
+	require.ErrorContains(t, err, "registration expired")
+
+	expired, err := NewExpiredRegistrationFlow(testManagerAddress, flow.RegistrationMessage)
+	require.NoError(t, err)
+	require.Equal(t, StepComplete, expired.Step)
+	expired, err = m.Run(context.Background(), expired, m.key)
+	require.NoError(t, err)
+	require.True(t, expired.Done())
+	require.Equal(t, []string{"initializeValidatorRegistration", "completeEndValidation"}, m.l1.calls)
+
+	// The P-Chain acknowledges that the validator is not registered
+	acknowledgement := m.aggregator.requests[len(m.aggregator.requests)-1]
+	require.Equal(t, &warpMessage.L1ValidatorRegistration{ValidationID: flow.ValidationID}, withoutBytes(acknowledgement.payload))
+	justification, err := RegistrationJustification(flow.RegistrationMessage)
+	require.NoError(t, err)
+	require.Equal(t, justification, acknowledgement.justification)
+
+	_, err = NewExpiredRegistrationFlow(testManagerAddress, flow.WarpMessage)
+	require.ErrorContains(t, err, "failed to parse registration message")
+}
@@ -0,0 +1,112 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/platformvm"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewL1ValidatorRegistrationMessage returns the unsigned L1ValidatorRegistrationMessage of the
+// P-Chain, acknowledging that validationID is registered, or that it is not and never will be.
+func NewL1ValidatorRegistrationMessage(
+	networkID uint32,
+	validationID ids.ID,
+	registered bool,
+) (*avalancheWarp.UnsignedMessage, error) {
+	payload, err := warpMessage.NewL1ValidatorRegistration(validationID, registered)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create L1 validator registration payload")
+	}
+	return newPChainMessage(networkID, payload.Bytes())
+}
+
+// NewL1ValidatorWeightMessage returns the unsigned L1ValidatorWeightMessage of the P-Chain,
+// acknowledging the weight update of validationID with the given nonce.
+func NewL1ValidatorWeightMessage(
+	networkID uint32,
+	validationID ids.ID,
+	nonce uint64,
+	weight uint64,
+) (*avalancheWarp.UnsignedMessage, error) {
+	payload, err := warpMessage.NewL1ValidatorWeight(validationID, nonce, weight)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create L1 validator weight payload")
+	}
+	return newPChainMessage(networkID, payload.Bytes())
+}
+
+func newPChainMessage(networkID uint32, payload []byte) (*avalancheWarp.UnsignedMessage, error) {
+	addressedCall, err := warpPayload.NewAddressedCall(nil, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create addressed call")
+	}
+	return avalancheWarp.NewUnsignedMessage(networkID, constants.PlatformChainID, addressedCall.Bytes())
+}
+
+// RegistrationJustification returns the justification of an L1ValidatorRegistrationMessage for a
+// validator registered with registrationMessage, the RegisterL1ValidatorMessage payload sent by
+// the validator manager.
+func RegistrationJustification(registrationMessage []byte) ([]byte, error) {
+	return proto.Marshal(&platformvm.L1ValidatorRegistrationJustification{
+		Preimage: &platformvm.L1ValidatorRegistrationJustification_RegisterL1ValidatorMessage{
+			RegisterL1ValidatorMessage: registrationMessage,
+		},
+	})
+}
+
+// InitialValidatorJustification returns the justification of an L1ValidatorRegistrationMessage
+// for the validator at index in the conversion of subnetID to an L1.
+func InitialValidatorJustification(subnetID ids.ID, index uint32) ([]byte, error) {
+	return proto.Marshal(&platformvm.L1ValidatorRegistrationJustification{
+		Preimage: &platformvm.L1ValidatorRegistrationJustification_ConvertSubnetToL1TxData{
+			ConvertSubnetToL1TxData: &platformvm.SubnetIDIndex{
+				SubnetId: subnetID[:],
+				Index:    index,
+			},
+		},
+	})
+}
+
+// WarpMessageFromReceipt returns the Warp message sent by the transaction of receipt.
+func WarpMessageFromReceipt(receipt *types.Receipt) (*avalancheWarp.UnsignedMessage, error) {
+	var unsignedMessage *avalancheWarp.UnsignedMessage
+	for _, log := range receipt.Logs {
+		if log.Address != warp.ContractAddress {
+			continue
+		}
+		if unsignedMessage != nil {
+			return nil, fmt.Errorf("transaction %s sent more than one Warp message", receipt.TxHash)
+		}
+		var err error
+		unsignedMessage, err = warp.UnpackSendWarpEventDataToMessage(log.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse Warp message of transaction %s", receipt.TxHash)
+		}
+	}
+	if unsignedMessage == nil {
+		return nil, fmt.Errorf("transaction %s did not send a Warp message", receipt.TxHash)
+	}
+	return unsignedMessage, nil
+}
+
+// ParsePayload returns the payload of a Warp message exchanged between a validator manager and
+// the P-Chain.
+func ParsePayload(unsignedMessage *avalancheWarp.UnsignedMessage) (warpMessage.Payload, error) {
+	addressedCall, err := warpPayload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse addressed call")
+	}
+	return warpMessage.Parse(addressedCall.Payload)
+}
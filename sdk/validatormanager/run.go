@@ -0,0 +1,517 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	walletCommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	uptimeUtils "github.com/ava-labs/icm-contracts/utils/uptime-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// MaxLogBlockRange is the number of blocks requested per eth_getLogs call when searching for the
+// registration of a validator.
+const MaxLogBlockRange uint64 = 2048
+
+// SignatureRetryInterval is the interval at which signatures are requested again when the L1's
+// validators do not sign a message, such as before they observe a P-Chain transaction.
+var SignatureRetryInterval = 2 * time.Second
+
+// Run runs flow until its operation is complete, persisting its progress after each step. If the
+// store already has a flow with the same ID, its progress is resumed instead, unless flow registers
+// again the node of a completed registration, which is archived under its validation ID. senderKey sends the
+// L1 transactions, and must own a PoA validator manager. The returned flow holds the progress made,
+// also when an error is returned.
+func (m *Manager) Run(ctx context.Context, flow *Flow, senderKey *ecdsa.PrivateKey) (*Flow, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if flow.Manager != m.Address {
+		return nil, fmt.Errorf("%s is for validator manager %s, not %s", flow, flow.Manager, m.Address)
+	}
+	stored, err := m.Store.Load(flow.ID)
+	var notFound *FlowNotFoundError
+	switch {
+	case err == nil:
+		if stored.Operation != flow.Operation {
+			return nil, fmt.Errorf("stored flow %s is a %s flow, not %s", flow.ID, stored.Operation, flow.Operation)
+		}
+		if !reregistration(stored, flow) {
+			flow = stored
+			break
+		}
+		if err := m.archive(stored); err != nil {
+			return nil, err
+		}
+		if err := m.save(flow); err != nil {
+			return nil, err
+		}
+	case errors.As(err, &notFound):
+		if err := m.save(flow); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	for !flow.Done() {
+		var err error
+		switch flow.Step {
+		case StepInitialize:
+			err = m.initialize(ctx, flow, senderKey)
+		case StepPChain:
+			err = m.issueToPChain(ctx, flow)
+		case StepComplete:
+			err = m.complete(ctx, flow, senderKey)
+		default:
+			err = fmt.Errorf("invalid step %q", flow.Step)
+		}
+		if err != nil {
+			return flow, errors.Wrapf(err, "%s failed at step %s", flow, flow.Step)
+		}
+	}
+	return flow, nil
+}
+
+// Resume runs the stored flow with the given ID until its operation is complete.
+func (m *Manager) Resume(ctx context.Context, id string, senderKey *ecdsa.PrivateKey) (*Flow, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	flow, err := m.Store.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	return m.Run(ctx, flow, senderKey)
+}
+
+// reregistration returns true if flow registers again the node of the completed RegisterValidator
+// flow stored with the same ID, after its previous validation ended.
+func reregistration(stored *Flow, flow *Flow) bool {
+	return flow.Operation == RegisterValidator &&
+		stored.Done() &&
+		flow.Step == StepInitialize &&
+		len(flow.Transactions) == 0
+}
+
+// archive stores the completed RegisterValidator flow under the ID of its validation, which
+// NewInitializedFlow also uses, so that the ID of its node is free for a new registration.
+func (m *Manager) archive(flow *Flow) error {
+	archived := *flow
+	archived.ID = registrationFlowID(flow.ValidationID)
+	return errors.Wrapf(m.save(&archived), "failed to archive %s", flow)
+}
+
+func (m *Manager) save(flow *Flow) error {
+	flow.UpdatedAt = time.Now().UTC()
+	return m.Store.Save(flow)
+}
+
+func (m *Manager) advance(flow *Flow, step Step) error {
+	flow.Step = step
+	return m.save(flow)
+}
+
+// initialize initializes the operation of flow on the validator manager, and records the Warp
+// message it sent to the P-Chain.
+func (m *Manager) initialize(ctx context.Context, flow *Flow, key *ecdsa.PrivateKey) error {
+	receipt, err := m.resumePendingTx(ctx, flow)
+	if err != nil {
+		return err
+	}
+	// The pending transaction may be the approval of an ERC20 stake, which sends no Warp message.
+	if receipt == nil || !sentWarpMessage(receipt) {
+		receipt, err = m.initializeOperation(ctx, flow, key)
+		if err != nil {
+			return err
+		}
+	}
+	if err := recordInitialization(m.Address, flow, receipt); err != nil {
+		return err
+	}
+	return m.advance(flow, StepPChain)
+}
+
+func sentWarpMessage(receipt *types.Receipt) bool {
+	_, err := WarpMessageFromReceipt(receipt)
+	return err == nil
+}
+
+// initializeOperation sends the transaction initializing the operation of flow.
+func (m *Manager) initializeOperation(ctx context.Context, flow *Flow, key *ecdsa.PrivateKey) (*types.Receipt, error) {
+	_, contractABI, err := m.contract()
+	if err != nil {
+		return nil, err
+	}
+	switch flow.Operation {
+	case RegisterValidator:
+		return m.initializeValidatorRegistration(ctx, flow, key)
+
+	case EndValidation:
+		if m.Type == PoA {
+			return m.transact(ctx, flow, m.callTx(ctx, key, nil, "initializeEndValidation", flow.ValidationID))
+		}
+		rawName := "initializeEndValidation"
+		if flow.Force {
+			rawName = "forceInitializeEndValidation"
+		}
+		name, err := method(contractABI, rawName, 3)
+		if err != nil {
+			return nil, err
+		}
+		if flow.Uptime == nil {
+			return m.transact(ctx, flow, m.callTx(ctx, key, nil, name, flow.ValidationID, false, uint32(0)))
+		}
+		uptimeProof, err := uptimeUtils.ConstructUptimeProofMessage(
+			m.SignatureAggregator,
+			m.NetworkID,
+			m.BlockchainID,
+			m.SubnetID,
+			flow.ValidationID,
+			*flow.Uptime,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return m.transact(ctx, flow, m.warpTx(ctx, key, uptimeProof, name, flow.ValidationID, true, uint32(0)))
+
+	case RegisterDelegator:
+		if flow.DelegationAmount == nil || flow.DelegationAmount.Sign() <= 0 {
+			return nil, errors.New("delegation amount must be positive")
+		}
+		switch m.Type {
+		case NativeTokenStaking:
+			return m.transact(ctx, flow, m.callTx(ctx, key, flow.DelegationAmount, "initializeDelegatorRegistration", flow.ValidationID))
+		case ERC20TokenStaking:
+			if err := m.approveStake(ctx, flow, key, flow.DelegationAmount); err != nil {
+				return nil, err
+			}
+			return m.transact(ctx, flow, m.callTx(ctx, key, nil, "initializeDelegatorRegistration", flow.ValidationID, flow.DelegationAmount))
+		default:
+			return nil, fmt.Errorf("%s validator manager %s does not support delegation", m.Type, m.Address)
+		}
+
+	case EndDelegation:
+		if !m.Type.IsPoS() {
+			return nil, fmt.Errorf("%s validator manager %s does not support delegation", m.Type, m.Address)
+		}
+		rawName := "initializeEndDelegation"
+		if flow.Force {
+			rawName = "forceInitializeEndDelegation"
+		}
+		name, err := method(contractABI, rawName, 3)
+		if err != nil {
+			return nil, err
+		}
+		return m.transact(ctx, flow, m.callTx(ctx, key, nil, name, flow.DelegationID, false, uint32(0)))
+
+	default:
+		return nil, fmt.Errorf("invalid operation %q", flow.Operation)
+	}
+}
+
+func (m *Manager) initializeValidatorRegistration(ctx context.Context, flow *Flow, key *ecdsa.PrivateKey) (*types.Receipt, error) {
+	v := flow.Validator
+	if v == nil {
+		return nil, errors.New("validator is not set")
+	}
+	// The registration input has the same ABI encoding in every validator manager.
+	input := poavalidatormanager.ValidatorRegistrationInput{
+		NodeID:                v.NodeID[:],
+		BlsPublicKey:          v.BLSPublicKey,
+		RegistrationExpiry:    v.RegistrationExpiry,
+		RemainingBalanceOwner: poavalidatormanager.PChainOwner(v.RemainingBalanceOwner),
+		DisableOwner:          poavalidatormanager.PChainOwner(v.DisableOwner),
+	}
+	if input.RemainingBalanceOwner.Addresses == nil {
+		input.RemainingBalanceOwner.Addresses = []common.Address{}
+	}
+	if input.DisableOwner.Addresses == nil {
+		input.DisableOwner.Addresses = []common.Address{}
+	}
+	if m.Type == PoA {
+		if v.Weight == 0 {
+			return nil, errors.New("weight of PoA validator must be positive")
+		}
+		return m.transact(ctx, flow, m.callTx(ctx, key, nil, "initializeValidatorRegistration", input, v.Weight))
+	}
+	if v.StakeAmount == nil {
+		if v.Weight == 0 {
+			return nil, errors.New("stake amount or weight of PoS validator must be set")
+		}
+		stakeAmount, err := m.WeightToValue(ctx, v.Weight)
+		if err != nil {
+			return nil, err
+		}
+		v.StakeAmount = stakeAmount
+		if err := m.save(flow); err != nil {
+			return nil, err
+		}
+	}
+	switch m.Type {
+	case NativeTokenStaking:
+		return m.transact(ctx, flow, m.callTx(
+			ctx, key, v.StakeAmount, "initializeValidatorRegistration",
+			input, v.DelegationFeeBips, v.MinStakeDuration,
+		))
+	case ERC20TokenStaking:
+		if err := m.approveStake(ctx, flow, key, v.StakeAmount); err != nil {
+			return nil, err
+		}
+		return m.transact(ctx, flow, m.callTx(
+			ctx, key, nil, "initializeValidatorRegistration",
+			input, v.DelegationFeeBips, v.MinStakeDuration, v.StakeAmount,
+		))
+	default:
+		return nil, fmt.Errorf("invalid validator manager type %s", m.Type)
+	}
+}
+
+// issueToPChain signs the Warp message of flow and issues it to the P-Chain.
+func (m *Manager) issueToPChain(ctx context.Context, flow *Flow) error {
+	accepted, err := m.acceptedByPChain(ctx, flow)
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(flow.WarpMessage)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse Warp message")
+		}
+		signedMessage, err := m.aggregate(ctx, unsignedMessage, nil)
+		if err != nil {
+			return err
+		}
+		var tx *txs.Tx
+		if flow.Operation == RegisterValidator {
+			var proofOfPossession [bls.SignatureLen]byte
+			copy(proofOfPossession[:], flow.Validator.ProofOfPossession)
+			tx, err = m.PChain.IssueRegisterL1ValidatorTx(
+				flow.Validator.Balance,
+				proofOfPossession,
+				signedMessage.Bytes(),
+				walletCommon.WithContext(ctx),
+			)
+		} else {
+			tx, err = m.PChain.IssueSetL1ValidatorWeightTx(signedMessage.Bytes(), walletCommon.WithContext(ctx))
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to issue P-Chain transaction")
+		}
+		flow.PChainTxID = tx.ID()
+	}
+	return m.advance(flow, StepComplete)
+}
+
+// acceptedByPChain returns true if the P-Chain already applied the Warp message of flow, which
+// happens when a flow is interrupted after issuing its P-Chain transaction. The removal of a
+// validator is only reported as applied if the P-Chain had the validator when it was issued.
+func (m *Manager) acceptedByPChain(ctx context.Context, flow *Flow) (bool, error) {
+	if m.PChainState == nil {
+		return false, nil
+	}
+	validator, _, err := m.PChainState.GetL1Validator(ctx, flow.ValidationID)
+	if err != nil {
+		if flow.Operation == EndValidation && flow.PChainHadValidator && isNotFound(err) {
+			return true, nil
+		}
+		// The validator is not registered, or the P-Chain is unavailable and the transaction is
+		// issued, which fails if the message was applied.
+		return false, nil
+	}
+	switch flow.Operation {
+	case RegisterValidator:
+		return true, nil
+	case EndValidation:
+		// The P-Chain removes validators whose weight is set to zero, so the removal was not
+		// applied yet. It is recorded before the removal is issued.
+		if !flow.PChainHadValidator {
+			flow.PChainHadValidator = true
+			if err := m.save(flow); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	default:
+		return validator.MinNonce > flow.Nonce, nil
+	}
+}
+
+// isNotFound returns true if err reports an L1 validator missing from the P-Chain. The error of
+// the platformvm client only has the message of the error of the P-Chain.
+func isNotFound(err error) bool {
+	return errors.Is(err, database.ErrNotFound) || strings.Contains(err.Error(), database.ErrNotFound.Error())
+}
+
+// complete signs the acknowledgement of the P-Chain and delivers it to the validator manager.
+func (m *Manager) complete(ctx context.Context, flow *Flow, key *ecdsa.PrivateKey) error {
+	receipt, err := m.resumePendingTx(ctx, flow)
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		unsignedMessage, justification, err := m.acknowledgement(ctx, flow)
+		if err != nil {
+			return err
+		}
+		signedMessage, err := m.aggregate(ctx, unsignedMessage, justification)
+		if err != nil {
+			return err
+		}
+		var args []interface{}
+		switch flow.Operation {
+		case RegisterValidator:
+			args = []interface{}{"completeValidatorRegistration", uint32(0)}
+		case EndValidation:
+			args = []interface{}{"completeEndValidation", uint32(0)}
+		case RegisterDelegator:
+			args = []interface{}{"completeDelegatorRegistration", flow.DelegationID, uint32(0)}
+		case EndDelegation:
+			args = []interface{}{"completeEndDelegation", flow.DelegationID, uint32(0)}
+		default:
+			return fmt.Errorf("invalid operation %q", flow.Operation)
+		}
+		if _, err := m.transact(ctx, flow, m.warpTx(ctx, key, signedMessage, args[0].(string), args[1:]...)); err != nil {
+			return err
+		}
+	}
+	return m.advance(flow, StepDone)
+}
+
+// acknowledgement returns the unsigned message of the P-Chain acknowledging the Warp message of
+// flow, and its justification.
+func (m *Manager) acknowledgement(ctx context.Context, flow *Flow) (*avalancheWarp.UnsignedMessage, []byte, error) {
+	switch flow.Operation {
+	case RegisterValidator:
+		unsignedMessage, err := NewL1ValidatorRegistrationMessage(m.NetworkID, flow.ValidationID, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		justification, err := RegistrationJustification(flow.RegistrationMessage)
+		return unsignedMessage, justification, err
+
+	case EndValidation:
+		unsignedMessage, err := NewL1ValidatorRegistrationMessage(m.NetworkID, flow.ValidationID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if flow.InitialValidatorIndex != nil {
+			justification, err := InitialValidatorJustification(m.SubnetID, *flow.InitialValidatorIndex)
+			return unsignedMessage, justification, err
+		}
+		if len(flow.RegistrationMessage) == 0 {
+			flow.RegistrationMessage, err = m.findRegistrationMessage(ctx, flow.ValidationID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := m.save(flow); err != nil {
+				return nil, nil, err
+			}
+		}
+		justification, err := RegistrationJustification(flow.RegistrationMessage)
+		return unsignedMessage, justification, err
+
+	case RegisterDelegator, EndDelegation:
+		unsignedMessage, err := NewL1ValidatorWeightMessage(m.NetworkID, flow.ValidationID, flow.Nonce, flow.Weight)
+		return unsignedMessage, nil, err
+
+	default:
+		return nil, nil, fmt.Errorf("invalid operation %q", flow.Operation)
+	}
+}
+
+// findRegistrationMessage returns the RegisterL1ValidatorMessage sent by the validator manager
+// when it registered validationID, searching the ValidationPeriodCreated events emitted since
+// FromBlock.
+func (m *Manager) findRegistrationMessage(ctx context.Context, validationID ids.ID) ([]byte, error) {
+	managerABI, err := poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	latest, err := m.Client.BlockNumber(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block number")
+	}
+	topics := [][]common.Hash{
+		{managerABI.Events["ValidationPeriodCreated"].ID},
+		{common.Hash(validationID)},
+	}
+	for start := m.FromBlock; start <= latest; start += MaxLogBlockRange {
+		end := min(start+MaxLogBlockRange-1, latest)
+		logs, err := m.Client.FilterLogs(ctx, interfaces.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{m.Address},
+			Topics:    topics,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get logs from block %d to %d", start, end)
+		}
+		if len(logs) == 0 {
+			continue
+		}
+		receipt, err := m.Client.TransactionReceipt(ctx, logs[0].TxHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get receipt of transaction %s", logs[0].TxHash)
+		}
+		unsignedMessage, err := WarpMessageFromReceipt(receipt)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := ParsePayload(unsignedMessage)
+		if err != nil {
+			return nil, err
+		}
+		registration, ok := payload.(*warpMessage.RegisterL1Validator)
+		if !ok || registration.ValidationID() != validationID {
+			return nil, fmt.Errorf("transaction %s did not send the registration of %s", receipt.TxHash, validationID)
+		}
+		return registration.Bytes(), nil
+	}
+	return nil, fmt.Errorf(
+		"registration of %s not found since block %d; set the initial validator index or registration message of the flow",
+		validationID,
+		m.FromBlock,
+	)
+}
+
+// aggregate requests signatures over unsignedMessage from the L1's validators until a quorum signs
+// it, or ctx is done.
+func (m *Manager) aggregate(
+	ctx context.Context,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+) (*avalancheWarp.Message, error) {
+	for {
+		signedMessage, err := m.SignatureAggregator.CreateSignedMessage(
+			unsignedMessage,
+			justification,
+			m.SubnetID,
+			QuorumPercentage,
+		)
+		if err == nil {
+			return signedMessage, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(err, "failed to aggregate signatures of message %s", unsignedMessage.ID())
+		case <-time.After(SignatureRetryInterval):
+		}
+	}
+}
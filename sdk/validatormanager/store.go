@@ -0,0 +1,164 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Store persists the progress of flows.
+type Store interface {
+	// Load returns the flow with the given ID, or a *FlowNotFoundError.
+	Load(id string) (*Flow, error)
+	Save(flow *Flow) error
+	// List returns the flows of the store, sorted by ID.
+	List() ([]*Flow, error)
+}
+
+// FlowNotFoundError is returned by a Store that has no flow with the given ID.
+type FlowNotFoundError struct {
+	ID string
+}
+
+func (e *FlowNotFoundError) Error() string {
+	return fmt.Sprintf("flow %s not found", e.ID)
+}
+
+// FileStore stores each flow as a JSON file in a directory.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a store of the flows in dir, which is created if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create flow directory %s", dir)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *FileStore) Load(id string) (*Flow, error) {
+	flowJSON, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, &FlowNotFoundError{ID: id}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read flow %s", id)
+	}
+	var flow Flow
+	if err := json.Unmarshal(flowJSON, &flow); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse flow %s", id)
+	}
+	return &flow, nil
+}
+
+// Save writes flow to a temporary file that replaces the flow's file, so that an interrupted
+// write does not corrupt the progress of the flow.
+func (s *FileStore) Save(flow *Flow) error {
+	flowJSON, err := json.MarshalIndent(flow, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, flow.ID+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to save flow %s", flow.ID)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(flowJSON, '\n')); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to save flow %s", flow.ID)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to save flow %s", flow.ID)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to save flow %s", flow.ID)
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), s.path(flow.ID)), "failed to save flow %s", flow.ID)
+}
+
+func (s *FileStore) List() ([]*Flow, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read flow directory %s", s.dir)
+	}
+	var flows []*Flow
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		flow, err := s.Load(id)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
+
+// MemoryStore stores flows in memory.
+type MemoryStore struct {
+	lock  sync.Mutex
+	flows map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{flows: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) Load(id string) (*Flow, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	flowJSON, ok := s.flows[id]
+	if !ok {
+		return nil, &FlowNotFoundError{ID: id}
+	}
+	var flow Flow
+	return &flow, json.Unmarshal(flowJSON, &flow)
+}
+
+// Save stores a copy of flow, so later changes to flow are not visible until it is saved again.
+func (s *MemoryStore) Save(flow *Flow) error {
+	flowJSON, err := json.Marshal(flow)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.flows[flow.ID] = flowJSON
+	return nil
+}
+
+func (s *MemoryStore) List() ([]*Flow, error) {
+	s.lock.Lock()
+	ids := make([]string, 0, len(s.flows))
+	for id := range s.flows {
+		ids = append(ids, id)
+	}
+	s.lock.Unlock()
+	sort.Strings(ids)
+	flows := make([]*Flow, 0, len(ids))
+	for _, id := range ids {
+		flow, err := s.Load(id)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
@@ -0,0 +1,53 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "flows")
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	_, err = store.Load("missing")
+	var notFound *FlowNotFoundError
+	require.ErrorAs(t, err, &notFound)
+
+	validator := testValidator()
+	registration, err := NewRegisterValidatorFlow(testManagerAddress, validator)
+	require.NoError(t, err)
+	registration.Step = StepComplete
+	registration.ValidationID = ids.ID{4}
+	registration.PendingTx = []byte{1, 2, 3}
+	require.NoError(t, store.Save(registration))
+	delegation := NewRegisterDelegatorFlow(testManagerAddress, ids.ID{4}, big.NewInt(10))
+	require.NoError(t, store.Save(delegation))
+
+	loaded, err := store.Load(registration.ID)
+	require.NoError(t, err)
+	require.Equal(t, registration.Step, loaded.Step)
+	require.Equal(t, registration.ValidationID, loaded.ValidationID)
+	require.Equal(t, registration.PendingTx, loaded.PendingTx)
+	require.Equal(t, validator.NodeID, loaded.Validator.NodeID)
+	require.Equal(t, validator.StakeAmount, loaded.Validator.StakeAmount)
+
+	// Temporary files are not left behind, and are not listed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), nil, 0o600))
+	flows, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, flows, 2)
+	require.Equal(t, delegation.ID, flows[0].ID)
+	require.Equal(t, registration.ID, flows[1].ID)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+}
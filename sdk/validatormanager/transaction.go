@@ -0,0 +1,185 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validatormanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	exampleerc20 "github.com/ava-labs/icm-contracts/abi-bindings/go/mocks/ExampleERC20"
+	erc20tokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ERC20TokenStakingManager"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
+	uptimeUtils "github.com/ava-labs/icm-contracts/utils/uptime-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/pkg/errors"
+)
+
+// transact signs the transaction returned by build, records it as the pending transaction of
+// flow, then sends it and waits for its acceptance. The flow is not saved after the transaction is
+// accepted, which is left to the caller.
+func (m *Manager) transact(
+	ctx context.Context,
+	flow *Flow,
+	build func() (*types.Transaction, error),
+) (*types.Receipt, error) {
+	tx, err := build()
+	if err != nil {
+		return nil, err
+	}
+	flow.PendingTx, err = tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.save(flow); err != nil {
+		return nil, err
+	}
+	if err := m.Client.SendTransaction(ctx, tx); err != nil {
+		return nil, errors.Wrapf(err, "failed to send transaction %s", tx.Hash())
+	}
+	return m.waitForPendingTx(ctx, flow, tx)
+}
+
+// resumePendingTx waits for the pending transaction of flow, sending it again if it is not known
+// to the L1. It returns nil if flow has no pending transaction.
+func (m *Manager) resumePendingTx(ctx context.Context, flow *Flow) (*types.Receipt, error) {
+	if len(flow.PendingTx) == 0 {
+		return nil, nil
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(flow.PendingTx); err != nil {
+		return nil, errors.Wrap(err, "failed to parse pending transaction")
+	}
+	_, err := m.Client.TransactionReceipt(ctx, tx.Hash())
+	if errors.Is(err, interfaces.NotFound) {
+		err = m.Client.SendTransaction(ctx, &tx)
+		if err != nil && !strings.Contains(err.Error(), "already known") {
+			return nil, errors.Wrapf(err, "failed to send pending transaction %s again", tx.Hash())
+		}
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipt of pending transaction %s", tx.Hash())
+	}
+	return m.waitForPendingTx(ctx, flow, &tx)
+}
+
+func (m *Manager) waitForPendingTx(ctx context.Context, flow *Flow, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := teleporterSDK.WaitForTransactionSuccess(ctx, m.Client, tx.Hash())
+	if err != nil {
+		var reverted *teleporterSDK.TransactionRevertedError
+		if errors.As(err, &reverted) {
+			// The transaction will not be accepted, so it is not sent again on resumption.
+			flow.PendingTx = nil
+			if saveErr := m.save(flow); saveErr != nil {
+				return nil, saveErr
+			}
+		}
+		return nil, err
+	}
+	// The accepted transaction is only recorded in memory: the caller saves it together with the
+	// result of the transaction, so that an interrupted flow does not forget that result and send
+	// another transaction on resumption.
+	flow.PendingTx = nil
+	flow.Transactions = append(flow.Transactions, tx.Hash())
+	return receipt, nil
+}
+
+// callTx returns a signed transaction calling the validator manager, without sending it.
+func (m *Manager) callTx(
+	ctx context.Context,
+	key *ecdsa.PrivateKey,
+	value *big.Int,
+	method string,
+	args ...interface{},
+) func() (*types.Transaction, error) {
+	return func() (*types.Transaction, error) {
+		contract, _, err := m.contract()
+		if err != nil {
+			return nil, err
+		}
+		opts, err := bind.NewKeyedTransactorWithChainID(key, m.EVMChainID)
+		if err != nil {
+			return nil, err
+		}
+		opts.Context = ctx
+		opts.Value = value
+		opts.NoSend = true
+		tx, err := contract.Transact(opts, method, args...)
+		return tx, errors.Wrapf(err, "failed to create %s transaction", method)
+	}
+}
+
+// warpTx returns a signed transaction calling the validator manager with signedMessage as its
+// Warp predicate, without sending it.
+func (m *Manager) warpTx(
+	ctx context.Context,
+	key *ecdsa.PrivateKey,
+	signedMessage *avalancheWarp.Message,
+	method string,
+	args ...interface{},
+) func() (*types.Transaction, error) {
+	return func() (*types.Transaction, error) {
+		_, contractABI, err := m.contract()
+		if err != nil {
+			return nil, err
+		}
+		callData, err := contractABI.Pack(method, args...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to pack %s", method)
+		}
+		params, err := teleporterSDK.CalculateTxParams(ctx, m.Client, teleporterSDK.PrivateKeyToAddress(key))
+		if err != nil {
+			return nil, err
+		}
+		tx := uptimeUtils.NewWarpMessageTx(
+			m.EVMChainID,
+			params.Nonce,
+			m.Address,
+			params.GasFeeCap,
+			params.GasTipCap,
+			callData,
+			signedMessage,
+		)
+		return teleporterSDK.SignTransaction(tx, key, m.EVMChainID)
+	}
+}
+
+// approveStake approves an ERC20 token staking manager to spend amount of its staking token, if
+// its allowance is lower.
+func (m *Manager) approveStake(ctx context.Context, flow *Flow, key *ecdsa.PrivateKey, amount *big.Int) error {
+	stakingManager, err := erc20tokenstakingmanager.NewERC20TokenStakingManagerCaller(m.Address, m.Client)
+	if err != nil {
+		return err
+	}
+	tokenAddress, err := stakingManager.Erc20(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get staking token of %s", m.Address)
+	}
+	// ExampleERC20 is an ERC20, so its binding calls any ERC20 token.
+	token, err := exampleerc20.NewExampleERC20(tokenAddress, m.Client)
+	if err != nil {
+		return err
+	}
+	owner := teleporterSDK.PrivateKeyToAddress(key)
+	allowance, err := token.Allowance(&bind.CallOpts{Context: ctx}, owner, m.Address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get allowance of %s", m.Address)
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+	_, err = m.transact(ctx, flow, func() (*types.Transaction, error) {
+		opts, err := bind.NewKeyedTransactorWithChainID(key, m.EVMChainID)
+		if err != nil {
+			return nil, err
+		}
+		opts.Context = ctx
+		opts.NoSend = true
+		return token.Approve(opts, m.Address, amount)
+	})
+	return errors.Wrapf(err, "failed to approve %s to spend %s", m.Address, amount)
+}
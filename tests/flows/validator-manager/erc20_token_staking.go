@@ -61,12 +61,7 @@ func ERC20TokenStakingManager(network *localnetwork.LocalNetwork) {
 	erc20, err := exampleerc20.NewExampleERC20(erc20Address, l1AInfo.RPCClient)
 	Expect(err).Should(BeNil())
 
-	signatureAggregator := utils.NewSignatureAggregator(
-		cChainInfo.NodeURIs[0],
-		[]ids.ID{
-			l1AInfo.SubnetID,
-		},
-	)
+	signatureAggregator := network.GetSignatureAggregator()
 	defer signatureAggregator.Shutdown()
 
 	//
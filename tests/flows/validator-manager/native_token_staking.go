@@ -57,12 +57,7 @@ func NativeTokenStakingManager(network *localnetwork.LocalNetwork) {
 	Expect(err).Should(BeNil())
 	utils.AddNativeMinterAdmin(ctx, l1AInfo, fundedKey, stakingManagerAddress)
 
-	signatureAggregator := utils.NewSignatureAggregator(
-		cChainInfo.NodeURIs[0],
-		[]ids.ID{
-			l1AInfo.SubnetID,
-		},
-	)
+	signatureAggregator := network.GetSignatureAggregator()
 	defer signatureAggregator.Shutdown()
 
 	//
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	goLog "log"
+	"net/netip"
 	"os"
 	"sort"
 	"time"
@@ -28,6 +29,7 @@ import (
 	proxyadmin "github.com/ava-labs/icm-contracts/abi-bindings/go/ProxyAdmin"
 	"github.com/ava-labs/icm-contracts/tests/interfaces"
 	"github.com/ava-labs/icm-contracts/tests/utils"
+	sigAggUtils "github.com/ava-labs/icm-contracts/utils/signature-aggregator-utils"
 	"github.com/ava-labs/subnet-evm/ethclient"
 	subnetEvmTestUtils "github.com/ava-labs/subnet-evm/tests/utils"
 
@@ -319,7 +321,13 @@ func (n *LocalNetwork) GetValidatorManager(subnetID ids.ID) common.Address {
 	return n.validatorManagers[subnetID]
 }
 
+// GetSignatureAggregator returns a signature aggregator of the L1s of the network, which runs the
+// binary at SIG_AGG_PATH if set. Otherwise, signatures are aggregated in-process, and requested
+// from the nodes as the binary does, so that the nodes' VMs verify each message they sign.
 func (n *LocalNetwork) GetSignatureAggregator() *utils.SignatureAggregator {
+	if os.Getenv("SIG_AGG_PATH") == "" {
+		return n.getLocalSignatureAggregator()
+	}
 	var subnetIDs []ids.ID
 	for _, l1 := range n.GetL1Infos() {
 		subnetIDs = append(subnetIDs, l1.SubnetID)
@@ -330,6 +338,22 @@ func (n *LocalNetwork) GetSignatureAggregator() *utils.SignatureAggregator {
 	)
 }
 
+func (n *LocalNetwork) getLocalSignatureAggregator() *utils.SignatureAggregator {
+	addresses := make(map[ids.NodeID]netip.AddrPort)
+	for _, node := range n.Network.Nodes {
+		addresses[node.NodeID] = node.StakingAddress
+	}
+	source, err := sigAggUtils.NewNodeSignatureSource(n.Network.GetNetworkID(), addresses)
+	Expect(err).Should(BeNil())
+	return utils.NewLocalSignatureAggregator(
+		sigAggUtils.NewAggregator(
+			sigAggUtils.NewPChainValidatorState(n.GetPrimaryNetworkInfo().NodeURIs[0]),
+			source,
+		),
+		source.Close,
+	)
+}
+
 func (n *LocalNetwork) GetExtraNodes(count int) []*tmpnet.Node {
 	Expect(len(n.extraNodes) >= count).Should(
 		BeTrue(),
@@ -406,6 +430,18 @@ func (n *LocalNetwork) GetL1Info(subnetID ids.ID) interfaces.L1TestInfo {
 	return interfaces.L1TestInfo{}
 }
 
+// GetL1InfoByName returns the info of the L1 created from the L1Spec of the given name.
+func (n *LocalNetwork) GetL1InfoByName(name string) interfaces.L1TestInfo {
+	var subnetID ids.ID
+	for _, l1 := range n.Network.Subnets {
+		if l1.Name == name {
+			subnetID = l1.SubnetID
+		}
+	}
+	Expect(subnetID).ShouldNot(Equal(ids.Empty), "unknown L1 %s", name)
+	return n.GetL1Info(subnetID)
+}
+
 // Returns all l1 info sorted in lexicographic order of L1Name.
 func (n *LocalNetwork) GetL1Infos() []interfaces.L1TestInfo {
 	l1s := make([]interfaces.L1TestInfo, len(n.Network.Subnets))
@@ -0,0 +1,427 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenarios
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	exampleerc20 "github.com/ava-labs/icm-contracts/abi-bindings/go/mocks/ExampleERC20"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	localnetwork "github.com/ava-labs/icm-contracts/tests/network"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/tests/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	// Gas limit of the execution of messages by send steps that do not set one
+	defaultRequiredGasLimit = 200_000
+	// Time to wait after each validator registration, so that the next one starts a new churn
+	// period of the validator manager
+	churnPeriodSleep = 5 * time.Second
+	// Time for a register-validator step to register each validator
+	validatorRegistrationTimeout = 90 * time.Second
+)
+
+// Runner runs scenarios against a LocalNetwork whose L1s are converted to PoA validator managers
+// and have TeleporterMessenger and TeleporterRegistry deployed, as in the Teleporter test suite.
+type Runner struct {
+	network    *localnetwork.LocalNetwork
+	teleporter utils.TeleporterTestInfo
+}
+
+// NewRunner returns a runner of scenarios on network.
+func NewRunner(network *localnetwork.LocalNetwork, teleporter utils.TeleporterTestInfo) *Runner {
+	return &Runner{
+		network:    network,
+		teleporter: teleporter,
+	}
+}
+
+// deployedContract is a contract deployed by a scenario.
+type deployedContract struct {
+	Contract
+	address common.Address
+	token   *exampleerc20.ExampleERC20
+}
+
+// sentMessage is a Teleporter message sent by a scenario.
+type sentMessage struct {
+	from      string
+	to        string
+	messageID ids.ID
+	message   teleportermessenger.TeleporterMessage
+	// Receipt of the latest transaction sending the message
+	receipt *types.Receipt
+}
+
+// run is the state of a scenario being run.
+type run struct {
+	*Runner
+	ctx        context.Context
+	scenario   *Scenario
+	fundedKey  *ecdsa.PrivateKey
+	aggregator *utils.SignatureAggregator
+	l1s        map[string]interfaces.L1TestInfo
+	contracts  map[string]*deployedContract
+	messages   map[string]*sentMessage
+}
+
+// Run deploys the contracts of scenario, then takes its steps in order and checks their expected
+// outcomes. Failures are reported with gomega, as in the Go test flows.
+func (r *Runner) Run(ctx context.Context, scenario *Scenario) {
+	_, fundedKey := r.network.GetFundedAccountInfo()
+	aggregator := r.network.GetSignatureAggregator()
+	defer aggregator.Shutdown()
+
+	s := &run{
+		Runner:     r,
+		ctx:        ctx,
+		scenario:   scenario,
+		fundedKey:  fundedKey,
+		aggregator: aggregator,
+		contracts:  make(map[string]*deployedContract),
+		messages:   make(map[string]*sentMessage),
+	}
+	s.refreshL1s()
+
+	for _, contract := range scenario.Contracts {
+		s.deploy(contract)
+	}
+	for i, step := range scenario.Steps {
+		log.Info("Running scenario step", "scenario", scenario.Name, "step", i+1, "action", step.Action)
+		description := fmt.Sprintf("scenario %q step %d (%s)", scenario.Name, i+1, step.Action)
+		receipt, message := s.takeStep(step)
+		s.checkExpectation(step, receipt, message, description)
+	}
+}
+
+func (s *run) refreshL1s() {
+	s.l1s = make(map[string]interfaces.L1TestInfo)
+	for _, l1 := range s.scenario.L1s {
+		s.l1s[l1.Name] = s.network.GetL1InfoByName(l1.Name)
+	}
+}
+
+func (s *run) deploy(contract Contract) {
+	l1 := s.l1s[contract.L1]
+	deployed := &deployedContract{Contract: contract}
+	switch contract.Type {
+	case ContractExampleERC20:
+		deployed.address, deployed.token = utils.DeployExampleERC20(s.ctx, s.fundedKey, l1)
+	case ContractTestMessenger:
+		deployed.address, _ = utils.DeployTestMessenger(
+			s.ctx,
+			s.fundedKey,
+			utils.PrivateKeyToAddress(s.fundedKey),
+			s.teleporter.TeleporterRegistryAddress(l1),
+			l1,
+		)
+	default:
+		// Scenarios are validated when loaded, so this is only reached by a type missing here.
+		Expect(fmt.Errorf("contract %s has unknown type %q", contract.Name, contract.Type)).Should(Succeed())
+	}
+	log.Info("Deployed scenario contract", "name", contract.Name, "type", contract.Type, "address", deployed.address)
+	s.contracts[contract.Name] = deployed
+}
+
+// takeStep takes the action of step, and returns the receipt of its transaction and the message it
+// acted on, if any.
+func (s *run) takeStep(step Step) (*types.Receipt, *sentMessage) {
+	switch step.Action {
+	case ActionSend:
+		return s.send(step)
+	case ActionSendReceipts:
+		return s.sendReceipts(step)
+	case ActionRelay:
+		message := s.messages[step.Message]
+		expectSuccess := step.Expect == nil || !step.Expect.Revert
+		receipt := s.teleporter.RelayTeleporterMessage(
+			s.ctx,
+			message.receipt,
+			s.l1s[message.from],
+			s.l1s[message.to],
+			expectSuccess,
+			s.fundedKey,
+			nil,
+			s.aggregator,
+		)
+		return receipt, message
+	case ActionAddFee:
+		message := s.messages[step.Message]
+		source := s.l1s[message.from]
+		feeTokenAddress := s.approveFee(step.Fee, source)
+		receipt := utils.SendAddFeeAmountAndWaitForAcceptance(
+			s.ctx,
+			source,
+			s.l1s[message.to],
+			message.messageID,
+			&step.Fee.Amount.Int,
+			feeTokenAddress,
+			s.fundedKey,
+			s.teleporter.TeleporterMessenger(source),
+		)
+		return receipt, message
+	case ActionRetry:
+		message := s.messages[step.Message]
+		destination := s.l1s[message.to]
+		receipt := utils.RetryMessageExecutionAndWaitForAcceptance(
+			s.ctx,
+			s.l1s[message.from].BlockchainID,
+			s.teleporter.TeleporterMessenger(destination),
+			destination,
+			message.message,
+			s.fundedKey,
+		)
+		return receipt, message
+	case ActionRetrySend:
+		message := s.messages[step.Message]
+		source := s.l1s[message.from]
+		opts, err := bind.NewKeyedTransactorWithChainID(s.fundedKey, source.EVMChainID)
+		Expect(err).Should(BeNil())
+		tx, err := s.teleporter.TeleporterMessenger(source).RetrySendCrossChainMessage(opts, message.message)
+		Expect(err).Should(BeNil())
+		message.receipt = utils.WaitForTransactionSuccess(s.ctx, source, tx.Hash())
+		return message.receipt, message
+	default:
+		// Scenarios are validated when loaded, so the only remaining action is register-validator.
+		Expect(step.Action).Should(Equal(ActionRegisterValidator))
+		s.registerValidators(step)
+		return nil, nil
+	}
+}
+
+func (s *run) send(step Step) (*types.Receipt, *sentMessage) {
+	source := s.l1s[step.From]
+	destination := s.l1s[step.To]
+	payload := s.payload(step)
+	requiredGasLimit := uint64(defaultRequiredGasLimit)
+	if step.RequiredGasLimit != nil {
+		requiredGasLimit = *step.RequiredGasLimit
+	}
+	allowedRelayers := make([]common.Address, len(step.AllowedRelayers))
+	for i, relayer := range step.AllowedRelayers {
+		allowedRelayers[i] = s.account(relayer)
+	}
+
+	receipt, messageID := utils.SendCrossChainMessageAndWaitForAcceptance(
+		s.ctx,
+		s.teleporter.TeleporterMessenger(source),
+		source,
+		destination,
+		teleportermessenger.TeleporterMessageInput{
+			DestinationBlockchainID: destination.BlockchainID,
+			DestinationAddress:      s.account(step.Destination),
+			FeeInfo:                 s.feeInfo(step.Fee, source),
+			RequiredGasLimit:        new(big.Int).SetUint64(requiredGasLimit),
+			AllowedRelayerAddresses: allowedRelayers,
+			Message:                 payload,
+		},
+		s.fundedKey,
+	)
+	return receipt, s.addMessage(step, receipt, messageID)
+}
+
+func (s *run) sendReceipts(step Step) (*types.Receipt, *sentMessage) {
+	source := s.l1s[step.From]
+	messageIDs := make([][32]byte, len(step.Receipts))
+	for i, label := range step.Receipts {
+		messageIDs[i] = s.messages[label].messageID
+	}
+	receipt, messageID := utils.SendSpecifiedReceiptsAndWaitForAcceptance(
+		s.ctx,
+		s.teleporter.TeleporterMessenger(source),
+		source,
+		s.l1s[step.To].BlockchainID,
+		messageIDs,
+		s.feeInfo(step.Fee, source),
+		[]common.Address{},
+		s.fundedKey,
+	)
+	return receipt, s.addMessage(step, receipt, messageID)
+}
+
+func (s *run) addMessage(step Step, receipt *types.Receipt, messageID ids.ID) *sentMessage {
+	sendEvent, err := utils.GetEventFromLogs(
+		receipt.Logs,
+		s.teleporter.TeleporterMessenger(s.l1s[step.From]).ParseSendCrossChainMessage,
+	)
+	Expect(err).Should(BeNil())
+	message := &sentMessage{
+		from:      step.From,
+		to:        step.To,
+		messageID: messageID,
+		message:   sendEvent.Message,
+		receipt:   receipt,
+	}
+	s.messages[step.ID] = message
+	return message
+}
+
+func (s *run) payload(step Step) []byte {
+	if step.Text != "" {
+		stringType, err := abi.NewType("string", "", nil)
+		Expect(err).Should(BeNil())
+		payload, err := abi.Arguments{{Type: stringType}}.Pack(step.Text)
+		Expect(err).Should(BeNil())
+		return payload
+	}
+	if step.Payload != "" {
+		payload, err := decodePayload(step.Payload)
+		Expect(err).Should(BeNil())
+		return payload
+	}
+	return []byte{}
+}
+
+// account returns the address an account of the scenario refers to.
+func (s *run) account(account string) common.Address {
+	if account == FundedAccount {
+		return utils.PrivateKeyToAddress(s.fundedKey)
+	}
+	if contract, ok := s.contracts[account]; ok {
+		return contract.address
+	}
+	Expect(common.IsHexAddress(account)).Should(BeTrue(), "invalid account %s", account)
+	return common.HexToAddress(account)
+}
+
+// feeInfo returns the fee info of fee, after approving TeleporterMessenger to spend it.
+func (s *run) feeInfo(fee *Fee, source interfaces.L1TestInfo) teleportermessenger.TeleporterFeeInfo {
+	if fee == nil {
+		return teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: common.Address{},
+			Amount:          big.NewInt(0),
+		}
+	}
+	return teleportermessenger.TeleporterFeeInfo{
+		FeeTokenAddress: s.approveFee(fee, source),
+		Amount:          &fee.Amount.Int,
+	}
+}
+
+// approveFee approves TeleporterMessenger on source to spend fee, and returns the address of its
+// token.
+func (s *run) approveFee(fee *Fee, source interfaces.L1TestInfo) common.Address {
+	token := s.contracts[fee.Token]
+	if fee.Amount.Sign() > 0 {
+		utils.ERC20Approve(
+			s.ctx,
+			token.token,
+			s.teleporter.TeleporterMessengerAddress(source),
+			&fee.Amount.Int,
+			source,
+			s.fundedKey,
+		)
+	}
+	return token.address
+}
+
+// registerValidators registers extra nodes of the network as validators of an L1, as in the
+// validator churn flow.
+func (s *run) registerValidators(step Step) {
+	l1 := s.l1s[step.L1]
+	count := step.validatorCount()
+	ctx, cancel := context.WithTimeout(s.ctx, time.Duration(count)*(validatorRegistrationTimeout+churnPeriodSleep))
+	defer cancel()
+
+	nodes := s.network.GetExtraNodes(count)
+	validatorManagerAddress := s.network.GetValidatorManager(l1.SubnetID)
+	validatorManager, err := poavalidatormanager.NewPoAValidatorManager(validatorManagerAddress, l1.RPCClient)
+	Expect(err).Should(BeNil())
+	pChainInfo := utils.GetPChainInfo(s.network.GetPrimaryNetworkInfo())
+
+	l1 = s.network.AddSubnetValidators(nodes, l1, true)
+	for _, node := range nodes {
+		pop, err := node.GetProofOfPossession()
+		Expect(err).Should(BeNil())
+		utils.InitializeAndCompletePoAValidatorRegistration(
+			ctx,
+			s.aggregator,
+			s.fundedKey,
+			s.fundedKey,
+			l1,
+			pChainInfo,
+			validatorManager,
+			validatorManagerAddress,
+			uint64(time.Now().Add(24*time.Hour).Unix()),
+			utils.Node{
+				NodeID:  node.NodeID,
+				NodePoP: pop,
+				Weight:  step.Weight,
+			},
+			s.network.GetPChainWallet(),
+			s.network.GetNetworkID(),
+		)
+		time.Sleep(churnPeriodSleep)
+	}
+
+	// Trigger the proposer VM to update its height on all L1s, so that they verify Warp messages
+	// against the new validator set.
+	for _, l1Info := range s.network.GetL1Infos() {
+		err = subnetEvmUtils.IssueTxsToActivateProposerVMFork(s.ctx, l1Info.EVMChainID, s.fundedKey, l1Info.WSClient)
+		Expect(err).Should(BeNil())
+	}
+	s.refreshL1s()
+}
+
+func (s *run) checkExpectation(step Step, receipt *types.Receipt, message *sentMessage, description string) {
+	expect := step.Expect
+	if expect == nil {
+		return
+	}
+	for _, event := range expect.Events {
+		eventID, err := teleporterEventID(event)
+		Expect(err).Should(BeNil())
+		Expect(receipt).ShouldNot(BeNil(), description)
+		Expect(hasEvent(receipt, eventID)).Should(BeTrue(), "%s: missing %s event", description, event)
+	}
+	if expect.Delivered != nil {
+		destination := s.l1s[message.to]
+		delivered, err := s.teleporter.TeleporterMessenger(destination).MessageReceived(
+			&bind.CallOpts{}, message.messageID,
+		)
+		Expect(err).Should(BeNil())
+		Expect(delivered).Should(Equal(*expect.Delivered), "%s: delivery of message %s", description, message.messageID)
+	}
+	for _, expected := range expect.Balances {
+		l1 := s.l1s[expected.L1]
+		account := s.account(expected.Account)
+		var (
+			balance *big.Int
+			err     error
+		)
+		if expected.Token == "" {
+			balance, err = l1.RPCClient.BalanceAt(s.ctx, account, nil)
+		} else {
+			balance, err = s.contracts[expected.Token].token.BalanceOf(&bind.CallOpts{}, account)
+		}
+		Expect(err).Should(BeNil())
+		Expect(balance.Cmp(&expected.Balance.Int)).Should(
+			Equal(0),
+			"%s: balance of %s on %s is %s, expected %s", description, expected.Account, expected.L1, balance, expected.Balance.String(),
+		)
+	}
+}
+
+func hasEvent(receipt *types.Receipt, eventID common.Hash) bool {
+	for _, log := range receipt.Logs {
+		if len(log.Topics) > 0 && log.Topics[0] == eventID {
+			return true
+		}
+	}
+	return false
+}
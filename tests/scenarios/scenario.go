@@ -0,0 +1,536 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package scenarios runs end-to-end test scenarios declared in YAML or JSON files against a
+// LocalNetwork, so that regression cases can be added without writing Go code.
+package scenarios
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// FundedAccount refers to the funded account of the network, which sends all transactions of a
+// scenario.
+const FundedAccount = "funded"
+
+// Action is the kind of a scenario step.
+type Action string
+
+const (
+	// Send a Teleporter message
+	ActionSend Action = "send"
+	// Relay a sent message to its destination
+	ActionRelay Action = "relay"
+	// Add to the fee of a sent message
+	ActionAddFee Action = "add-fee"
+	// Retry the execution of a message whose execution failed on its destination
+	ActionRetry Action = "retry"
+	// Resend a message that has not been delivered, e.g. after a validator set change
+	ActionRetrySend Action = "retry-send"
+	// Send the receipts of delivered messages back to their source
+	ActionSendReceipts Action = "send-receipts"
+	// Register new validators of an L1 with its PoA validator manager
+	ActionRegisterValidator Action = "register-validator"
+)
+
+// ContractType is the type of a contract deployed by a scenario.
+type ContractType string
+
+const (
+	ContractExampleERC20  ContractType = "ExampleERC20"
+	ContractTestMessenger ContractType = "TestMessenger"
+)
+
+// Scenario is an end-to-end test case: the L1s it runs on, the contracts it deploys before its
+// steps, and steps that each take an action and check its outcome.
+type Scenario struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	L1s         []L1Spec   `json:"l1s"`
+	Contracts   []Contract `json:"contracts,omitempty"`
+	Steps       []Step     `json:"steps"`
+
+	// Path of the file the scenario was loaded from
+	Path string `json:"-"`
+}
+
+// L1Spec is an L1 a scenario runs on. Scenarios sharing a network must agree on the specs of the
+// L1s of the same name.
+type L1Spec struct {
+	Name       string `json:"name"`
+	EVMChainID uint64 `json:"evmChainID"`
+	NodeCount  int    `json:"nodeCount"`
+}
+
+// Contract is a contract deployed by the funded account on an L1 before the steps of a scenario.
+// Steps refer to it by name, as an account or a fee token.
+type Contract struct {
+	Name string       `json:"name"`
+	Type ContractType `json:"type"`
+	L1   string       `json:"l1"`
+}
+
+// Step is an action of a scenario, and its expected outcome.
+type Step struct {
+	Action Action `json:"action"`
+
+	// Label of the message sent by send and send-receipts steps
+	ID string `json:"id,omitempty"`
+	// Label of the message acted on by relay, add-fee, retry and retry-send steps
+	Message string `json:"message,omitempty"`
+
+	// Source and destination L1s of send and send-receipts steps
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+
+	// Fields of send steps. The payload is either raw hex bytes, or text ABI encoded as a string,
+	// which is the payload TestMessenger receives.
+	Destination      string   `json:"destination,omitempty"`
+	Payload          string   `json:"payload,omitempty"`
+	Text             string   `json:"text,omitempty"`
+	RequiredGasLimit *uint64  `json:"requiredGasLimit,omitempty"`
+	AllowedRelayers  []string `json:"allowedRelayers,omitempty"`
+
+	// Fee of send and send-receipts steps, or added by add-fee steps
+	Fee *Fee `json:"fee,omitempty"`
+
+	// Labels of the messages whose receipts are sent by send-receipts steps
+	Receipts []string `json:"receipts,omitempty"`
+
+	// Fields of register-validator steps
+	L1     string `json:"l1,omitempty"`
+	Weight uint64 `json:"weight,omitempty"`
+	Count  int    `json:"count,omitempty"`
+
+	Expect *Expectation `json:"expect,omitempty"`
+}
+
+// Fee is an amount of an ExampleERC20 contract of the scenario.
+type Fee struct {
+	Token  string `json:"token"`
+	Amount Amount `json:"amount"`
+}
+
+// Expectation is the expected outcome of a step.
+type Expectation struct {
+	// The transaction of a relay step reverts
+	Revert bool `json:"revert,omitempty"`
+	// TeleporterMessenger events emitted by the transaction of the step, e.g. MessageExecuted
+	Events []string `json:"events,omitempty"`
+	// Whether the message of the step has been received by its destination
+	Delivered *bool `json:"delivered,omitempty"`
+	// Balances after the step
+	Balances []Balance `json:"balances,omitempty"`
+}
+
+// Balance is the balance of an account on an L1, in the native token or in an ExampleERC20
+// contract of the scenario.
+type Balance struct {
+	L1      string `json:"l1"`
+	Account string `json:"account"`
+	Token   string `json:"token,omitempty"`
+	Balance Amount `json:"balance"`
+}
+
+// Amount is an integer amount, written as a JSON number or as a decimal string for amounts
+// beyond the precision of YAML numbers.
+type Amount struct {
+	big.Int
+}
+
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	s := string(bytes.Trim(b, `"`))
+	if _, ok := a.SetString(s, 10); !ok {
+		return fmt.Errorf("invalid amount %s", b)
+	}
+	return nil
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// Load reads the scenario of a .yaml, .yml or .json file, and validates it.
+func Load(path string) (*Scenario, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scenario Scenario
+	switch filepath.Ext(path) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&scenario)
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(b, &scenario)
+	default:
+		return nil, fmt.Errorf("unsupported scenario file %s", path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse scenario %s", path)
+	}
+	scenario.Path = path
+	if err := scenario.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid scenario %s", path)
+	}
+	return &scenario, nil
+}
+
+// LoadDir loads the scenarios of all .yaml, .yml and .json files of dir, in lexicographic order of
+// their file names.
+func LoadDir(dir string) ([]*Scenario, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var scenarios []*Scenario
+	for _, entry := range entries {
+		switch filepath.Ext(entry.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		scenario, err := Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios, nil
+}
+
+// Validate checks that the L1s, contracts and messages the scenario refers to are declared, and
+// that each step has the fields of its action.
+func (s *Scenario) Validate() error {
+	if s.Name == "" {
+		return errors.New("missing name")
+	}
+	if len(s.L1s) == 0 {
+		return errors.New("no L1s")
+	}
+	l1s := make(map[string]bool)
+	for _, l1 := range s.L1s {
+		switch {
+		case l1.Name == "":
+			return errors.New("L1 without a name")
+		case l1s[l1.Name]:
+			return fmt.Errorf("duplicate L1 %s", l1.Name)
+		case l1.EVMChainID == 0:
+			return fmt.Errorf("L1 %s has no EVM chain ID", l1.Name)
+		case l1.NodeCount <= 0:
+			return fmt.Errorf("L1 %s has no nodes", l1.Name)
+		}
+		l1s[l1.Name] = true
+	}
+
+	contracts := make(map[string]Contract)
+	for _, contract := range s.Contracts {
+		switch {
+		case contract.Name == "" || contract.Name == FundedAccount || common.IsHexAddress(contract.Name):
+			return fmt.Errorf("invalid contract name %q", contract.Name)
+		case contract.Type != ContractExampleERC20 && contract.Type != ContractTestMessenger:
+			return fmt.Errorf("contract %s has unknown type %q", contract.Name, contract.Type)
+		case !l1s[contract.L1]:
+			return fmt.Errorf("contract %s is deployed on unknown L1 %q", contract.Name, contract.L1)
+		}
+		if _, ok := contracts[contract.Name]; ok {
+			return fmt.Errorf("duplicate contract %s", contract.Name)
+		}
+		contracts[contract.Name] = contract
+	}
+
+	v := validator{l1s: l1s, contracts: contracts, messages: make(map[string]message)}
+	for i, step := range s.Steps {
+		if err := v.validateStep(step); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step.Action, err)
+		}
+	}
+	return nil
+}
+
+// message is the route of a message labeled by a step.
+type message struct {
+	from string
+	to   string
+}
+
+// validator tracks the declarations a step may refer to.
+type validator struct {
+	l1s       map[string]bool
+	contracts map[string]Contract
+	messages  map[string]message
+}
+
+func (v *validator) validateStep(step Step) error {
+	switch step.Action {
+	case ActionSend:
+		if err := v.validateRoute(step.From, step.To); err != nil {
+			return err
+		}
+		if step.Destination == "" {
+			return errors.New("missing destination")
+		}
+		if err := v.validateAccount(step.Destination, step.To); err != nil {
+			return err
+		}
+		if step.Payload != "" && step.Text != "" {
+			return errors.New("both payload and text are set")
+		}
+		if step.Payload != "" {
+			if _, err := decodePayload(step.Payload); err != nil {
+				return err
+			}
+		}
+		for _, relayer := range step.AllowedRelayers {
+			if relayer != FundedAccount && !common.IsHexAddress(relayer) {
+				return fmt.Errorf("invalid relayer %q", relayer)
+			}
+		}
+		if err := v.validateFee(step.Fee, step.From, false); err != nil {
+			return err
+		}
+		if err := v.addMessage(step.ID, step.From, step.To); err != nil {
+			return err
+		}
+	case ActionSendReceipts:
+		if err := v.validateRoute(step.From, step.To); err != nil {
+			return err
+		}
+		if len(step.Receipts) == 0 {
+			return errors.New("no receipts")
+		}
+		for _, label := range step.Receipts {
+			m, ok := v.messages[label]
+			if !ok {
+				return fmt.Errorf("unknown message %q", label)
+			}
+			if m.from != step.To || m.to != step.From {
+				return fmt.Errorf("message %s was not sent from %s to %s", label, step.To, step.From)
+			}
+		}
+		if err := v.validateFee(step.Fee, step.From, false); err != nil {
+			return err
+		}
+		if err := v.addMessage(step.ID, step.From, step.To); err != nil {
+			return err
+		}
+	case ActionRelay, ActionRetry, ActionRetrySend:
+		if _, ok := v.messages[step.Message]; !ok {
+			return fmt.Errorf("unknown message %q", step.Message)
+		}
+	case ActionAddFee:
+		m, ok := v.messages[step.Message]
+		if !ok {
+			return fmt.Errorf("unknown message %q", step.Message)
+		}
+		if err := v.validateFee(step.Fee, m.from, true); err != nil {
+			return err
+		}
+	case ActionRegisterValidator:
+		if !v.l1s[step.L1] {
+			return fmt.Errorf("unknown L1 %q", step.L1)
+		}
+		if step.Weight == 0 {
+			return errors.New("missing weight")
+		}
+		if step.Count < 0 {
+			return fmt.Errorf("invalid count %d", step.Count)
+		}
+	default:
+		return fmt.Errorf("unknown action %q", step.Action)
+	}
+	return v.validateExpectation(step)
+}
+
+func (v *validator) validateRoute(from string, to string) error {
+	if !v.l1s[from] {
+		return fmt.Errorf("unknown source L1 %q", from)
+	}
+	if !v.l1s[to] {
+		return fmt.Errorf("unknown destination L1 %q", to)
+	}
+	if from == to {
+		return fmt.Errorf("source and destination are both %s", from)
+	}
+	return nil
+}
+
+func (v *validator) addMessage(label string, from string, to string) error {
+	if label == "" {
+		return errors.New("missing id")
+	}
+	if _, ok := v.messages[label]; ok {
+		return fmt.Errorf("duplicate message %s", label)
+	}
+	v.messages[label] = message{from: from, to: to}
+	return nil
+}
+
+// validateAccount checks that account is the funded account, an address, or a contract of the
+// scenario on l1.
+func (v *validator) validateAccount(account string, l1 string) error {
+	if account == FundedAccount || common.IsHexAddress(account) {
+		return nil
+	}
+	contract, ok := v.contracts[account]
+	if !ok {
+		return fmt.Errorf("unknown account %q", account)
+	}
+	if contract.L1 != l1 {
+		return fmt.Errorf("contract %s is not deployed on %s", account, l1)
+	}
+	return nil
+}
+
+func (v *validator) validateToken(token string, l1 string) error {
+	contract, ok := v.contracts[token]
+	if !ok || contract.Type != ContractExampleERC20 {
+		return fmt.Errorf("token %q is not an %s contract", token, ContractExampleERC20)
+	}
+	if contract.L1 != l1 {
+		return fmt.Errorf("token %s is not deployed on %s", token, l1)
+	}
+	return nil
+}
+
+func (v *validator) validateFee(fee *Fee, l1 string, required bool) error {
+	if fee == nil {
+		if required {
+			return errors.New("missing fee")
+		}
+		return nil
+	}
+	if fee.Amount.Sign() < 0 {
+		return fmt.Errorf("negative fee %s", fee.Amount.String())
+	}
+	return v.validateToken(fee.Token, l1)
+}
+
+func (v *validator) validateExpectation(step Step) error {
+	expect := step.Expect
+	if expect == nil {
+		return nil
+	}
+	if expect.Revert && step.Action != ActionRelay {
+		return errors.New("only relay steps can expect a revert")
+	}
+	if expect.Revert && len(expect.Events) != 0 {
+		return errors.New("a reverted transaction has no events")
+	}
+	if step.Action == ActionRegisterValidator && len(expect.Events) != 0 {
+		return errors.New("register-validator steps have no Teleporter events")
+	}
+	for _, event := range expect.Events {
+		if _, err := teleporterEventID(event); err != nil {
+			return err
+		}
+	}
+	if expect.Delivered != nil && step.Action == ActionRegisterValidator {
+		return errors.New("register-validator steps have no message")
+	}
+	for _, balance := range expect.Balances {
+		if !v.l1s[balance.L1] {
+			return fmt.Errorf("unknown L1 %q", balance.L1)
+		}
+		if err := v.validateAccount(balance.Account, balance.L1); err != nil {
+			return err
+		}
+		if balance.Token != "" {
+			if err := v.validateToken(balance.Token, balance.L1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// teleporterEventID returns the topic of the TeleporterMessenger event name.
+func teleporterEventID(name string) (common.Hash, error) {
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	event, ok := teleporterABI.Events[name]
+	if !ok {
+		names := make([]string, 0, len(teleporterABI.Events))
+		for name := range teleporterABI.Events {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return common.Hash{}, fmt.Errorf("unknown event %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return event.ID, nil
+}
+
+func decodePayload(payload string) ([]byte, error) {
+	b, err := hexutil.Decode(payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid payload %q", payload)
+	}
+	return b, nil
+}
+
+// NetworkL1Specs returns the L1s the scenarios run on, with the most nodes any scenario asks for.
+func NetworkL1Specs(scenarios []*Scenario) ([]L1Spec, error) {
+	specs := make(map[string]L1Spec)
+	for _, scenario := range scenarios {
+		for _, l1 := range scenario.L1s {
+			spec, ok := specs[l1.Name]
+			if ok && spec.EVMChainID != l1.EVMChainID {
+				return nil, fmt.Errorf(
+					"scenario %s declares L1 %s with EVM chain ID %d instead of %d",
+					scenario.Name, l1.Name, l1.EVMChainID, spec.EVMChainID,
+				)
+			}
+			if !ok || l1.NodeCount > spec.NodeCount {
+				specs[l1.Name] = l1
+			}
+		}
+	}
+	l1s := make([]L1Spec, 0, len(specs))
+	for _, spec := range specs {
+		l1s = append(l1s, spec)
+	}
+	sort.Slice(l1s, func(i, j int) bool {
+		return l1s[i].Name < l1s[j].Name
+	})
+	return l1s, nil
+}
+
+// ExtraNodeCount returns the number of nodes the register-validator steps of the scenarios add as
+// validators.
+func ExtraNodeCount(scenarios []*Scenario) int {
+	count := 0
+	for _, scenario := range scenarios {
+		for _, step := range scenario.Steps {
+			if step.Action == ActionRegisterValidator {
+				count += step.validatorCount()
+			}
+		}
+	}
+	return count
+}
+
+func (s Step) validatorCount() int {
+	if s.Count == 0 {
+		return 1
+	}
+	return s.Count
+}
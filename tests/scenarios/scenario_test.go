@@ -0,0 +1,230 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenarios
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testL1s = `
+l1s:
+  - name: A
+    evmChainID: 1
+    nodeCount: 2
+  - name: B
+    evmChainID: 2
+    nodeCount: 2
+`
+
+func writeScenario(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadScenarioFiles(t *testing.T) {
+	scenarios, err := LoadDir("files")
+	require.NoError(t, err)
+	require.NotEmpty(t, scenarios)
+
+	names := make(map[string]bool)
+	for _, scenario := range scenarios {
+		require.False(t, names[scenario.Name], "duplicate scenario %s", scenario.Name)
+		names[scenario.Name] = true
+	}
+	_, err = NetworkL1Specs(scenarios)
+	require.NoError(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	yamlPath := writeScenario(t, "scenario.yaml", "name: test"+testL1s+`
+contracts:
+  - name: token
+    type: ExampleERC20
+    l1: A
+steps:
+  - action: send
+    id: m
+    from: A
+    to: B
+    destination: funded
+    fee:
+      token: token
+      amount: 10
+    expect:
+      balances:
+        - l1: A
+          account: funded
+          token: token
+          balance: "10000000000000000000000000000"
+`)
+	scenario, err := Load(yamlPath)
+	require.NoError(t, err)
+	require.Equal(t, yamlPath, scenario.Path)
+	require.Len(t, scenario.Steps, 1)
+	require.Equal(t, int64(10), scenario.Steps[0].Fee.Amount.Int64())
+	require.Equal(t, "10000000000000000000000000000", scenario.Steps[0].Expect.Balances[0].Balance.String())
+
+	jsonPath := writeScenario(t, "scenario.json", `{
+		"name": "test",
+		"l1s": [{"name": "A", "evmChainID": 1, "nodeCount": 1}],
+		"steps": [{"action": "register-validator", "l1": "A", "weight": 100, "count": 2}]
+	}`)
+	scenario, err = Load(jsonPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, ExtraNodeCount([]*Scenario{scenario}))
+
+	_, err = Load(writeScenario(t, "unknown.yaml", "name: test\nunknown: true"+testL1s))
+	require.ErrorContains(t, err, "failed to parse scenario")
+
+	_, err = Load(writeScenario(t, "unknown.json", `{"name": "test", "unknown": true}`))
+	require.ErrorContains(t, err, "unknown field")
+
+	_, err = Load(writeScenario(t, "scenario.toml", ""))
+	require.ErrorContains(t, err, "unsupported scenario file")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps string
+		err   string
+	}{
+		{
+			name: "unknown action",
+			steps: `
+  - action: teleport`,
+			err: `step 1 (teleport): unknown action "teleport"`,
+		},
+		{
+			name: "unknown L1",
+			steps: `
+  - action: send
+    id: m
+    from: A
+    to: C
+    destination: funded`,
+			err: `unknown destination L1 "C"`,
+		},
+		{
+			name: "unknown message",
+			steps: `
+  - action: relay
+    message: m`,
+			err: `step 1 (relay): unknown message "m"`,
+		},
+		{
+			name: "duplicate message",
+			steps: `
+  - action: send
+    id: m
+    from: A
+    to: B
+    destination: funded
+  - action: send
+    id: m
+    from: B
+    to: A
+    destination: funded`,
+			err: "step 2 (send): duplicate message m",
+		},
+		{
+			name: "receipt of a message in the same direction",
+			steps: `
+  - action: send
+    id: m
+    from: A
+    to: B
+    destination: funded
+  - action: send-receipts
+    id: r
+    from: A
+    to: B
+    receipts: [m]`,
+			err: "message m was not sent from B to A",
+		},
+		{
+			name: "unknown fee token",
+			steps: `
+  - action: send
+    id: m
+    from: A
+    to: B
+    destination: funded
+    fee:
+      token: token
+      amount: 1`,
+			err: `token "token" is not an ExampleERC20 contract`,
+		},
+		{
+			name: "invalid payload",
+			steps: `
+  - action: send
+    id: m
+    from: A
+    to: B
+    destination: funded
+    payload: "1234"`,
+			err: "invalid payload",
+		},
+		{
+			name: "unknown event",
+			steps: `
+  - action: send
+    id: m
+    from: A
+    to: B
+    destination: funded
+    expect:
+      events: [MessageSent]`,
+			err: `unknown event "MessageSent"`,
+		},
+		{
+			name: "revert of a send",
+			steps: `
+  - action: send
+    id: m
+    from: A
+    to: B
+    destination: funded
+    expect:
+      revert: true`,
+			err: "only relay steps can expect a revert",
+		},
+		{
+			name: "validator without weight",
+			steps: `
+  - action: register-validator
+    l1: A`,
+			err: "missing weight",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Load(writeScenario(t, "scenario.yaml", "name: test"+testL1s+"steps:"+test.steps))
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestNetworkL1Specs(t *testing.T) {
+	scenarios := []*Scenario{
+		{Name: "1", L1s: []L1Spec{{Name: "B", EVMChainID: 2, NodeCount: 1}, {Name: "A", EVMChainID: 1, NodeCount: 2}}},
+		{Name: "2", L1s: []L1Spec{{Name: "B", EVMChainID: 2, NodeCount: 3}}},
+	}
+	specs, err := NetworkL1Specs(scenarios)
+	require.NoError(t, err)
+	require.Equal(t, []L1Spec{
+		{Name: "A", EVMChainID: 1, NodeCount: 2},
+		{Name: "B", EVMChainID: 2, NodeCount: 3},
+	}, specs)
+
+	scenarios = append(scenarios, &Scenario{Name: "3", L1s: []L1Spec{{Name: "A", EVMChainID: 3, NodeCount: 1}}})
+	_, err = NetworkL1Specs(scenarios)
+	require.ErrorContains(t, err, "scenario 3 declares L1 A with EVM chain ID 3 instead of 1")
+}
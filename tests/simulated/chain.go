@@ -0,0 +1,244 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	"github.com/ava-labs/subnet-evm/consensus/dummy"
+	"github.com/ava-labs/subnet-evm/constants"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/eth"
+	"github.com/ava-labs/subnet-evm/eth/ethconfig"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/node"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/precompile/precompileconfig"
+	"github.com/ava-labs/subnet-evm/rpc"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// blockGap is the number of seconds between the blocks of a chain, long enough for the block gas
+// cost to stay at zero.
+const blockGap = 10
+
+type fakePushGossiper struct{}
+
+func (*fakePushGossiper) Add(*types.Transaction) {}
+
+// Chain is an in-process subnet-evm chain of a simulated L1, with the Warp precompile enabled. A
+// block is built and accepted for each transaction sent with its Client, and Warp messages
+// included in transactions are verified against the validator sets of the Network.
+type Chain struct {
+	SubnetID     ids.ID
+	BlockchainID ids.ID
+	EVMChainID   *big.Int
+
+	network     *Network
+	chainConfig *params.ChainConfig
+	eth         *eth.Ethereum
+	clock       *mockable.Clock
+	server      *rpc.Server
+	client      ethclient.Client
+
+	// lock serializes the building of blocks.
+	lock sync.Mutex
+}
+
+func newChain(
+	network *Network,
+	subnetID ids.ID,
+	blockchainID ids.ID,
+	evmChainID *big.Int,
+	alloc types.GenesisAlloc,
+) (*Chain, error) {
+	genesisTime := uint64(time.Now().Unix())
+	chainConfig := *params.TestChainConfig
+	chainConfig.ChainID = evmChainID
+	chainConfig.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewDefaultConfig(subnetEvmUtils.NewUint64(genesisTime)),
+	}
+	snowCtx := *subnetEvmUtils.TestSnowContext()
+	snowCtx.NetworkID = network.networkID
+	snowCtx.SubnetID = subnetID
+	snowCtx.ChainID = blockchainID
+	snowCtx.ValidatorState = network.validatorState()
+	chainConfig.SnowCtx = &snowCtx
+
+	ethConf := ethconfig.DefaultConfig
+	ethConf.Genesis = &core.Genesis{
+		Config:    &chainConfig,
+		Timestamp: genesisTime,
+		GasLimit:  chainConfig.FeeConfig.GasLimit.Uint64(),
+		Alloc:     alloc,
+	}
+	ethConf.AllowUnfinalizedQueries = true
+	// Allow keyless deployments, such as the one of TeleporterMessenger
+	ethConf.AllowUnprotectedTxs = true
+	ethConf.Miner.Etherbase = constants.BlackholeAddr
+	ethConf.Miner.TestOnlyAllowDuplicateBlocks = true
+	ethConf.TxPool.NoLocals = true
+
+	nodeConf := node.DefaultConfig
+	stack, err := node.New(&nodeConf)
+	if err != nil {
+		return nil, err
+	}
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(int64(genesisTime), 0))
+	engine := dummy.NewFakerWithModeAndClock(dummy.Mode{ModeSkipCoinbase: true}, clock)
+	backend, err := eth.New(
+		stack, &ethConf, &fakePushGossiper{}, rawdb.NewMemoryDatabase(), eth.Settings{}, common.Hash{},
+		engine, clock,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create chain")
+	}
+	backend.Start()
+	server := rpc.NewServer(0)
+	for _, api := range backend.APIs() {
+		if err := server.RegisterName(api.Namespace, api.Service); err != nil {
+			return nil, err
+		}
+	}
+	c := &Chain{
+		SubnetID:     subnetID,
+		BlockchainID: blockchainID,
+		EVMChainID:   evmChainID,
+		network:      network,
+		chainConfig:  &chainConfig,
+		eth:          backend,
+		clock:        clock,
+		server:       server,
+	}
+	c.client = &committingClient{
+		evmClient: ethclient.NewClient(rpc.DialInProc(server)),
+		chain:     c,
+	}
+	return c, nil
+}
+
+// Client returns a client of the chain, which builds a block for each transaction it sends.
+func (c *Chain) Client() ethclient.Client {
+	return c.client
+}
+
+// L1TestInfo returns the chain as the L1TestInfo of the test utilities.
+func (c *Chain) L1TestInfo() interfaces.L1TestInfo {
+	return interfaces.L1TestInfo{
+		SubnetID:     c.SubnetID,
+		BlockchainID: c.BlockchainID,
+		WSClient:     c.client,
+		RPCClient:    c.client,
+		EVMChainID:   c.EVMChainID,
+	}
+}
+
+// Commit builds and accepts a block with the pending transactions of the chain. Warp messages
+// are verified against the validator sets at the current height of the simulated P-Chain.
+func (c *Chain) Commit() (common.Hash, error) {
+	return c.buildBlock(blockGap)
+}
+
+// AdjustTime builds and accepts a block with the pending transactions, adjustment after the latest
+// block.
+func (c *Chain) AdjustTime(adjustment time.Duration) error {
+	_, err := c.buildBlock(uint64(adjustment / time.Second))
+	return err
+}
+
+func (c *Chain) buildBlock(gap uint64) (common.Hash, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	chain := c.eth.BlockChain()
+	if err := c.eth.TxPool().Sync(); err != nil {
+		return common.Hash{}, err
+	}
+	c.clock.Set(time.Unix(int64(chain.CurrentBlock().Time+gap), 0))
+	predicateContext := &precompileconfig.PredicateContext{
+		SnowCtx:            c.chainConfig.SnowCtx,
+		ProposerVMBlockCtx: &block.Context{PChainHeight: c.network.PChainHeight()},
+	}
+	blk, err := c.eth.Miner().GenerateBlock(predicateContext)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to build block")
+	}
+	if err := chain.InsertBlock(blk); err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to insert block")
+	}
+	if err := chain.Accept(blk); err != nil {
+		return common.Hash{}, errors.Wrap(err, "failed to accept block")
+	}
+	chain.DrainAcceptorQueue()
+	return blk.Hash(), nil
+}
+
+// WarpMessages returns the Warp messages sent by the transaction of receipt.
+func (c *Chain) WarpMessages(receipt *types.Receipt) ([]*avalancheWarp.UnsignedMessage, error) {
+	var messages []*avalancheWarp.UnsignedMessage
+	for _, log := range receipt.Logs {
+		if log.Address != warp.ContractAddress {
+			continue
+		}
+		message, err := warp.UnpackSendWarpEventDataToMessage(log.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse Warp message")
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// SignedWarpMessage returns the Warp message sent by the transaction of receipt, signed by all the
+// validators of the chain's L1.
+func (c *Chain) SignedWarpMessage(receipt *types.Receipt) (*avalancheWarp.Message, error) {
+	messages, err := c.WarpMessages(receipt)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) != 1 {
+		return nil, errors.Errorf("transaction %s sent %d Warp messages", receipt.TxHash, len(messages))
+	}
+	return c.network.SignMessage(messages[0])
+}
+
+// Close stops the chain.
+func (c *Chain) Close() {
+	c.client.Close()
+	c.server.Stop()
+	_ = c.eth.Stop()
+}
+
+// evmClient is embedded by committingClient under a name that does not shadow the Client method
+// of ethclient.Client.
+type evmClient = ethclient.Client
+
+// committingClient builds a block after sending each transaction, so that transactions are mined
+// as soon as they are sent.
+type committingClient struct {
+	evmClient
+	chain *Chain
+}
+
+func (c *committingClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.evmClient.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	_, err := c.chain.Commit()
+	return err
+}
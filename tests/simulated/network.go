@@ -0,0 +1,351 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulated runs subnet-evm L1s in-process, with a simulated P-Chain whose validators sign
+// Warp messages with local BLS keys, so that cross-chain flows run in plain go test without
+// avalanchego nodes or a signature aggregator.
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	sigAggUtils "github.com/ava-labs/icm-contracts/utils/signature-aggregator-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// DefaultValidatorWeight is the weight of the validators created by AddL1.
+const DefaultValidatorWeight uint64 = 100
+
+var fundedBalance = new(big.Int).Mul(big.NewInt(1e18), big.NewInt(1_000_000))
+
+// Validator is a validator of a simulated L1, which signs Warp messages with its BLS key.
+type Validator struct {
+	NodeID    ids.NodeID
+	SecretKey *bls.SecretKey
+	Weight    uint64
+}
+
+// NewValidator returns a validator with a random node ID and BLS key.
+func NewValidator(weight uint64) (*Validator, error) {
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{
+		NodeID:    ids.GenerateTestNodeID(),
+		SecretKey: sk,
+		Weight:    weight,
+	}, nil
+}
+
+// PublicKey returns the BLS public key of the validator.
+func (v *Validator) PublicKey() *bls.PublicKey {
+	return bls.PublicFromSecretKey(v.SecretKey)
+}
+
+// validatorSet is the validator set of a subnet from a P-Chain height on.
+type validatorSet struct {
+	height     uint64
+	validators []*Validator
+}
+
+// Network is a simulated P-Chain and the in-process L1s it validates. The P-Chain only tracks the
+// validator sets of the L1s: each change of a validator set increments the P-Chain height, which
+// is the height Warp messages are verified at by the blocks built afterwards.
+type Network struct {
+	networkID uint32
+	fundedKey *ecdsa.PrivateKey
+
+	lock         sync.RWMutex
+	pChainHeight uint64
+	// Validator sets of each subnet, by increasing height
+	validatorSets map[ids.ID][]validatorSet
+	// Chains by blockchain ID
+	chains map[ids.ID]*Chain
+	pChain *PChain
+}
+
+// NewNetwork creates a network without L1s, whose chains fund a random key.
+func NewNetwork() (*Network, error) {
+	fundedKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	n := &Network{
+		networkID:     constants.LocalID,
+		fundedKey:     fundedKey,
+		validatorSets: make(map[ids.ID][]validatorSet),
+		chains:        make(map[ids.ID]*Chain),
+	}
+	n.pChain = &PChain{
+		network:     n,
+		keys:        make(map[[bls.PublicKeyLen]byte]*bls.SecretKey),
+		validations: make(map[ids.ID]*l1Validation),
+	}
+	return n, nil
+}
+
+// NetworkID returns the network ID of the Warp messages of the network.
+func (n *Network) NetworkID() uint32 {
+	return n.networkID
+}
+
+// GetFundedAccountInfo returns the account funded on every chain of the network.
+func (n *Network) GetFundedAccountInfo() (common.Address, *ecdsa.PrivateKey) {
+	return crypto.PubkeyToAddress(n.fundedKey.PublicKey), n.fundedKey
+}
+
+// AddL1 starts a chain validated by numValidators new validators of weight
+// DefaultValidatorWeight, with genesis alloc in addition to the funded account.
+func (n *Network) AddL1(numValidators int, alloc types.GenesisAlloc) (*Chain, error) {
+	vdrs := make([]*Validator, numValidators)
+	for i := range vdrs {
+		vdr, err := NewValidator(DefaultValidatorWeight)
+		if err != nil {
+			return nil, err
+		}
+		vdrs[i] = vdr
+	}
+	subnetID := ids.GenerateTestID()
+	n.SetValidators(subnetID, vdrs)
+
+	genesisAlloc := types.GenesisAlloc{
+		crypto.PubkeyToAddress(n.fundedKey.PublicKey): {Balance: fundedBalance},
+	}
+	for address, account := range alloc {
+		genesisAlloc[address] = account
+	}
+
+	n.lock.Lock()
+	evmChainID := big.NewInt(int64(1337 + len(n.chains)))
+	n.lock.Unlock()
+	chain, err := newChain(n, subnetID, ids.GenerateTestID(), evmChainID, genesisAlloc)
+	if err != nil {
+		return nil, err
+	}
+	n.lock.Lock()
+	n.chains[chain.BlockchainID] = chain
+	n.lock.Unlock()
+	return chain, nil
+}
+
+// Chains returns the chains of the network, in the order they were added.
+func (n *Network) Chains() []*Chain {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	chains := make([]*Chain, 0, len(n.chains))
+	for _, chain := range n.chains {
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i].EVMChainID.Cmp(chains[j].EVMChainID) < 0
+	})
+	return chains
+}
+
+// SetValidators replaces the validators of subnetID at a new P-Chain height.
+func (n *Network) SetValidators(subnetID ids.ID, vdrs []*Validator) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.pChainHeight++
+	n.validatorSets[subnetID] = append(n.validatorSets[subnetID], validatorSet{
+		height:     n.pChainHeight,
+		validators: append([]*Validator(nil), vdrs...),
+	})
+}
+
+// Validators returns the current validators of subnetID.
+func (n *Network) Validators(subnetID ids.ID) []*Validator {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.validatorsAt(n.pChainHeight, subnetID)
+}
+
+// PChainHeight returns the current height of the simulated P-Chain.
+func (n *Network) PChainHeight() uint64 {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.pChainHeight
+}
+
+func (n *Network) validatorsAt(height uint64, subnetID ids.ID) []*Validator {
+	sets := n.validatorSets[subnetID]
+	for i := len(sets) - 1; i >= 0; i-- {
+		if sets[i].height <= height {
+			return sets[i].validators
+		}
+	}
+	return nil
+}
+
+// SignMessage signs unsignedMessage with all the current validators of its source chain's L1.
+func (n *Network) SignMessage(unsignedMessage *avalancheWarp.UnsignedMessage) (*avalancheWarp.Message, error) {
+	if unsignedMessage.SourceChainID == constants.PlatformChainID {
+		return nil, errors.New("P-Chain messages must be signed with SignPChainMessage")
+	}
+	subnetID, err := n.validatorState().GetSubnetID(context.Background(), unsignedMessage.SourceChainID)
+	if err != nil {
+		return nil, err
+	}
+	return n.SignMessageWith(unsignedMessage, subnetID, n.Validators(subnetID))
+}
+
+// SignPChainMessage signs unsignedMessage, sent by the P-Chain to the L1 subnetID, with the
+// validators of that L1, which is how L1s verify messages from the Primary Network.
+func (n *Network) SignPChainMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	subnetID ids.ID,
+) (*avalancheWarp.Message, error) {
+	return n.SignMessageWith(unsignedMessage, subnetID, n.Validators(subnetID))
+}
+
+// SignMessageWith signs unsignedMessage with signers, a subset of the current validators of
+// subnetID, e.g. to build messages without a quorum.
+func (n *Network) SignMessageWith(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	subnetID ids.ID,
+	signers []*Validator,
+) (*avalancheWarp.Message, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers")
+	}
+	// The bit set of a signature indexes the canonical ordering of the validator set.
+	canonical, _, err := avalancheWarp.FlattenValidatorSet(validatorOutputs(n.Validators(subnetID)))
+	if err != nil {
+		return nil, err
+	}
+	indices := make(map[ids.NodeID]int, len(canonical))
+	for i, vdr := range canonical {
+		for _, nodeID := range vdr.NodeIDs {
+			indices[nodeID] = i
+		}
+	}
+	bits := set.NewBits()
+	signatures := make([]*bls.Signature, len(signers))
+	for i, signer := range signers {
+		index, ok := indices[signer.NodeID]
+		if !ok {
+			return nil, fmt.Errorf("%s is not a validator of %s", signer.NodeID, subnetID)
+		}
+		bits.Add(index)
+		signatures[i] = bls.Sign(signer.SecretKey, unsignedMessage.Bytes())
+	}
+	aggregate, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+	signature := &avalancheWarp.BitSetSignature{Signers: bits.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggregate))
+	return avalancheWarp.NewMessage(unsignedMessage, signature)
+}
+
+// SignatureAggregator returns an aggregator of the signatures of the validators of the network,
+// which can stand in for the signature aggregator of flows that take one.
+func (n *Network) SignatureAggregator() *sigAggUtils.Aggregator {
+	return sigAggUtils.NewAggregator(n.validatorState(), &validatorSigner{network: n})
+}
+
+// Close stops all chains of the network.
+func (n *Network) Close() {
+	for _, chain := range n.Chains() {
+		chain.Close()
+	}
+}
+
+func validatorOutputs(vdrs []*Validator) map[ids.NodeID]*validators.GetValidatorOutput {
+	outputs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrs))
+	for _, vdr := range vdrs {
+		outputs[vdr.NodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.NodeID,
+			PublicKey: vdr.PublicKey(),
+			Weight:    vdr.Weight,
+		}
+	}
+	return outputs
+}
+
+// validatorSigner signs messages with the keys of the validators of the network, at any height.
+type validatorSigner struct {
+	network *Network
+}
+
+func (s *validatorSigner) GetSignature(
+	_ context.Context,
+	nodeID ids.NodeID,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	_ []byte,
+) (*bls.Signature, error) {
+	s.network.lock.RLock()
+	defer s.network.lock.RUnlock()
+	for _, sets := range s.network.validatorSets {
+		for _, vdrSet := range sets {
+			for _, vdr := range vdrSet.validators {
+				if vdr.NodeID == nodeID {
+					return bls.Sign(vdr.SecretKey, unsignedMessage.Bytes()), nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("%s is not a validator", nodeID)
+}
+
+func (n *Network) validatorState() validators.State {
+	return &validatorState{network: n}
+}
+
+// validatorState serves the validator sets of the network to the Warp precompile.
+type validatorState struct {
+	network *Network
+}
+
+func (*validatorState) GetMinimumHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (s *validatorState) GetCurrentHeight(context.Context) (uint64, error) {
+	return s.network.PChainHeight(), nil
+}
+
+func (s *validatorState) GetSubnetID(_ context.Context, chainID ids.ID) (ids.ID, error) {
+	if chainID == constants.PlatformChainID {
+		return constants.PrimaryNetworkID, nil
+	}
+	s.network.lock.RLock()
+	defer s.network.lock.RUnlock()
+	chain, ok := s.network.chains[chainID]
+	if !ok {
+		return ids.Empty, fmt.Errorf("unknown chain %s", chainID)
+	}
+	return chain.SubnetID, nil
+}
+
+func (s *validatorState) GetValidatorSet(
+	_ context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	s.network.lock.RLock()
+	defer s.network.lock.RUnlock()
+	return validatorOutputs(s.network.validatorsAt(height, subnetID)), nil
+}
+
+func (s *validatorState) GetCurrentValidatorSet(
+	context.Context,
+	ids.ID,
+) (map[ids.ID]*validators.GetCurrentValidatorOutput, uint64, error) {
+	return nil, 0, errors.New("current validator sets are not simulated")
+}
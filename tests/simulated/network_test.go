@@ -0,0 +1,271 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	erc20tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHome"
+	erc20tokenremote "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/ERC20TokenRemote"
+	exampleerc20 "github.com/ava-labs/icm-contracts/abi-bindings/go/mocks/ExampleERC20"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
+	"github.com/ava-labs/icm-contracts/sdk/ictt"
+	"github.com/ava-labs/icm-contracts/sdk/teleporter"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	warpValidators "github.com/ava-labs/subnet-evm/warp/validators"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNetwork(t *testing.T, numL1s int, numValidators int) (*Network, []*Chain) {
+	network, err := NewNetwork()
+	require.NoError(t, err)
+	t.Cleanup(network.Close)
+	chains := make([]*Chain, numL1s)
+	for i := range chains {
+		chains[i], err = network.AddL1(numValidators, nil)
+		require.NoError(t, err)
+	}
+	return network, chains
+}
+
+// deployTeleporter deploys TeleporterMessenger with the first transaction of key, so that it has
+// the same address on every chain.
+func deployTeleporter(t *testing.T, chain *Chain, key *ecdsa.PrivateKey) (common.Address, *teleportermessenger.TeleporterMessenger) {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chain.EVMChainID)
+	require.NoError(t, err)
+	address, tx, messenger, err := teleportermessenger.DeployTeleporterMessenger(opts, chain.Client())
+	require.NoError(t, err)
+	receipt, err := chain.Client().TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	return address, messenger
+}
+
+// requireSuccess returns the receipt of tx, which must have succeeded.
+func requireSuccess(t *testing.T, chain *Chain, tx *types.Transaction, err error) *types.Receipt {
+	require.NoError(t, err)
+	receipt, err := chain.Client().TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	return receipt
+}
+
+// relay delivers the Teleporter message sent in receipt on source to destination.
+func relay(
+	t *testing.T,
+	network *Network,
+	source *Chain,
+	destination *Chain,
+	teleporterAddress common.Address,
+	receipt *types.Receipt,
+) {
+	_, fundedKey := network.GetFundedAccountInfo()
+	signedMessage, err := source.SignedWarpMessage(receipt)
+	require.NoError(t, err)
+	message, err := teleporter.ParseTeleporterMessage(signedMessage.UnsignedMessage)
+	require.NoError(t, err)
+	_, err = teleporter.ReceiveCrossChainMessage(context.Background(), destination.Client(),
+		destination.EVMChainID, teleporterAddress, signedMessage, message.RequiredGasLimit, fundedKey)
+	require.NoError(t, err)
+}
+
+func TestTeleporterSendReceive(t *testing.T) {
+	ctx := context.Background()
+	network, chains := newTestNetwork(t, 2, 5)
+	source, destination := chains[0], chains[1]
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+	teleporterAddress, sourceMessenger := deployTeleporter(t, source, fundedKey)
+	destinationAddress, _ := deployTeleporter(t, destination, fundedKey)
+	require.Equal(t, teleporterAddress, destinationAddress)
+
+	sent, err := teleporter.SendCrossChainMessage(ctx, source.Client(), source.EVMChainID, sourceMessenger,
+		teleportermessenger.TeleporterMessageInput{
+			DestinationBlockchainID: destination.BlockchainID,
+			DestinationAddress:      fundedAddress,
+			FeeInfo: teleportermessenger.TeleporterFeeInfo{
+				FeeTokenAddress: common.Address{},
+				Amount:          big.NewInt(0),
+			},
+			RequiredGasLimit:        big.NewInt(1),
+			AllowedRelayerAddresses: []common.Address{},
+			Message:                 []byte{1, 2, 3, 4},
+		},
+		fundedKey,
+	)
+	require.NoError(t, err)
+	signedMessage, err := source.SignedWarpMessage(sent.Receipt)
+	require.NoError(t, err)
+	require.Equal(t, network.NetworkID(), signedMessage.NetworkID)
+	require.Equal(t, source.BlockchainID, signedMessage.SourceChainID)
+
+	// A message signed by less than the quorum of the source L1 is not verified.
+	unverified, err := network.SignMessageWith(
+		&signedMessage.UnsignedMessage,
+		source.SubnetID,
+		network.Validators(source.SubnetID)[:3],
+	)
+	require.NoError(t, err)
+	_, err = teleporter.ReceiveCrossChainMessage(ctx, destination.Client(), destination.EVMChainID,
+		teleporterAddress, unverified, big.NewInt(1), fundedKey)
+	require.ErrorContains(t, err, "failed to receive cross chain message")
+
+	received, err := teleporter.ReceiveCrossChainMessage(ctx, destination.Client(), destination.EVMChainID,
+		teleporterAddress, signedMessage, big.NewInt(1), fundedKey)
+	require.NoError(t, err)
+	require.Equal(t, sent.MessageID, received.MessageID)
+	require.Equal(t, []byte{1, 2, 3, 4}, received.Event.Message.Message)
+}
+
+func TestValidatorSetChanges(t *testing.T) {
+	network, chains := newTestNetwork(t, 1, 2)
+	chain := chains[0]
+	initial := network.Validators(chain.SubnetID)
+	height := network.PChainHeight()
+
+	validator, err := NewValidator(DefaultValidatorWeight)
+	require.NoError(t, err)
+	network.SetValidators(chain.SubnetID, []*Validator{validator})
+	require.Equal(t, height+1, network.PChainHeight())
+	require.Equal(t, []*Validator{validator}, network.Validators(chain.SubnetID))
+
+	// Validator sets are kept at previous heights, to verify messages at those heights.
+	outputs, err := network.validatorState().GetValidatorSet(context.Background(), height, chain.SubnetID)
+	require.NoError(t, err)
+	require.Len(t, outputs, len(initial))
+	for _, vdr := range initial {
+		require.Contains(t, outputs, vdr.NodeID)
+	}
+
+	// P-Chain messages are signed by the validators of the receiving L1.
+	addressedCall, err := payload.NewAddressedCall(nil, []byte{1})
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(network.NetworkID(), constants.PlatformChainID, addressedCall.Bytes())
+	require.NoError(t, err)
+	_, err = network.SignMessage(unsignedMessage)
+	require.ErrorContains(t, err, "SignPChainMessage")
+	signedMessage, err := network.SignPChainMessage(unsignedMessage, chain.SubnetID)
+	require.NoError(t, err)
+	require.NoError(t, signedMessage.Signature.Verify(
+		context.Background(),
+		unsignedMessage,
+		network.NetworkID(),
+		warpValidators.NewState(network.validatorState(), chain.SubnetID, constants.PlatformChainID, false),
+		network.PChainHeight(),
+		1,
+		1,
+	))
+
+	_, err = network.SignMessageWith(unsignedMessage, chain.SubnetID, initial)
+	require.ErrorContains(t, err, "is not a validator of")
+
+	// The signature aggregator signs with the current validators.
+	aggregated, err := network.SignatureAggregator().CreateSignedMessage(unsignedMessage, nil, chain.SubnetID, 67)
+	require.NoError(t, err)
+	require.Equal(t, signedMessage.Bytes(), aggregated.Bytes())
+}
+
+func TestICTTTransfer(t *testing.T) {
+	ctx := context.Background()
+	network, chains := newTestNetwork(t, 2, 5)
+	home, remote := chains[0], chains[1]
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+	teleporterAddress, _ := deployTeleporter(t, home, fundedKey)
+	deployTeleporter(t, remote, fundedKey)
+
+	registries := make([]common.Address, len(chains))
+	for i, chain := range chains {
+		opts, err := bind.NewKeyedTransactorWithChainID(fundedKey, chain.EVMChainID)
+		require.NoError(t, err)
+		address, tx, _, err := teleporterregistry.DeployTeleporterRegistry(opts, chain.Client(),
+			[]teleporterregistry.ProtocolRegistryEntry{{Version: big.NewInt(1), ProtocolAddress: teleporterAddress}})
+		requireSuccess(t, chain, tx, err)
+		registries[i] = address
+	}
+
+	homeOpts, err := bind.NewKeyedTransactorWithChainID(fundedKey, home.EVMChainID)
+	require.NoError(t, err)
+	tokenAddress, tx, token, err := exampleerc20.DeployExampleERC20(homeOpts, home.Client())
+	requireSuccess(t, home, tx, err)
+	homeAddress, tx, _, err := erc20tokenhome.DeployERC20TokenHome(homeOpts, home.Client(),
+		registries[0], fundedAddress, big.NewInt(1), tokenAddress, 18)
+	requireSuccess(t, home, tx, err)
+
+	remoteOpts, err := bind.NewKeyedTransactorWithChainID(fundedKey, remote.EVMChainID)
+	require.NoError(t, err)
+	remoteAddress, tx, remoteToken, err := erc20tokenremote.DeployERC20TokenRemote(remoteOpts, remote.Client(),
+		erc20tokenremote.TokenRemoteSettings{
+			TeleporterRegistryAddress: registries[1],
+			TeleporterManager:         fundedAddress,
+			MinTeleporterVersion:      big.NewInt(1),
+			TokenHomeBlockchainID:     home.BlockchainID,
+			TokenHomeAddress:          homeAddress,
+			TokenHomeDecimals:         18,
+		}, "Wrapped Mock Token", "WEXMP", 18)
+	requireSuccess(t, remote, tx, err)
+	tx, err = remoteToken.RegisterWithHome(remoteOpts, erc20tokenremote.TeleporterFeeInfo{Amount: big.NewInt(0)})
+	relay(t, network, remote, home, teleporterAddress, requireSuccess(t, remote, tx, err))
+
+	homeTransferrer := &ictt.Transferrer{
+		Client:            home.Client(),
+		EVMChainID:        home.EVMChainID,
+		BlockchainID:      home.BlockchainID,
+		Address:           homeAddress,
+		TokenType:         ictt.ERC20,
+		TeleporterAddress: teleporterAddress,
+	}
+	remoteTransferrer := &ictt.Transferrer{
+		Client:            remote.Client(),
+		EVMChainID:        remote.EVMChainID,
+		BlockchainID:      remote.BlockchainID,
+		Address:           remoteAddress,
+		TokenType:         ictt.ERC20,
+		TeleporterAddress: teleporterAddress,
+	}
+	bridge, err := ictt.NewBridge(ctx, homeTransferrer, remoteTransferrer)
+	require.NoError(t, err)
+
+	// Tokens sent to the remote are locked in the home and minted by the remote.
+	amount := big.NewInt(1e18)
+	sent, err := bridge.Send(ctx, homeTransferrer, remoteTransferrer, ictt.Transfer{
+		Recipient: fundedAddress,
+		Amount:    amount,
+	}, fundedKey)
+	require.NoError(t, err)
+	relay(t, network, home, remote, teleporterAddress, sent.Receipt)
+	delivery, err := bridge.WaitForDelivery(ctx, sent)
+	require.NoError(t, err)
+	require.Equal(t, fundedAddress, delivery.Recipient)
+	require.Equal(t, amount, delivery.Amount)
+	balance, err := remoteToken.BalanceOf(&bind.CallOpts{}, fundedAddress)
+	require.NoError(t, err)
+	require.Equal(t, amount, balance)
+
+	// Tokens sent back are burned by the remote and released by the home.
+	recipient := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	returned := big.NewInt(4e17)
+	sent, err = bridge.Send(ctx, remoteTransferrer, homeTransferrer, ictt.Transfer{
+		Recipient: recipient,
+		Amount:    returned,
+	}, fundedKey)
+	require.NoError(t, err)
+	relay(t, network, remote, home, teleporterAddress, sent.Receipt)
+	delivery, err = bridge.WaitForDelivery(ctx, sent)
+	require.NoError(t, err)
+	require.Equal(t, returned, delivery.Amount)
+	balance, err = token.BalanceOf(&bind.CallOpts{}, recipient)
+	require.NoError(t, err)
+	require.Equal(t, returned, balance)
+	balance, err = token.BalanceOf(&bind.CallOpts{}, homeAddress)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(amount, returned), balance)
+}
@@ -0,0 +1,256 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	walletCommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// l1Validation is a validation of an L1 registered with the P-Chain.
+type l1Validation struct {
+	subnetID ids.ID
+	nodeID   ids.NodeID
+	nonce    uint64
+}
+
+// PChain is a wallet of the simulated P-Chain. It converts the L1s of the network, and applies the
+// registrations and weight updates of their validators to the validator sets of the network, once
+// it verified the Warp messages sent by the validator managers. It can stand in for the P-Chain
+// wallet of flows that take one.
+type PChain struct {
+	network *Network
+
+	lock sync.Mutex
+	// Secret keys of the validators that may be registered, by public key
+	keys map[[bls.PublicKeyLen]byte]*bls.SecretKey
+	// Registered validations by validation ID
+	validations map[ids.ID]*l1Validation
+}
+
+// PChain returns the wallet of the simulated P-Chain of the network.
+func (n *Network) PChain() *PChain {
+	return n.pChain
+}
+
+// AddKey makes sk known to the P-Chain, so that a validator registered with its public key signs
+// Warp messages once registered. Registrations of validators with unknown keys are rejected, as
+// they could not sign.
+func (p *PChain) AddKey(sk *bls.SecretKey) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var publicKey [bls.PublicKeyLen]byte
+	copy(publicKey[:], bls.PublicKeyToCompressedBytes(bls.PublicFromSecretKey(sk)))
+	p.keys[publicKey] = sk
+}
+
+// ConvertSubnetToL1 converts the L1 subnetID, with its current validators as initial validators,
+// to be managed by the validator manager at managerAddress on managerChainID. It returns the
+// conversion data, and the SubnetToL1ConversionMessage to initialize the validator set of the
+// validator manager with.
+func (p *PChain) ConvertSubnetToL1(
+	subnetID ids.ID,
+	managerChainID ids.ID,
+	managerAddress common.Address,
+) (warpMessage.SubnetToL1ConversionData, *avalancheWarp.Message, error) {
+	vdrs := p.network.Validators(subnetID)
+	if len(vdrs) == 0 {
+		return warpMessage.SubnetToL1ConversionData{}, nil, fmt.Errorf("subnet %s has no validators", subnetID)
+	}
+	conversionData := warpMessage.SubnetToL1ConversionData{
+		SubnetID:       subnetID,
+		ManagerChainID: managerChainID,
+		ManagerAddress: managerAddress[:],
+		Validators:     make([]warpMessage.SubnetToL1ConversionValidatorData, len(vdrs)),
+	}
+	p.lock.Lock()
+	for i, vdr := range vdrs {
+		conversionData.Validators[i] = warpMessage.SubnetToL1ConversionValidatorData{
+			NodeID: vdr.NodeID.Bytes(),
+			Weight: vdr.Weight,
+		}
+		copy(conversionData.Validators[i].BLSPublicKey[:], bls.PublicKeyToCompressedBytes(vdr.PublicKey()))
+		// The validation ID of an initial validator is derived from its index in the conversion.
+		p.validations[subnetID.Append(uint32(i))] = &l1Validation{
+			subnetID: subnetID,
+			nodeID:   vdr.NodeID,
+		}
+	}
+	p.lock.Unlock()
+
+	conversionID, err := warpMessage.SubnetToL1ConversionID(conversionData)
+	if err != nil {
+		return warpMessage.SubnetToL1ConversionData{}, nil, errors.Wrap(err, "failed to compute conversion ID")
+	}
+	conversion, err := warpMessage.NewSubnetToL1Conversion(conversionID)
+	if err != nil {
+		return warpMessage.SubnetToL1ConversionData{}, nil, errors.Wrap(err, "failed to create conversion message")
+	}
+	addressedCall, err := warpPayload.NewAddressedCall(nil, conversion.Bytes())
+	if err != nil {
+		return warpMessage.SubnetToL1ConversionData{}, nil, errors.Wrap(err, "failed to create addressed call")
+	}
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(
+		p.network.networkID,
+		constants.PlatformChainID,
+		addressedCall.Bytes(),
+	)
+	if err != nil {
+		return warpMessage.SubnetToL1ConversionData{}, nil, errors.Wrap(err, "failed to create Warp message")
+	}
+	signedMessage, err := p.network.SignPChainMessage(unsignedMessage, subnetID)
+	if err != nil {
+		return warpMessage.SubnetToL1ConversionData{}, nil, err
+	}
+	return conversionData, signedMessage, nil
+}
+
+// IssueRegisterL1ValidatorTx adds the validator of the RegisterL1ValidatorMessage in message to
+// the validators of its L1.
+func (p *PChain) IssueRegisterL1ValidatorTx(
+	balance uint64,
+	proofOfPossession [bls.SignatureLen]byte,
+	message []byte,
+	_ ...walletCommon.Option,
+) (*txs.Tx, error) {
+	payload, err := p.verify(message)
+	if err != nil {
+		return nil, err
+	}
+	registration, ok := payload.(*warpMessage.RegisterL1Validator)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %T payload", payload)
+	}
+	nodeID, err := ids.ToNodeID(registration.NodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid node ID")
+	}
+	pop := signer.ProofOfPossession{
+		PublicKey:         registration.BLSPublicKey,
+		ProofOfPossession: proofOfPossession,
+	}
+	if err := pop.Verify(); err != nil {
+		return nil, errors.Wrap(err, "invalid proof of possession")
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	validationID := registration.ValidationID()
+	if _, ok := p.validations[validationID]; ok {
+		return nil, fmt.Errorf("validation %s is already registered", validationID)
+	}
+	sk, ok := p.keys[registration.BLSPublicKey]
+	if !ok {
+		return nil, fmt.Errorf("unknown BLS key of %s", nodeID)
+	}
+	vdrs := p.network.Validators(registration.SubnetID)
+	for _, vdr := range vdrs {
+		if vdr.NodeID == nodeID {
+			return nil, fmt.Errorf("%s is already a validator of %s", nodeID, registration.SubnetID)
+		}
+	}
+	p.network.SetValidators(registration.SubnetID, append(vdrs, &Validator{
+		NodeID:    nodeID,
+		SecretKey: sk,
+		Weight:    registration.Weight,
+	}))
+	p.validations[validationID] = &l1Validation{
+		subnetID: registration.SubnetID,
+		nodeID:   nodeID,
+	}
+	return newTx(&txs.RegisterL1ValidatorTx{
+		Balance:           balance,
+		ProofOfPossession: proofOfPossession,
+		Message:           message,
+	})
+}
+
+// IssueSetL1ValidatorWeightTx applies the L1ValidatorWeightMessage in message to the validators of
+// its L1. A weight of zero removes the validator.
+func (p *PChain) IssueSetL1ValidatorWeightTx(message []byte, _ ...walletCommon.Option) (*txs.Tx, error) {
+	payload, err := p.verify(message)
+	if err != nil {
+		return nil, err
+	}
+	weight, ok := payload.(*warpMessage.L1ValidatorWeight)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %T payload", payload)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	validation, ok := p.validations[weight.ValidationID]
+	if !ok {
+		return nil, fmt.Errorf("unknown validation %s", weight.ValidationID)
+	}
+	if weight.Nonce < validation.nonce {
+		return nil, fmt.Errorf("nonce %d of validation %s is below %d", weight.Nonce, weight.ValidationID, validation.nonce)
+	}
+	vdrs := make([]*Validator, 0, len(p.network.Validators(validation.subnetID)))
+	for _, vdr := range p.network.Validators(validation.subnetID) {
+		if vdr.NodeID != validation.nodeID {
+			vdrs = append(vdrs, vdr)
+			continue
+		}
+		if weight.Weight != 0 {
+			updated := *vdr
+			updated.Weight = weight.Weight
+			vdrs = append(vdrs, &updated)
+		}
+	}
+	p.network.SetValidators(validation.subnetID, vdrs)
+	if weight.Weight == 0 {
+		delete(p.validations, weight.ValidationID)
+	} else {
+		validation.nonce = weight.Nonce + 1
+	}
+	return newTx(&txs.SetL1ValidatorWeightTx{Message: message})
+}
+
+// verify verifies the signed Warp message sent by a validator manager to the P-Chain, against the
+// current validators of the L1 of its source chain, and returns its payload.
+func (p *PChain) verify(message []byte) (warpMessage.Payload, error) {
+	signedMessage, err := avalancheWarp.ParseMessage(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse Warp message")
+	}
+	if err := signedMessage.Signature.Verify(
+		context.Background(),
+		&signedMessage.UnsignedMessage,
+		p.network.networkID,
+		p.network.validatorState(),
+		p.network.PChainHeight(),
+		warp.WarpDefaultQuorumNumerator,
+		warp.WarpQuorumDenominator,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to verify Warp message")
+	}
+	addressedCall, err := warpPayload.ParseAddressedCall(signedMessage.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse addressed call")
+	}
+	return warpMessage.Parse(addressedCall.Payload)
+}
+
+func newTx(unsignedTx txs.UnsignedTx) (*txs.Tx, error) {
+	tx := &txs.Tx{Unsigned: unsignedTx}
+	if err := tx.Initialize(txs.Codec); err != nil {
+		return nil, errors.Wrap(err, "failed to initialize P-Chain transaction")
+	}
+	return tx, nil
+}
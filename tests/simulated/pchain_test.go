@@ -0,0 +1,160 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulated
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	ivalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IValidatorManager"
+	"github.com/ava-labs/icm-contracts/sdk/teleporter"
+	"github.com/ava-labs/icm-contracts/sdk/validatormanager"
+	uptimeUtils "github.com/ava-labs/icm-contracts/utils/uptime-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// Statuses of validations in the validator manager.
+const (
+	validatorStatusActive    uint8 = 2
+	validatorStatusCompleted uint8 = 4
+)
+
+// deployPoAValidatorManager deploys a PoAValidatorManager on chain, owned by the funded account,
+// and initializes its validator set with the current validators of the L1.
+func deployPoAValidatorManager(
+	t *testing.T,
+	network *Network,
+	chain *Chain,
+) (*validatormanager.Manager, *poavalidatormanager.PoAValidatorManager) {
+	ctx := context.Background()
+	fundedAddress, fundedKey := network.GetFundedAccountInfo()
+	opts, err := bind.NewKeyedTransactorWithChainID(fundedKey, chain.EVMChainID)
+	require.NoError(t, err)
+	address, tx, poaManager, err := poavalidatormanager.DeployPoAValidatorManager(opts, chain.Client(), 0)
+	require.NoError(t, err)
+	receipt, err := teleporter.WaitForTransactionSuccess(ctx, chain.Client(), tx.Hash())
+	require.NoError(t, err)
+	fromBlock := receipt.BlockNumber.Uint64()
+
+	tx, err = poaManager.Initialize(opts, poavalidatormanager.ValidatorManagerSettings{
+		SubnetID:               chain.SubnetID,
+		ChurnPeriodSeconds:     0,
+		MaximumChurnPercentage: 20,
+	}, fundedAddress)
+	require.NoError(t, err)
+	_, err = teleporter.WaitForTransactionSuccess(ctx, chain.Client(), tx.Hash())
+	require.NoError(t, err)
+
+	conversionData, signedMessage, err := network.PChain().ConvertSubnetToL1(chain.SubnetID, chain.BlockchainID, address)
+	require.NoError(t, err)
+	initialValidators := make([]ivalidatormanager.InitialValidator, len(conversionData.Validators))
+	for i, vdr := range conversionData.Validators {
+		initialValidators[i] = ivalidatormanager.InitialValidator{
+			NodeID:       vdr.NodeID,
+			BlsPublicKey: vdr.BLSPublicKey[:],
+			Weight:       vdr.Weight,
+		}
+	}
+	abi, err := ivalidatormanager.IValidatorManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	callData, err := abi.Pack("initializeValidatorSet", ivalidatormanager.ConversionData{
+		SubnetID:                     conversionData.SubnetID,
+		ValidatorManagerBlockchainID: conversionData.ManagerChainID,
+		ValidatorManagerAddress:      common.BytesToAddress(conversionData.ManagerAddress),
+		InitialValidators:            initialValidators,
+	}, uint32(0))
+	require.NoError(t, err)
+	txParams, err := teleporter.CalculateTxParams(ctx, chain.Client(), fundedAddress)
+	require.NoError(t, err)
+	tx, err = teleporter.SignTransaction(uptimeUtils.NewWarpMessageTx(
+		chain.EVMChainID,
+		txParams.Nonce,
+		address,
+		txParams.GasFeeCap,
+		txParams.GasTipCap,
+		callData,
+		signedMessage,
+	), fundedKey, chain.EVMChainID)
+	require.NoError(t, err)
+	_, err = teleporter.SendTransactionAndWaitForSuccess(ctx, chain.Client(), tx)
+	require.NoError(t, err)
+
+	return &validatormanager.Manager{
+		Client:              chain.Client(),
+		EVMChainID:          chain.EVMChainID,
+		NetworkID:           network.NetworkID(),
+		BlockchainID:        chain.BlockchainID,
+		SubnetID:            chain.SubnetID,
+		Address:             address,
+		Type:                validatormanager.PoA,
+		FromBlock:           fromBlock,
+		PChain:              network.PChain(),
+		SignatureAggregator: network.SignatureAggregator(),
+	}, poaManager
+}
+
+func TestPoAValidatorRegistration(t *testing.T) {
+	ctx := context.Background()
+	network, chains := newTestNetwork(t, 1, 5)
+	chain := chains[0]
+	_, fundedKey := network.GetFundedAccountInfo()
+	manager, poaManager := deployPoAValidatorManager(t, network, chain)
+
+	// The initial validators are active in the validator manager.
+	initialValidationID := chain.SubnetID.Append(0)
+	validation, err := poaManager.GetValidator(&bind.CallOpts{}, initialValidationID)
+	require.NoError(t, err)
+	require.Equal(t, validatorStatusActive, validation.Status)
+
+	vdr, err := NewValidator(DefaultValidatorWeight)
+	require.NoError(t, err)
+	pop := signer.NewProofOfPossession(vdr.SecretKey)
+	flow, err := validatormanager.NewRegisterValidatorFlow(manager.Address, validatormanager.Validator{
+		NodeID:             vdr.NodeID,
+		BLSPublicKey:       pop.PublicKey[:],
+		ProofOfPossession:  pop.ProofOfPossession[:],
+		RegistrationExpiry: uint64(time.Now().Add(24 * time.Hour).Unix()),
+		Weight:             vdr.Weight,
+	})
+	require.NoError(t, err)
+
+	// The P-Chain rejects validators whose keys it does not know, as they could not sign.
+	_, err = manager.Run(ctx, flow, fundedKey)
+	require.ErrorContains(t, err, "unknown BLS key")
+
+	network.PChain().AddKey(vdr.SecretKey)
+	flow, err = manager.Run(ctx, flow, fundedKey)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	require.NotEqual(t, ids.Empty, flow.PChainTxID)
+
+	validation, err = poaManager.GetValidator(&bind.CallOpts{}, flow.ValidationID)
+	require.NoError(t, err)
+	require.Equal(t, validatorStatusActive, validation.Status)
+	require.Equal(t, vdr.Weight, validation.Weight)
+	nodeIDs := make([]ids.NodeID, 0, 6)
+	for _, v := range network.Validators(chain.SubnetID) {
+		nodeIDs = append(nodeIDs, v.NodeID)
+	}
+	require.Len(t, nodeIDs, 6)
+	require.Contains(t, nodeIDs, vdr.NodeID)
+
+	// Ending the validation removes the validator from the L1.
+	flow, err = manager.Run(ctx, validatormanager.NewEndValidationFlow(manager.Address, flow.ValidationID), fundedKey)
+	require.NoError(t, err)
+	require.True(t, flow.Done())
+	validation, err = poaManager.GetValidator(&bind.CallOpts{}, flow.ValidationID)
+	require.NoError(t, err)
+	require.Equal(t, validatorStatusCompleted, validation.Status)
+	require.Len(t, network.Validators(chain.SubnetID), 5)
+	for _, v := range network.Validators(chain.SubnetID) {
+		require.NotEqual(t, vdr.NodeID, v.NodeID)
+	}
+}
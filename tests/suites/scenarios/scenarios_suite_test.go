@@ -0,0 +1,158 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package scenarios_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/icm-contracts/tests/network"
+	"github.com/ava-labs/icm-contracts/tests/scenarios"
+	"github.com/ava-labs/icm-contracts/tests/utils"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	teleporterByteCodeFile  = "./out/TeleporterMessenger.sol/TeleporterMessenger.json"
+	warpGenesisTemplateFile = "./tests/utils/warp-genesis-template.json"
+	defaultScenariosDir     = "./tests/scenarios/files"
+
+	scenarioLabel = "scenario"
+
+	// Time for each scenario to run
+	scenarioTimeout = 10 * time.Minute
+)
+
+var (
+	LocalNetworkInstance *network.LocalNetwork
+	TeleporterInfo       utils.TeleporterTestInfo
+	Scenarios            []*scenarios.Scenario
+)
+
+func TestScenarios(t *testing.T) {
+	if os.Getenv("RUN_E2E") == "" {
+		t.Skip("Environment variable RUN_E2E not set; skipping E2E tests")
+	}
+
+	RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Scenarios e2e test")
+}
+
+// scenariosDir returns the directory of the scenario files to run, which SCENARIOS_DIR overrides.
+func scenariosDir() string {
+	if dir := os.Getenv("SCENARIOS_DIR"); dir != "" {
+		return dir
+	}
+	return defaultScenariosDir
+}
+
+// Define the before and after suite functions.
+var _ = ginkgo.BeforeSuite(func() {
+	// Generate the Teleporter deployment values
+	teleporterDeployerTransaction,
+		teleporterDeployedBytecode,
+		teleporterDeployerAddress,
+		teleporterContractAddress,
+		err := deploymentUtils.ConstructKeylessTransaction(
+		teleporterByteCodeFile,
+		false,
+		deploymentUtils.GetDefaultContractCreationGasPrice(),
+	)
+	Expect(err).Should(BeNil())
+
+	// The network has the L1s of all scenarios, and an extra node for each validator they register
+	scenarioL1s, err := scenarios.NetworkL1Specs(Scenarios)
+	Expect(err).Should(BeNil())
+	l1Specs := make([]network.L1Spec, 0, len(scenarioL1s))
+	for _, l1 := range scenarioL1s {
+		l1Specs = append(l1Specs, network.L1Spec{
+			Name:                         l1.Name,
+			EVMChainID:                   l1.EVMChainID,
+			TeleporterContractAddress:    teleporterContractAddress,
+			TeleporterDeployedBytecode:   teleporterDeployedBytecode,
+			TeleporterDeployerAddress:    teleporterDeployerAddress,
+			NodeCount:                    l1.NodeCount,
+			RequirePrimaryNetworkSigners: true,
+		})
+	}
+
+	// Create the local network instance
+	ctx, cancel := context.WithTimeout(context.Background(), 240*2*time.Second)
+	defer cancel()
+
+	LocalNetworkInstance = network.NewLocalNetwork(
+		ctx,
+		"scenarios-test-local-network",
+		warpGenesisTemplateFile,
+		l1Specs,
+		max(2, len(l1Specs)),
+		scenarios.ExtraNodeCount(Scenarios),
+	)
+	TeleporterInfo = utils.NewTeleporterTestInfo(LocalNetworkInstance.GetAllL1Infos())
+	log.Info("Started local network")
+
+	// Only need to deploy Teleporter on the C-Chain since it is included in the genesis of the l1 chains.
+	_, fundedKey := LocalNetworkInstance.GetFundedAccountInfo()
+	TeleporterInfo.DeployTeleporterMessenger(
+		ctx,
+		LocalNetworkInstance.GetPrimaryNetworkInfo(),
+		teleporterDeployerTransaction,
+		teleporterDeployerAddress,
+		teleporterContractAddress,
+		fundedKey,
+	)
+
+	for _, l1 := range LocalNetworkInstance.GetAllL1Infos() {
+		TeleporterInfo.SetTeleporter(teleporterContractAddress, l1)
+		TeleporterInfo.InitializeBlockchainID(l1, fundedKey)
+		TeleporterInfo.DeployTeleporterRegistry(l1, fundedKey)
+	}
+
+	for _, subnet := range LocalNetworkInstance.GetL1Infos() {
+		// Choose weights such that register-validator steps can add a validator of the same weight
+		// within the churn limit of the validator manager
+		weights := make([]uint64, len(subnet.NodeURIs))
+		for i := range weights {
+			weights[i] = units.Schmeckle
+		}
+		LocalNetworkInstance.ConvertSubnet(
+			ctx,
+			subnet,
+			utils.PoAValidatorManager,
+			weights,
+			fundedKey,
+			false,
+		)
+	}
+
+	log.Info("Set up ginkgo before suite")
+})
+
+var _ = ginkgo.AfterSuite(func() {
+	LocalNetworkInstance.TearDownNetwork()
+	LocalNetworkInstance = nil
+})
+
+var _ = ginkgo.Describe("[Scenario tests]", func() {
+	// The scenarios are loaded when the spec tree is built, so that each is its own spec
+	var err error
+	Scenarios, err = scenarios.LoadDir(scenariosDir())
+	Expect(err).Should(BeNil())
+
+	for _, scenario := range Scenarios {
+		ginkgo.It(scenario.Name,
+			ginkgo.Label(scenarioLabel),
+			func() {
+				ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+				defer cancel()
+				scenarios.NewRunner(LocalNetworkInstance, TeleporterInfo).Run(ctx, scenario)
+			})
+	}
+})
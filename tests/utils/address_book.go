@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	addressBookUtils "github.com/ava-labs/icm-contracts/utils/address-book-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/onsi/gomega"
+)
+
+// Environment variable holding the path of the address book used by the e2e tests
+const addressBookPathEnvVar = "ADDRESS_BOOK_PATH"
+
+// LoadAddressBook loads the address book at ADDRESS_BOOK_PATH, which is empty if the file
+// does not exist yet.
+func LoadAddressBook() *addressBookUtils.AddressBook {
+	path := os.Getenv(addressBookPathEnvVar)
+	Expect(path).ShouldNot(BeEmpty())
+	book, err := addressBookUtils.LoadAddressBook(path)
+	Expect(err).Should(BeNil())
+	return book
+}
+
+// SaveAddressBook writes the address book to ADDRESS_BOOK_PATH.
+func SaveAddressBook(book *addressBookUtils.AddressBook) {
+	path := os.Getenv(addressBookPathEnvVar)
+	Expect(path).ShouldNot(BeEmpty())
+	Expect(book.Save(path)).Should(BeNil())
+}
+
+// GetAddressBookAddress returns the address of contractName on l1 from the address book,
+// after verifying that it is deployed.
+func GetAddressBookAddress(
+	ctx context.Context,
+	book *addressBookUtils.AddressBook,
+	l1 interfaces.L1TestInfo,
+	contractName string,
+) common.Address {
+	entry, ok := book.Lookup(l1.EVMChainID.Uint64(), contractName)
+	Expect(ok).Should(BeTrue(), "no address book entry for %s on chain %s", contractName, l1.EVMChainID)
+	err := addressBookUtils.VerifyDeployment(ctx, l1.RPCClient, entry.Deployment)
+	Expect(err).Should(BeNil())
+	return entry.Address
+}
+
+// RecordDeployment adds the contract deployed by a successful transaction to the address book.
+func RecordDeployment(
+	ctx context.Context,
+	book *addressBookUtils.AddressBook,
+	l1 interfaces.L1TestInfo,
+	contractName string,
+	tx *types.Transaction,
+) {
+	receipt := WaitForTransactionSuccess(ctx, l1, tx.Hash())
+	Expect(receipt.ContractAddress).ShouldNot(Equal(common.Address{}))
+	sender, err := types.LatestSignerForChainID(l1.EVMChainID).Sender(tx)
+	Expect(err).Should(BeNil())
+	book.Merge([]addressBookUtils.Deployment{{
+		ContractName:    contractName,
+		Address:         receipt.ContractAddress,
+		ChainID:         l1.EVMChainID.Uint64(),
+		TxHash:          tx.Hash(),
+		TransactionType: addressBookUtils.TransactionTypeCreate,
+		Deployer:        sender,
+		BlockNumber:     receipt.BlockNumber.Uint64(),
+		Timestamp:       uint64(time.Now().Unix()),
+	}})
+}
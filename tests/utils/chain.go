@@ -21,8 +21,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	nativeMinter "github.com/ava-labs/icm-contracts/abi-bindings/go/INativeMinter"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
 	"github.com/ava-labs/icm-contracts/tests/interfaces"
-	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/eth/tracers"
@@ -44,6 +44,9 @@ const (
 
 var NativeTransferGas uint64 = 21_000
 
+// transactionTimeout is how long the helpers wait for a transaction to be mined.
+const transactionTimeout = 20 * time.Second
+
 var WarpEnabledChainConfig = tmpnet.FlagsMap{
 	"log-level":         "debug",
 	"warp-api-enabled":  true,
@@ -210,7 +213,7 @@ func waitForTransaction(
 	txHash common.Hash,
 	success bool,
 ) *types.Receipt {
-	cctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	cctx, cancel := context.WithTimeout(ctx, transactionTimeout)
 	defer cancel()
 
 	receipt, err := WaitMined(cctx, l1Info.RPCClient, txHash)
@@ -226,41 +229,18 @@ func waitForTransaction(
 	return receipt
 }
 
-// Polls for a transaction receipt of the given txHash on each queryTicker tick until
-// either a transaction receipt returned, or the context is cancelled or expired.
-func waitForTransactionReceipt(
-	cctx context.Context,
-	rpcClient ethclient.Client,
-	txHash common.Hash,
-) (*types.Receipt, error) {
-	queryTicker := time.NewTicker(200 * time.Millisecond)
-	defer queryTicker.Stop()
-	for {
-		receipt, err := rpcClient.TransactionReceipt(cctx, txHash)
-		if err == nil {
-			return receipt, nil
-		}
-
-		if errors.Is(err, subnetEvmInterfaces.NotFound) {
-			log.Debug("Transaction not yet mined")
-		} else {
-			log.Error("Receipt retrieval failed", "err", err)
-			return nil, err
-		}
-
-		// Wait for the next round.
-		select {
-		case <-cctx.Done():
-			return nil, cctx.Err()
-		case <-queryTicker.C:
-		}
+// Asserts that err is nil, printing a trace of the transaction if it reverted.
+func expectTransactionSuccess(ctx context.Context, l1Info interfaces.L1TestInfo, err error) {
+	var reverted *teleporterSDK.TransactionRevertedError
+	if errors.As(err, &reverted) {
+		TraceTransactionAndExit(ctx, l1Info.RPCClient, reverted.Receipt.TxHash)
 	}
+	Expect(err).Should(BeNil())
 }
 
 // Signs a transaction using the provided key for the specified chainID
 func SignTransaction(tx *types.Transaction, key *ecdsa.PrivateKey, chainID *big.Int) *types.Transaction {
-	txSigner := types.LatestSignerForChainID(chainID)
-	signedTx, err := types.SignTx(tx, txSigner, key)
+	signedTx, err := teleporterSDK.SignTransaction(tx, key, chainID)
 	Expect(err).Should(BeNil())
 
 	return signedTx
@@ -272,19 +252,10 @@ func CalculateTxParams(
 	l1Info interfaces.L1TestInfo,
 	fundedAddress common.Address,
 ) (*big.Int, *big.Int, uint64) {
-	baseFee, err := l1Info.RPCClient.EstimateBaseFee(ctx)
-	Expect(err).Should(BeNil())
-
-	gasTipCap, err := l1Info.RPCClient.SuggestGasTipCap(ctx)
+	params, err := teleporterSDK.CalculateTxParams(ctx, l1Info.RPCClient, fundedAddress)
 	Expect(err).Should(BeNil())
 
-	nonce, err := l1Info.RPCClient.NonceAt(ctx, fundedAddress, nil)
-	Expect(err).Should(BeNil())
-
-	gasFeeCap := baseFee.Mul(baseFee, big.NewInt(gasUtils.BaseFeeFactor))
-	gasFeeCap.Add(gasFeeCap, big.NewInt(gasUtils.MaxPriorityFeePerGas))
-
-	return gasFeeCap, gasTipCap, nonce
+	return params.GasFeeCap, params.GasTipCap, params.Nonce
 }
 
 // Gomega will print the transaction trace and exit
@@ -310,64 +281,16 @@ func TraceTransaction(ctx context.Context, rpcClient ethclient.Client, txHash co
 
 // WaitMined waits for tx to be mined on the blockchain.
 // It stops waiting when the context is canceled.
-// Takes a tx hash instead of the full tx in the subnet-evm version of this function.
-// Copied and modified from https://github.com/ava-labs/subnet-evm/blob/v0.6.0-fuji/accounts/abi/bind/util.go#L42
 func WaitMined(ctx context.Context, rpcClient ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
 	now := time.Now()
-	receipt, err := waitForTransactionReceipt(ctx, rpcClient, txHash)
+	receipt, err := teleporterSDK.WaitMined(ctx, rpcClient, txHash)
 	if err != nil {
 		return nil, err
 	}
-	since := time.Since(now)
-	goLog.Println("Transaction mined", "txHash", txHash.Hex(), "duration", since)
-
-	// Check that the block height endpoint returns a block height as high as the block number that the transaction was
-	// included in. This is to workaround the issue where multiple nodes behind a public RPC endpoint see
-	// transactions/blocks at different points in time. Ideally, all nodes in the network should have seen this block
-	// and transaction before returning from WaitMined. The block height endpoint of public RPC endpoints is
-	// configured to return the lowest value currently returned by any node behind the load balancer, so waiting for
-	// it to be at least as high as the block height specified in the receipt should provide a relatively strong
-	// indication that the transaction has been seen widely throughout the network.
-	err = waitForBlockHeight(ctx, rpcClient, receipt.BlockNumber.Uint64())
-	if err != nil {
-		return nil, err
-	}
-
+	goLog.Println("Transaction mined", "txHash", txHash.Hex(), "duration", time.Since(now))
 	return receipt, nil
 }
 
-// Polls for the eth_blockNumber endpoint for the latest blockheight on each queryTicker tick until
-// either the returned height is greater than or equal to the expectedBlockNumber, or the context
-// is cancelled or expired.
-func waitForBlockHeight(
-	cctx context.Context,
-	rpcClient ethclient.Client,
-	expectedBlockNumber uint64,
-) error {
-	queryTicker := time.NewTicker(2 * time.Second)
-	defer queryTicker.Stop()
-	for {
-		currentBlockNumber, err := rpcClient.BlockNumber(cctx)
-		if err != nil {
-			return err
-		}
-
-		if currentBlockNumber >= expectedBlockNumber {
-			return nil
-		} else {
-			log.Info("Waiting for block height where transaction was included",
-				"blockNumber", expectedBlockNumber)
-		}
-
-		// Wait for the next round.
-		select {
-		case <-cctx.Done():
-			return cctx.Err()
-		case <-queryTicker.C:
-		}
-	}
-}
-
 //
 // Log utils
 //
@@ -389,13 +312,7 @@ func GetEventFromLogsOrTrace[T any](
 
 // Returns the first log in 'logs' that is successfully parsed by 'parser'
 func GetEventFromLogs[T any](logs []*types.Log, parser func(log types.Log) (T, error)) (T, error) {
-	for _, log := range logs {
-		event, err := parser(*log)
-		if err == nil {
-			return event, nil
-		}
-	}
-	return *new(T), fmt.Errorf("failed to find %T event in receipt logs", *new(T))
+	return teleporterSDK.GetEventFromLogs(logs, parser)
 }
 
 //
@@ -1,13 +1,11 @@
 package utils
 
 import (
-	"bytes"
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
@@ -15,21 +13,32 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	sigAggUtils "github.com/ava-labs/icm-contracts/utils/signature-aggregator-utils"
 	"github.com/ethereum/go-ethereum/log"
 
 	. "github.com/onsi/gomega"
 )
 
 const (
-	DEFAULT_API_PORT = 8080
-	SIG_AGG_API_PATH = "/aggregate-signatures"
+	SIG_AGG_API_PATH = sigAggUtils.AggregateSignaturesPath
+
+	// Time for the signature aggregator binary to become healthy
+	startupTimeout = 30 * time.Second
+	// Number of times the signature aggregator binary is started on new ports
+	startAttempts = 3
 )
 
+var errSignatureAggregatorExited = errors.New("signature aggregator exited before becoming healthy")
+
 // This is a wrapper around a signature aggregator binary instead of importing the package directly
-// to avoid cyclic dependencies
+// to avoid cyclic dependencies, or around the Go implementation of its API served in-process
 type SignatureAggregator struct {
 	cmd        *exec.Cmd
 	cancelFunc context.CancelFunc
+	server     *http.Server
+	onShutdown func()
+	baseURL    string
+	client     *sigAggUtils.Client
 }
 
 type SignatureAggregatorConfig struct {
@@ -37,6 +46,7 @@ type SignatureAggregatorConfig struct {
 	InfoAPI         ApiConfig `json:"info-api"`
 	SubnetIDs       []string  `json:"tracked-subnet-ids"`
 	ApiPort         int       `json:"api-port"`
+	MetricsPort     int       `json:"metrics-port"`
 	AllowPrivateIPs bool      `json:"allow-private-ips"`
 }
 
@@ -46,22 +56,26 @@ type ApiConfig struct {
 	HTTPHeaders map[string]string `json:"http-headers"`
 }
 
-type AggregateSignaturesRequest struct {
-	Message          string `json:"message"`
-	Justification    string `json:"justification,omitempty"`
-	SigningSubnetID  string `json:"signing-subnet-id,omitempty"`
-	QuorumPercentage uint64 `json:"quorum-percentage,omitempty"`
-}
+type AggregateSignaturesRequest = sigAggUtils.AggregateSignaturesRequest
 
-type SignatureAggregatorResponse struct {
-	SignedMessage string `json:"signed-message"`
-}
+type SignatureAggregatorResponse = sigAggUtils.AggregateSignaturesResponse
 
 func (s *SignatureAggregator) Shutdown() {
-	s.cancelFunc()
+	if s.cancelFunc != nil {
+		s.cancelFunc()
+	}
+	if s.server != nil {
+		s.server.Close()
+	}
+	if s.onShutdown != nil {
+		s.onShutdown()
+	}
 }
 
 // Aggregator utils
+
+// NewSignatureAggregator starts the signature aggregator binary at SIG_AGG_PATH on free ports, and
+// returns once it is healthy.
 func NewSignatureAggregator(apiUri string, subnetIDs []ids.ID) *SignatureAggregator {
 	sigAggPath := os.Getenv("SIG_AGG_PATH")
 	Expect(sigAggPath).ShouldNot(BeEmpty())
@@ -77,40 +91,117 @@ func NewSignatureAggregator(apiUri string, subnetIDs []ids.ID) *SignatureAggrega
 			BaseURL: apiUri,
 		},
 		SubnetIDs:       subnetIDStrings,
-		ApiPort:         DEFAULT_API_PORT,
 		AllowPrivateIPs: true,
 	}
+	// Another process may bind a port between freePort and the start of the binary, which then
+	// exits. It is started again on new ports.
+	var err error
+	for attempt := 1; attempt <= startAttempts; attempt++ {
+		cfg.ApiPort = freePort()
+		cfg.MetricsPort = freePort()
+		var aggregator *SignatureAggregator
+		aggregator, err = startSignatureAggregator(sigAggPath, cfg)
+		if !errors.Is(err, errSignatureAggregatorExited) {
+			Expect(err).Should(BeNil())
+			return aggregator
+		}
+		log.Warn("Signature aggregator exited on startup", "attempt", attempt, "apiPort", cfg.ApiPort)
+	}
+	Expect(err).Should(BeNil())
+	return nil
+}
+
+// startSignatureAggregator starts the binary at sigAggPath with cfg, and returns once it is
+// healthy. It returns errSignatureAggregatorExited if the binary exits before, e.g. when one of
+// its ports is taken.
+func startSignatureAggregator(sigAggPath string, cfg SignatureAggregatorConfig) (*SignatureAggregator, error) {
 	// write config to a JSON file in /tmp directory
 	configFile, err := os.CreateTemp("/tmp", "sig_agg_config_*.json")
-	Expect(err).Should(BeNil())
+	if err != nil {
+		return nil, err
+	}
 	defer configFile.Close()
 
 	encoder := json.NewEncoder(configFile)
-	err = encoder.Encode(cfg)
-	Expect(err).Should(BeNil())
+	if err := encoder.Encode(cfg); err != nil {
+		return nil, err
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
 	cmd := exec.CommandContext(ctx, sigAggPath, "--config-file", configFile.Name())
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	err = cmd.Start()
-	Expect(err).Should(BeNil())
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	healthCtx, healthCancel := context.WithTimeout(ctx, startupTimeout)
+	defer healthCancel()
+	exited := make(chan struct{})
 	go func() {
 		err := cmd.Wait()
+		close(exited)
+		// Stop waiting for the process to be healthy once it has exited
+		healthCancel()
 		// Context cancellation is the only expected way for the process to exit, otherwise log an error
 		// Don't panic to allow for easier cleanup
 		if !errors.Is(ctx.Err(), context.Canceled) {
-			log.Error("Signature aggregator exited abnormally", err)
+			log.Error("Signature aggregator exited abnormally", "err", err)
 		}
 	}()
 
-	// TODO: when the signature aggregator health check endpoint is improved to not return
-	// before ready to serve requests replace this sleep.
-	time.Sleep(time.Second * 5)
+	baseURL := fmt.Sprintf("http://localhost:%d", cfg.ApiPort)
+	client := sigAggUtils.NewClient(baseURL)
+	if err := client.WaitForHealthy(healthCtx); err != nil {
+		select {
+		case <-exited:
+			err = errSignatureAggregatorExited
+		default:
+		}
+		cancel()
+		return nil, err
+	}
 	return &SignatureAggregator{
 		cancelFunc: cancel,
 		cmd:        cmd,
+		baseURL:    baseURL,
+		client:     client,
+	}, nil
+}
+
+// freePort returns a local port that is free to listen on, so that concurrent test suites do not
+// collide on the ports of their signature aggregators. The port is released when it returns, so it
+// may be taken again before it is used.
+func freePort() int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).Should(BeNil())
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// NewLocalSignatureAggregator serves the API of aggregator on a free local port, in place of the
+// signature aggregator binary. It is ready to serve requests when it returns. onShutdown, if not
+// nil, is called on Shutdown, e.g. to release the connections of the aggregator's signature source.
+func NewLocalSignatureAggregator(aggregator *sigAggUtils.Aggregator, onShutdown func()) *SignatureAggregator {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).Should(BeNil())
+	server := &http.Server{
+		Handler:           aggregator,
+		ReadHeaderTimeout: sigAggUtils.DefaultRequestTimeout,
+	}
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Signature aggregator exited abnormally", "err", err)
+		}
+	}()
+	baseURL := "http://" + listener.Addr().String()
+	return &SignatureAggregator{
+		server:     server,
+		onShutdown: onShutdown,
+		baseURL:    baseURL,
+		client:     sigAggUtils.NewClient(baseURL),
 	}
 }
 
@@ -120,57 +211,5 @@ func (s *SignatureAggregator) CreateSignedMessage(
 	inputSigningSubnet ids.ID,
 	quorumPercentage uint64,
 ) (*avalancheWarp.Message, error) {
-	client := &http.Client{
-		Timeout: 20 * time.Second,
-	}
-	requestURL := fmt.Sprintf("http://localhost:%d%s", DEFAULT_API_PORT, SIG_AGG_API_PATH)
-	reqBody := AggregateSignaturesRequest{
-		Message:          hex.EncodeToString(unsignedMessage.Bytes()),
-		Justification:    hex.EncodeToString(justification),
-		SigningSubnetID:  inputSigningSubnet.String(),
-		QuorumPercentage: quorumPercentage,
-	}
-
-	b, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader := bytes.NewReader(b)
-
-	req, err := http.NewRequest(http.MethodPost, requestURL, bodyReader)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("expected status code 200, got %d", res.StatusCode)
-	}
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var response SignatureAggregatorResponse
-	err = json.Unmarshal(body, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	decodedMessage, err := hex.DecodeString(response.SignedMessage)
-	if err != nil {
-		return nil, err
-	}
-
-	signedMessage, err := avalancheWarp.ParseMessage(decodedMessage)
-	if err != nil {
-		return nil, err
-	}
-
-	return signedMessage, nil
+	return s.client.CreateSignedMessage(unsignedMessage, justification, inputSigningSubnet, quorumPercentage)
 }
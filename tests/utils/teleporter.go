@@ -1,22 +1,21 @@
 package utils
 
 import (
-	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"math/big"
 
 	"github.com/ava-labs/avalanchego/ids"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
-	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	validatorsetsig "github.com/ava-labs/icm-contracts/abi-bindings/go/governance/ValidatorSetSig"
 	exampleerc20 "github.com/ava-labs/icm-contracts/abi-bindings/go/mocks/ExampleERC20"
 	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
 	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
 	testmessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/tests/TestMessenger"
+	teleporterSDK "github.com/ava-labs/icm-contracts/sdk/teleporter"
 	"github.com/ava-labs/icm-contracts/tests/interfaces"
 	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
-	gasUtils "github.com/ava-labs/icm-contracts/utils/gas-utils"
+	teleporterUtils "github.com/ava-labs/icm-contracts/utils/teleporter-utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
@@ -29,9 +28,11 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+// DefaultTeleporterTransactionGas and DefaultTeleporterTransactionValue are the gas limit and value
+// of the sendCrossChainMessage transactions built by CreateSendCrossChainMessageTransaction.
 var (
-	DefaultTeleporterTransactionGas   uint64 = 300_000
-	DefaultTeleporterTransactionValue        = common.Big0
+	DefaultTeleporterTransactionGas   = teleporterSDK.DefaultSendCrossChainMessageGas
+	DefaultTeleporterTransactionValue = common.Big0
 )
 
 type ChainTeleporterInfo struct {
@@ -431,14 +432,10 @@ func DeployTestMessenger(
 //
 
 func ParseTeleporterMessage(unsignedMessage avalancheWarp.UnsignedMessage) *teleportermessenger.TeleporterMessage {
-	addressedPayload, err := payload.ParseAddressedCall(unsignedMessage.Payload)
+	teleporterMessage, err := teleporterSDK.ParseTeleporterMessage(unsignedMessage)
 	Expect(err).Should(BeNil())
 
-	teleporterMessage := teleportermessenger.TeleporterMessage{}
-	err = teleporterMessage.Unpack(addressedPayload.Payload)
-	Expect(err).Should(BeNil())
-
-	return &teleporterMessage
+	return teleporterMessage
 }
 
 //
@@ -455,20 +452,19 @@ func SendAddFeeAmountAndWaitForAcceptance(
 	senderKey *ecdsa.PrivateKey,
 	transactor *teleportermessenger.TeleporterMessenger,
 ) *types.Receipt {
-	opts, err := bind.NewKeyedTransactorWithChainID(
-		senderKey,
+	cctx, cancel := context.WithTimeout(ctx, transactionTimeout)
+	defer cancel()
+	receipt, err := teleporterSDK.AddFeeAmount(
+		cctx,
+		source.RPCClient,
 		source.EVMChainID,
+		transactor,
+		messageID,
+		feeContractAddress,
+		amount,
+		senderKey,
 	)
-	Expect(err).Should(BeNil())
-
-	tx, err := transactor.AddFeeAmount(opts, messageID, feeContractAddress, amount)
-	Expect(err).Should(BeNil())
-
-	receipt := WaitForTransactionSuccess(ctx, source, tx.Hash())
-
-	addFeeAmountEvent, err := GetEventFromLogs(receipt.Logs, transactor.ParseAddFeeAmount)
-	Expect(err).Should(BeNil())
-	Expect(addFeeAmountEvent.MessageID[:]).Should(Equal(messageID[:]))
+	expectTransactionSuccess(ctx, source, err)
 
 	log.Info("Send AddFeeAmount transaction on source chain",
 		"messageID", messageID,
@@ -487,13 +483,20 @@ func RetryMessageExecutionAndWaitForAcceptance(
 	message teleportermessenger.TeleporterMessage,
 	senderKey *ecdsa.PrivateKey,
 ) *types.Receipt {
-	opts, err := bind.NewKeyedTransactorWithChainID(senderKey, destinationL1.EVMChainID)
-	Expect(err).Should(BeNil())
-
-	tx, err := destinationTeleporterMessenger.RetryMessageExecution(opts, sourceBlockchainID, message)
-	Expect(err).Should(BeNil())
+	cctx, cancel := context.WithTimeout(ctx, transactionTimeout)
+	defer cancel()
+	receipt, err := teleporterSDK.RetryMessageExecution(
+		cctx,
+		destinationL1.RPCClient,
+		destinationL1.EVMChainID,
+		destinationTeleporterMessenger,
+		sourceBlockchainID,
+		message,
+		senderKey,
+	)
+	expectTransactionSuccess(ctx, destinationL1, err)
 
-	return WaitForTransactionSuccess(ctx, destinationL1, tx.Hash())
+	return receipt
 }
 
 func RedeemRelayerRewardsAndConfirm(
@@ -559,25 +562,27 @@ func SendSpecifiedReceiptsAndWaitForAcceptance(
 	allowedRelayerAddresses []common.Address,
 	senderKey *ecdsa.PrivateKey,
 ) (*types.Receipt, ids.ID) {
-	opts, err := bind.NewKeyedTransactorWithChainID(senderKey, source.EVMChainID)
-	Expect(err).Should(BeNil())
-
-	tx, err := sourceTeleporterMessenger.SendSpecifiedReceipts(
-		opts, destinationBlockchainID, messageIDs, feeInfo, allowedRelayerAddresses)
-	Expect(err).Should(BeNil())
-
-	receipt := WaitForTransactionSuccess(ctx, source, tx.Hash())
-
-	// Check the transaction logs for the SendCrossChainMessage event emitted by the Teleporter contract
-	event, err := GetEventFromLogs(receipt.Logs, sourceTeleporterMessenger.ParseSendCrossChainMessage)
-	Expect(err).Should(BeNil())
-	Expect(event.DestinationBlockchainID[:]).Should(Equal(destinationBlockchainID[:]))
+	cctx, cancel := context.WithTimeout(ctx, transactionTimeout)
+	defer cancel()
+	sent, err := teleporterSDK.SendSpecifiedReceipts(
+		cctx,
+		source.RPCClient,
+		source.EVMChainID,
+		sourceTeleporterMessenger,
+		destinationBlockchainID,
+		messageIDs,
+		feeInfo,
+		allowedRelayerAddresses,
+		senderKey,
+	)
+	expectTransactionSuccess(ctx, source, err)
+	Expect(sent.Event.DestinationBlockchainID[:]).Should(Equal(destinationBlockchainID[:]))
 
 	log.Info("Sending SendSpecifiedReceipts transaction",
 		"destinationBlockchainID", destinationBlockchainID,
-		"txHash", tx.Hash())
+		"txHash", sent.Receipt.TxHash)
 
-	return receipt, event.MessageID
+	return sent.Receipt, sent.MessageID
 }
 
 func SendCrossChainMessageAndWaitForAcceptance(
@@ -588,26 +593,24 @@ func SendCrossChainMessageAndWaitForAcceptance(
 	input teleportermessenger.TeleporterMessageInput,
 	senderKey *ecdsa.PrivateKey,
 ) (*types.Receipt, ids.ID) {
-	opts, err := bind.NewKeyedTransactorWithChainID(senderKey, source.EVMChainID)
-	Expect(err).Should(BeNil())
-
-	// Send a transaction to the Teleporter contract
-	tx, err := sourceTeleporterMessenger.SendCrossChainMessage(opts, input)
-	Expect(err).Should(BeNil())
-
-	// Wait for the transaction to be accepted
-	receipt := WaitForTransactionSuccess(ctx, source, tx.Hash())
-
-	// Check the transaction logs for the SendCrossChainMessage event emitted by the Teleporter contract
-	event, err := GetEventFromLogs(receipt.Logs, sourceTeleporterMessenger.ParseSendCrossChainMessage)
-	Expect(err).Should(BeNil())
+	cctx, cancel := context.WithTimeout(ctx, transactionTimeout)
+	defer cancel()
+	sent, err := teleporterSDK.SendCrossChainMessage(
+		cctx,
+		source.RPCClient,
+		source.EVMChainID,
+		sourceTeleporterMessenger,
+		input,
+		senderKey,
+	)
+	expectTransactionSuccess(ctx, source, err)
 
 	log.Info("Sending SendCrossChainMessage transaction on source chain",
 		"sourceChainID", source.BlockchainID,
 		"destinationBlockchainID", destination.BlockchainID,
-		"txHash", tx.Hash())
+		"txHash", sent.Receipt.TxHash)
 
-	return receipt, event.MessageID
+	return sent.Receipt, sent.MessageID
 }
 
 // Returns true if the transaction receipt contains a ReceiptReceived log with the specified messageID
@@ -616,13 +619,7 @@ func CheckReceiptReceived(
 	messageID [32]byte,
 	transactor *teleportermessenger.TeleporterMessenger,
 ) bool {
-	for _, log := range receipt.Logs {
-		event, err := transactor.ParseReceiptReceived(*log)
-		if err == nil && bytes.Equal(event.MessageID[:], messageID[:]) {
-			return true
-		}
-	}
-	return false
+	return teleporterSDK.ReceiptReceived(receipt, messageID)
 }
 
 func GetOutstandingReceiptCount(
@@ -647,24 +644,17 @@ func CreateSendCrossChainMessageTransaction(
 	senderKey *ecdsa.PrivateKey,
 	teleporterContractAddress common.Address,
 ) *types.Transaction {
-	data, err := teleportermessenger.PackSendCrossChainMessage(input)
+	tx, err := teleporterSDK.CreateSendCrossChainMessageTransaction(
+		ctx,
+		source.RPCClient,
+		source.EVMChainID,
+		teleporterContractAddress,
+		input,
+		senderKey,
+	)
 	Expect(err).Should(BeNil())
 
-	gasFeeCap, gasTipCap, nonce := CalculateTxParams(ctx, source, PrivateKeyToAddress(senderKey))
-
-	// Send a transaction to the Teleporter contract
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   source.EVMChainID,
-		Nonce:     nonce,
-		To:        &teleporterContractAddress,
-		Gas:       DefaultTeleporterTransactionGas,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		Value:     DefaultTeleporterTransactionValue,
-		Data:      data,
-	})
-
-	return SignTransaction(tx, senderKey, source.EVMChainID)
+	return tx
 }
 
 // Constructs a transaction to call receiveCrossChainMessage
@@ -679,39 +669,18 @@ func CreateReceiveCrossChainMessageTransaction(
 ) *types.Transaction {
 	// Construct the transaction to send the Warp message to the destination chain
 	log.Info("Constructing receiveCrossChainMessage transaction for the destination chain")
-	numSigners, err := signedMessage.Signature.NumSigners()
-	Expect(err).Should(BeNil())
-
-	teleporterMessage := ParseTeleporterMessage(signedMessage.UnsignedMessage)
-	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(
-		numSigners,
+	tx, err := teleporterSDK.CreateReceiveCrossChainMessageTransaction(
+		ctx,
+		l1Info.RPCClient,
+		l1Info.EVMChainID,
+		teleporterContractAddress,
+		signedMessage,
 		requiredGasLimit,
-		len(signedMessage.Bytes()),
-		len(signedMessage.Payload),
-		len(teleporterMessage.Receipts),
+		senderKey,
 	)
 	Expect(err).Should(BeNil())
 
-	callData, err := teleportermessenger.PackReceiveCrossChainMessage(0, PrivateKeyToAddress(senderKey))
-	Expect(err).Should(BeNil())
-
-	gasFeeCap, gasTipCap, nonce := CalculateTxParams(ctx, l1Info, PrivateKeyToAddress(senderKey))
-
-	destinationTx := predicateutils.NewPredicateTx(
-		l1Info.EVMChainID,
-		nonce,
-		&teleporterContractAddress,
-		gasLimit,
-		gasFeeCap,
-		gasTipCap,
-		big.NewInt(0),
-		callData,
-		types.AccessList{},
-		warp.ContractAddress,
-		signedMessage.Bytes(),
-	)
-
-	return SignTransaction(destinationTx, senderKey, l1Info.EVMChainID)
+	return tx
 }
 
 // Constructs a transaction to call addProtocolVersion
@@ -816,17 +785,12 @@ func CreateOffChainRegistryMessage(
 	registryAddress common.Address,
 	entry teleporterregistry.ProtocolRegistryEntry,
 ) *avalancheWarp.UnsignedMessage {
-	sourceAddress := []byte{}
-	payloadBytes, err := teleporterregistry.PackTeleporterRegistryWarpPayload(entry, registryAddress)
-	Expect(err).Should(BeNil())
-
-	addressedPayload, err := payload.NewAddressedCall(sourceAddress, payloadBytes)
-	Expect(err).Should(BeNil())
-
-	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(
+	unsignedMessage, err := teleporterUtils.NewOffChainRegistryMessage(
 		networkID,
 		l1.BlockchainID,
-		addressedPayload.Bytes(),
+		registryAddress,
+		entry.Version,
+		entry.ProtocolAddress,
 	)
 	Expect(err).Should(BeNil())
 
@@ -6,6 +6,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	erc20tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHome"
 	nativetokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/NativeTokenHome"
+	icttSDK "github.com/ava-labs/icm-contracts/sdk/ictt"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 
@@ -19,7 +20,7 @@ func ApplyTokenScaling(
 	multiplyOnRemote bool,
 	homeTokenAmount *big.Int,
 ) *big.Int {
-	return scaleTokens(tokenMultiplier, multiplyOnRemote, homeTokenAmount, true)
+	return icttSDK.ApplyTokenScaling(tokenMultiplier, multiplyOnRemote, homeTokenAmount)
 }
 
 // RemoveTokenScaling removes token scaling from the given amount of remote tokens.
@@ -29,22 +30,7 @@ func RemoveTokenScaling(
 	multiplyOnRemote bool,
 	remoteTokenAmount *big.Int,
 ) *big.Int {
-	return scaleTokens(tokenMultiplier, multiplyOnRemote, remoteTokenAmount, false)
-}
-
-func scaleTokens(
-	tokenMultiplier *big.Int,
-	multiplyOnRemote bool,
-	amount *big.Int,
-	isSendToRemote bool,
-) *big.Int {
-	// Multiply when multiplyOnRemote and isSendToRemote are
-	// both true or both false.
-	if multiplyOnRemote == isSendToRemote {
-		return big.NewInt(0).Mul(amount, tokenMultiplier)
-	}
-
-	return big.NewInt(0).Div(amount, tokenMultiplier)
+	return icttSDK.RemoveTokenScaling(tokenMultiplier, multiplyOnRemote, remoteTokenAmount)
 }
 
 // GetScaledAmountFromERC20TokenHome returns the scaled amount of remote tokens that
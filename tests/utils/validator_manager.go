@@ -32,12 +32,12 @@ import (
 	iposvalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IPoSValidatorManager"
 	ivalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IValidatorManager"
 	"github.com/ava-labs/icm-contracts/tests/interfaces"
+	uptimeUtils "github.com/ava-labs/icm-contracts/utils/uptime-utils"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 	predicateutils "github.com/ava-labs/subnet-evm/predicate"
 	subnetEvmUtils "github.com/ava-labs/subnet-evm/tests/utils"
-	"github.com/ava-labs/subnet-evm/warp/messages"
 	"github.com/ethereum/go-ethereum/common"
 	"google.golang.org/protobuf/proto"
 
@@ -794,22 +794,13 @@ func ConstructUptimeProofMessage(
 	networkID uint32,
 	signatureAggregator *SignatureAggregator,
 ) *avalancheWarp.Message {
-	uptimePayload, err := messages.NewValidatorUptime(validationID, uptime)
-	Expect(err).Should(BeNil())
-	addressedCall, err := warpPayload.NewAddressedCall(nil, uptimePayload.Bytes())
-	Expect(err).Should(BeNil())
-	uptimeProofUnsignedMessage, err := avalancheWarp.NewUnsignedMessage(
+	uptimeProofSignedMessage, err := uptimeUtils.ConstructUptimeProofMessage(
+		signatureAggregator,
 		networkID,
 		l1.BlockchainID,
-		addressedCall.Bytes(),
-	)
-	Expect(err).Should(BeNil())
-
-	uptimeProofSignedMessage, err := signatureAggregator.CreateSignedMessage(
-		uptimeProofUnsignedMessage,
-		nil,
 		l1.SubnetID,
-		67,
+		validationID,
+		uptime,
 	)
 	Expect(err).Should(BeNil())
 	return uptimeProofSignedMessage
@@ -0,0 +1,209 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// AddressBookVersion is the version of the address book format written by this package.
+// Address books with a newer version are rejected.
+const AddressBookVersion = 1
+
+// AddressBook records the current and previous deployments of each contract on each chain.
+type AddressBook struct {
+	Version int `json:"version"`
+	// Chains maps decimal EVM chain IDs to the entries of the contracts deployed on them, by name
+	Chains map[string]map[string]*Entry `json:"chains"`
+}
+
+// Entry is the current deployment of a contract on a chain, along with the deployments it
+// replaced, latest first.
+type Entry struct {
+	Deployment
+	History []Deployment `json:"history,omitempty"`
+}
+
+// NewAddressBook returns an empty address book.
+func NewAddressBook() *AddressBook {
+	return &AddressBook{
+		Version: AddressBookVersion,
+		Chains:  make(map[string]map[string]*Entry),
+	}
+}
+
+// LoadAddressBook reads an address book from path. If the file does not exist, an empty
+// address book is returned.
+func LoadAddressBook(path string) (*AddressBook, error) {
+	bookJSON, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewAddressBook(), nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read address book")
+	}
+	book := NewAddressBook()
+	if err := json.Unmarshal(bookJSON, book); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse address book %s", path)
+	}
+	if book.Version > AddressBookVersion {
+		return nil, fmt.Errorf("address book %s has version %d, newer than the supported version %d",
+			path, book.Version, AddressBookVersion)
+	}
+	book.Version = AddressBookVersion
+	if book.Chains == nil {
+		book.Chains = make(map[string]map[string]*Entry)
+	}
+	if err := book.validateKeys(); err != nil {
+		return nil, errors.Wrapf(err, "invalid address book %s", path)
+	}
+	return book, nil
+}
+
+// validateKeys returns an error if an entry, or a deployment in its history, is not recorded
+// under its chain ID and contract name, e.g. after the address book was edited by hand. Lookups
+// would otherwise return the deployment of another contract or chain.
+func (b *AddressBook) validateKeys() error {
+	for key, chain := range b.Chains {
+		chainID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid chain ID %s", key)
+		}
+		for name, entry := range chain {
+			if entry == nil {
+				return fmt.Errorf("empty entry for %s on chain %d", name, chainID)
+			}
+			for _, d := range append([]Deployment{entry.Deployment}, entry.History...) {
+				if d.ChainID != chainID || d.ContractName != name {
+					return fmt.Errorf("deployment %s of %s on chain %d is recorded as %s on chain %d",
+						d.TxHash, d.ContractName, d.ChainID, name, chainID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Save writes the address book to path as indented JSON, creating its directory if needed.
+func (b *AddressBook) Save(path string) error {
+	bookJSON, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal address book")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrap(err, "failed to create address book directory")
+	}
+	return errors.Wrap(os.WriteFile(path, append(bookJSON, '\n'), 0o644), "failed to write address book")
+}
+
+// Merge adds deployments to the address book, and returns the deployments that were not already
+// recorded. A deployment replaces the current entry of its contract if it is more recent, in
+// which case the current deployment is moved to the entry's history. Older deployments are
+// added to the history. Deployments are identified by their transaction hash, so merging the
+// same broadcast files again does not change the address book.
+func (b *AddressBook) Merge(deployments []Deployment) []Deployment {
+	var added []Deployment
+	for _, d := range deployments {
+		chain := b.Chains[chainKey(d.ChainID)]
+		if chain == nil {
+			chain = make(map[string]*Entry)
+			b.Chains[chainKey(d.ChainID)] = chain
+		}
+		entry, ok := chain[d.ContractName]
+		if !ok {
+			chain[d.ContractName] = &Entry{Deployment: d}
+			added = append(added, d)
+			continue
+		}
+		if entry.contains(d.TxHash) {
+			continue
+		}
+		added = append(added, d)
+		if isNewer(d, entry.Deployment) {
+			entry.History = append([]Deployment{entry.Deployment}, entry.History...)
+			entry.Deployment = d
+		} else {
+			entry.History = append(entry.History, d)
+		}
+		sort.SliceStable(entry.History, func(i, j int) bool {
+			return isNewer(entry.History[i], entry.History[j])
+		})
+	}
+	return added
+}
+
+// Lookup returns the entry of a contract on a chain.
+func (b *AddressBook) Lookup(chainID uint64, contractName string) (*Entry, bool) {
+	entry, ok := b.Chains[chainKey(chainID)][contractName]
+	return entry, ok
+}
+
+// Address returns the current address of a contract on a chain.
+func (b *AddressBook) Address(chainID uint64, contractName string) (common.Address, error) {
+	entry, ok := b.Lookup(chainID, contractName)
+	if !ok {
+		return common.Address{}, fmt.Errorf("no address for %s on chain %d", contractName, chainID)
+	}
+	return entry.Address, nil
+}
+
+// Entries returns the current entries of a chain, ordered by contract name.
+func (b *AddressBook) Entries(chainID uint64) []*Entry {
+	chain := b.Chains[chainKey(chainID)]
+	entries := make([]*Entry, 0, len(chain))
+	for _, entry := range chain {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ContractName < entries[j].ContractName
+	})
+	return entries
+}
+
+// ChainIDs returns the IDs of the chains in the address book, in ascending order.
+func (b *AddressBook) ChainIDs() ([]uint64, error) {
+	chainIDs := make([]uint64, 0, len(b.Chains))
+	for key := range b.Chains {
+		chainID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain ID %s in address book", key)
+		}
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+	return chainIDs, nil
+}
+
+func (e *Entry) contains(txHash common.Hash) bool {
+	if e.TxHash == txHash {
+		return true
+	}
+	for _, d := range e.History {
+		if d.TxHash == txHash {
+			return true
+		}
+	}
+	return false
+}
+
+// isNewer returns whether a was deployed after b. Deployments in the same block are ordered by
+// the time their script was run.
+func isNewer(a Deployment, b Deployment) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber > b.BlockNumber
+	}
+	return a.Timestamp > b.Timestamp
+}
+
+func chainKey(chainID uint64) string {
+	return strconv.FormatUint(chainID, 10)
+}
@@ -0,0 +1,194 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const broadcastDir = "../../broadcast"
+
+func TestParseBroadcast(t *testing.T) {
+	deployments, err := ParseBroadcast(filepath.Join(broadcastDir, "DeployValidator.s.sol/43114/run-latest.json"))
+	require.NoError(t, err)
+	require.Len(t, deployments, 2)
+	require.Equal(t, Deployment{
+		ContractName:    "ValidatorMessages",
+		Address:         common.HexToAddress("0x4485e094b60d4e24000e0718fc0342fca27db745"),
+		ChainID:         43114,
+		TxHash:          common.HexToHash("0x281c110c88bfcddc32991347a96154b0a0ec26db3956d8519755289c43581e62"),
+		TransactionType: "CREATE2",
+		Deployer:        common.HexToAddress("0x38c5479620f6c2f29677f04d89e356cf6e75cfde"),
+		BlockNumber:     57167104,
+		Script:          "DeployValidator.s.sol",
+		Commit:          "c1aa41f2",
+		Timestamp:       1739288566,
+	}, deployments[0])
+	require.Equal(t, "CoqnetERC20TokenStakingManager", deployments[1].ContractName)
+	require.Equal(t, []string{"0"}, deployments[1].ConstructorArgs)
+
+	// Calls are not deployments, and dry runs have no receipts
+	deployments, err = ParseBroadcast(filepath.Join(broadcastDir, "PoaToPos.s.sol/43113/run-latest.json"))
+	require.NoError(t, err)
+	require.Empty(t, deployments)
+	deployments, err = ParseBroadcast(filepath.Join(broadcastDir, "DeployToken.s.sol/43114/dry-run/run-latest.json"))
+	require.NoError(t, err)
+	require.Empty(t, deployments)
+
+	paths, err := FindBroadcasts(broadcastDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(broadcastDir, "DeployRewardsCalculator.s.sol/43114/run-latest.json"),
+		filepath.Join(broadcastDir, "DeployToken.s.sol/43113/run-latest.json"),
+		filepath.Join(broadcastDir, "DeployToken.s.sol/43114/run-latest.json"),
+		filepath.Join(broadcastDir, "DeployValidator.s.sol/43113/run-latest.json"),
+		filepath.Join(broadcastDir, "DeployValidator.s.sol/43114/run-latest.json"),
+		filepath.Join(broadcastDir, "PoaToPos.s.sol/43113/run-latest.json"),
+	}, paths)
+}
+
+func TestAddressBook(t *testing.T) {
+	deployments, err := ImportBroadcasts(broadcastDir)
+	require.NoError(t, err)
+	book := NewAddressBook()
+	require.Len(t, book.Merge(deployments), len(deployments))
+	// Merging the same deployments again changes nothing
+	require.Empty(t, book.Merge(deployments))
+
+	chainIDs, err := book.ChainIDs()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{43113, 43114}, chainIDs)
+	address, err := book.Address(43113, "CoqnetERC20TokenStakingManager")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xd441929a278a01303547e643f4798d9bb5b4fcf8"), address)
+	_, err = book.Address(43113, "RewardsCalculator")
+	require.ErrorContains(t, err, "no address for RewardsCalculator on chain 43113")
+
+	// An earlier run of the script is added to the history, and doesn't replace the current entry
+	earlier, err := ParseBroadcast(filepath.Join(broadcastDir, "DeployValidator.s.sol/43113/run-1738772436.json"))
+	require.NoError(t, err)
+	require.Len(t, book.Merge(earlier), 2)
+	entry, ok := book.Lookup(43113, "CoqnetERC20TokenStakingManager")
+	require.True(t, ok)
+	require.Equal(t, address, entry.Address)
+	require.Len(t, entry.History, 1)
+	require.Equal(t, common.HexToAddress("0xd9e3d21f5798f9d400dd03f4c7dc3554df49d3c1"), entry.History[0].Address)
+
+	// A later deployment replaces the current entry
+	later := entry.Deployment
+	later.TxHash = common.HexToHash("0x01")
+	later.Address = common.HexToAddress("0x02")
+	later.BlockNumber++
+	require.Len(t, book.Merge([]Deployment{later}), 1)
+	entry, _ = book.Lookup(43113, "CoqnetERC20TokenStakingManager")
+	require.Equal(t, later.Address, entry.Address)
+	require.Equal(t, []common.Address{address, common.HexToAddress("0xd9e3d21f5798f9d400dd03f4c7dc3554df49d3c1")},
+		[]common.Address{entry.History[0].Address, entry.History[1].Address})
+
+	path := filepath.Join(t.TempDir(), "deployments", "address-book.json")
+	require.NoError(t, book.Save(path))
+	loaded, err := LoadAddressBook(path)
+	require.NoError(t, err)
+	require.Equal(t, book, loaded)
+
+	empty, err := LoadAddressBook(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	require.Equal(t, NewAddressBook(), empty)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2, "chains": {}}`), 0o600))
+	_, err = LoadAddressBook(path)
+	require.ErrorContains(t, err, "newer than the supported version 1")
+
+	// Entries must be recorded under their chain ID and contract name
+	moved := NewAddressBook()
+	moved.Merge([]Deployment{later})
+	moved.Chains["43114"] = moved.Chains["43113"]
+	delete(moved.Chains, "43113")
+	require.NoError(t, moved.Save(path))
+	_, err = LoadAddressBook(path)
+	require.ErrorContains(t, err, "is recorded as CoqnetERC20TokenStakingManager on chain 43114")
+	renamed := NewAddressBook()
+	renamed.Merge([]Deployment{later})
+	renamed.Chains["43113"]["WCOQ"] = renamed.Chains["43113"]["CoqnetERC20TokenStakingManager"]
+	delete(renamed.Chains["43113"], "CoqnetERC20TokenStakingManager")
+	require.NoError(t, renamed.Save(path))
+	_, err = LoadAddressBook(path)
+	require.ErrorContains(t, err, "is recorded as WCOQ on chain 43113")
+}
+
+type mockClient struct {
+	chainID  *big.Int
+	code     map[common.Address][]byte
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (m *mockClient) ChainID(context.Context) (*big.Int, error) {
+	return m.chainID, nil
+}
+
+func (m *mockClient) CodeAt(_ context.Context, account common.Address, _ *big.Int) ([]byte, error) {
+	return m.code[account], nil
+}
+
+func (m *mockClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, ok := m.receipts[txHash]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return receipt, nil
+}
+
+func TestVerifyChain(t *testing.T) {
+	deployments, err := ParseBroadcast(filepath.Join(broadcastDir, "DeployValidator.s.sol/43114/run-latest.json"))
+	require.NoError(t, err)
+	book := NewAddressBook()
+	book.Merge(deployments)
+	messages, manager := deployments[0], deployments[1]
+
+	client := &mockClient{
+		chainID: big.NewInt(43114),
+		code: map[common.Address][]byte{
+			messages.Address: {1},
+			manager.Address:  {1},
+		},
+		receipts: map[common.Hash]*types.Receipt{
+			messages.TxHash: {
+				Status:      types.ReceiptStatusSuccessful,
+				BlockNumber: new(big.Int).SetUint64(messages.BlockNumber),
+			},
+			manager.TxHash: {
+				Status:          types.ReceiptStatusSuccessful,
+				BlockNumber:     new(big.Int).SetUint64(manager.BlockNumber),
+				ContractAddress: manager.Address,
+			},
+		},
+	}
+	results, err := VerifyChain(context.Background(), client, book)
+	require.NoError(t, err)
+	require.Equal(t, []Verification{
+		{ContractName: manager.ContractName, ChainID: 43114, Address: manager.Address, Verified: true},
+		{ContractName: messages.ContractName, ChainID: 43114, Address: messages.Address, Verified: true},
+	}, results)
+
+	delete(client.code, messages.Address)
+	client.receipts[manager.TxHash].ContractAddress = common.Address{}
+	results, err = VerifyChain(context.Background(), client, book)
+	require.NoError(t, err)
+	require.Contains(t, results[0].Error, "created 0x0000000000000000000000000000000000000000")
+	require.Contains(t, results[1].Error, "no code at")
+
+	// Entries of other chains are not verified
+	client.chainID = big.NewInt(1)
+	results, err = VerifyChain(context.Background(), client, book)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
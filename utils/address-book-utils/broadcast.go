@@ -0,0 +1,186 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Name of the broadcast file of the latest run of a script on a chain
+	latestBroadcastFileName = "run-latest.json"
+	// Directory of the broadcast files of simulated runs, which contain no receipts
+	dryRunDirName = "dry-run"
+)
+
+// Transaction types of deployments
+const (
+	TransactionTypeCreate  = "CREATE"
+	TransactionTypeCreate2 = "CREATE2"
+)
+
+// Deployment is a contract deployed by a transaction of a foundry script.
+type Deployment struct {
+	ContractName string         `json:"contractName"`
+	Address      common.Address `json:"address"`
+	ChainID      uint64         `json:"chainId"`
+	TxHash       common.Hash    `json:"txHash"`
+	// TransactionType is CREATE or CREATE2
+	TransactionType string         `json:"transactionType"`
+	Deployer        common.Address `json:"deployer"`
+	// ConstructorArgs are the constructor arguments as formatted by foundry
+	ConstructorArgs []string `json:"constructorArgs,omitempty"`
+	BlockNumber     uint64   `json:"blockNumber"`
+	// Script is the name of the script's broadcast directory, e.g. DeployValidator.s.sol
+	Script string `json:"script,omitempty"`
+	// Commit is the short git commit the script was run at
+	Commit string `json:"commit,omitempty"`
+	// Timestamp is the unix time the script was run at
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// broadcast is the subset of a foundry broadcast file needed to find deployments.
+type broadcast struct {
+	// Simulated transactions have no hash, and calls have no contract address
+	Transactions []struct {
+		Hash            *common.Hash    `json:"hash"`
+		TransactionType string          `json:"transactionType"`
+		ContractName    string          `json:"contractName"`
+		ContractAddress *common.Address `json:"contractAddress"`
+		Arguments       []string        `json:"arguments"`
+		Transaction     struct {
+			From common.Address `json:"from"`
+		} `json:"transaction"`
+	} `json:"transactions"`
+	Receipts []struct {
+		TransactionHash common.Hash    `json:"transactionHash"`
+		Status          hexutil.Uint64 `json:"status"`
+		BlockNumber     hexutil.Uint64 `json:"blockNumber"`
+	} `json:"receipts"`
+	Timestamp uint64 `json:"timestamp"`
+	Chain     uint64 `json:"chain"`
+	Commit    string `json:"commit"`
+}
+
+// ParseBroadcast returns the contracts deployed by the transactions of a foundry broadcast file.
+// Only CREATE and CREATE2 transactions with a successful receipt are included, so calls and
+// simulated runs without receipts yield no deployments. Contracts created by other contracts,
+// such as the ProxyAdmin of a TransparentUpgradeableProxy, are not named by foundry and are
+// not included either.
+func ParseBroadcast(path string) ([]Deployment, error) {
+	broadcastJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read broadcast file")
+	}
+	var b broadcast
+	if err := json.Unmarshal(broadcastJSON, &b); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse broadcast file %s", path)
+	}
+	if b.Chain == 0 {
+		return nil, fmt.Errorf("broadcast file %s has no chain ID", path)
+	}
+
+	blockNumbers := make(map[common.Hash]uint64)
+	for _, receipt := range b.Receipts {
+		if receipt.Status == 1 {
+			blockNumbers[receipt.TransactionHash] = uint64(receipt.BlockNumber)
+		}
+	}
+	script := filepath.Base(filepath.Dir(filepath.Dir(path)))
+	var deployments []Deployment
+	for _, tx := range b.Transactions {
+		if tx.TransactionType != TransactionTypeCreate && tx.TransactionType != TransactionTypeCreate2 {
+			continue
+		}
+		if tx.Hash == nil || tx.ContractAddress == nil {
+			continue
+		}
+		blockNumber, ok := blockNumbers[*tx.Hash]
+		if !ok {
+			continue
+		}
+		if tx.ContractName == "" {
+			return nil, fmt.Errorf("deployment %s in %s has no contract name", tx.Hash, path)
+		}
+		deployments = append(deployments, Deployment{
+			ContractName:    tx.ContractName,
+			Address:         *tx.ContractAddress,
+			ChainID:         b.Chain,
+			TxHash:          *tx.Hash,
+			TransactionType: tx.TransactionType,
+			Deployer:        tx.Transaction.From,
+			ConstructorArgs: tx.Arguments,
+			BlockNumber:     blockNumber,
+			Script:          script,
+			Commit:          b.Commit,
+			Timestamp:       b.Timestamp,
+		})
+	}
+	return deployments, nil
+}
+
+// FindBroadcasts returns the paths of the latest broadcast files of each script and chain under
+// a foundry broadcast directory, laid out as <dir>/<script>/<chain ID>/run-latest.json, ordered
+// by script and chain ID. Simulated runs are skipped.
+func FindBroadcasts(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == dryRunDirName {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == latestBroadcastFileName {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find broadcast files")
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		scriptI, chainI := scriptAndChain(paths[i])
+		scriptJ, chainJ := scriptAndChain(paths[j])
+		if scriptI != scriptJ {
+			return scriptI < scriptJ
+		}
+		return chainI < chainJ
+	})
+	return paths, nil
+}
+
+func scriptAndChain(path string) (string, uint64) {
+	chainDir := filepath.Dir(path)
+	chainID, _ := strconv.ParseUint(filepath.Base(chainDir), 10, 64)
+	return filepath.Base(filepath.Dir(chainDir)), chainID
+}
+
+// ImportBroadcasts returns the deployments of all latest broadcast files under dir, in the
+// order of FindBroadcasts.
+func ImportBroadcasts(dir string) ([]Deployment, error) {
+	paths, err := FindBroadcasts(dir)
+	if err != nil {
+		return nil, err
+	}
+	var deployments []Deployment
+	for _, path := range paths {
+		d, err := ParseBroadcast(path)
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, d...)
+	}
+	return deployments, nil
+}
@@ -0,0 +1,85 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Client is the subset of an EVM client needed to verify deployments.
+type Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Verification is the result of verifying an address book entry on-chain.
+type Verification struct {
+	ContractName string         `json:"contractName"`
+	ChainID      uint64         `json:"chainId"`
+	Address      common.Address `json:"address"`
+	Verified     bool           `json:"verified"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// VerifyDeployment returns an error if the deployment is not reflected on-chain, that is if
+// no code is deployed at its address, or its transaction was not successfully included in its
+// block. For CREATE deployments, the transaction must have created the contract at its address.
+func VerifyDeployment(ctx context.Context, client Client, d Deployment) error {
+	code, err := client.CodeAt(ctx, d.Address, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get code")
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no code at %s", d.Address)
+	}
+	receipt, err := client.TransactionReceipt(ctx, d.TxHash)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get receipt of transaction %s", d.TxHash)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s failed", d.TxHash)
+	}
+	if receipt.BlockNumber == nil || receipt.BlockNumber.Uint64() != d.BlockNumber {
+		return fmt.Errorf("transaction %s was included in block %v, expected %d", d.TxHash, receipt.BlockNumber, d.BlockNumber)
+	}
+	if d.TransactionType == TransactionTypeCreate && receipt.ContractAddress != d.Address {
+		return fmt.Errorf("transaction %s created %s, expected %s", d.TxHash, receipt.ContractAddress, d.Address)
+	}
+	return nil
+}
+
+// VerifyChain verifies the current entries of the chain the client is connected to, ordered by
+// contract name. An error is only returned if the chain cannot be queried, while failed
+// verifications are reported in the results.
+func VerifyChain(ctx context.Context, client Client, book *AddressBook) ([]Verification, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain ID")
+	}
+	if !chainID.IsUint64() {
+		return nil, fmt.Errorf("invalid chain ID %s", chainID)
+	}
+	entries := book.Entries(chainID.Uint64())
+	results := make([]Verification, len(entries))
+	for i, entry := range entries {
+		results[i] = Verification{
+			ContractName: entry.ContractName,
+			ChainID:      entry.ChainID,
+			Address:      entry.Address,
+		}
+		if err := VerifyDeployment(ctx, client, entry.Deployment); err != nil {
+			results[i].Error = err.Error()
+		} else {
+			results[i].Verified = true
+		}
+	}
+	return results, nil
+}
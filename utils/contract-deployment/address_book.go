@@ -0,0 +1,138 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	addressBookUtils "github.com/ava-labs/icm-contracts/utils/address-book-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/spf13/cobra"
+)
+
+const defaultAddressBookPath = "deployments/address-book.json"
+
+var (
+	addressBookPath string
+	broadcastDir    string
+	chainID         uint64
+)
+
+var addressBookCmd = &cobra.Command{
+	Use:   "address-book",
+	Short: "Maintains the address book of deployed contracts",
+	Long: `Maintains a versioned JSON address book of the contracts deployed on each
+chain, imported from the broadcast files of foundry scripts. The address book
+records the current address of each contract on each chain, along with the
+deployments it replaced.`,
+}
+
+var addressBookImportCmd = &cobra.Command{
+	Use:   "import [BROADCAST_FILE...]",
+	Short: "Imports foundry broadcast files into the address book",
+	Long: `Imports the contracts deployed by foundry scripts into the address book. The
+given broadcast files are imported, or if none are given, the run-latest.json
+files of every script and chain under --broadcast-dir, skipping dry runs.
+Deployments that are already recorded are ignored, and the newly imported
+deployments are printed as JSON.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		book, err := addressBookUtils.LoadAddressBook(addressBookPath)
+		if err != nil {
+			return err
+		}
+		var deployments []addressBookUtils.Deployment
+		if len(args) == 0 {
+			deployments, err = addressBookUtils.ImportBroadcasts(broadcastDir)
+			if err != nil {
+				return err
+			}
+		}
+		for _, path := range args {
+			d, err := addressBookUtils.ParseBroadcast(path)
+			if err != nil {
+				return err
+			}
+			deployments = append(deployments, d...)
+		}
+		added := book.Merge(deployments)
+		if err := book.Save(addressBookPath); err != nil {
+			return err
+		}
+		if added == nil {
+			added = []addressBookUtils.Deployment{}
+		}
+		return printJSON(cmd, added)
+	},
+}
+
+var addressBookGetCmd = &cobra.Command{
+	Use:   "get --chain-id CHAIN_ID CONTRACT_NAME",
+	Short: "Prints the address of a contract",
+	Long: `Prints the current address of the contract CONTRACT_NAME on the chain with
+EVM chain ID --chain-id.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		book, err := addressBookUtils.LoadAddressBook(addressBookPath)
+		if err != nil {
+			return err
+		}
+		address, err := book.Address(chainID, args[0])
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), address.Hex())
+		return err
+	},
+}
+
+var addressBookVerifyCmd = &cobra.Command{
+	Use:   "verify --rpc RPC_URL",
+	Short: "Verifies the address book entries of a chain",
+	Long: `Verifies the current entries of the chain --rpc is connected to: code must be
+deployed at each address, and each deployment transaction must have succeeded in
+the recorded block. The results are printed as JSON, and the command fails if any
+entry cannot be verified.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		book, err := addressBookUtils.LoadAddressBook(addressBookPath)
+		if err != nil {
+			return err
+		}
+		client, err := ethclient.Dial(rpcEndpoint)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		results, err := addressBookUtils.VerifyChain(context.Background(), client, book)
+		if err != nil {
+			return err
+		}
+		if err := printJSON(cmd, results); err != nil {
+			return err
+		}
+		var failed int
+		for _, result := range results {
+			if !result.Verified {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to verify %d address book entries", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(addressBookCmd)
+	addressBookCmd.AddCommand(addressBookImportCmd, addressBookGetCmd, addressBookVerifyCmd)
+
+	addressBookCmd.PersistentFlags().StringVar(&addressBookPath, "book", defaultAddressBookPath, "Path to the address book")
+	addressBookImportCmd.Flags().StringVar(&broadcastDir, "broadcast-dir", "broadcast", "Foundry broadcast directory to import")
+	addressBookGetCmd.Flags().Uint64Var(&chainID, "chain-id", 0, "EVM chain ID of the chain")
+	addressBookVerifyCmd.Flags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint of the chain to verify")
+	cobra.CheckErr(addressBookGetCmd.MarkFlagRequired("chain-id"))
+	cobra.CheckErr(addressBookVerifyCmd.MarkFlagRequired("rpc"))
+}
@@ -0,0 +1,74 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	addressBookUtils "github.com/ava-labs/icm-contracts/utils/address-book-utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressBookCmd(t *testing.T) {
+	book := filepath.Join(t.TempDir(), "address-book.json")
+	out, err := executeTestCmd(t, rootCmd, "address-book", "import", "--book", book, "--broadcast-dir", "../../broadcast")
+	require.NoError(t, err)
+	var added []addressBookUtils.Deployment
+	require.NoError(t, json.Unmarshal([]byte(out), &added))
+	require.Len(t, added, 6)
+
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "import again",
+			args: []string{"address-book", "import", "--book", book, "--broadcast-dir", "../../broadcast"},
+			out:  "[]",
+		},
+		{
+			name: "import file",
+			args: []string{"address-book", "import", "--book", book, "../../broadcast/DeployValidator.s.sol/43113/run-1738772436.json"},
+			out:  "0xd9e3d21f5798f9d400dd03f4c7dc3554df49d3c1",
+		},
+		{
+			name: "get",
+			args: []string{"address-book", "get", "--book", book, "--chain-id", "43114", "WCOQ"},
+			out:  "0x6FED18dbBd1E80D70AB633b55Abb820890ABFf27",
+		},
+		{
+			name: "get missing",
+			args: []string{"address-book", "get", "--book", book, "--chain-id", "1", "WCOQ"},
+			err:  fmt.Errorf("no address for WCOQ on chain 1"),
+		},
+		{
+			name: "verify missing rpc",
+			args: []string{"address-book", "verify", "--book", book},
+			err:  fmt.Errorf(`required flag(s) "rpc" not set`),
+		},
+		{
+			name: "help",
+			args: []string{"address-book", "--help"},
+			err:  nil,
+			out:  "Maintains a versioned JSON address book",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
@@ -4,51 +4,137 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
+	"math/big"
 	"os"
-	"strconv"
 
 	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 )
 
-func main() {
-	if len(os.Args) < 2 {
-		log.Panic("Invalid argument count. Must provide at least one argument to specify command type.")
-	}
-	commandType := os.Args[1]
-
-	switch commandType {
-	case "constructKeylessTx":
-		// Get the byte code of the teleporter contract to be deployed.
-		if len(os.Args) != 3 {
-			log.Panic("Invalid argument count. Must provide JSON file containing contract bytecode.")
-		}
-		_, _, _, _, err := deploymentUtils.ConstructKeylessTransaction(
-			os.Args[2],
-			true,
-			deploymentUtils.GetDefaultContractCreationGasPrice(),
-		)
-		if err != nil {
-			log.Panic("Failed to construct keyless transaction.", err)
-		}
-	case "deriveContractAddress":
-		// Get the byte code of the teleporter contract to be deployed.
-		if len(os.Args) != 4 {
-			log.Panic("Invalid argument count. Must provide address and nonce.")
-		}
-
-		deployerAddress := common.HexToAddress(os.Args[2])
-		nonce, err := strconv.ParseUint(os.Args[3], 10, 64)
-		if err != nil {
-			log.Panic("Failed to parse nonce as uint", err)
-		}
-
-		resultAddress := crypto.CreateAddress(deployerAddress, nonce)
-		fmt.Println(resultAddress.Hex())
-	default:
-		log.Panic("Invalid command type. Supported options are \"constructKeylessTx\" and \"deriveContractAddress\".")
+var rootCmd = &cobra.Command{
+	Use:   "contract-deployment",
+	Short: "A CLI for deploying contracts to the same address on every chain",
+	Long: `A CLI for deploying contracts to the same address on every chain. Contracts
+are deployed either by a keyless transaction signed using Nick's method, as is
+done for TeleporterMessenger, or with CREATE2 through the deterministic
+deployment proxy. The CLI constructs the deployment transactions, plans the
+funding they require on each network, and verifies the deployed code.`,
+	SilenceUsage: true,
+}
+
+// Flags describing the deployment, shared by the subcommands
+var (
+	constructorArgs string
+	gasLimit        uint64
+	gasPrice        string
+	salt            string
+)
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+// addDeploymentFlags adds the flags configuring the creation bytecode and the keyless transaction.
+func addDeploymentFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&constructorArgs, "constructor-args", "", "Hex encoded ABI encoded constructor arguments")
+	cmd.Flags().Uint64Var(&gasLimit, "gas-limit", 0, "Gas limit of the keyless transaction (default 4000000)")
+	cmd.Flags().StringVar(&gasPrice, "gas-price", "", "Gas price of the keyless transaction in wei (default 2500 gwei)")
+}
+
+// parseGasPrice returns the gas price given in wei, or nil for the default gas price.
+func parseGasPrice(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
 	}
+	price, ok := new(big.Int).SetString(value, 10)
+	if !ok || price.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid gas price %s", value)
+	}
+	return price, nil
+}
+
+func parseConstructorArgs() ([]byte, error) {
+	if constructorArgs == "" {
+		return nil, nil
+	}
+	args, err := hexutil.Decode(constructorArgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid constructor arguments")
+	}
+	return args, nil
+}
+
+func parseSalt() (common.Hash, error) {
+	b, err := hexutil.Decode(salt)
+	if err != nil || len(b) > common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid salt %s, expected at most 32 hex encoded bytes", salt)
+	}
+	return common.BytesToHash(b), nil
+}
+
+// keylessTransaction constructs the keyless transaction deploying the artifact with the
+// constructor arguments flag and the given gas price and limit.
+func keylessTransaction(
+	artifactPath string,
+	price string,
+	limit uint64,
+	opts deploymentUtils.KeylessTransactionOptions,
+) (*deploymentUtils.KeylessTransaction, error) {
+	var err error
+	opts.ConstructorArgs, err = parseConstructorArgs()
+	if err != nil {
+		return nil, err
+	}
+	opts.GasPrice, err = parseGasPrice(price)
+	if err != nil {
+		return nil, err
+	}
+	opts.GasLimit = limit
+	return deploymentUtils.ConstructKeylessTransactionWithOptions(artifactPath, opts)
+}
+
+// create2Address derives the address the artifact is deployed to through the deterministic
+// deployment proxy with the salt flag.
+func create2Address(artifactPath string) (common.Address, error) {
+	saltHash, err := parseSalt()
+	if err != nil {
+		return common.Address{}, err
+	}
+	args, err := parseConstructorArgs()
+	if err != nil {
+		return common.Address{}, err
+	}
+	creationCode, err := deploymentUtils.LoadCreationCode(artifactPath, args)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return deploymentUtils.DeriveCreate2Address(saltHash, creationCode), nil
+}
+
+// printJSON prints value as indented JSON to standard output, so that it can be parsed by scripts.
+func printJSON(cmd *cobra.Command, value interface{}) error {
+	valueJSON, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(valueJSON))
+	return err
+}
+
+func main() {
+	Execute()
 }
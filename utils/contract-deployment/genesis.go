@@ -0,0 +1,168 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	genesisUtils "github.com/ava-labs/icm-contracts/utils/genesis-utils"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var genesisOutput string
+
+// genesisConfig is the JSON configuration of the genesis command.
+type genesisConfig struct {
+	ChainID   uint64 `json:"chainID"`
+	Timestamp uint64 `json:"timestamp"`
+	GasLimit  uint64 `json:"gasLimit,omitempty"`
+
+	Alloc map[common.Address]*math.HexOrDecimal256 `json:"alloc,omitempty"`
+
+	Warp *struct {
+		QuorumNumerator              uint64 `json:"quorumNumerator"`
+		RequirePrimaryNetworkSigners bool   `json:"requirePrimaryNetworkSigners"`
+	} `json:"warp,omitempty"`
+	NativeMinter *struct {
+		Admins   []common.Address `json:"admins"`
+		Managers []common.Address `json:"managers"`
+		Enabled  []common.Address `json:"enabled"`
+	} `json:"nativeMinter,omitempty"`
+
+	TeleporterMessenger *struct {
+		// Artifact is the foundry artifact of TeleporterMessenger, deployed with the same keyless
+		// transaction as on every other chain
+		Artifact string `json:"artifact"`
+		GasPrice string `json:"gasPrice,omitempty"`
+		GasLimit uint64 `json:"gasLimit,omitempty"`
+	} `json:"teleporterMessenger,omitempty"`
+	ValidatorManager *struct {
+		ProxyAddress             common.Address `json:"proxyAddress"`
+		ProxyAdminOwner          common.Address `json:"proxyAdminOwner"`
+		ImplementationAddress    common.Address `json:"implementationAddress"`
+		ValidatorMessagesAddress common.Address `json:"validatorMessagesAddress"`
+		// If SubnetID is set, the proxy is initialized with Owner as the owner of the
+		// validator manager
+		SubnetID               ids.ID         `json:"subnetID,omitempty"`
+		ChurnPeriodSeconds     uint64         `json:"churnPeriodSeconds,omitempty"`
+		MaximumChurnPercentage uint8          `json:"maximumChurnPercentage,omitempty"`
+		Owner                  common.Address `json:"owner,omitempty"`
+	} `json:"validatorManager,omitempty"`
+}
+
+var genesisCmd = &cobra.Command{
+	Use:   "genesis CONFIG",
+	Short: "Builds a subnet-evm genesis with predeployed ICM contracts",
+	Long: `Builds a subnet-evm genesis from the JSON configuration CONFIG, with
+TeleporterMessenger and a PoAValidatorManager behind a TransparentUpgradeableProxy
+predeployed, and the Warp and NativeMinter precompiles enabled. The contracts'
+constructors are run in an in-memory EVM, so that their code and storage are
+exactly as if they had been deployed by transactions. TeleporterMessenger is
+deployed by the same keyless transaction as on every other chain, and so has the
+same address.
+
+TeleporterRegistry and ValidatorSetSig store the blockchain ID of their chain,
+which is derived from the transaction creating the chain and so is not known when
+its genesis is built. They must be deployed after the chain is launched. The
+genesis is written to --output, or printed if it is not set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configJSON, err := os.ReadFile(args[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to read genesis config")
+		}
+		// Unknown fields are rejected, so that contracts that cannot be predeployed are not ignored.
+		decoder := json.NewDecoder(bytes.NewReader(configJSON))
+		decoder.DisallowUnknownFields()
+		var config genesisConfig
+		if err := decoder.Decode(&config); err != nil {
+			return errors.Wrap(err, "failed to parse genesis config")
+		}
+		genesis, err := buildGenesis(&config)
+		if err != nil {
+			return err
+		}
+		if genesisOutput == "" {
+			return printJSON(cmd, genesis)
+		}
+		genesisJSON, err := json.MarshalIndent(genesis, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(genesisOutput, genesisJSON, 0o644)
+	},
+}
+
+func buildGenesis(config *genesisConfig) (*core.Genesis, error) {
+	if config.ChainID == 0 {
+		return nil, fmt.Errorf("chain ID is not set")
+	}
+	builder, err := genesisUtils.NewBuilder(new(big.Int).SetUint64(config.ChainID), config.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	if config.GasLimit != 0 {
+		builder.SetGasLimit(config.GasLimit)
+	}
+	for account, balance := range config.Alloc {
+		builder.Fund(account, (*big.Int)(balance))
+	}
+	if config.Warp != nil {
+		builder.EnableWarp(config.Warp.QuorumNumerator, config.Warp.RequirePrimaryNetworkSigners)
+	}
+	if config.NativeMinter != nil {
+		builder.EnableNativeMinter(config.NativeMinter.Admins, config.NativeMinter.Managers, config.NativeMinter.Enabled)
+	}
+
+	if config.TeleporterMessenger != nil {
+		keylessTx, err := keylessTransaction(
+			config.TeleporterMessenger.Artifact,
+			config.TeleporterMessenger.GasPrice,
+			config.TeleporterMessenger.GasLimit,
+			deploymentUtils.KeylessTransactionOptions{},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := builder.PredeployKeyless(keylessTx.Tx); err != nil {
+			return nil, errors.Wrap(err, "failed to predeploy TeleporterMessenger")
+		}
+	}
+	if manager := config.ValidatorManager; manager != nil {
+		predeploy := genesisUtils.ValidatorManagerPredeploy{
+			ProxyAddress:             manager.ProxyAddress,
+			ProxyAdminOwner:          manager.ProxyAdminOwner,
+			ImplementationAddress:    manager.ImplementationAddress,
+			ValidatorMessagesAddress: manager.ValidatorMessagesAddress,
+			Owner:                    manager.Owner,
+		}
+		if manager.SubnetID != ids.Empty {
+			predeploy.Settings = &poavalidatormanager.ValidatorManagerSettings{
+				SubnetID:               manager.SubnetID,
+				ChurnPeriodSeconds:     manager.ChurnPeriodSeconds,
+				MaximumChurnPercentage: manager.MaximumChurnPercentage,
+			}
+		}
+		if _, err := builder.PredeployPoAValidatorManager(predeploy); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Genesis()
+}
+
+func init() {
+	genesisCmd.Flags().StringVar(&genesisOutput, "output", "", "File the genesis is written to")
+	rootCmd.AddCommand(genesisCmd)
+}
@@ -0,0 +1,114 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testGenesisConfig = `{
+	"chainID": 99999,
+	"timestamp": 1700000000,
+	"alloc": {"0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC": "1000000000000000000000"},
+	"warp": {"quorumNumerator": 67},
+	"nativeMinter": {"admins": ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]},
+	"teleporterMessenger": {"artifact": "%s"},
+	"validatorManager": {
+		"proxyAddress": "0x0000000000000000000000000000000000002000",
+		"proxyAdminOwner": "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC",
+		"implementationAddress": "0x0000000000000000000000000000000000002001",
+		"validatorMessagesAddress": "0x0000000000000000000000000000000000002002",
+		"subnetID": "2DeHa7Qb6sufPkmQcFWG2uCd4pBPv9WB6dkzroiMQhd1NSRtof",
+		"churnPeriodSeconds": 3600,
+		"maximumChurnPercentage": 20,
+		"owner": "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	}%s
+}`
+
+// writeGenesisConfig writes the test genesis config, with the given extra fields.
+func writeGenesisConfig(t *testing.T, extra string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := fmt.Sprintf(testGenesisConfig, exampleArtifact, extra)
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o644))
+	return path
+}
+
+func TestGenesisCmd(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "genesis.json")
+	_, err := executeTestCmd(
+		t, rootCmd, "genesis", writeGenesisConfig(t, ""),
+		"--output", outputPath,
+	)
+	require.NoError(t, err)
+
+	genesisJSON, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	var genesis core.Genesis
+	require.NoError(t, json.Unmarshal(genesisJSON, &genesis))
+	require.Equal(t, uint64(99999), genesis.Config.ChainID.Uint64())
+	require.Equal(t, uint64(1700000000), genesis.Timestamp)
+
+	keylessTx, err := deploymentUtils.ConstructKeylessTransactionWithOptions(
+		exampleArtifact,
+		deploymentUtils.KeylessTransactionOptions{},
+	)
+	require.NoError(t, err)
+	for _, address := range []common.Address{
+		keylessTx.ContractAddress,
+		common.HexToAddress("0x0000000000000000000000000000000000002000"),
+		common.HexToAddress("0x0000000000000000000000000000000000002001"),
+		common.HexToAddress("0x0000000000000000000000000000000000002002"),
+	} {
+		require.Contains(t, genesis.Alloc, address)
+		require.NotEmpty(t, genesis.Alloc[address].Code, address)
+	}
+	require.Contains(t, genesis.Alloc, common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"))
+	require.Contains(t, genesis.Alloc, keylessTx.DeployerAddress)
+	require.Len(t, genesis.Config.GenesisPrecompiles, 2)
+}
+
+func TestGenesisCmdErrors(t *testing.T) {
+	emptyConfig := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(emptyConfig, []byte("{}"), 0o644))
+
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+	}{
+		{
+			name: "missing config",
+			args: []string{"genesis"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			// The blockchain ID stored by TeleporterRegistry is not known before the chain is created
+			name: "TeleporterRegistry",
+			args: []string{"genesis", writeGenesisConfig(t, `,
+	"teleporterRegistry": {"address": "0x0000000000000000000000000000000000001000"}`)},
+			err: fmt.Errorf(`unknown field "teleporterRegistry"`),
+		},
+		{
+			name: "missing chain ID",
+			args: []string{"genesis", emptyConfig},
+			err:  fmt.Errorf("chain ID is not set"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeTestCmd(t, rootCmd, tt.args...)
+			require.ErrorContains(t, err, tt.err.Error())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputDir    string
+	contractName string
+)
+
+// keylessTxOutput is the JSON output of keyless-tx and of each network of plan.
+type keylessTxOutput struct {
+	Network         string         `json:"network,omitempty"`
+	Transaction     hexutil.Bytes  `json:"transaction,omitempty"`
+	GasLimit        uint64         `json:"gasLimit"`
+	GasPrice        *hexutil.Big   `json:"gasPrice"`
+	DeployerAddress common.Address `json:"deployerAddress"`
+	RequiredFunding *hexutil.Big   `json:"requiredFunding"`
+	ContractAddress common.Address `json:"contractAddress"`
+}
+
+func newKeylessTxOutput(keylessTx *deploymentUtils.KeylessTransaction) keylessTxOutput {
+	return keylessTxOutput{
+		Transaction:     keylessTx.Tx,
+		GasLimit:        keylessTx.GasLimit,
+		GasPrice:        (*hexutil.Big)(keylessTx.GasPrice),
+		DeployerAddress: keylessTx.DeployerAddress,
+		RequiredFunding: (*hexutil.Big)(keylessTx.RequiredFunding),
+		ContractAddress: keylessTx.ContractAddress,
+	}
+}
+
+var keylessTxCmd = &cobra.Command{
+	Use:     "keyless-tx ARTIFACT",
+	Aliases: []string{"constructKeylessTx"},
+	Short:   "Constructs a keyless transaction deploying a contract",
+	Long: `Constructs a keyless transaction using Nick's method that deploys the contract
+in the foundry artifact ARTIFACT, with the constructor arguments --constructor-args
+appended to its bytecode. The transaction, the keyless deployer address that must
+be funded to send it, the funding required (gas limit × gas price) and the
+resulting contract address are printed as JSON, and written to files in
+--output-dir. The files are named after --name, or after TeleporterMessenger if
+no name is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keylessTx, err := keylessTransaction(args[0], gasPrice, gasLimit, deploymentUtils.KeylessTransactionOptions{
+			OutputDir:    outputDir,
+			ContractName: contractName,
+		})
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd, newKeylessTxOutput(keylessTx))
+	},
+}
+
+var deriveAddressCmd = &cobra.Command{
+	Use:     "derive-address (DEPLOYER_ADDRESS NONCE | --salt SALT ARTIFACT)",
+	Aliases: []string{"deriveContractAddress"},
+	Short:   "Derives the address of a deployed contract",
+	Long: `Derives the address of the contract deployed by DEPLOYER_ADDRESS with the
+given NONCE. If --salt is set, derives the address of the contract in the foundry
+artifact ARTIFACT, with the constructor arguments --constructor-args, deployed
+with CREATE2 through the deterministic deployment proxy using the given salt.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if salt != "" {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if salt != "" {
+			address, err := create2Address(args[0])
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), address.Hex())
+			return err
+		}
+
+		if !common.IsHexAddress(args[0]) {
+			return fmt.Errorf("invalid deployer address %s", args[0])
+		}
+		nonce, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid nonce %s", args[1])
+		}
+		address := crypto.CreateAddress(common.HexToAddress(args[0]), nonce)
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), address.Hex())
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keylessTxCmd, deriveAddressCmd)
+
+	addDeploymentFlags(keylessTxCmd)
+	keylessTxCmd.Flags().StringVar(&outputDir, "output-dir", ".", "Directory to write the transaction and addresses to")
+	keylessTxCmd.Flags().StringVar(&contractName, "name", "", "Name of the contract used in the output file names")
+
+	deriveAddressCmd.Flags().StringVar(&salt, "salt", "", "Hex encoded CREATE2 salt")
+	deriveAddressCmd.Flags().StringVar(&constructorArgs, "constructor-args", "", "Hex encoded ABI encoded constructor arguments")
+}
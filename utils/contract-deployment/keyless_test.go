@@ -0,0 +1,137 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeylessTxCmd(t *testing.T) {
+	outputDir := t.TempDir()
+	out, err := executeTestCmd(t, rootCmd,
+		"keyless-tx", exampleArtifact,
+		"--gas-limit", "100000",
+		"--gas-price", "100000000000",
+		"--output-dir", outputDir,
+		"--name", "Example",
+	)
+	require.NoError(t, err)
+	var output keylessTxOutput
+	require.NoError(t, json.Unmarshal([]byte(out), &output))
+	require.Equal(t, uint64(100_000), output.GasLimit)
+	require.Equal(t, "0x2386f26fc10000", output.RequiredFunding.String())
+	require.Equal(t, crypto.CreateAddress(output.DeployerAddress, 0), output.ContractAddress)
+	require.FileExists(t, filepath.Join(outputDir, "UniversalExampleDeployerTransaction.txt"))
+
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "missing artifact",
+			args: []string{"keyless-tx"},
+			err:  fmt.Errorf("accepts 1 arg(s), received 0"),
+		},
+		{
+			name: "invalid gas price",
+			args: []string{"keyless-tx", exampleArtifact, "--gas-price", "0x1"},
+			err:  fmt.Errorf("invalid gas price 0x1"),
+		},
+		{
+			name: "invalid constructor args",
+			args: []string{"keyless-tx", exampleArtifact, "--constructor-args", "1234"},
+			err:  fmt.Errorf("invalid constructor arguments"),
+		},
+		{
+			name: "help",
+			args: []string{"keyless-tx", "--help"},
+			err:  nil,
+			out:  "Constructs a keyless transaction using Nick's method",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestDeriveAddressCmd(t *testing.T) {
+	deployer := common.HexToAddress("0x38545c4b331D8BFb3bee94C62D77a6735b5eF8c0")
+	creationCode, err := deploymentUtils.LoadCreationCode(exampleArtifact, common.Hex2Bytes("01"))
+	require.NoError(t, err)
+
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "nonce",
+			args: []string{"derive-address", deployer.Hex(), "1"},
+			out:  crypto.CreateAddress(deployer, 1).Hex(),
+		},
+		{
+			name: "legacy name",
+			args: []string{"deriveContractAddress", deployer.Hex(), "0"},
+			out:  crypto.CreateAddress(deployer, 0).Hex(),
+		},
+		{
+			name: "create2",
+			args: []string{"derive-address", "--salt", "0x01", "--constructor-args", "0x01", exampleArtifact},
+			out:  deploymentUtils.DeriveCreate2Address(common.HexToHash("0x01"), creationCode).Hex(),
+		},
+		{
+			name: "invalid nonce",
+			args: []string{"derive-address", deployer.Hex(), "one"},
+			err:  fmt.Errorf("invalid nonce one"),
+		},
+		{
+			name: "invalid salt",
+			args: []string{"derive-address", "--salt", "1", exampleArtifact},
+			err:  fmt.Errorf("invalid salt 1"),
+		},
+		{
+			name: "missing nonce",
+			args: []string{"derive-address", deployer.Hex()},
+			err:  fmt.Errorf("accepts 2 arg(s), received 1"),
+		},
+		{
+			name: "help",
+			args: []string{"derive-address", "--help"},
+			err:  nil,
+			out:  "Derives the address of the contract deployed by DEPLOYER_ADDRESS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Deployment status of a network, reported by plan when the network has an RPC endpoint
+const (
+	statusDeployed     = "deployed"
+	statusReady        = "ready"
+	statusNeedsFunding = "needs-funding"
+	// The deployer has sent another transaction, so the keyless transaction can no longer be sent
+	statusNonceUsed = "deployer-nonce-used"
+)
+
+var networksFile string
+
+// network is an entry of the networks file.
+type network struct {
+	Name string `json:"name"`
+	// RPC is an optional RPC endpoint used to check the deployer's balance and the deployment
+	RPC string `json:"rpc,omitempty"`
+	// GasPrice in wei of the keyless transaction on this network. Defaults to --gas-price.
+	GasPrice string `json:"gasPrice,omitempty"`
+	// GasLimit of the keyless transaction on this network. Defaults to --gas-limit.
+	GasLimit uint64 `json:"gasLimit,omitempty"`
+}
+
+type networkPlan struct {
+	keylessTxOutput
+	DeployerBalance *hexutil.Big `json:"deployerBalance,omitempty"`
+	MissingFunding  *hexutil.Big `json:"missingFunding,omitempty"`
+	Status          string       `json:"status,omitempty"`
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan ARTIFACT [--networks NETWORKS_FILE]",
+	Short: "Plans the keyless deployment of a contract on each network",
+	Long: `Prints, for each network, the keyless deployer address, the funding it requires
+(gas limit × gas price) and the resulting contract address of the keyless
+transaction deploying the contract in the foundry artifact ARTIFACT. Since the
+addresses depend on the gas limit and price, the contract is only deployed to the
+same address on networks using the same values.
+
+Networks are read from the JSON file --networks, containing a list of objects
+with a "name", and optionally a "gasPrice" in wei and a "gasLimit" overriding
+--gas-price and --gas-limit, and an "rpc" endpoint. If the RPC endpoint is set,
+the deployer's balance is read, and the status of the deployment is reported as
+one of:
+  - deployed: code is deployed at the contract address
+  - ready: the deployer is funded and the transaction can be sent
+  - needs-funding: the deployer needs missingFunding more to send the transaction
+  - deployer-nonce-used: the deployer has sent another transaction, so the
+    keyless transaction can no longer be sent
+Without --networks, a single plan is printed for --gas-price and --gas-limit.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		networks := []network{{}}
+		if networksFile != "" {
+			var err error
+			networks, err = readNetworks(networksFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		plans := make([]networkPlan, len(networks))
+		for i, n := range networks {
+			price, limit := n.GasPrice, n.GasLimit
+			if price == "" {
+				price = gasPrice
+			}
+			if limit == 0 {
+				limit = gasLimit
+			}
+			keylessTx, err := keylessTransaction(args[0], price, limit, deploymentUtils.KeylessTransactionOptions{})
+			if err != nil {
+				return errors.Wrapf(err, "failed to plan network %s", n.Name)
+			}
+			plans[i].keylessTxOutput = newKeylessTxOutput(keylessTx)
+			plans[i].Network = n.Name
+			plans[i].Transaction = nil
+			if n.RPC != "" {
+				if err := checkNetwork(context.Background(), n.RPC, &plans[i]); err != nil {
+					return errors.Wrapf(err, "failed to check network %s", n.Name)
+				}
+			}
+		}
+		return printJSON(cmd, plans)
+	},
+}
+
+func readNetworks(path string) ([]network, error) {
+	networksJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read networks file")
+	}
+	var networks []network
+	if err := json.Unmarshal(networksJSON, &networks); err != nil {
+		return nil, errors.Wrap(err, "failed to parse networks file")
+	}
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("no networks in %s", path)
+	}
+	for i, n := range networks {
+		if n.Name == "" {
+			return nil, fmt.Errorf("network %d has no name", i)
+		}
+	}
+	return networks, nil
+}
+
+// deploymentClient is the subset of an EVM client needed to check a deployment.
+type deploymentClient interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+func checkNetwork(ctx context.Context, rpc string, plan *networkPlan) error {
+	client, err := ethclient.Dial(rpc)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return checkDeployment(ctx, client, plan)
+}
+
+// checkDeployment sets the deployer balance and deployment status of plan from the network's state.
+func checkDeployment(ctx context.Context, client deploymentClient, plan *networkPlan) error {
+	code, err := client.CodeAt(ctx, plan.ContractAddress, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get contract code")
+	}
+	balance, err := client.BalanceAt(ctx, plan.DeployerAddress, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get deployer balance")
+	}
+	nonce, err := client.NonceAt(ctx, plan.DeployerAddress, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get deployer nonce")
+	}
+	plan.DeployerBalance = (*hexutil.Big)(balance)
+
+	required := plan.RequiredFunding.ToInt()
+	switch {
+	case len(code) > 0:
+		plan.Status = statusDeployed
+	case nonce > 0:
+		plan.Status = statusNonceUsed
+	case balance.Cmp(required) >= 0:
+		plan.Status = statusReady
+	default:
+		plan.Status = statusNeedsFunding
+		plan.MissingFunding = (*hexutil.Big)(new(big.Int).Sub(required, balance))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	addDeploymentFlags(planCmd)
+	planCmd.Flags().StringVar(&networksFile, "networks", "", "Path to a JSON file listing the networks to plan")
+}
@@ -0,0 +1,118 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type mockClient struct {
+	code    []byte
+	balance *big.Int
+	nonce   uint64
+}
+
+func (m *mockClient) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return m.code, nil
+}
+
+func (m *mockClient) BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error) {
+	return m.balance, nil
+}
+
+func (m *mockClient) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return m.nonce, nil
+}
+
+func TestPlanCmd(t *testing.T) {
+	networks := filepath.Join(t.TempDir(), "networks.json")
+	require.NoError(t, os.WriteFile(networks, []byte(`[
+		{"name": "a"},
+		{"name": "b", "gasPrice": "25000000000"},
+		{"name": "c", "gasLimit": 1000000}
+	]`), 0o600))
+
+	out, err := executeTestCmd(t, rootCmd, "plan", exampleArtifact, "--networks", networks, "--gas-price", "100000000000")
+	require.NoError(t, err)
+	var plans []networkPlan
+	require.NoError(t, json.Unmarshal([]byte(out), &plans))
+	require.Len(t, plans, 3)
+	require.Equal(t, "a", plans[0].Network)
+	require.Nil(t, plans[0].Transaction)
+	require.Equal(t, big.NewInt(4e17), plans[0].RequiredFunding.ToInt())
+	require.Equal(t, big.NewInt(1e17), plans[1].RequiredFunding.ToInt())
+	require.Equal(t, big.NewInt(1e17), plans[2].RequiredFunding.ToInt())
+	// Different gas values result in different deployers and contract addresses
+	require.NotEqual(t, plans[0].ContractAddress, plans[1].ContractAddress)
+	require.NotEqual(t, plans[0].ContractAddress, plans[2].ContractAddress)
+
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "default network",
+			args: []string{"plan", exampleArtifact},
+			out:  `"gasLimit": 4000000`,
+		},
+		{
+			name: "missing networks file",
+			args: []string{"plan", exampleArtifact, "--networks", "missing.json"},
+			err:  fmt.Errorf("failed to read networks file"),
+		},
+		{
+			name: "help",
+			args: []string{"plan", "--help"},
+			err:  nil,
+			out:  "Prints, for each network, the keyless deployer address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
+
+func TestCheckDeployment(t *testing.T) {
+	required := big.NewInt(100)
+	for _, tt := range []struct {
+		name    string
+		client  *mockClient
+		status  string
+		missing *big.Int
+	}{
+		{"deployed", &mockClient{code: []byte{1}, balance: big.NewInt(0), nonce: 1}, statusDeployed, nil},
+		{"nonce used", &mockClient{balance: big.NewInt(1000), nonce: 1}, statusNonceUsed, nil},
+		{"ready", &mockClient{balance: big.NewInt(100)}, statusReady, nil},
+		{"needs funding", &mockClient{balance: big.NewInt(40)}, statusNeedsFunding, big.NewInt(60)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := networkPlan{keylessTxOutput: keylessTxOutput{RequiredFunding: (*hexutil.Big)(required)}}
+			require.NoError(t, checkDeployment(context.Background(), tt.client, &plan))
+			require.Equal(t, tt.status, plan.Status)
+			require.Equal(t, (*hexutil.Big)(tt.client.balance), plan.DeployerBalance)
+			require.Equal(t, (*hexutil.Big)(tt.missing), plan.MissingFunding)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleArtifact = "../deployment-utils/testdata/Example.json"
+
+func executeTestCmd(t *testing.T, c *cobra.Command, args ...string) (string, error) {
+	// Flags are bound to package variables, so reset them to their defaults between runs
+	resetFlags(c)
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	c.SetArgs(args)
+
+	err := c.Execute()
+	return strings.TrimSpace(buf.String()), err
+}
+
+func resetFlags(c *cobra.Command) {
+	c.Flags().VisitAll(func(flag *pflag.Flag) {
+		_ = flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	})
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+func TestRootCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "base",
+			args: []string{},
+			err:  nil,
+			out:  "A CLI for deploying contracts to the same address on every chain",
+		},
+		{
+			name: "invalid",
+			args: []string{"invalid"},
+			err:  fmt.Errorf("unknown command"),
+		},
+		{
+			name: "help",
+			args: []string{"--help"},
+			err:  nil,
+			out:  "A CLI for deploying contracts to the same address on every chain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
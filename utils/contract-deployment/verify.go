@@ -0,0 +1,127 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	proxyUtils "github.com/ava-labs/icm-contracts/utils/proxy-utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rpcEndpoint     string
+	contractAddress string
+	ignoreMetadata  bool
+)
+
+type verification struct {
+	Network  string         `json:"network,omitempty"`
+	Address  common.Address `json:"address"`
+	Verified bool           `json:"verified"`
+	Error    string         `json:"error,omitempty"`
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify ARTIFACT (--rpc RPC_URL | --networks NETWORKS_FILE)",
+	Short: "Verifies the code deployed at a contract's universal address",
+	Long: `Verifies that the code deployed at the universal address of the contract in the
+foundry artifact ARTIFACT matches the artifact's deployedBytecode, ignoring
+immutable variables, and the metadata appended by solc if --ignore-metadata is set.
+
+The address is --address if set. Otherwise, it is the CREATE2 address if --salt
+is set, or the address of the keyless transaction deploying the contract with
+--constructor-args, --gas-limit and --gas-price. The code is read from --rpc, or
+from the RPC endpoint of each network of the networks file --networks, in the
+format used by plan. The results are printed as JSON, and the command fails if
+the code does not match on any network.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		networks := []network{{RPC: rpcEndpoint}}
+		if networksFile != "" {
+			var err error
+			networks, err = readNetworks(networksFile)
+			if err != nil {
+				return err
+			}
+		} else if rpcEndpoint == "" {
+			return fmt.Errorf("one of --rpc or --networks is required")
+		}
+
+		results := make([]verification, 0, len(networks))
+		var failed int
+		for _, n := range networks {
+			if n.RPC == "" {
+				return fmt.Errorf("network %s has no RPC endpoint", n.Name)
+			}
+			address, err := universalAddress(args[0], n)
+			if err != nil {
+				return err
+			}
+			result := verification{Network: n.Name, Address: address}
+			if err := verifyNetwork(context.Background(), n.RPC, address, args[0]); err != nil {
+				result.Error = err.Error()
+				failed++
+			} else {
+				result.Verified = true
+			}
+			results = append(results, result)
+		}
+		if err := printJSON(cmd, results); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to verify the contract on %d networks", failed)
+		}
+		return nil
+	},
+}
+
+// universalAddress returns the address the artifact is expected to be deployed to on network n.
+func universalAddress(artifactPath string, n network) (common.Address, error) {
+	switch {
+	case contractAddress != "":
+		if !common.IsHexAddress(contractAddress) {
+			return common.Address{}, fmt.Errorf("invalid contract address %s", contractAddress)
+		}
+		return common.HexToAddress(contractAddress), nil
+	case salt != "":
+		return create2Address(artifactPath)
+	}
+	price, limit := n.GasPrice, n.GasLimit
+	if price == "" {
+		price = gasPrice
+	}
+	if limit == 0 {
+		limit = gasLimit
+	}
+	keylessTx, err := keylessTransaction(artifactPath, price, limit, deploymentUtils.KeylessTransactionOptions{})
+	if err != nil {
+		return common.Address{}, err
+	}
+	return keylessTx.ContractAddress, nil
+}
+
+func verifyNetwork(ctx context.Context, rpc string, address common.Address, artifactPath string) error {
+	client, err := ethclient.Dial(rpc)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return proxyUtils.VerifyDeployedBytecode(ctx, client, address, artifactPath, ignoreMetadata)
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	addDeploymentFlags(verifyCmd)
+	verifyCmd.Flags().StringVar(&rpcEndpoint, "rpc", "", "RPC endpoint to read the deployed code from")
+	verifyCmd.Flags().StringVar(&networksFile, "networks", "", "Path to a JSON file listing the networks to verify")
+	verifyCmd.Flags().StringVar(&contractAddress, "address", "", "Address of the contract, instead of deriving it")
+	verifyCmd.Flags().StringVar(&salt, "salt", "", "Hex encoded CREATE2 salt the contract was deployed with")
+	verifyCmd.Flags().BoolVar(&ignoreMetadata, "ignore-metadata", false, "Ignore the metadata appended to the bytecode by solc")
+}
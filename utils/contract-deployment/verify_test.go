@@ -0,0 +1,54 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCmd(t *testing.T) {
+	var tests = []struct {
+		name string
+		args []string
+		err  error
+		out  string
+	}{
+		{
+			name: "missing rpc",
+			args: []string{"verify", exampleArtifact},
+			err:  fmt.Errorf("one of --rpc or --networks is required"),
+		},
+		{
+			name: "invalid address",
+			args: []string{"verify", exampleArtifact, "--rpc", "http://127.0.0.1:1", "--address", "0x1234"},
+			err:  fmt.Errorf("invalid contract address 0x1234"),
+		},
+		{
+			name: "unreachable rpc",
+			args: []string{"verify", exampleArtifact, "--rpc", "http://127.0.0.1:1"},
+			err:  fmt.Errorf("failed to verify the contract on 1 networks"),
+		},
+		{
+			name: "help",
+			args: []string{"verify", "--help"},
+			err:  nil,
+			out:  "Verifies that the code deployed at the universal address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeTestCmd(t, rootCmd, tt.args...)
+			if tt.err != nil {
+				require.ErrorContains(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Contains(t, out, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The deterministic deployment proxy (https://github.com/Arachnid/deterministic-deployment-proxy)
+// is a CREATE2 factory deployed to the same address on every chain using Nick's method. Calling it
+// with a 32 byte salt followed by creation bytecode deploys the contract with CREATE2, so the
+// contract address only depends on the salt and the creation bytecode, including constructor
+// arguments, and not on the sender or its nonce.
+//
+// The factory deployment transaction has a fixed gas price of 100 gwei, so it can only be sent on
+// chains whose base fee is at most 100 gwei.
+const create2FactoryDeploymentTxHex = "0xf8a58085174876e800830186a08080b853604580600e600039806000f350fe" +
+	"7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f5801515" +
+	"6039578182fd5b8082525050506014600cf31ba0222222222222222222222222222222222222222222222222222222222222" +
+	"2222a02222222222222222222222222222222222222222222222222222222222222222"
+
+var (
+	// Create2FactoryAddress is the address of the deterministic deployment proxy
+	Create2FactoryAddress = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+	// Create2FactoryDeployerAddress is the keyless address that sends the factory deployment
+	// transaction, which must be funded with Create2FactoryDeploymentFunding
+	Create2FactoryDeployerAddress = common.HexToAddress("0x3fAB184622Dc19b6109349B94811493BF2a45362")
+	// Create2FactoryDeploymentFunding is the gas limit of 100,000 times the gas price of 100 gwei
+	Create2FactoryDeploymentFunding = big.NewInt(1e16)
+)
+
+// Create2FactoryDeploymentTransaction returns the serialized keyless transaction deploying the
+// deterministic deployment proxy.
+func Create2FactoryDeploymentTransaction() []byte {
+	return common.FromHex(create2FactoryDeploymentTxHex)
+}
+
+// DeriveCreate2Address returns the address a contract with the given creation bytecode, including
+// constructor arguments, is deployed to by the deterministic deployment proxy with the given salt.
+func DeriveCreate2Address(salt common.Hash, creationCode []byte) common.Address {
+	return crypto.CreateAddress2(Create2FactoryAddress, salt, crypto.Keccak256(creationCode))
+}
+
+// PackCreate2Deployment returns the calldata deploying the given creation bytecode through the
+// deterministic deployment proxy with the given salt.
+func PackCreate2Deployment(salt common.Hash, creationCode []byte) []byte {
+	return append(salt.Bytes(), creationCode...)
+}
+
+// NewCreate2DeploymentTx returns an unsigned transaction deploying the given creation bytecode
+// through the deterministic deployment proxy with the given salt. The transaction reverts if
+// a contract is already deployed at the derived address.
+func NewCreate2DeploymentTx(
+	chainID *big.Int,
+	nonce uint64,
+	gasLimit uint64,
+	gasFeeCap *big.Int,
+	gasTipCap *big.Int,
+	salt common.Hash,
+	creationCode []byte,
+) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        &Create2FactoryAddress,
+		Gas:       gasLimit,
+		GasFeeCap: gasFeeCap,
+		GasTipCap: gasTipCap,
+		Value:     big.NewInt(0),
+		Data:      PackCreate2Deployment(salt, creationCode),
+	})
+}
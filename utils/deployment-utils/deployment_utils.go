@@ -6,10 +6,13 @@ package utils
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/fs"
 	"log"
 	"math/big"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/ava-labs/subnet-evm/core/types"
@@ -33,6 +36,9 @@ const (
 )
 
 var (
+	// Placeholder solc leaves for the address of a linked library
+	libraryPlaceholderPattern = regexp.MustCompile(`__\$[0-9a-fA-F]{34}\$__`)
+
 	vValue = big.NewInt(
 		27,
 	) // Must be less than 35 to be considered non-EIP155
@@ -62,6 +68,99 @@ func extractByteCode(byteCodeFileName string) (byteCodeFile, error) {
 	return byteCodeJSON, nil
 }
 
+// LoadCreationCode returns the creation bytecode of the contract in the given foundry
+// artifact, with the ABI encoded constructor arguments appended.
+func LoadCreationCode(byteCodeFileName string, constructorArgs []byte) ([]byte, error) {
+	byteCodeFile, err := extractByteCode(byteCodeFileName)
+	if err != nil {
+		return nil, err
+	}
+	return creationCode(byteCodeFile, constructorArgs)
+}
+
+// LoadLinkedCreationCode is LoadCreationCode for a contract linking a single library, such as the
+// ValidatorMessages library of the validator managers. The library placeholders of the bytecode are
+// replaced with the address of the library.
+func LoadLinkedCreationCode(
+	byteCodeFileName string,
+	library common.Address,
+	constructorArgs []byte,
+) ([]byte, error) {
+	byteCodeFile, err := extractByteCode(byteCodeFileName)
+	if err != nil {
+		return nil, err
+	}
+	byteCodeFile.ByteCode.Object = LinkLibrary(byteCodeFile.ByteCode.Object, library)
+	return creationCode(byteCodeFile, constructorArgs)
+}
+
+// HasLibraryPlaceholders reports whether the creation bytecode of the contract in the given
+// foundry artifact links libraries, which must be deployed before the contract.
+func HasLibraryPlaceholders(byteCodeFileName string) (bool, error) {
+	byteCodeFile, err := extractByteCode(byteCodeFileName)
+	if err != nil {
+		return false, err
+	}
+	return libraryPlaceholderPattern.MatchString(byteCodeFile.ByteCode.Object), nil
+}
+
+// LinkLibrary replaces the library placeholders left by solc in bytecode with the address of
+// the library. The bytecode must link a single library, as all placeholders are replaced.
+func LinkLibrary(bytecode string, library common.Address) string {
+	return libraryPlaceholderPattern.ReplaceAllString(bytecode, library.Hex()[2:])
+}
+
+func creationCode(byteCodeFile byteCodeFile, constructorArgs []byte) ([]byte, error) {
+	object := strings.TrimPrefix(byteCodeFile.ByteCode.Object, "0x")
+	if strings.Contains(object, "__$") {
+		return nil, errors.New("Bytecode contains unlinked library placeholders")
+	}
+	byteCode, err := hex.DecodeString(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode bytecode")
+	}
+	if len(byteCode) == 0 {
+		return nil, errors.New("Bytecode file contains no bytecode")
+	}
+	return append(byteCode, constructorArgs...), nil
+}
+
+// KeylessTransactionOptions configures a keyless contract creation transaction.
+type KeylessTransactionOptions struct {
+	// ConstructorArgs are the ABI encoded constructor arguments, appended to the bytecode
+	ConstructorArgs []byte
+	// GasLimit of the transaction. Defaults to 4,000,000, which is enough for TeleporterMessenger.
+	GasLimit uint64
+	// GasPrice of the transaction. Defaults to GetDefaultContractCreationGasPrice.
+	GasPrice *big.Int
+	// OutputDir is the directory the transaction, deployer address and contract address are
+	// written to. Nothing is written if it is empty.
+	OutputDir string
+	// ContractName names the written files Universal<ContractName>DeployerTransaction.txt,
+	// Universal<ContractName>DeployerAddress.txt and Universal<ContractName>ContractAddress.txt.
+	// If empty, the TeleporterMessenger file names are used.
+	ContractName string
+}
+
+// KeylessTransaction is a contract creation transaction signed using Nick's method, which
+// deploys the contract to the same address on every chain.
+type KeylessTransaction struct {
+	// Tx is the serialized transaction
+	Tx []byte
+	// DeployedByteCode is the hex encoded runtime bytecode of the contract
+	DeployedByteCode string
+	// DeployerAddress is the keyless address that sends the transaction, which must be funded
+	// with RequiredFunding before the transaction is sent
+	DeployerAddress common.Address
+	// ContractAddress is the address the contract is deployed to
+	ContractAddress common.Address
+	// GasLimit and GasPrice of the transaction
+	GasLimit uint64
+	GasPrice *big.Int
+	// RequiredFunding is the balance the deployer address needs to pay for the transaction
+	RequiredFunding *big.Int
+}
+
 // Constructs a keyless transaction using Nick's method
 // Optionally writes the transaction, deployer address, and contract address to file
 // Returns the transaction bytes, deployer address, and contract address
@@ -70,30 +169,69 @@ func ConstructKeylessTransaction(
 	writeFile bool,
 	contractCreationGasPrice *big.Int,
 ) ([]byte, string, common.Address, common.Address, error) {
-	// Convert the R and S values (which must be the same) from hex.
-	rsValue, ok := new(big.Int).SetString(rsValueHex, 16)
-	if !ok {
-		return nil, "", common.Address{}, common.Address{}, errors.New(
-			"Failed to convert R and S value to big.Int.",
-		)
+	opts := KeylessTransactionOptions{GasPrice: contractCreationGasPrice}
+	if writeFile {
+		opts.OutputDir = "."
 	}
-
-	byteCodeFile, err := extractByteCode(byteCodeFileName)
+	keylessTx, err := ConstructKeylessTransactionWithOptions(byteCodeFileName, opts)
 	if err != nil {
 		return nil, "", common.Address{}, common.Address{}, err
 	}
+	return keylessTx.Tx, keylessTx.DeployedByteCode, keylessTx.DeployerAddress, keylessTx.ContractAddress, nil
+}
 
-	// Parse the raw bytecode to be included in the deployment transaction.
-	byteCode, err := hex.DecodeString(strings.TrimPrefix(byteCodeFile.ByteCode.Object, "0x"))
+// ConstructKeylessTransactionWithOptions constructs a keyless transaction using Nick's method
+// deploying the contract in the given foundry artifact, configured by opts.
+func ConstructKeylessTransactionWithOptions(
+	byteCodeFileName string,
+	opts KeylessTransactionOptions,
+) (*KeylessTransaction, error) {
+	byteCodeFile, err := extractByteCode(byteCodeFileName)
 	if err != nil {
-		return nil, "", common.Address{}, common.Address{}, err
+		return nil, err
+	}
+	byteCode, err := creationCode(byteCodeFile, opts.ConstructorArgs)
+	if err != nil {
+		return nil, err
+	}
+	keylessTx, err := NewKeylessTransaction(byteCode, opts.GasLimit, opts.GasPrice)
+	if err != nil {
+		return nil, err
+	}
+	keylessTx.DeployedByteCode = byteCodeFile.DeployedByteCode.Object
+
+	log.Println(opts.contractName(), "Keyless Deployer Address: ", keylessTx.DeployerAddress.Hex())
+	log.Println(opts.contractName(), "Universal Contract Address: ", keylessTx.ContractAddress.Hex())
+
+	if opts.OutputDir != "" {
+		if err := keylessTx.writeFiles(opts.OutputDir, opts.ContractName); err != nil {
+			return nil, err
+		}
+	}
+	return keylessTx, nil
+}
+
+// NewKeylessTransaction constructs a keyless transaction using Nick's method deploying the given
+// creation bytecode. A zero gasLimit or nil gasPrice uses the defaults.
+func NewKeylessTransaction(byteCode []byte, gasLimit uint64, gasPrice *big.Int) (*KeylessTransaction, error) {
+	if gasLimit == 0 {
+		gasLimit = defaultContractCreationGasLimit
+	}
+	if gasPrice == nil {
+		gasPrice = GetDefaultContractCreationGasPrice()
+	}
+
+	// Convert the R and S values (which must be the same) from hex.
+	rsValue, ok := new(big.Int).SetString(rsValueHex, 16)
+	if !ok {
+		return nil, errors.New("Failed to convert R and S value to big.Int.")
 	}
 
 	// Construct the legacy transaction with pre-determined signature values.
 	contractCreationTx := types.NewTx(&types.LegacyTx{
 		Nonce:    0,
-		Gas:      defaultContractCreationGasLimit,
-		GasPrice: contractCreationGasPrice,
+		Gas:      gasLimit,
+		GasPrice: gasPrice,
 		To:       nil, // Contract creation transaction
 		Value:    big.NewInt(0),
 		Data:     byteCode,
@@ -105,64 +243,63 @@ func ConstructKeylessTransaction(
 	// Recover the "sender" address of the transaction.
 	senderAddress, err := types.HomesteadSigner{}.Sender(contractCreationTx)
 	if err != nil {
-		return nil, "", common.Address{}, common.Address{}, errors.Wrap(
-			err,
-			"Failed to recover the sender address of transaction",
-		)
+		return nil, errors.Wrap(err, "Failed to recover the sender address of transaction")
 	}
 
-	// Serialize the raw transaction and sender address.
+	// Serialize the raw transaction.
 	contractCreationTxBytes, err := contractCreationTx.MarshalBinary()
 	if err != nil {
-		return nil, "", common.Address{}, common.Address{}, errors.Wrap(
-			err,
-			"Failed to serialize raw transaction",
-		)
+		return nil, errors.Wrap(err, "Failed to serialize raw transaction")
 	}
-	contractCreationTxString := "0x" + hex.EncodeToString(contractCreationTxBytes)
-	senderAddressString := senderAddress.Hex() // "0x" prepended by Hex() already.
 
-	// Derive the resulting contract address given that it will be deployed from the sender address using the nonce of 0.
-	contractAddress := crypto.CreateAddress(senderAddress, 0)
-	contractAddressString := contractAddress.Hex() // "0x" prepended by Hex() already.
-
-	log.Println("TeleporterMessenger Keyless Deployer Address: ", senderAddressString)
-	log.Println("TeleporterMessenger Universal Contract Address: ", contractAddressString)
+	return &KeylessTransaction{
+		Tx:              contractCreationTxBytes,
+		DeployerAddress: senderAddress,
+		// Derive the resulting contract address given that it will be deployed from the sender address using the nonce of 0.
+		ContractAddress: crypto.CreateAddress(senderAddress, 0),
+		GasLimit:        gasLimit,
+		GasPrice:        gasPrice,
+		RequiredFunding: new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit)),
+	}, nil
+}
 
-	if writeFile {
-		err = os.WriteFile(
-			contractCreationTxFileName,
-			[]byte(contractCreationTxString),
-			fs.ModePerm,
-		)
-		if err != nil {
-			return nil, "", common.Address{}, common.Address{}, errors.Wrap(
-				err,
-				"Failed to write to contract creation tx file",
-			)
-		}
+func (opts KeylessTransactionOptions) contractName() string {
+	if opts.ContractName == "" {
+		return "TeleporterMessenger"
+	}
+	return opts.ContractName
+}
 
-		err = os.WriteFile(contractCreationAddrFileName, []byte(senderAddressString), fs.ModePerm)
-		if err != nil {
-			return nil, "", common.Address{}, common.Address{}, errors.Wrap(
-				err,
-				"Failed to write to deployer address file",
-			)
-		}
+func (k *KeylessTransaction) writeFiles(outputDir string, contractName string) error {
+	txFileName := contractCreationTxFileName
+	deployerFileName := contractCreationAddrFileName
+	contractFileName := universalContractAddressFileName
+	if contractName != "" {
+		txFileName = fmt.Sprintf("Universal%sDeployerTransaction.txt", contractName)
+		deployerFileName = fmt.Sprintf("Universal%sDeployerAddress.txt", contractName)
+		contractFileName = fmt.Sprintf("Universal%sContractAddress.txt", contractName)
+	}
 
-		err = os.WriteFile(
-			universalContractAddressFileName,
-			[]byte(contractAddressString),
-			fs.ModePerm,
-		)
+	if err := os.MkdirAll(outputDir, fs.ModePerm); err != nil {
+		return errors.Wrap(err, "Failed to create output directory")
+	}
+	// "0x" prepended by Hex() already.
+	files := []struct {
+		name     string
+		contents string
+		desc     string
+	}{
+		{txFileName, "0x" + hex.EncodeToString(k.Tx), "contract creation tx"},
+		{deployerFileName, k.DeployerAddress.Hex(), "deployer address"},
+		{contractFileName, k.ContractAddress.Hex(), "contract address"},
+	}
+	for _, file := range files {
+		err := os.WriteFile(filepath.Join(outputDir, file.name), []byte(file.contents), fs.ModePerm)
 		if err != nil {
-			return nil, "", common.Address{}, common.Address{}, errors.Wrap(
-				err,
-				"Failed to write to contract address",
-			)
+			return errors.Wrapf(err, "Failed to write to %s file", file.desc)
 		}
 	}
-	return contractCreationTxBytes, byteCodeFile.DeployedByteCode.Object, senderAddress, contractAddress, nil
+	return nil
 }
 
 func GetDefaultContractCreationGasPrice() *big.Int {
@@ -2,11 +2,33 @@ package utils
 
 import (
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 )
 
+const (
+	exampleByteCodeFile  = "testdata/Example.json"
+	unlinkedByteCodeFile = "testdata/Unlinked.json"
+)
+
+var (
+	exampleCreationCode = common.FromHex("0x600a600c600039600a6000f3602a60005260206000f3")
+	exampleRuntimeCode  = common.FromHex("0x602a60005260206000f3")
+)
+
 func TestGetDefaultContractCreationGasPrice(t *testing.T) {
 	gasPrice := GetDefaultContractCreationGasPrice()
 	require.Equal(t, defaultContractCreationGasPrice, gasPrice)
@@ -21,3 +43,151 @@ func TestGetDefaultContractCreationGasPrice(t *testing.T) {
 	gasPrice = GetDefaultContractCreationGasPrice()
 	require.Equal(t, newDefaultGasPrice, gasPrice)
 }
+
+func TestConstructKeylessTransactionWithOptions(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "out")
+	constructorArgs := common.LeftPadBytes([]byte{1}, common.HashLength)
+	keylessTx, err := ConstructKeylessTransactionWithOptions(exampleByteCodeFile, KeylessTransactionOptions{
+		ConstructorArgs: constructorArgs,
+		GasLimit:        100_000,
+		GasPrice:        big.NewInt(100e9),
+		OutputDir:       outputDir,
+		ContractName:    "Example",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0x602a60005260206000f3", keylessTx.DeployedByteCode)
+	require.Equal(t, big.NewInt(1e16), keylessTx.RequiredFunding)
+
+	var tx types.Transaction
+	require.NoError(t, tx.UnmarshalBinary(keylessTx.Tx))
+	require.Nil(t, tx.To())
+	require.Equal(t, uint64(100_000), tx.Gas())
+	require.Equal(t, big.NewInt(100e9), tx.GasPrice())
+	require.Equal(t, append(exampleCreationCode, constructorArgs...), tx.Data())
+	sender, err := types.HomesteadSigner{}.Sender(&tx)
+	require.NoError(t, err)
+	require.Equal(t, keylessTx.DeployerAddress, sender)
+	require.Equal(t, crypto.CreateAddress(sender, 0), keylessTx.ContractAddress)
+
+	for fileName, expected := range map[string]string{
+		"UniversalExampleDeployerTransaction.txt": hexutilEncode(keylessTx.Tx),
+		"UniversalExampleDeployerAddress.txt":     keylessTx.DeployerAddress.Hex(),
+		"UniversalExampleContractAddress.txt":     keylessTx.ContractAddress.Hex(),
+	} {
+		contents, err := os.ReadFile(filepath.Join(outputDir, fileName))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(contents))
+	}
+
+	// The defaults and TeleporterMessenger file names are used if not set, and changing
+	// the constructor arguments changes the contract address
+	keylessTx2, err := ConstructKeylessTransactionWithOptions(exampleByteCodeFile, KeylessTransactionOptions{
+		OutputDir: outputDir,
+	})
+	require.NoError(t, err)
+	require.NoError(t, tx.UnmarshalBinary(keylessTx2.Tx))
+	require.Equal(t, defaultContractCreationGasLimit, tx.Gas())
+	require.Equal(t, GetDefaultContractCreationGasPrice(), tx.GasPrice())
+	require.NotEqual(t, keylessTx.ContractAddress, keylessTx2.ContractAddress)
+	for _, fileName := range []string{
+		contractCreationTxFileName,
+		contractCreationAddrFileName,
+		universalContractAddressFileName,
+	} {
+		require.FileExists(t, filepath.Join(outputDir, fileName))
+	}
+
+	_, err = LoadCreationCode(unlinkedByteCodeFile, nil)
+	require.ErrorContains(t, err, "unlinked library placeholders")
+}
+
+func TestLoadLinkedCreationCode(t *testing.T) {
+	linked, err := HasLibraryPlaceholders(unlinkedByteCodeFile)
+	require.NoError(t, err)
+	require.True(t, linked)
+	linked, err = HasLibraryPlaceholders(exampleByteCodeFile)
+	require.NoError(t, err)
+	require.False(t, linked)
+
+	library := common.HexToAddress("0x00000000000000000000000000000000000000AB")
+	creationCode, err := LoadLinkedCreationCode(unlinkedByteCodeFile, library, []byte{1})
+	require.NoError(t, err)
+	expected := append(common.FromHex("0x6080"), library.Bytes()...)
+	expected = append(expected, common.FromHex("0x6000f3")...)
+	require.Equal(t, append(expected, 1), creationCode)
+}
+
+func TestCreate2Factory(t *testing.T) {
+	var tx types.Transaction
+	require.NoError(t, tx.UnmarshalBinary(Create2FactoryDeploymentTransaction()))
+	deployer, err := types.HomesteadSigner{}.Sender(&tx)
+	require.NoError(t, err)
+	require.Equal(t, Create2FactoryDeployerAddress, deployer)
+	require.Equal(t, Create2FactoryAddress, crypto.CreateAddress(deployer, 0))
+	require.Equal(t, Create2FactoryDeploymentFunding, new(big.Int).Mul(tx.GasPrice(), new(big.Int).SetUint64(tx.Gas())))
+
+	creationCode, err := LoadCreationCode(exampleByteCodeFile, nil)
+	require.NoError(t, err)
+	require.Equal(t, exampleCreationCode, creationCode)
+
+	salt := common.HexToHash("0x01")
+	deployTx := NewCreate2DeploymentTx(big.NewInt(1), 0, 100_000, big.NewInt(1), big.NewInt(1), salt, creationCode)
+	require.Equal(t, Create2FactoryAddress, *deployTx.To())
+	require.Equal(t, append(salt.Bytes(), creationCode...), deployTx.Data())
+
+	address := DeriveCreate2Address(salt, creationCode)
+	require.NotEqual(t, address, DeriveCreate2Address(common.Hash{}, creationCode))
+	require.NotEqual(t, address, DeriveCreate2Address(salt, append(creationCode, 0)))
+
+	// Deploy the factory with its keyless transaction, and the example contract through it
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.AddBalance(Create2FactoryDeployerAddress, uint256.MustFromBig(Create2FactoryDeploymentFunding))
+	applyMessage(t, statedb, Create2FactoryDeployerAddress, nil, tx.Gas(), tx.Data())
+	require.NotEmpty(t, statedb.GetCode(Create2FactoryAddress))
+
+	sender := common.HexToAddress("0x0000000000000000000000000000000000001111")
+	result := applyMessage(t, statedb, sender, deployTx.To(), deployTx.Gas(), deployTx.Data())
+	require.Equal(t, address, common.BytesToAddress(result))
+	require.Equal(t, exampleRuntimeCode, statedb.GetCode(address))
+}
+
+func applyMessage(
+	t *testing.T,
+	statedb *state.StateDB,
+	from common.Address,
+	to *common.Address,
+	gasLimit uint64,
+	data []byte,
+) []byte {
+	msg := &core.Message{
+		From:              from,
+		To:                to,
+		Value:             big.NewInt(0),
+		GasLimit:          gasLimit,
+		GasPrice:          big.NewInt(0),
+		GasFeeCap:         big.NewInt(0),
+		GasTipCap:         big.NewInt(0),
+		Data:              data,
+		SkipAccountChecks: true,
+	}
+	blockContext := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		BlockNumber: big.NewInt(1),
+		Time:        uint64(upgrade.InitiallyActiveTime.Unix()),
+		GasLimit:    gasLimit,
+		Difficulty:  big.NewInt(0),
+		BaseFee:     big.NewInt(0),
+	}
+	evm := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), statedb, params.TestChainConfig, vm.Config{NoBaseFee: true})
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(gasLimit))
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	return result.ReturnData
+}
+
+func hexutilEncode(b []byte) string {
+	return "0x" + common.Bytes2Hex(b)
+}
@@ -0,0 +1,1196 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	erc20tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHome"
+	erc20tokenremote "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenRemote/ERC20TokenRemote"
+	exampleerc20 "github.com/ava-labs/icm-contracts/abi-bindings/go/mocks/ExampleERC20"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
+	erc20tokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ERC20TokenStakingManager"
+	examplerewardcalculator "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ExampleRewardCalculator"
+	nativetokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/NativeTokenStakingManager"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/allowlist"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/predicate"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ava-labs/subnet-evm/warp/messages"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Storage slot of TeleporterMessenger's sentMessageInfo mapping
+	sentMessageInfoSlot = 5
+
+	calibrationGasCap         uint64 = 30_000_000
+	calibrationRequiredGas    uint64 = 100_000
+	calibrationNetworkID             = 1
+	calibrationSentMessageFee        = 1_000
+	// Gas limit of the messages delivered to set up the state of calibrations
+	calibrationDeliveryGas    uint64 = 1_000_000
+	calibrationTransferAmount        = 1e18
+)
+
+var (
+	// Receivers of Teleporter messages. The no-op receiver returns immediately, and the
+	// exhausting receiver loops until it runs out of gas, so the message is stored as failed.
+	noopReceiverCode       = []byte{0x00}                   // STOP
+	exhaustingReceiverCode = []byte{0x5b, 0x60, 0x00, 0x56} // JUMPDEST PUSH1 0 JUMP
+
+	// Receiver of the messages and token transfers sent by calibrations, with the no-op code
+	calibrationReceiver = common.HexToAddress("0x0000000000000000000000000000000000002222")
+	// Supply of the TokenRemote minted to the relayer
+	calibrationTokenSupply = new(big.Int).Mul(big.NewInt(calibrationTransferAmount), big.NewInt(1_000_000))
+)
+
+// calibrationChain is an in-memory subnet-evm state with TeleporterMessenger deployed. The Warp
+// precompile is enabled, and since no predicate results are set, all Warp messages included in
+// transactions are treated as verified, without checking their signatures.
+type calibrationChain struct {
+	config        *params.ChainConfig
+	state         *state.StateDB
+	teleporterABI *abi.ABI
+	blockchainID  ids.ID
+	sourceChainID ids.ID
+	relayer       common.Address
+	teleporter    common.Address
+	// Nonce of the next message delivered by deliver, above the nonces used by receiveMessage
+	nextNonce int64
+	// Hash of the last applied message, whose logs are read by executed
+	txHash  common.Hash
+	txCount int64
+	// Timestamp of the block messages are applied in
+	timestamp uint64
+}
+
+func newCalibrationChain(t *testing.T) *calibrationChain {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewDefaultConfig(subnetEvmUtils.NewUint64(0)),
+	}
+	c := &calibrationChain{
+		config:        &config,
+		state:         statedb,
+		teleporterABI: teleporterABI,
+		blockchainID:  ids.GenerateTestID(),
+		sourceChainID: ids.GenerateTestID(),
+		relayer:       common.HexToAddress("0x0000000000000000000000000000000000001111"),
+		nextNonce:     1_000,
+		timestamp:     uint64(upgrade.InitiallyActiveTime.Unix()),
+	}
+	c.config.SnowCtx = &snow.Context{NetworkID: calibrationNetworkID, ChainID: c.blockchainID}
+	c.state.AddBalance(c.relayer, uint256.NewInt(1e18))
+
+	c.teleporter = crypto.CreateAddress(c.relayer, c.state.GetNonce(c.relayer))
+	result, err := c.apply(nil, common.FromHex(teleportermessenger.TeleporterMessengerMetaData.Bin), calibrationGasCap, nil)
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	require.NotEmpty(t, c.state.GetCode(c.teleporter))
+	return c
+}
+
+func (c *calibrationChain) apply(
+	to *common.Address,
+	data []byte,
+	gasLimit uint64,
+	accessList types.AccessList,
+) (*core.ExecutionResult, error) {
+	return c.applyValue(to, big.NewInt(0), data, gasLimit, accessList)
+}
+
+// applyValue applies a message sending value from the relayer.
+func (c *calibrationChain) applyValue(
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+	gasLimit uint64,
+	accessList types.AccessList,
+) (*core.ExecutionResult, error) {
+	msg := &core.Message{
+		To:                to,
+		From:              c.relayer,
+		Value:             value,
+		GasLimit:          gasLimit,
+		GasPrice:          big.NewInt(0),
+		GasFeeCap:         big.NewInt(0),
+		GasTipCap:         big.NewInt(0),
+		Data:              data,
+		AccessList:        accessList,
+		SkipAccountChecks: true,
+	}
+	blockContext := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		BlockNumber: big.NewInt(1),
+		Time:        c.timestamp,
+		GasLimit:    calibrationGasCap,
+		Difficulty:  big.NewInt(0),
+		BaseFee:     big.NewInt(0),
+	}
+	c.txCount++
+	c.txHash = common.BigToHash(big.NewInt(c.txCount))
+	c.state.SetTxContext(c.txHash, 0)
+	evm := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), c.state, c.config, vm.Config{NoBaseFee: true})
+	return core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(calibrationGasCap))
+}
+
+// receiveMessage builds a receiveCrossChainMessage call delivering a Teleporter message with the
+// given message size and number of receipts, signed by numSigners validators. The messages
+// acknowledged by the receipts are stored as sent with a fee, so that marking them pays rewards.
+// It returns the calldata, the access list, and the Warp and Teleporter message sizes.
+func (c *calibrationChain) receiveMessage(
+	t *testing.T,
+	receiver common.Address,
+	messageSize int,
+	numReceipts int,
+	numSigners int,
+) ([]byte, types.AccessList, int, int) {
+	receipts := make([]teleportermessenger.TeleporterMessageReceipt, numReceipts)
+	for i := range receipts {
+		receipts[i] = teleportermessenger.TeleporterMessageReceipt{
+			ReceivedMessageNonce: big.NewInt(int64(i + 1)),
+			RelayerRewardAddress: common.BigToAddress(big.NewInt(int64(0x2000 + i))),
+		}
+		messageID := crypto.Keccak256Hash(
+			common.LeftPadBytes(c.teleporter.Bytes(), 32),
+			c.blockchainID[:],
+			c.sourceChainID[:],
+			common.BigToHash(receipts[i].ReceivedMessageNonce).Bytes(),
+		)
+		infoSlot := new(big.Int).SetBytes(crypto.Keccak256(messageID[:], common.BigToHash(big.NewInt(sentMessageInfoSlot)).Bytes()))
+		c.state.SetState(c.teleporter, common.BigToHash(infoSlot), crypto.Keccak256Hash(messageID[:]))
+		c.state.SetState(c.teleporter, common.BigToHash(infoSlot.Add(infoSlot, big.NewInt(1))), common.BytesToHash(receiver.Bytes()))
+		c.state.SetState(c.teleporter, common.BigToHash(infoSlot.Add(infoSlot, big.NewInt(1))), common.BigToHash(big.NewInt(calibrationSentMessageFee)))
+	}
+	message := make([]byte, messageSize)
+	for i := range message {
+		message[i] = 0xff
+	}
+	return c.delivery(t, teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     c.relayer,
+		DestinationBlockchainID: c.blockchainID,
+		DestinationAddress:      receiver,
+		RequiredGasLimit:        new(big.Int).SetUint64(calibrationRequiredGas),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                receipts,
+		Message:                 message,
+	}, numSigners)
+}
+
+// delivery builds a receiveCrossChainMessage call delivering teleporterMessage from the source
+// chain, signed by numSigners validators. It returns the calldata, the access list, and the Warp
+// and Teleporter message sizes.
+func (c *calibrationChain) delivery(
+	t *testing.T,
+	message teleportermessenger.TeleporterMessage,
+	numSigners int,
+) ([]byte, types.AccessList, int, int) {
+	teleporterMessage, err := c.teleporterABI.Methods["retrySendCrossChainMessage"].Inputs.Pack(message)
+	require.NoError(t, err)
+	accessList, warpMessageSize := c.warpAccessList(t, c.sourceChainID, c.teleporter.Bytes(), teleporterMessage, numSigners)
+
+	calldata, err := c.teleporterABI.Pack("receiveCrossChainMessage", uint32(0), c.relayer)
+	require.NoError(t, err)
+	return calldata, accessList, warpMessageSize, len(teleporterMessage)
+}
+
+// warpAccessList returns the access list delivering the Warp message sent by sourceAddress on
+// the chain sourceChainID with the given payload, signed by numSigners validators, and the size
+// of the signed message.
+func (c *calibrationChain) warpAccessList(
+	t *testing.T,
+	sourceChainID ids.ID,
+	sourceAddress []byte,
+	payload []byte,
+	numSigners int,
+) (types.AccessList, int) {
+	addressedCall, err := warpPayload.NewAddressedCall(sourceAddress, payload)
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(calibrationNetworkID, sourceChainID, addressedCall.Bytes())
+	require.NoError(t, err)
+	signers := set.NewBits()
+	for i := 0; i < numSigners; i++ {
+		signers.Add(i)
+	}
+	signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{Signers: signers.Bytes()})
+	require.NoError(t, err)
+	return types.AccessList{{
+		Address:     warp.ContractAddress,
+		StorageKeys: subnetEvmUtils.BytesToHashSlice(predicate.PackPredicate(signedMessage.Bytes())),
+	}}, len(signedMessage.Bytes())
+}
+
+// requiredGasLimit returns the lowest gas limit for which the call of TeleporterMessenger succeeds.
+func (c *calibrationChain) requiredGasLimit(t *testing.T, calldata []byte, accessList types.AccessList) uint64 {
+	return c.requiredGasLimitAt(t, c.teleporter, calldata, accessList)
+}
+
+// requiredGasLimitAt returns the lowest gas limit for which the call of to succeeds, found by
+// binary search. Unlike the gas used, this accounts for gas that is refunded or withheld from
+// sub-calls. The state is left unchanged.
+func (c *calibrationChain) requiredGasLimitAt(
+	t *testing.T,
+	to common.Address,
+	calldata []byte,
+	accessList types.AccessList,
+) uint64 {
+	succeeds := func(gasLimit uint64) bool {
+		snapshot := c.state.Snapshot()
+		defer c.state.RevertToSnapshot(snapshot)
+		result, err := c.apply(&to, calldata, gasLimit, accessList)
+		return err == nil && !result.Failed()
+	}
+	if !succeeds(calibrationGasCap) {
+		snapshot := c.state.Snapshot()
+		result, err := c.apply(&to, calldata, calibrationGasCap, accessList)
+		c.state.RevertToSnapshot(snapshot)
+		require.NoError(t, err)
+		require.FailNow(t, "call failed", "%s: %s", result.Err, revertReason(result))
+	}
+	low, high := uint64(0), calibrationGasCap
+	for low+1 < high {
+		mid := (low + high) / 2
+		if succeeds(mid) {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return high
+}
+
+// TestCalibrateReceiveMessageGasLimit checks that CalculateReceiveMessageGasLimit never
+// under-estimates the gas needed to deliver a message with the compiled TeleporterMessenger.
+// Run with -v to print the overestimate margin of each case.
+func TestCalibrateReceiveMessageGasLimit(t *testing.T) {
+	c := newCalibrationChain(t)
+	receivers := map[string][]byte{
+		"noop":       noopReceiverCode,
+		"exhausting": exhaustingReceiverCode,
+	}
+	cal := &calibration{t: t}
+	for name, code := range receivers {
+		receiver := crypto.Keccak256Hash([]byte(name)).Bytes()
+		receiverAddress := common.BytesToAddress(receiver)
+		c.state.SetCode(receiverAddress, code)
+		for _, messageSize := range []int{1, 100, 1_000, 10_000} {
+			for _, numReceipts := range []int{0, 1, MaxReceiptsPerMessage} {
+				for _, numSigners := range []int{1, 10, 100} {
+					calldata, accessList, warpMessageSize, teleporterMessageSize := c.receiveMessage(
+						t, receiverAddress, messageSize, numReceipts, numSigners,
+					)
+					required := c.requiredGasLimit(t, calldata, accessList)
+					estimate, err := CalculateReceiveMessageGasLimit(
+						numSigners,
+						new(big.Int).SetUint64(calibrationRequiredGas),
+						warpMessageSize,
+						teleporterMessageSize,
+						numReceipts,
+					)
+					require.NoError(t, err)
+
+					cal.check(
+						fmt.Sprintf("receiver=%s message=%d receipts=%d signers=%d", name, messageSize, numReceipts, numSigners),
+						estimate,
+						required,
+					)
+				}
+			}
+		}
+	}
+	cal.done()
+}
+
+// calibration tracks the smallest overestimate margin of the cases of a calibration test.
+type calibration struct {
+	t         *testing.T
+	minMargin float64
+	checked   bool
+}
+
+// check requires estimate to be at least the required gas limit of a case.
+func (c *calibration) check(caseName string, estimate uint64, required uint64) {
+	margin := float64(estimate)/float64(required) - 1
+	c.t.Logf("%s: required %d, estimated %d, margin %.1f%%", caseName, required, estimate, margin*100)
+	require.GreaterOrEqual(c.t, estimate, required, caseName)
+	if !c.checked || margin < c.minMargin {
+		c.minMargin = margin
+		c.checked = true
+	}
+}
+
+func (c *calibration) done() {
+	c.t.Logf("minimum margin %.1f%%", c.minMargin*100)
+}
+
+func revertReason(result *core.ExecutionResult) string {
+	reason, err := abi.UnpackRevert(result.Revert())
+	if err != nil {
+		return ""
+	}
+	return reason
+}
+
+// checkpoint returns a function restoring the current state. Unlike a snapshot, it can be restored
+// after applying several messages, whose access lists are not journaled.
+func (c *calibrationChain) checkpoint() func() {
+	saved := c.state.Copy()
+	return func() {
+		c.state = saved
+	}
+}
+
+// mustApply applies a message with the maximum gas limit, and requires it to succeed.
+func (c *calibrationChain) mustApply(t *testing.T, to *common.Address, data []byte, accessList types.AccessList) {
+	result, err := c.apply(to, data, calibrationGasCap, accessList)
+	require.NoError(t, err)
+	require.NoError(t, result.Err, revertReason(result))
+}
+
+// deploy deploys the contract with the given bytecode, with constructor arguments encoded by
+// contractABI.
+func (c *calibrationChain) deploy(t *testing.T, bin string, contractABI *abi.ABI, args ...interface{}) common.Address {
+	constructorArgs, err := contractABI.Pack("", args...)
+	require.NoError(t, err)
+	address := crypto.CreateAddress(c.relayer, c.state.GetNonce(c.relayer))
+	c.mustApply(t, nil, append(common.FromHex(bin), constructorArgs...), nil)
+	require.NotEmpty(t, c.state.GetCode(address))
+	return address
+}
+
+// transact calls method of the contract at to, and requires the call to succeed.
+func (c *calibrationChain) transact(t *testing.T, to common.Address, contractABI *abi.ABI, method string, args ...interface{}) {
+	calldata, err := contractABI.Pack(method, args...)
+	require.NoError(t, err)
+	c.mustApply(t, &to, calldata, nil)
+}
+
+// call returns the outputs of method of the contract at to, without changing the state.
+func (c *calibrationChain) call(
+	t *testing.T,
+	to common.Address,
+	contractABI *abi.ABI,
+	method string,
+	args ...interface{},
+) []interface{} {
+	calldata, err := contractABI.Pack(method, args...)
+	require.NoError(t, err)
+	snapshot := c.state.Snapshot()
+	defer c.state.RevertToSnapshot(snapshot)
+	result, err := c.apply(&to, calldata, calibrationGasCap, nil)
+	require.NoError(t, err)
+	require.NoError(t, result.Err, revertReason(result))
+	outputs, err := contractABI.Unpack(method, result.ReturnData)
+	require.NoError(t, err)
+	return outputs
+}
+
+// deliver delivers a Teleporter message from originSender on the source chain to destination, and
+// requires its execution to succeed, unless the message is empty.
+func (c *calibrationChain) deliver(t *testing.T, originSender common.Address, destination common.Address, message []byte) {
+	c.nextNonce++
+	calldata, accessList, _, _ := c.delivery(t, teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(c.nextNonce),
+		OriginSenderAddress:     originSender,
+		DestinationBlockchainID: c.blockchainID,
+		DestinationAddress:      destination,
+		RequiredGasLimit:        new(big.Int).SetUint64(calibrationDeliveryGas),
+		AllowedRelayerAddresses: []common.Address{},
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{},
+		Message:                 message,
+	}, 1)
+	c.mustApply(t, &c.teleporter, calldata, accessList)
+	// Empty messages are only received, without calling their destination.
+	if len(message) == 0 {
+		return
+	}
+	executed := c.teleporterABI.Events["MessageExecuted"].ID
+	for _, log := range c.state.GetLogs(c.txHash, 1, common.Hash{}) {
+		if log.Topics[0] == executed {
+			return
+		}
+	}
+	require.FailNow(t, "execution of delivered message failed")
+}
+
+// sendInput returns the input of sendCrossChainMessage sending a message of messageSize bytes to
+// the source chain, with a fee of fee feeToken.
+func (c *calibrationChain) sendInput(
+	numAllowedRelayers int,
+	messageSize int,
+	feeToken common.Address,
+	fee int64,
+) teleportermessenger.TeleporterMessageInput {
+	allowedRelayers := make([]common.Address, numAllowedRelayers)
+	for i := range allowedRelayers {
+		allowedRelayers[i] = common.BigToAddress(big.NewInt(int64(0x3000 + i)))
+	}
+	message := make([]byte, messageSize)
+	for i := range message {
+		message[i] = 0xff
+	}
+	return teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: c.sourceChainID,
+		DestinationAddress:      calibrationReceiver,
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: feeToken,
+			Amount:          big.NewInt(fee),
+		},
+		RequiredGasLimit:        new(big.Int).SetUint64(calibrationRequiredGas),
+		AllowedRelayerAddresses: allowedRelayers,
+		Message:                 message,
+	}
+}
+
+// setReceiptQueue empties the queue of receipts of messages received from the source chain, which
+// are attached to the messages sent to it, then fills it with numReceipts receipts.
+func (c *calibrationChain) setReceiptQueue(t *testing.T, numReceipts int) {
+	queueSize := func() int {
+		return int(c.call(t, c.teleporter, c.teleporterABI, "getReceiptQueueSize", c.sourceChainID)[0].(*big.Int).Int64())
+	}
+	for queueSize() > 0 {
+		c.transact(t, c.teleporter, c.teleporterABI, "sendCrossChainMessage", c.sendInput(0, 1, common.Address{}, 0))
+	}
+	for i := 0; i < numReceipts; i++ {
+		c.deliver(t, c.relayer, calibrationReceiver, []byte{})
+	}
+	require.Equal(t, numReceipts, queueSize())
+}
+
+// deployToken deploys an ExampleERC20, which mints its supply to the relayer, and approves
+// spenders to spend it.
+func (c *calibrationChain) deployToken(t *testing.T, spenders ...common.Address) (common.Address, *abi.ABI) {
+	tokenABI, err := exampleerc20.ExampleERC20MetaData.GetAbi()
+	require.NoError(t, err)
+	token := c.deploy(t, exampleerc20.ExampleERC20MetaData.Bin, tokenABI)
+	for _, spender := range spenders {
+		c.transact(t, token, tokenABI, "approve", spender, abi.MaxUint256)
+	}
+	return token, tokenABI
+}
+
+// TestCalibrateSendMessageGasLimit checks that CalculateSendMessageGasLimit never under-estimates
+// the gas needed to send a message with the compiled TeleporterMessenger.
+// Run with -v to print the overestimate margin of each case.
+func TestCalibrateSendMessageGasLimit(t *testing.T) {
+	c := newCalibrationChain(t)
+	feeToken, _ := c.deployToken(t, c.teleporter)
+	cal := &calibration{t: t}
+	for _, numReceipts := range []int{0, 1, MaxReceiptsPerMessage} {
+		restore := c.checkpoint()
+		c.setReceiptQueue(t, numReceipts)
+		for _, numAllowedRelayers := range []int{0, 1, 5} {
+			for _, messageSize := range []int{1, 100, 1_000, 10_000} {
+				for _, payFee := range []bool{false, true} {
+					var fee int64
+					if payFee {
+						fee = calibrationSentMessageFee
+					}
+					calldata, err := c.teleporterABI.Pack(
+						"sendCrossChainMessage",
+						c.sendInput(numAllowedRelayers, messageSize, feeToken, fee),
+					)
+					require.NoError(t, err)
+					required := c.requiredGasLimit(t, calldata, nil)
+					estimate, err := CalculateSendMessageGasLimit(numAllowedRelayers, numReceipts, messageSize, payFee)
+					require.NoError(t, err)
+					cal.check(
+						fmt.Sprintf("receipts=%d relayers=%d message=%d fee=%t", numReceipts, numAllowedRelayers, messageSize, payFee),
+						estimate,
+						required,
+					)
+				}
+			}
+		}
+		restore()
+	}
+	cal.done()
+}
+
+// TestCalibrateAddFeeAmountGasLimit checks that CalculateAddFeeAmountGasLimit never
+// under-estimates the gas needed to add to the fee of a sent message, including messages sent
+// without a fee, whose fee amount and balance of TeleporterMessenger are set from zero.
+func TestCalibrateAddFeeAmountGasLimit(t *testing.T) {
+	c := newCalibrationChain(t)
+	feeToken, _ := c.deployToken(t, c.teleporter)
+	cal := &calibration{t: t}
+	c.transact(t, c.teleporter, c.teleporterABI, "initializeBlockchainID")
+	for _, initialFee := range []int64{0, calibrationSentMessageFee} {
+		restore := c.checkpoint()
+		messageID := c.call(t, c.teleporter, c.teleporterABI, "getNextMessageID", c.sourceChainID)[0].([32]byte)
+		c.transact(t, c.teleporter, c.teleporterABI, "sendCrossChainMessage", c.sendInput(0, 1, feeToken, initialFee))
+		calldata, err := c.teleporterABI.Pack("addFeeAmount", messageID, feeToken, big.NewInt(calibrationSentMessageFee))
+		require.NoError(t, err)
+		cal.check(fmt.Sprintf("initial fee=%d", initialFee), CalculateAddFeeAmountGasLimit(), c.requiredGasLimit(t, calldata, nil))
+		restore()
+	}
+	cal.done()
+}
+
+// Types of TransferrerMessage
+const (
+	registerRemoteMessageType uint8 = 0
+	singleHopSendMessageType  uint8 = 1
+)
+
+// icttCalibrationChain is a calibration chain with an ERC20TokenHome and an ERC20TokenRemote,
+// registered with their counterparts on the source chain. The relayer holds the tokens of both,
+// and an ExampleERC20 to pay fees with.
+type icttCalibrationChain struct {
+	*calibrationChain
+	homeABI   *abi.ABI
+	remoteABI *abi.ABI
+	home      common.Address
+	remote    common.Address
+	feeToken  common.Address
+	// Counterparts of home and remote on the source chain
+	sourceRemote common.Address
+	sourceHome   common.Address
+}
+
+func newICTTCalibrationChain(t *testing.T) *icttCalibrationChain {
+	c := &icttCalibrationChain{
+		calibrationChain: newCalibrationChain(t),
+		sourceRemote:     common.HexToAddress("0x0000000000000000000000000000000000004444"),
+		sourceHome:       common.HexToAddress("0x0000000000000000000000000000000000005555"),
+	}
+	registryABI, err := teleporterregistry.TeleporterRegistryMetaData.GetAbi()
+	require.NoError(t, err)
+	c.homeABI, err = erc20tokenhome.ERC20TokenHomeMetaData.GetAbi()
+	require.NoError(t, err)
+	c.remoteABI, err = erc20tokenremote.ERC20TokenRemoteMetaData.GetAbi()
+	require.NoError(t, err)
+
+	registry := c.deploy(t, teleporterregistry.TeleporterRegistryMetaData.Bin, registryABI,
+		[]teleporterregistry.ProtocolRegistryEntry{{Version: big.NewInt(1), ProtocolAddress: c.teleporter}},
+	)
+	token, tokenABI := c.deployToken(t)
+	c.home = c.deploy(t, erc20tokenhome.ERC20TokenHomeMetaData.Bin, c.homeABI,
+		registry, c.relayer, big.NewInt(1), token, uint8(18),
+	)
+	c.remote = c.deploy(t, erc20tokenremote.ERC20TokenRemoteMetaData.Bin, c.remoteABI,
+		erc20tokenremote.TokenRemoteSettings{
+			TeleporterRegistryAddress: registry,
+			TeleporterManager:         c.relayer,
+			MinTeleporterVersion:      big.NewInt(1),
+			TokenHomeBlockchainID:     c.sourceChainID,
+			TokenHomeAddress:          c.sourceHome,
+			TokenHomeDecimals:         18,
+		},
+		"Calibration Token", "CAL", uint8(18),
+	)
+	c.feeToken, _ = c.deployToken(t, c.home, c.remote)
+	c.transact(t, token, tokenABI, "approve", c.home, abi.MaxUint256)
+	c.transact(t, c.remote, c.remoteABI, "approve", c.remote, abi.MaxUint256)
+
+	// The remote on the source chain registers with the home, and the home on the source chain
+	// sends tokens of the remote to the relayer.
+	c.deliver(t, c.sourceRemote, c.home, transferrerMessage(t, registerRemoteMessageType,
+		abiEncode(t, []string{"uint256", "uint8", "uint8"}, big.NewInt(0), uint8(18), uint8(18)),
+	))
+	c.deliver(t, c.sourceHome, c.remote, transferrerMessage(t, singleHopSendMessageType,
+		abiEncode(t, []string{"address", "uint256"}, c.relayer, calibrationTokenSupply),
+	))
+	return c
+}
+
+// abiEncode returns the ABI encoding of values of the given types, as abi.encode.
+func abiEncode(t *testing.T, typeNames []string, values ...interface{}) []byte {
+	var args abi.Arguments
+	for _, typeName := range typeNames {
+		typ, err := abi.NewType(typeName, "", nil)
+		require.NoError(t, err)
+		args = append(args, abi.Argument{Type: typ})
+	}
+	encoded, err := args.Pack(values...)
+	require.NoError(t, err)
+	return encoded
+}
+
+// transferrerMessage returns the ABI encoding of a TransferrerMessage, which as a dynamic struct
+// is preceded by its offset.
+func transferrerMessage(t *testing.T, messageType uint8, payload []byte) []byte {
+	return append(
+		common.BigToHash(big.NewInt(common.HashLength)).Bytes(),
+		abiEncode(t, []string{"uint8", "bytes"}, messageType, payload)...,
+	)
+}
+
+// transferCalldata returns the calldata of the send or sendAndCall of transfer by the home, or the
+// remote if fromRemote is set.
+func (c *icttCalibrationChain) transferCalldata(t *testing.T, transfer TokenTransfer, fromRemote bool) []byte {
+	contractABI := c.homeABI
+	destination := c.sourceRemote
+	if fromRemote {
+		contractABI = c.remoteABI
+		destination = c.sourceHome
+	}
+	var (
+		destinationBlockchainID = c.sourceChainID
+		multiHopFallback        common.Address
+		primaryFee              = big.NewInt(0)
+	)
+	if transfer.MultiHop {
+		destinationBlockchainID = ids.ID{0xee}
+		destination = c.sourceRemote
+		multiHopFallback = c.relayer
+	}
+	if transfer.PayPrimaryFee {
+		primaryFee = big.NewInt(calibrationSentMessageFee)
+	}
+	amount := big.NewInt(calibrationTransferAmount)
+	requiredGasLimit := new(big.Int).SetUint64(transfer.RequiredGasLimit)
+
+	var (
+		calldata []byte
+		err      error
+	)
+	if !transfer.Call {
+		// The input structs of the home and remote bindings have the same ABI encoding.
+		calldata, err = contractABI.Pack("send", erc20tokenremote.SendTokensInput{
+			DestinationBlockchainID:            destinationBlockchainID,
+			DestinationTokenTransferrerAddress: destination,
+			Recipient:                          c.relayer,
+			PrimaryFeeTokenAddress:             c.feeToken,
+			PrimaryFee:                         primaryFee,
+			SecondaryFee:                       big.NewInt(0),
+			RequiredGasLimit:                   requiredGasLimit,
+			MultiHopFallback:                   multiHopFallback,
+		}, amount)
+	} else {
+		payload := make([]byte, transfer.RecipientPayloadSize)
+		for i := range payload {
+			payload[i] = 0xff
+		}
+		calldata, err = contractABI.Pack("sendAndCall", erc20tokenremote.SendAndCallInput{
+			DestinationBlockchainID:            destinationBlockchainID,
+			DestinationTokenTransferrerAddress: destination,
+			RecipientContract:                  calibrationReceiver,
+			RecipientPayload:                   payload,
+			RequiredGasLimit:                   requiredGasLimit,
+			RecipientGasLimit:                  new(big.Int).SetUint64(transfer.RequiredGasLimit / 2),
+			MultiHopFallback:                   multiHopFallback,
+			FallbackRecipient:                  c.relayer,
+			PrimaryFeeTokenAddress:             c.feeToken,
+			PrimaryFee:                         primaryFee,
+			SecondaryFee:                       big.NewInt(0),
+		}, amount)
+	}
+	require.NoError(t, err)
+	return calldata
+}
+
+// TestCalibrateTokenTransferSendGasLimit checks that EstimateTokenTransferGas never
+// under-estimates the gas needed to send a token transfer with the compiled ERC20TokenHome and
+// ERC20TokenRemote. Run with -v to print the overestimate margin of each case.
+func TestCalibrateTokenTransferSendGasLimit(t *testing.T) {
+	c := newICTTCalibrationChain(t)
+	cal := &calibration{t: t}
+	for _, numReceipts := range []int{0, MaxReceiptsPerMessage} {
+		restore := c.checkpoint()
+		c.setReceiptQueue(t, numReceipts)
+		for _, fromRemote := range []bool{false, true} {
+			for _, multiHop := range []bool{false, true} {
+				// Only transfers from a TokenRemote are routed by the TokenHome to another TokenRemote.
+				if multiHop && !fromRemote {
+					continue
+				}
+				for _, payloadSize := range []int{-1, 1, 100, 1_000, 10_000} {
+					for _, payFee := range []bool{false, true} {
+						transfer := TokenTransfer{
+							Call:                 payloadSize >= 0,
+							MultiHop:             multiHop,
+							RecipientPayloadSize: max(payloadSize, 0),
+							RequiredGasLimit:     calibrationRequiredGas,
+							PayPrimaryFee:        payFee,
+							NumReceipts:          numReceipts,
+						}
+						contract := c.home
+						if fromRemote {
+							contract = c.remote
+						}
+						required := c.requiredGasLimitAt(t, contract, c.transferCalldata(t, transfer, fromRemote), nil)
+						estimate, err := EstimateTokenTransferGas(transfer, 1)
+						require.NoError(t, err)
+						cal.check(
+							fmt.Sprintf(
+								"receipts=%d remote=%t multiHop=%t call=%t payload=%d fee=%t",
+								numReceipts, fromRemote, multiHop, transfer.Call, transfer.RecipientPayloadSize, payFee,
+							),
+							estimate.SendGasLimit,
+							required,
+						)
+					}
+				}
+			}
+		}
+		restore()
+	}
+	cal.done()
+}
+
+// TestMultiHopRequiredGas checks that the required gas limits of the first leg of multi-hop
+// transfers are those set by the compiled TokenRemote.
+func TestMultiHopRequiredGas(t *testing.T) {
+	c := newICTTCalibrationChain(t)
+	for name, expected := range map[string]uint64{
+		"MULTI_HOP_SEND_REQUIRED_GAS": MultiHopSendRequiredGas,
+		"MULTI_HOP_CALL_REQUIRED_GAS": MultiHopCallRequiredGas,
+		"MULTI_HOP_CALL_GAS_PER_WORD": MultiHopCallGasPerWord,
+	} {
+		require.Equal(t, new(big.Int).SetUint64(expected), c.call(t, c.remote, c.remoteABI, name)[0], name)
+	}
+}
+
+// Settings of the validator managers deployed by calibrations
+const (
+	calibrationWeightToValueFactor        = 1e12
+	calibrationStakeAmount                = 1e18
+	calibrationInitialWeight       uint64 = 1e9
+	calibrationMinStakeDuration    uint64 = 3_600
+	calibrationRewardBasisPoints   uint64 = 1_000
+	calibrationDelegationFeeBips   uint16 = 100
+)
+
+// validatorManagerCalibrationChain is a calibration chain with a validator manager, whose
+// Warp messages from the P-Chain are sent by the zero address on the empty chain ID. Its
+// staking token, or native token, is held by the relayer, which owns all of its validators
+// and delegations.
+type validatorManagerCalibrationChain struct {
+	*calibrationChain
+	name       string
+	managerABI *abi.ABI
+	manager    common.Address
+	// Staking token of ERC20TokenStakingManager, or the zero address
+	token    common.Address
+	tokenABI *abi.ABI
+	pos      bool
+	native   bool
+	subnetID ids.ID
+	// Number of node IDs returned by node
+	numNodes int
+}
+
+func newValidatorManagerCalibrationChain(t *testing.T, name string) *validatorManagerCalibrationChain {
+	c := &validatorManagerCalibrationChain{
+		calibrationChain: newCalibrationChain(t),
+		name:             name,
+		subnetID:         ids.GenerateTestID(),
+	}
+	var (
+		bin string
+		err error
+	)
+	switch name {
+	case "PoAValidatorManager":
+		c.managerABI, err = poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+		bin = poavalidatormanager.PoAValidatorManagerMetaData.Bin
+	case "ERC20TokenStakingManager":
+		c.managerABI, err = erc20tokenstakingmanager.ERC20TokenStakingManagerMetaData.GetAbi()
+		bin = erc20tokenstakingmanager.ERC20TokenStakingManagerMetaData.Bin
+		c.pos = true
+	case "NativeTokenStakingManager":
+		c.managerABI, err = nativetokenstakingmanager.NativeTokenStakingManagerMetaData.GetAbi()
+		bin = nativetokenstakingmanager.NativeTokenStakingManagerMetaData.Bin
+		c.pos = true
+		c.native = true
+	default:
+		require.FailNow(t, "unknown validator manager "+name)
+	}
+	require.NoError(t, err)
+
+	library := crypto.CreateAddress(c.relayer, c.state.GetNonce(c.relayer))
+	c.mustApply(t, nil, common.FromHex(poavalidatormanager.ValidatorMessagesMetaData.Bin), nil)
+	// ICMInitializable.Allowed, so that the manager is initialized without a proxy
+	c.manager = c.deploy(t, deploymentUtils.LinkLibrary(bin, library), c.managerABI, uint8(0))
+
+	settings := poavalidatormanager.ValidatorManagerSettings{
+		SubnetID:               c.subnetID,
+		ChurnPeriodSeconds:     1,
+		MaximumChurnPercentage: 20,
+	}
+	if !c.pos {
+		c.transact(t, c.manager, c.managerABI, "initialize", settings, c.relayer)
+		return c
+	}
+	calculatorABI, err := examplerewardcalculator.ExampleRewardCalculatorMetaData.GetAbi()
+	require.NoError(t, err)
+	posSettings := erc20tokenstakingmanager.PoSValidatorManagerSettings{
+		BaseSettings:             erc20tokenstakingmanager.ValidatorManagerSettings(settings),
+		MinimumStakeAmount:       big.NewInt(calibrationStakeAmount),
+		MaximumStakeAmount:       big.NewInt(calibrationStakeAmount),
+		MinimumStakeDuration:     calibrationMinStakeDuration,
+		MinimumDelegationFeeBips: calibrationDelegationFeeBips,
+		MaximumStakeMultiplier:   4,
+		WeightToValueFactor:      big.NewInt(calibrationWeightToValueFactor),
+		RewardCalculator: c.deploy(t, examplerewardcalculator.ExampleRewardCalculatorMetaData.Bin, calculatorABI,
+			calibrationRewardBasisPoints,
+		),
+		UptimeBlockchainID: c.blockchainID,
+	}
+	if c.native {
+		// The rewards are minted by the NativeMinter precompile
+		c.config.GenesisPrecompiles[nativeminter.ConfigKey] = nativeminter.NewConfig(subnetEvmUtils.NewUint64(0), nil, nil, nil, nil)
+		allowlist.SetAllowListRole(c.state, nativeminter.ContractAddress, c.manager, allowlist.EnabledRole)
+		// Set as by the activation of the precompile, so that calls of it pass the code size check
+		c.state.SetCode(nativeminter.ContractAddress, []byte{0x1})
+		c.transact(t, c.manager, c.managerABI, "initialize", posSettings)
+		return c
+	}
+	c.token, c.tokenABI = c.deployToken(t, c.manager)
+	c.transact(t, c.manager, c.managerABI, "initialize", posSettings, c.token)
+	return c
+}
+
+// node returns a new node ID and BLS public key. Their bytes are not zero, like those of real
+// keys, so that they are charged as such in calldata.
+func (c *validatorManagerCalibrationChain) node() ([]byte, [48]byte) {
+	c.numNodes++
+	seed := big.NewInt(int64(c.numNodes)).Bytes()
+	var blsPublicKey [48]byte
+	copy(blsPublicKey[:], crypto.Keccak512(seed))
+	return crypto.Keccak256(seed)[:20], blsPublicKey
+}
+
+// pack packs the call of the overload of method with as many inputs as args.
+func (c *validatorManagerCalibrationChain) pack(t *testing.T, method string, args ...interface{}) []byte {
+	for _, m := range c.managerABI.Methods {
+		if m.RawName == method && len(m.Inputs) == len(args) {
+			calldata, err := c.managerABI.Pack(m.Name, args...)
+			require.NoError(t, err)
+			return calldata
+		}
+	}
+	require.FailNow(t, "method not found", "%s of %s with %d inputs", method, c.name, len(args))
+	return nil
+}
+
+// transactValue calls the validator manager with calldata sending value, and requires the call to
+// succeed. It returns the topics of the logs of event emitted by the call.
+func (c *validatorManagerCalibrationChain) transactValue(
+	t *testing.T,
+	calldata []byte,
+	value *big.Int,
+	event string,
+) [][]common.Hash {
+	result, err := c.applyValue(&c.manager, value, calldata, calibrationGasCap, nil)
+	require.NoError(t, err)
+	require.NoError(t, result.Err, revertReason(result))
+	var topics [][]common.Hash
+	for _, log := range c.state.GetLogs(c.txHash, 1, common.Hash{}) {
+		if log.Topics[0] == c.managerABI.Events[event].ID {
+			topics = append(topics, log.Topics)
+		}
+	}
+	return topics
+}
+
+// calibrate checks the estimate of op against the gas limit required by the call of the
+// validator manager with calldata consuming a Warp message with payload, then applies the call.
+// Messages from the P-Chain are sent on the empty chain ID, and uptime proofs on the chain itself.
+func (c *validatorManagerCalibrationChain) calibrate(
+	t *testing.T,
+	cal *calibration,
+	caseName string,
+	op ValidatorManagerOperation,
+	calldata []byte,
+	payload []byte,
+) {
+	require.Len(t, calldata, op.CalldataSize, caseName)
+	require.Len(t, payload, op.PayloadSize, caseName)
+	sourceChainID := ids.Empty
+	if op == SubmitUptimeProof {
+		sourceChainID = c.blockchainID
+	}
+	for _, numSigners := range []int{1, 10, 100} {
+		accessList, _ := c.warpAccessList(t, sourceChainID, nil, payload, numSigners)
+		required := c.requiredGasLimitAt(t, c.manager, calldata, accessList)
+		estimate, err := op.GasLimit(numSigners)
+		require.NoError(t, err)
+		cal.check(fmt.Sprintf("%s %s signers=%d", c.name, caseName, numSigners), estimate, required)
+	}
+	accessList, _ := c.warpAccessList(t, sourceChainID, nil, payload, 1)
+	c.mustApply(t, &c.manager, calldata, accessList)
+}
+
+// initializeValidatorSet returns the calldata of initializeValidatorSet with numValidators
+// initial validators, and the payload of the P-Chain's SubnetToL1ConversionMessage.
+func (c *validatorManagerCalibrationChain) initializeValidatorSet(t *testing.T, numValidators int) ([]byte, []byte) {
+	data := warpMessage.SubnetToL1ConversionData{
+		SubnetID:       c.subnetID,
+		ManagerChainID: c.blockchainID,
+		ManagerAddress: c.manager[:],
+	}
+	conversionData := erc20tokenstakingmanager.ConversionData{
+		SubnetID:                     c.subnetID,
+		ValidatorManagerBlockchainID: c.blockchainID,
+		ValidatorManagerAddress:      c.manager,
+	}
+	for i := 0; i < numValidators; i++ {
+		nodeID, blsPublicKey := c.node()
+		weight := calibrationInitialWeight / uint64(numValidators)
+		data.Validators = append(data.Validators, warpMessage.SubnetToL1ConversionValidatorData{
+			NodeID:       nodeID,
+			BLSPublicKey: blsPublicKey,
+			Weight:       weight,
+		})
+		conversionData.InitialValidators = append(conversionData.InitialValidators, erc20tokenstakingmanager.InitialValidator{
+			NodeID:       nodeID,
+			BlsPublicKey: blsPublicKey[:],
+			Weight:       weight,
+		})
+	}
+	conversionID, err := warpMessage.SubnetToL1ConversionID(data)
+	require.NoError(t, err)
+	conversion, err := warpMessage.NewSubnetToL1Conversion(conversionID)
+	require.NoError(t, err)
+	return c.pack(t, "initializeValidatorSet", conversionData, uint32(0)), conversion.Bytes()
+}
+
+// initializeValidatorRegistration initializes the registration of a validator with the minimum
+// stake, or a weight of 1% of the initial weight for PoAValidatorManager, and returns its
+// validation ID.
+func (c *validatorManagerCalibrationChain) initializeValidatorRegistration(t *testing.T) ids.ID {
+	nodeID, blsPublicKey := c.node()
+	input := erc20tokenstakingmanager.ValidatorRegistrationInput{
+		NodeID:                nodeID,
+		BlsPublicKey:          blsPublicKey[:],
+		RegistrationExpiry:    c.timestamp + 3_600,
+		RemainingBalanceOwner: erc20tokenstakingmanager.PChainOwner{Addresses: []common.Address{}},
+		DisableOwner:          erc20tokenstakingmanager.PChainOwner{Addresses: []common.Address{}},
+	}
+	var (
+		calldata []byte
+		value    = big.NewInt(0)
+	)
+	switch {
+	case !c.pos:
+		calldata = c.pack(t, "initializeValidatorRegistration", input, calibrationInitialWeight/100)
+	case c.native:
+		calldata = c.pack(t, "initializeValidatorRegistration", input, calibrationDelegationFeeBips, calibrationMinStakeDuration)
+		value = big.NewInt(calibrationStakeAmount)
+		c.state.AddBalance(c.relayer, uint256.MustFromBig(value))
+	default:
+		calldata = c.pack(t, "initializeValidatorRegistration", input, calibrationDelegationFeeBips,
+			calibrationMinStakeDuration, big.NewInt(calibrationStakeAmount),
+		)
+	}
+	topics := c.transactValue(t, calldata, value, "ValidationPeriodCreated")
+	require.Len(t, topics, 1)
+	return ids.ID(topics[0][1])
+}
+
+// initializeDelegatorRegistration initializes the registration of a delegator of validationID
+// with the minimum stake, and returns its delegation ID and the nonce of the weight update.
+func (c *validatorManagerCalibrationChain) initializeDelegatorRegistration(t *testing.T, validationID ids.ID) (ids.ID, uint64) {
+	var (
+		calldata []byte
+		value    = big.NewInt(0)
+	)
+	if c.native {
+		calldata = c.pack(t, "initializeDelegatorRegistration", validationID)
+		value = big.NewInt(calibrationStakeAmount)
+		c.state.AddBalance(c.relayer, uint256.MustFromBig(value))
+	} else {
+		calldata = c.pack(t, "initializeDelegatorRegistration", validationID, big.NewInt(calibrationStakeAmount))
+	}
+	topics := c.transactValue(t, calldata, value, "DelegatorAdded")
+	require.Len(t, topics, 1)
+	return ids.ID(topics[0][1]), c.validator(t, validationID).MessageNonce
+}
+
+// validator returns the validation period of validationID.
+func (c *validatorManagerCalibrationChain) validator(t *testing.T, validationID ids.ID) erc20tokenstakingmanager.Validator {
+	outputs := c.call(t, c.manager, c.managerABI, "getValidator", validationID)
+	return *abi.ConvertType(outputs[0], new(erc20tokenstakingmanager.Validator)).(*erc20tokenstakingmanager.Validator)
+}
+
+// withdrawStake transfers the staking tokens held by the relayer away, so that the stake
+// unlocked by calibrated calls is credited to an empty balance.
+func (c *validatorManagerCalibrationChain) withdrawStake(t *testing.T) {
+	if c.native {
+		c.state.SetBalance(c.relayer, uint256.NewInt(0))
+		return
+	}
+	if c.token == (common.Address{}) {
+		return
+	}
+	balance := c.call(t, c.token, c.tokenABI, "balanceOf", c.relayer)[0].(*big.Int)
+	c.transact(t, c.token, c.tokenABI, "transfer", common.HexToAddress("0x0000000000000000000000000000000000006666"), balance)
+}
+
+func registrationPayload(t *testing.T, validationID ids.ID, registered bool) []byte {
+	registration, err := warpMessage.NewL1ValidatorRegistration(validationID, registered)
+	require.NoError(t, err)
+	return registration.Bytes()
+}
+
+func weightPayload(t *testing.T, validationID ids.ID, nonce uint64, weight uint64) []byte {
+	weightMessage, err := warpMessage.NewL1ValidatorWeight(validationID, nonce, weight)
+	require.NoError(t, err)
+	return weightMessage.Bytes()
+}
+
+func uptimePayload(t *testing.T, validationID ids.ID, uptime uint64) []byte {
+	validatorUptime, err := messages.NewValidatorUptime(validationID, uptime)
+	require.NoError(t, err)
+	return validatorUptime.Bytes()
+}
+
+// TestCalibrateValidatorManagerGasLimits checks that the gas limits of ValidatorManagerOperation
+// never under-estimate the gas needed to deliver Warp messages to the compiled validator
+// managers. Rewards are paid to accounts without a balance, and stake is unlocked to the relayer
+// with an empty balance. Run with -v to print the overestimate margin of each case.
+func TestCalibrateValidatorManagerGasLimits(t *testing.T) {
+	for _, name := range []string{"PoAValidatorManager", "ERC20TokenStakingManager", "NativeTokenStakingManager"} {
+		t.Run(name, func(t *testing.T) {
+			c := newValidatorManagerCalibrationChain(t, name)
+			cal := &calibration{t: t}
+
+			for _, numValidators := range []int{1, 5, 20} {
+				restore := c.checkpoint()
+				calldata, payload := c.initializeValidatorSet(t, numValidators)
+				c.calibrate(t, cal, fmt.Sprintf("initializeValidatorSet validators=%d", numValidators),
+					InitializeValidatorSet(numValidators), calldata, payload,
+				)
+				restore()
+			}
+			calldata, payload := c.initializeValidatorSet(t, 5)
+			accessList, _ := c.warpAccessList(t, ids.Empty, nil, payload, 1)
+			c.mustApply(t, &c.manager, calldata, accessList)
+			initialValidationID := c.subnetID.Append(0)
+
+			validationID := c.initializeValidatorRegistration(t)
+			c.calibrate(t, cal, "completeValidatorRegistration", CompleteValidatorRegistration,
+				c.pack(t, "completeValidatorRegistration", uint32(0)), registrationPayload(t, validationID, true),
+			)
+			if c.pos {
+				c.calibratePoS(t, cal, validationID)
+			}
+
+			// The registration of a validator expires without being completed
+			c.timestamp += 7_200
+			expiredValidationID := c.initializeValidatorRegistration(t)
+			c.withdrawStake(t)
+			c.calibrate(t, cal, "completeEndValidation expired", CompleteEndValidation,
+				c.pack(t, "completeEndValidation", uint32(0)), registrationPayload(t, expiredValidationID, false),
+			)
+
+			// An initial validator is removed, in a new churn period
+			c.timestamp++
+			if c.pos {
+				c.mustApply(t, &c.manager, c.pack(t, "forceInitializeEndValidation", initialValidationID, false, uint32(0)), nil)
+			} else {
+				c.transact(t, c.manager, c.managerABI, "initializeEndValidation", initialValidationID)
+			}
+			c.calibrate(t, cal, "completeEndValidation initial", CompleteEndValidation,
+				c.pack(t, "completeEndValidation", uint32(0)), registrationPayload(t, initialValidationID, false),
+			)
+			cal.done()
+		})
+	}
+}
+
+// calibratePoS calibrates the operations of the PoS validator managers with the validation
+// validationID, which was just registered. Rewards are paid to new accounts.
+func (c *validatorManagerCalibrationChain) calibratePoS(t *testing.T, cal *calibration, validationID ids.ID) {
+	// The first uptime proof of a validator sets its uptime from zero
+	c.timestamp += calibrationMinStakeDuration
+	c.calibrate(t, cal, "submitUptimeProof", SubmitUptimeProof,
+		c.pack(t, "submitUptimeProof", validationID, uint32(0)),
+		uptimePayload(t, validationID, calibrationMinStakeDuration),
+	)
+
+	// Three delegations are initialized, and the first two are registered
+	var (
+		delegationIDs []ids.ID
+		nonces        []uint64
+	)
+	for i := 0; i < 3; i++ {
+		delegationID, nonce := c.initializeDelegatorRegistration(t, validationID)
+		delegationIDs = append(delegationIDs, delegationID)
+		nonces = append(nonces, nonce)
+	}
+	weight := c.validator(t, validationID).Weight
+	for i := 0; i < 2; i++ {
+		c.calibrate(t, cal, "completeDelegatorRegistration", CompleteDelegatorRegistration,
+			c.pack(t, "completeDelegatorRegistration", delegationIDs[i], uint32(0)),
+			weightPayload(t, validationID, nonces[i], weight),
+		)
+	}
+
+	// The first two delegations are ended after the minimum stake duration, and the removal of
+	// the first is completed while the validator is active
+	c.timestamp += calibrationMinStakeDuration
+	for i := 0; i < 2; i++ {
+		accessList, _ := c.warpAccessList(t, c.blockchainID, nil, uptimePayload(t, validationID, 2*calibrationMinStakeDuration), 1)
+		c.mustApply(t, &c.manager, c.pack(t, "initializeEndDelegation", delegationIDs[i], true, uint32(0),
+			common.BigToAddress(big.NewInt(int64(0x7000+i))),
+		), accessList)
+		validator := c.validator(t, validationID)
+		nonces[i] = validator.MessageNonce
+		weight = validator.Weight
+	}
+	c.withdrawStake(t)
+	c.calibrate(t, cal, "completeEndDelegation", CompleteEndDelegation,
+		c.pack(t, "completeEndDelegation", delegationIDs[0], uint32(0)),
+		weightPayload(t, validationID, nonces[1], weight),
+	)
+
+	// The validation is ended, with its reward and delegation fees paid to a new account
+	c.timestamp += calibrationMinStakeDuration
+	accessList, _ := c.warpAccessList(t, c.blockchainID, nil, uptimePayload(t, validationID, 3*calibrationMinStakeDuration), 1)
+	c.mustApply(t, &c.manager, c.pack(t, "initializeEndValidation", validationID, true, uint32(0),
+		common.HexToAddress("0x0000000000000000000000000000000000008888"),
+	), accessList)
+	c.withdrawStake(t)
+	c.calibrate(t, cal, "completeEndValidation", CompleteEndValidation,
+		c.pack(t, "completeEndValidation", uint32(0)), registrationPayload(t, validationID, false),
+	)
+
+	// The removal of the second delegation, and the registration of the third, are completed
+	// after the validation ended
+	c.withdrawStake(t)
+	c.calibrate(t, cal, "completeEndDelegation validator ended", CompleteEndDelegation,
+		c.pack(t, "completeEndDelegation", delegationIDs[1], uint32(0)),
+		weightPayload(t, validationID, nonces[1], weight),
+	)
+	c.withdrawStake(t)
+	c.calibrate(t, cal, "completeDelegatorRegistration validator ended", CompleteDelegatorRegistration,
+		c.pack(t, "completeDelegatorRegistration", delegationIDs[2], uint32(0)),
+		weightPayload(t, validationID, nonces[2], weight),
+	)
+}
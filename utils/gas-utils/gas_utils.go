@@ -8,13 +8,37 @@ import (
 	"math/big"
 
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/subnet-evm/params"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const (
 	ReceiveCrossChainMessageBaseGasCost uint64 = 250_000
-	MarkMessageReceiptGasCost           uint64 = 2500
-	DecodeMessageGasCostPerByte         uint64 = 35
+	// Gas used to mark each receipt of a received message, which deletes the sent message info
+	// and credits the fee to a relayer reward amount that may not have been set before.
+	MarkMessageReceiptGasCost   uint64 = 50_000
+	DecodeMessageGasCostPerByte uint64 = 35
+
+	// The gas costs of sending messages and adding fees are measured against the compiled
+	// TeleporterMessenger by TestCalibrateSendMessageGasLimit and TestCalibrateAddFeeAmountGasLimit.
+
+	// Gas used by sendCrossChainMessage for the sender reentrancy guard, the message nonce,
+	// storing the message hash and fee info, and emitting the event, excluding the Warp precompile.
+	SendCrossChainMessageBaseGasCost uint64 = 120_000
+	// Gas used by sendCrossChainMessage per byte of the encoded Teleporter message to encode,
+	// hash and emit it.
+	EncodeMessageGasCostPerByte uint64 = 20
+	// Gas used by sendCrossChainMessage to dequeue each receipt attached to the message.
+	SendMessageReceiptGasCost uint64 = 10_000
+	// Gas used to transfer an ERC20 fee into TeleporterMessenger, including the balance checks.
+	FeeTransferGasCost uint64 = 60_000
+	// Gas used by addFeeAmount for the reentrancy guards, updating the fee info and emitting
+	// the event, excluding the fee transfer.
+	AddFeeAmountBaseGasCost uint64 = 20_000
+
+	// Maximum number of receipts attached to a Teleporter message, from ReceiptQueue
+	MaxReceiptsPerMessage = 5
 
 	BaseFeeFactor        = 2
 	MaxPriorityFeePerGas = 2500000000 // 2.5 gwei
@@ -40,28 +64,168 @@ func CalculateReceiveMessageGasLimit(
 		return 0, errors.New("required gas limit too high")
 	}
 
-	gasAmounts := []uint64{
+	// The variable gas on message bytes is accounted for both when used in predicate verification
+	// and also when used in `getVerifiedWarpMessage`
+	warpGas, err := CalculateWarpMessageGasCost(warpMessageSize, numSigners)
+	if err != nil {
+		return 0, err
+	}
+	return sumGas(
 		executionRequiredGasLimit.Uint64(),
-		// The variable gas on message bytes is accounted for both when used in predicate verification
-		// and also when used in `getVerifiedWarpMessage`
-		uint64(warpMessageSize) * warp.GasCostPerWarpMessageBytes * 2,
+		warpGas,
 		// Take into the variable gas cost for decoding the Teleporter message
 		// and marking the receipts as received.
-		uint64(teleporterMessageSize) * DecodeMessageGasCostPerByte,
-		uint64(teleporterReceiptsCount) * MarkMessageReceiptGasCost,
+		uint64(teleporterMessageSize)*DecodeMessageGasCostPerByte,
+		uint64(teleporterReceiptsCount)*MarkMessageReceiptGasCost,
 		ReceiveCrossChainMessageBaseGasCost,
-		uint64(numSigners) * warp.GasCostPerWarpSigner,
+	)
+}
+
+// CalculateSendMessageGasLimit calculates the estimated gas limit of a transaction calling
+// sendCrossChainMessage with the given number of allowed relayers and message size.
+// numReceipts is the number of receipts attached to the message from the receipt queue of
+// the destination blockchain, which is at most MaxReceiptsPerMessage. If payFee is set,
+// the cost of transferring an ERC20 fee is included.
+func CalculateSendMessageGasLimit(
+	numAllowedRelayers int,
+	numReceipts int,
+	messageSize int,
+	payFee bool,
+) (uint64, error) {
+	executionGas, err := sendMessageExecutionGas(numAllowedRelayers, numReceipts, messageSize, payFee)
+	if err != nil {
+		return 0, err
+	}
+	// sendCrossChainMessage(TeleporterMessageInput) encodes the offset of the input, its seven
+	// head words and the allowed relayers, followed by the message
+	calldataSize := 4 + 9*common.HashLength + numAllowedRelayers*common.HashLength + encodedBytesSize(messageSize)
+	return sumGas(
+		params.TxGas,
+		CalldataGasCost(calldataSize),
+		executionGas,
+	)
+}
+
+// sendMessageExecutionGas returns the gas used by sendCrossChainMessage, excluding the intrinsic
+// gas of the transaction. This is also used by contracts sending Teleporter messages, such as ICTT.
+func sendMessageExecutionGas(numAllowedRelayers int, numReceipts int, messageSize int, payFee bool) (uint64, error) {
+	if numReceipts > MaxReceiptsPerMessage {
+		numReceipts = MaxReceiptsPerMessage
+	}
+	teleporterMessageSize := uint64(TeleporterMessageSize(numAllowedRelayers, numReceipts, messageSize))
+	gasAmounts := []uint64{
+		SendCrossChainMessageBaseGasCost,
+		teleporterMessageSize * EncodeMessageGasCostPerByte,
+		uint64(numReceipts) * SendMessageReceiptGasCost,
+		warp.SendWarpMessageGasCost,
+		teleporterMessageSize * warp.SendWarpMessageGasCostPerByte,
+	}
+	if payFee {
+		gasAmounts = append(gasAmounts, FeeTransferGasCost)
+	}
+	return sumGas(gasAmounts...)
+}
+
+// CalculateAddFeeAmountGasLimit returns the estimated gas limit of a transaction calling addFeeAmount.
+func CalculateAddFeeAmountGasLimit() uint64 {
+	// addFeeAmount(bytes32,address,uint256)
+	calldataSize := 4 + 3*common.HashLength
+	return params.TxGas + CalldataGasCost(calldataSize) + AddFeeAmountBaseGasCost + FeeTransferGasCost
+}
+
+// CalculateWarpMessageGasCost returns the gas used to verify a Warp message of the given size
+// included in a transaction's predicate, signed by numSigners validators, and to read it with
+// getVerifiedWarpMessage.
+func CalculateWarpMessageGasCost(warpMessageSize int, numSigners int) (uint64, error) {
+	predicateSize := uint64(PredicateSize(warpMessageSize))
+	return sumGas(
 		warp.GasCostPerSignatureVerification,
+		predicateSize*warp.GasCostPerWarpMessageBytes,
+		uint64(numSigners)*warp.GasCostPerWarpSigner,
+		// getVerifiedWarpMessage charges for the packed predicate rather than the message
+		warp.GetVerifiedWarpMessageBaseCost,
+		predicateSize*warp.GasCostPerWarpMessageBytes,
+	)
+}
+
+// TeleporterMessageSize returns the size of an ABI encoded TeleporterMessage with the given
+// number of allowed relayers and receipts, and message size.
+func TeleporterMessageSize(numAllowedRelayers int, numReceipts int, messageSize int) int {
+	// The offset of the message, its eight head words, the allowed relayers and receipts,
+	// and the message
+	return 11*common.HashLength +
+		numAllowedRelayers*common.HashLength +
+		numReceipts*2*common.HashLength +
+		encodedBytesSize(messageSize)
+}
+
+// WarpMessageSize returns the size of a signed Warp message with an addressed call payload
+// of the given size, signed by numSigners validators. If the signers are not the first
+// numSigners validators of the canonical validator set, the signer bit set is larger.
+func WarpMessageSize(payloadSize int, numSigners int) int {
+	const (
+		codecVersionSize = 2
+		typeIDSize       = 4
+		lengthSize       = 4
+		networkIDSize    = 4
+		addressSize      = common.AddressLength
+		signatureSize    = 96
+	)
+	addressedCallSize := codecVersionSize + typeIDSize + lengthSize + addressSize + lengthSize + payloadSize
+	unsignedMessageSize := codecVersionSize + networkIDSize + common.HashLength + lengthSize + addressedCallSize
+	signersSize := (numSigners + 7) / 8
+	return unsignedMessageSize + typeIDSize + lengthSize + signersSize + signatureSize
+}
+
+// PredicateSize returns the size of a Warp message packed into a transaction's access list,
+// which is delimited and padded to a multiple of 32 bytes.
+func PredicateSize(warpMessageSize int) int {
+	return (warpMessageSize + common.HashLength) / common.HashLength * common.HashLength
+}
+
+// CalldataGasCost returns the intrinsic gas cost of calldata of the given size, assuming all
+// bytes are non-zero.
+func CalldataGasCost(size int) uint64 {
+	return uint64(size) * params.TxDataNonZeroGasEIP2028
+}
+
+// GasFeeCap returns the gas fee cap used for transactions, allowing the base fee to
+// increase by BaseFeeFactor before the transaction is included.
+func GasFeeCap(baseFee *big.Int) *big.Int {
+	gasFeeCap := new(big.Int).Mul(baseFee, big.NewInt(BaseFeeFactor))
+	return gasFeeCap.Add(gasFeeCap, big.NewInt(MaxPriorityFeePerGas))
+}
+
+// CalculateTxCost returns the expected and maximum costs in wei of a transaction with the
+// given gas limit, at the current base fee and gas tip cap. The expected cost pays the base
+// fee and tip, and the maximum cost pays the gas fee cap from GasFeeCap.
+func CalculateTxCost(gasLimit uint64, baseFee *big.Int, gasTipCap *big.Int) (*big.Int, *big.Int) {
+	gas := new(big.Int).SetUint64(gasLimit)
+	gasPrice := new(big.Int).Add(baseFee, gasTipCap)
+	if gasFeeCap := GasFeeCap(baseFee); gasPrice.Cmp(gasFeeCap) > 0 {
+		gasPrice = gasFeeCap
 	}
+	expected := new(big.Int).Mul(gas, gasPrice)
+	maximum := new(big.Int).Mul(gas, GasFeeCap(baseFee))
+	return expected, maximum
+}
+
+// encodedBytesSize returns the size of ABI encoded bytes, which are prefixed with their
+// length and padded to a multiple of 32 bytes.
+func encodedBytesSize(size int) int {
+	return common.HashLength + (size+common.HashLength-1)/common.HashLength*common.HashLength
+}
 
-	res := gasAmounts[0]
-	var err error
-	for i := 1; i < len(gasAmounts); i++ {
-		res, err = math.Add64(res, gasAmounts[i])
+func sumGas(gasAmounts ...uint64) (uint64, error) {
+	var (
+		res uint64
+		err error
+	)
+	for _, gas := range gasAmounts {
+		res, err = math.Add64(res, gas)
 		if err != nil {
 			return 0, err
 		}
 	}
-
 	return res, nil
 }
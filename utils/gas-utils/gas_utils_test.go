@@ -0,0 +1,199 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpMessage "github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	erc20tokenhome "github.com/ava-labs/icm-contracts/abi-bindings/go/ictt/TokenHome/ERC20TokenHome"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	erc20tokenstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ERC20TokenStakingManager"
+	"github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/warp/messages"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeleporterMessageSize(t *testing.T) {
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		numAllowedRelayers int
+		numReceipts        int
+		messageSize        int
+	}{
+		{0, 0, 0},
+		{1, 0, 1},
+		{2, 5, 32},
+		{3, 2, 100},
+	} {
+		message := teleportermessenger.TeleporterMessage{
+			MessageNonce:            big.NewInt(1),
+			RequiredGasLimit:        big.NewInt(1),
+			AllowedRelayerAddresses: make([]common.Address, tc.numAllowedRelayers),
+			Receipts:                make([]teleportermessenger.TeleporterMessageReceipt, tc.numReceipts),
+			Message:                 make([]byte, tc.messageSize),
+		}
+		for i := range message.Receipts {
+			message.Receipts[i].ReceivedMessageNonce = big.NewInt(1)
+		}
+		// A single tuple argument is encoded as abi.encode(message)
+		encoded, err := teleporterABI.Methods["retrySendCrossChainMessage"].Inputs.Pack(message)
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), TeleporterMessageSize(tc.numAllowedRelayers, tc.numReceipts, tc.messageSize))
+
+		calldata, err := teleporterABI.Pack("sendCrossChainMessage", teleportermessenger.TeleporterMessageInput{
+			FeeInfo:                 teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(1)},
+			RequiredGasLimit:        big.NewInt(1),
+			AllowedRelayerAddresses: message.AllowedRelayerAddresses,
+			Message:                 message.Message,
+		})
+		require.NoError(t, err)
+		expected, err := sumGas(21_000, uint64(len(calldata))*16)
+		require.NoError(t, err)
+		gasLimit, err := CalculateSendMessageGasLimit(tc.numAllowedRelayers, tc.numReceipts, tc.messageSize, false)
+		require.NoError(t, err)
+		executionGas, err := sendMessageExecutionGas(tc.numAllowedRelayers, tc.numReceipts, tc.messageSize, false)
+		require.NoError(t, err)
+		require.Equal(t, expected+executionGas, gasLimit)
+	}
+}
+
+func TestWarpMessageSize(t *testing.T) {
+	for _, tc := range []struct {
+		payloadSize int
+		numSigners  int
+	}{
+		{0, 1},
+		{38, 5},
+		{500, 8},
+		{1000, 100},
+	} {
+		addressedCall, err := warpPayload.NewAddressedCall(common.Address{}.Bytes(), make([]byte, tc.payloadSize))
+		require.NoError(t, err)
+		unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.Empty, addressedCall.Bytes())
+		require.NoError(t, err)
+		signers := set.NewBits()
+		for i := 0; i < tc.numSigners; i++ {
+			signers.Add(i)
+		}
+		signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{
+			Signers: signers.Bytes(),
+		})
+		require.NoError(t, err)
+		size := WarpMessageSize(tc.payloadSize, tc.numSigners)
+		require.Equal(t, len(signedMessage.Bytes()), size)
+		require.Equal(t, len(predicate.PackPredicate(signedMessage.Bytes())), PredicateSize(size))
+	}
+}
+
+func TestValidatorManagerPayloadSizes(t *testing.T) {
+	registration, err := warpMessage.NewL1ValidatorRegistration(ids.Empty, true)
+	require.NoError(t, err)
+	require.Len(t, registration.Bytes(), L1ValidatorRegistrationMessageSize)
+	weight, err := warpMessage.NewL1ValidatorWeight(ids.Empty, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, weight.Bytes(), L1ValidatorWeightMessageSize)
+	conversion, err := warpMessage.NewSubnetToL1Conversion(ids.Empty)
+	require.NoError(t, err)
+	require.Len(t, conversion.Bytes(), SubnetToL1ConversionMessageSize)
+	uptime, err := messages.NewValidatorUptime(ids.Empty, 1)
+	require.NoError(t, err)
+	require.Len(t, uptime.Bytes(), ValidatorUptimeMessageSize)
+
+	stakingManagerABI, err := erc20tokenstakingmanager.ERC20TokenStakingManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	validators := make([]erc20tokenstakingmanager.InitialValidator, 3)
+	for i := range validators {
+		validators[i] = erc20tokenstakingmanager.InitialValidator{
+			NodeID:       make([]byte, 20),
+			BlsPublicKey: make([]byte, 48),
+			Weight:       1,
+		}
+	}
+	calldata, err := stakingManagerABI.Pack(
+		"initializeValidatorSet",
+		erc20tokenstakingmanager.ConversionData{InitialValidators: validators},
+		uint32(0),
+	)
+	require.NoError(t, err)
+	require.Len(t, calldata, InitializeValidatorSet(len(validators)).CalldataSize)
+	calldata, err = stakingManagerABI.Pack("completeDelegatorRegistration", [32]byte{}, uint32(0))
+	require.NoError(t, err)
+	require.Len(t, calldata, CompleteDelegatorRegistration.CalldataSize)
+
+	// The Warp message is the largest cost of completing a registration
+	gasLimit, err := CompleteValidatorRegistration.GasLimit(5)
+	require.NoError(t, err)
+	warpGas, err := CalculateWarpMessageGasCost(WarpMessageSize(L1ValidatorRegistrationMessageSize, 5), 5)
+	require.NoError(t, err)
+	require.Greater(t, warpGas, gasLimit/2)
+}
+
+func TestEstimateTokenTransferGas(t *testing.T) {
+	tokenHomeABI, err := erc20tokenhome.ERC20TokenHomeMetaData.GetAbi()
+	require.NoError(t, err)
+	calldata, err := tokenHomeABI.Pack("sendAndCall", erc20tokenhome.SendAndCallInput{
+		RecipientPayload:  make([]byte, 100),
+		RequiredGasLimit:  big.NewInt(1),
+		RecipientGasLimit: big.NewInt(1),
+		PrimaryFee:        big.NewInt(1),
+		SecondaryFee:      big.NewInt(1),
+	}, big.NewInt(1))
+	require.NoError(t, err)
+	require.Len(t, calldata, 4+13*32+encodedBytesSize(100))
+
+	singleHop, err := EstimateTokenTransferGas(TokenTransfer{RequiredGasLimit: 100_000}, 5)
+	require.NoError(t, err)
+	require.Len(t, singleHop.ReceiveGasLimits, 1)
+	require.Greater(t, singleHop.ReceiveGasLimits[0], uint64(100_000))
+
+	// Paying a fee and attaching receipts increase the cost of sending
+	withFee, err := EstimateTokenTransferGas(TokenTransfer{RequiredGasLimit: 100_000, PayPrimaryFee: true, NumReceipts: 2}, 5)
+	require.NoError(t, err)
+	require.Equal(t, singleHop.SendGasLimit+SendTokensFeeGasCost+FeeTransferGasCost+2*SendMessageReceiptGasCost+2*64*(EncodeMessageGasCostPerByte+8), withFee.SendGasLimit)
+
+	// The first leg of a multi-hop transfer executes on the home with a fixed required gas,
+	// and the second leg uses the required gas limit of the input
+	multiHop, err := EstimateTokenTransferGas(TokenTransfer{MultiHop: true, RequiredGasLimit: 100_000}, 5)
+	require.NoError(t, err)
+	require.Len(t, multiHop.ReceiveGasLimits, 2)
+	require.Greater(t, multiHop.ReceiveGasLimits[0], MultiHopSendRequiredGas)
+	require.Greater(t, multiHop.ReceiveGasLimits[1], singleHop.ReceiveGasLimits[0])
+
+	multiHopCall, err := EstimateTokenTransferGas(TokenTransfer{
+		Call:                 true,
+		MultiHop:             true,
+		RecipientPayloadSize: 100,
+		RequiredGasLimit:     200_000,
+	}, 5)
+	require.NoError(t, err)
+	require.Greater(t, multiHopCall.ReceiveGasLimits[0], MultiHopCallRequiredGas+4*MultiHopCallGasPerWord)
+	require.Greater(t, multiHopCall.SendGasLimit, multiHop.SendGasLimit)
+	total, err := multiHopCall.Total()
+	require.NoError(t, err)
+	require.Equal(t, multiHopCall.SendGasLimit+multiHopCall.ReceiveGasLimits[0]+multiHopCall.ReceiveGasLimits[1], total)
+}
+
+func TestCalculateTxCost(t *testing.T) {
+	baseFee := big.NewInt(25_000_000_000)
+	require.Equal(t, big.NewInt(52_500_000_000), GasFeeCap(baseFee))
+
+	expected, maximum := CalculateTxCost(100_000, baseFee, big.NewInt(1_000_000_000))
+	require.Equal(t, big.NewInt(2_600_000_000_000_000), expected)
+	require.Equal(t, big.NewInt(5_250_000_000_000_000), maximum)
+
+	// The tip is capped by the gas fee cap
+	expected, maximum = CalculateTxCost(100_000, baseFee, big.NewInt(100_000_000_000))
+	require.Equal(t, maximum, expected)
+
+	require.Equal(t, uint64(21_000+100*16+AddFeeAmountBaseGasCost+FeeTransferGasCost), CalculateAddFeeAmountGasLimit())
+}
@@ -0,0 +1,169 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The gas costs of sends are measured against the compiled ERC20TokenHome and ERC20TokenRemote
+// by TestCalibrateTokenTransferSendGasLimit.
+const (
+	// Required gas limits of the first leg of multi-hop transfers, as set by TokenRemote
+	MultiHopSendRequiredGas uint64 = 340_000
+	MultiHopCallRequiredGas uint64 = 350_000
+	MultiHopCallGasPerWord  uint64 = 1_500
+
+	// Gas used by send and sendAndCall to transfer the tokens in, update the bridged balances
+	// and emit the event, excluding the Teleporter message.
+	SendTokensBaseGasCost uint64 = 60_000
+	// Gas used by sendAndCall per byte of the recipient payload to copy, encode and emit it,
+	// excluding the Teleporter message.
+	SendAndCallGasCostPerByte uint64 = 20
+	// Gas used by send and sendAndCall to transfer a primary fee in from the sender and allow
+	// TeleporterMessenger to spend it, excluding the fee transfer into TeleporterMessenger.
+	SendTokensFeeGasCost uint64 = 65_000
+)
+
+// TokenTransfer describes an ICTT send or sendAndCall.
+type TokenTransfer struct {
+	// Call is set for sendAndCall
+	Call bool
+	// MultiHop is set for transfers from a TokenRemote to another TokenRemote via their TokenHome
+	MultiHop bool
+	// RecipientPayloadSize is the size of the recipient payload of a sendAndCall
+	RecipientPayloadSize int
+	// RequiredGasLimit is the required gas limit of the input, which is used by the last leg
+	RequiredGasLimit uint64
+	// PayPrimaryFee is set if a non-zero primary fee is paid to relay the first leg
+	PayPrimaryFee bool
+	// NumReceipts is the number of receipts attached to the first leg's Teleporter message
+	NumReceipts int
+}
+
+// TokenTransferGasEstimate is the estimated gas of each transaction of a token transfer.
+type TokenTransferGasEstimate struct {
+	// SendGasLimit is the gas limit of the transaction calling send or sendAndCall
+	SendGasLimit uint64
+	// ReceiveGasLimits are the gas limits of the transactions delivering the Teleporter message
+	// of each leg, which is one for single-hop transfers and two for multi-hop transfers
+	ReceiveGasLimits []uint64
+}
+
+// Total returns the sum of the gas limits of all transactions of the transfer.
+func (e TokenTransferGasEstimate) Total() (uint64, error) {
+	return sumGas(append([]uint64{e.SendGasLimit}, e.ReceiveGasLimits...)...)
+}
+
+// EstimateTokenTransferGas estimates the gas of sending a token transfer, and of delivering the
+// Teleporter message of each of its legs when signed by numSigners validators. Since the receipts
+// attached to the secondary leg of a multi-hop transfer are not known upfront, the maximum number
+// of receipts is assumed.
+func EstimateTokenTransferGas(transfer TokenTransfer, numSigners int) (TokenTransferGasEstimate, error) {
+	var estimate TokenTransferGasEstimate
+
+	firstLegMessageSize := transferrerMessageSize(transfer.Call, transfer.MultiHop, transfer.RecipientPayloadSize)
+	teleporterGas, err := sendMessageExecutionGas(0, transfer.NumReceipts, firstLegMessageSize, transfer.PayPrimaryFee)
+	if err != nil {
+		return estimate, err
+	}
+	// send(SendTokensInput,uint256) encodes eight static words and the amount, while
+	// sendAndCall(SendAndCallInput,uint256) encodes the offset of the input, its eleven head
+	// words, the amount and the recipient payload.
+	calldataSize := 4 + 9*common.HashLength
+	var payloadGas uint64
+	if transfer.Call {
+		calldataSize = 4 + 13*common.HashLength + encodedBytesSize(transfer.RecipientPayloadSize)
+		payloadGas = uint64(transfer.RecipientPayloadSize) * SendAndCallGasCostPerByte
+	}
+	var feeGas uint64
+	if transfer.PayPrimaryFee {
+		feeGas = SendTokensFeeGasCost
+	}
+	estimate.SendGasLimit, err = sumGas(
+		params.TxGas,
+		CalldataGasCost(calldataSize),
+		SendTokensBaseGasCost,
+		payloadGas,
+		feeGas,
+		teleporterGas,
+	)
+	if err != nil {
+		return estimate, err
+	}
+
+	firstLegRequiredGas := transfer.RequiredGasLimit
+	if transfer.MultiHop {
+		firstLegRequiredGas = MultiHopSendRequiredGas
+		if transfer.Call {
+			numWords := uint64((transfer.RecipientPayloadSize + common.HashLength - 1) / common.HashLength)
+			firstLegRequiredGas = MultiHopCallRequiredGas + numWords*MultiHopCallGasPerWord
+		}
+	}
+	firstLegGas, err := calculateTransferReceiveGasLimit(
+		numSigners,
+		firstLegRequiredGas,
+		firstLegMessageSize,
+		transfer.NumReceipts,
+	)
+	if err != nil {
+		return estimate, err
+	}
+	estimate.ReceiveGasLimits = []uint64{firstLegGas}
+	if !transfer.MultiHop {
+		return estimate, nil
+	}
+
+	secondLegGas, err := calculateTransferReceiveGasLimit(
+		numSigners,
+		transfer.RequiredGasLimit,
+		transferrerMessageSize(transfer.Call, false, transfer.RecipientPayloadSize),
+		MaxReceiptsPerMessage,
+	)
+	if err != nil {
+		return estimate, err
+	}
+	estimate.ReceiveGasLimits = append(estimate.ReceiveGasLimits, secondLegGas)
+	return estimate, nil
+}
+
+func calculateTransferReceiveGasLimit(
+	numSigners int,
+	requiredGasLimit uint64,
+	transferrerMessageSize int,
+	numReceipts int,
+) (uint64, error) {
+	teleporterMessageSize := TeleporterMessageSize(0, numReceipts, transferrerMessageSize)
+	return CalculateReceiveMessageGasLimit(
+		numSigners,
+		new(big.Int).SetUint64(requiredGasLimit),
+		WarpMessageSize(teleporterMessageSize, numSigners),
+		teleporterMessageSize,
+		numReceipts,
+	)
+}
+
+// transferrerMessageSize returns the size of an ABI encoded TransferrerMessage sent by send or
+// sendAndCall. A TransferrerMessage encodes its offset, message type and payload.
+func transferrerMessageSize(call bool, multiHop bool, recipientPayloadSize int) int {
+	var payloadSize int
+	switch {
+	case !call && !multiHop:
+		// SingleHopSendMessage
+		payloadSize = 2 * common.HashLength
+	case !call && multiHop:
+		// MultiHopSendMessage
+		payloadSize = 7 * common.HashLength
+	case call && !multiHop:
+		// SingleHopCallMessage, with its offset and eight head words
+		payloadSize = 9*common.HashLength + encodedBytesSize(recipientPayloadSize)
+	default:
+		// MultiHopCallMessage, with its offset and eleven head words
+		payloadSize = 12*common.HashLength + encodedBytesSize(recipientPayloadSize)
+	}
+	return 3*common.HashLength + encodedBytesSize(payloadSize)
+}
@@ -0,0 +1,109 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Sizes of the Warp message payloads consumed by the validator manager contracts, including
+// their codec version and type ID.
+const (
+	SubnetToL1ConversionMessageSize    = 38
+	L1ValidatorRegistrationMessageSize = 39
+	L1ValidatorWeightMessageSize       = 54
+	ValidatorUptimeMessageSize         = 46
+)
+
+// Gas used by the validator manager functions consuming Warp messages, excluding the intrinsic
+// gas and the Warp message verification. The PoS costs include unlocking stake and paying rewards
+// to new accounts. They are calibrated against the compiled PoAValidatorManager,
+// ERC20TokenStakingManager staking an OpenZeppelin ERC20, and NativeTokenStakingManager by
+// TestCalibrateValidatorManagerGasLimits, with a margin of about 10% over the most expensive case.
+// CoqnetERC20TokenStakingManager does not override these functions.
+const (
+	InitializeValidatorSetBaseGasCost         uint64 = 75_000
+	InitializeValidatorSetGasCostPerValidator uint64 = 110_000
+	CompleteValidatorRegistrationGasCost      uint64 = 45_000
+	CompleteEndValidationGasCost              uint64 = 120_000
+	CompleteDelegatorRegistrationGasCost      uint64 = 50_000
+	CompleteEndDelegationGasCost              uint64 = 160_000
+	SubmitUptimeProofGasCost                  uint64 = 60_000
+)
+
+// ValidatorManagerOperation is a validator manager function that consumes a Warp message
+// delivered in the transaction's predicate.
+type ValidatorManagerOperation struct {
+	// ExecutionGas is the gas used by the function, excluding the intrinsic gas and the Warp
+	// message verification
+	ExecutionGas uint64
+	// CalldataSize is the size of the function's calldata
+	CalldataSize int
+	// PayloadSize is the size of the Warp message payload
+	PayloadSize int
+}
+
+var (
+	// completeValidatorRegistration(uint32)
+	CompleteValidatorRegistration = ValidatorManagerOperation{
+		ExecutionGas: CompleteValidatorRegistrationGasCost,
+		CalldataSize: 4 + common.HashLength,
+		PayloadSize:  L1ValidatorRegistrationMessageSize,
+	}
+	// completeEndValidation(uint32)
+	CompleteEndValidation = ValidatorManagerOperation{
+		ExecutionGas: CompleteEndValidationGasCost,
+		CalldataSize: 4 + common.HashLength,
+		PayloadSize:  L1ValidatorRegistrationMessageSize,
+	}
+	// completeDelegatorRegistration(bytes32,uint32)
+	CompleteDelegatorRegistration = ValidatorManagerOperation{
+		ExecutionGas: CompleteDelegatorRegistrationGasCost,
+		CalldataSize: 4 + 2*common.HashLength,
+		PayloadSize:  L1ValidatorWeightMessageSize,
+	}
+	// completeEndDelegation(bytes32,uint32)
+	CompleteEndDelegation = ValidatorManagerOperation{
+		ExecutionGas: CompleteEndDelegationGasCost,
+		CalldataSize: 4 + 2*common.HashLength,
+		PayloadSize:  L1ValidatorWeightMessageSize,
+	}
+	// submitUptimeProof(bytes32,uint32)
+	SubmitUptimeProof = ValidatorManagerOperation{
+		ExecutionGas: SubmitUptimeProofGasCost,
+		CalldataSize: 4 + 2*common.HashLength,
+		PayloadSize:  ValidatorUptimeMessageSize,
+	}
+)
+
+// InitializeValidatorSet returns the initializeValidatorSet operation for the given number of
+// initial validators, with 20 byte node IDs and 48 byte BLS public keys.
+func InitializeValidatorSet(numValidators int) ValidatorManagerOperation {
+	// initializeValidatorSet(ConversionData,uint32) encodes the offset of the conversion data and
+	// the message index, the conversion data's four head words and validator count, and for each
+	// validator its offset, three head words, node ID and BLS public key.
+	validatorSize := 4*common.HashLength + encodedBytesSize(20) + encodedBytesSize(48)
+	return ValidatorManagerOperation{
+		ExecutionGas: InitializeValidatorSetBaseGasCost +
+			uint64(numValidators)*InitializeValidatorSetGasCostPerValidator,
+		CalldataSize: 4 + 7*common.HashLength + numValidators*validatorSize,
+		PayloadSize:  SubnetToL1ConversionMessageSize,
+	}
+}
+
+// GasLimit returns the estimated gas limit of a transaction performing the operation with a
+// Warp message signed by numSigners validators.
+func (op ValidatorManagerOperation) GasLimit(numSigners int) (uint64, error) {
+	warpGas, err := CalculateWarpMessageGasCost(WarpMessageSize(op.PayloadSize, numSigners), numSigners)
+	if err != nil {
+		return 0, err
+	}
+	return sumGas(
+		params.TxGas,
+		CalldataGasCost(op.CalldataSize),
+		warpGas,
+		op.ExecutionGas,
+	)
+}
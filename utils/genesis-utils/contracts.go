@@ -0,0 +1,107 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"fmt"
+
+	transparentupgradeableproxy "github.com/ava-labs/icm-contracts/abi-bindings/go/TransparentUpgradeableProxy"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	proxyUtils "github.com/ava-labs/icm-contracts/utils/proxy-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// InitializersDisallowed is ICMInitializable.Disallowed, which disables the initializers of an
+// implementation contract deployed behind a proxy.
+const InitializersDisallowed uint8 = 1
+
+// ValidatorManagerPredeploy describes a PoAValidatorManager predeployed behind a
+// TransparentUpgradeableProxy.
+type ValidatorManagerPredeploy struct {
+	ProxyAddress common.Address
+	// ProxyAdminOwner owns the ProxyAdmin created by the proxy, and may upgrade it
+	ProxyAdminOwner       common.Address
+	ImplementationAddress common.Address
+	// ValidatorMessagesAddress is the address of the ValidatorMessages library linked by the
+	// implementation
+	ValidatorMessagesAddress common.Address
+	// Settings initializes the proxy with Owner as the owner of the validator manager. If nil,
+	// the proxy is not initialized.
+	Settings *poavalidatormanager.ValidatorManagerSettings
+	Owner    common.Address
+}
+
+// PredeployPoAValidatorManager predeploys the ValidatorMessages library, a PoAValidatorManager
+// implementation with its initializers disabled, and a TransparentUpgradeableProxy to it, which
+// creates its ProxyAdmin. If settings are given, the proxy is initialized with them, as if
+// initialize had been called by a transaction. Returns the address of the ProxyAdmin.
+func (b *Builder) PredeployPoAValidatorManager(predeploy ValidatorManagerPredeploy) (common.Address, error) {
+	err := b.PredeployAt(
+		predeploy.ValidatorMessagesAddress,
+		common.FromHex(poavalidatormanager.ValidatorMessagesMetaData.Bin),
+	)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to predeploy ValidatorMessages")
+	}
+
+	implementationABI, err := poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, err
+	}
+	implementationArgs, err := implementationABI.Pack("", InitializersDisallowed)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to pack constructor arguments")
+	}
+	implementationCode := append(
+		common.FromHex(LinkLibrary(poavalidatormanager.PoAValidatorManagerMetaData.Bin, predeploy.ValidatorMessagesAddress)),
+		implementationArgs...,
+	)
+	if err := b.PredeployAt(predeploy.ImplementationAddress, implementationCode); err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to predeploy PoAValidatorManager")
+	}
+
+	initData := []byte{}
+	if predeploy.Settings != nil {
+		initData, err = implementationABI.Pack("initialize", *predeploy.Settings, predeploy.Owner)
+		if err != nil {
+			return common.Address{}, errors.Wrap(err, "failed to pack initialize")
+		}
+	}
+	proxyCode, err := creationCode(
+		transparentupgradeableproxy.TransparentUpgradeableProxyMetaData,
+		predeploy.ImplementationAddress,
+		predeploy.ProxyAdminOwner,
+		initData,
+	)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if err := b.PredeployAt(predeploy.ProxyAddress, proxyCode); err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to predeploy TransparentUpgradeableProxy")
+	}
+
+	// The proxy creates its ProxyAdmin with its first nonce
+	proxyAdmin := crypto.CreateAddress(predeploy.ProxyAddress, 1)
+	if admin := common.BytesToAddress(b.state.GetState(predeploy.ProxyAddress, proxyUtils.AdminSlot).Bytes()); admin != proxyAdmin {
+		return common.Address{}, fmt.Errorf("proxy admin is %s, expected %s", admin, proxyAdmin)
+	}
+	b.Include(proxyAdmin)
+	return proxyAdmin, nil
+}
+
+// creationCode returns the creation code of a contract with its ABI encoded constructor arguments.
+func creationCode(metadata *bind.MetaData, args ...interface{}) ([]byte, error) {
+	contractABI, err := metadata.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	packedArgs, err := contractABI.Pack("", args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack constructor arguments")
+	}
+	return append(common.FromHex(metadata.Bin), packedArgs...), nil
+}
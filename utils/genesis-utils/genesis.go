@@ -0,0 +1,313 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/upgrade"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/commontype"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/triedb"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Gas available to each predeployed contract's constructor
+	predeployGasLimit uint64 = 30_000_000
+	// Default gas limit of genesis blocks
+	DefaultGasLimit uint64 = 20_000_000
+)
+
+var (
+	// Account the constructors of predeployed contracts are called from. It is not included in
+	// the genesis allocation.
+	predeployerAddress = common.HexToAddress("0x0000000000000000000000000000000000fEEd00")
+)
+
+// Builder builds a subnet-evm genesis with predeployed contracts. Contracts are predeployed by
+// running their constructors in an in-memory EVM, so that their code, including immutable
+// variables, and their storage are exactly as if they had been deployed by a transaction.
+type Builder struct {
+	chainID   *big.Int
+	timestamp uint64
+	gasLimit  uint64
+	feeConfig commontype.FeeConfig
+
+	precompiles params.Precompiles
+	evmConfig   *params.ChainConfig
+	db          state.Database
+	state       *state.StateDB
+	// Accounts included in the genesis allocation
+	accounts map[common.Address]struct{}
+}
+
+// NewBuilder returns a builder of the genesis of the chain with EVM chain ID chainID, whose
+// genesis block has the given timestamp. Precompiles enabled in the genesis are activated at
+// that timestamp.
+func NewBuilder(chainID *big.Int, timestamp uint64) (*Builder, error) {
+	db := state.NewDatabaseWithConfig(rawdb.NewMemoryDatabase(), &triedb.Config{Preimages: true})
+	statedb, err := state.New(types.EmptyRootHash, db, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create state")
+	}
+	// The Warp precompile is enabled while predeploying contracts, since their constructors
+	// may read the blockchain ID. It is not known yet, see PredeployAt.
+	evmConfig := *params.TestChainConfig
+	evmConfig.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewDefaultConfig(subnetEvmUtils.NewUint64(0)),
+	}
+	evmConfig.SnowCtx = &snow.Context{ChainID: ids.Empty}
+	return &Builder{
+		chainID:     chainID,
+		timestamp:   timestamp,
+		gasLimit:    DefaultGasLimit,
+		feeConfig:   params.DefaultFeeConfig,
+		precompiles: make(params.Precompiles),
+		evmConfig:   &evmConfig,
+		db:          db,
+		state:       statedb,
+		accounts:    make(map[common.Address]struct{}),
+	}, nil
+}
+
+// SetGasLimit sets the gas limit of the genesis block and of the fee config.
+func (b *Builder) SetGasLimit(gasLimit uint64) {
+	b.gasLimit = gasLimit
+	b.feeConfig.GasLimit = new(big.Int).SetUint64(gasLimit)
+}
+
+// SetFeeConfig sets the fee config of the chain.
+func (b *Builder) SetFeeConfig(feeConfig commontype.FeeConfig) {
+	b.feeConfig = feeConfig
+}
+
+// Fund adds amount to the balance of account.
+func (b *Builder) Fund(account common.Address, amount *big.Int) {
+	b.state.AddBalance(account, uint256.MustFromBig(amount))
+	b.accounts[account] = struct{}{}
+}
+
+// EnableWarp enables the Warp precompile from the genesis block.
+func (b *Builder) EnableWarp(quorumNumerator uint64, requirePrimaryNetworkSigners bool) {
+	b.precompiles[warp.ConfigKey] = warp.NewConfig(
+		subnetEvmUtils.NewUint64(b.timestamp),
+		quorumNumerator,
+		requirePrimaryNetworkSigners,
+	)
+}
+
+// EnableNativeMinter enables the NativeMinter precompile from the genesis block, with the given
+// admin, manager and enabled addresses.
+func (b *Builder) EnableNativeMinter(admins []common.Address, managers []common.Address, enableds []common.Address) {
+	b.precompiles[nativeminter.ConfigKey] = nativeminter.NewConfig(
+		subnetEvmUtils.NewUint64(b.timestamp),
+		admins,
+		enableds,
+		managers,
+		nil,
+	)
+}
+
+// PredeployKeyless predeploys the contract created by a keyless transaction, such as the
+// transaction deploying TeleporterMessenger. The contract is created by the keyless deployer,
+// so it is deployed at its universal address, and the deployer's nonce is set to 1, so that the
+// keyless transaction cannot be sent on the chain. Returns the address of the contract.
+func (b *Builder) PredeployKeyless(keylessTx []byte) (common.Address, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(keylessTx); err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to parse keyless transaction")
+	}
+	if tx.To() != nil || tx.Nonce() != 0 {
+		return common.Address{}, fmt.Errorf("transaction %s is not a keyless contract creation", tx.Hash())
+	}
+	deployer, err := types.HomesteadSigner{}.Sender(tx)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to recover keyless deployer")
+	}
+	if b.state.GetNonce(deployer) != 0 {
+		return common.Address{}, fmt.Errorf("keyless deployer %s has already been used", deployer)
+	}
+	address, _, err := b.apply(deployer, nil, tx.Data())
+	if err != nil {
+		return common.Address{}, err
+	}
+	b.accounts[deployer] = struct{}{}
+	b.accounts[address] = struct{}{}
+	return address, nil
+}
+
+// PredeployAt predeploys a contract at address by running its creation code, including any
+// ABI encoded constructor arguments, as the code of address. The constructor observes
+// address(this) as address, and its code and storage end up at address, as if it had been
+// created there.
+//
+// The blockchain ID of a chain is derived from the transaction creating it, which includes its
+// genesis, so it is not known when the genesis is built. The Warp precompile reports the empty ID,
+// and contracts storing the blockchain ID of their chain, such as TeleporterRegistry and
+// ValidatorSetSig, must be deployed after the chain is launched instead.
+func (b *Builder) PredeployAt(address common.Address, creationCode []byte) error {
+	if b.state.GetCodeSize(address) != 0 || b.state.GetNonce(address) != 0 {
+		return fmt.Errorf("account %s is already in use", address)
+	}
+	// Contracts start with nonce 1, so that contracts they create are at the expected addresses
+	b.state.SetNonce(address, 1)
+	b.state.SetCode(address, creationCode)
+	_, runtimeCode, err := b.apply(predeployerAddress, &address, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run constructor at %s", address)
+	}
+	if len(runtimeCode) == 0 {
+		return fmt.Errorf("constructor at %s returned no code", address)
+	}
+	b.state.SetCode(address, runtimeCode)
+	b.accounts[address] = struct{}{}
+	return nil
+}
+
+// Call calls a predeployed contract, e.g. to initialize it. Accounts created by the call are
+// only included in the genesis allocation if they are added with Include.
+func (b *Builder) Call(to common.Address, data []byte) ([]byte, error) {
+	_, ret, err := b.apply(predeployerAddress, &to, data)
+	return ret, err
+}
+
+// Include adds an account created by a predeployed contract, such as the ProxyAdmin created by
+// a TransparentUpgradeableProxy, to the genesis allocation.
+func (b *Builder) Include(account common.Address) {
+	b.accounts[account] = struct{}{}
+}
+
+func (b *Builder) apply(
+	from common.Address,
+	to *common.Address,
+	data []byte,
+) (common.Address, []byte, error) {
+	msg := &core.Message{
+		To:                to,
+		From:              from,
+		Nonce:             b.state.GetNonce(from),
+		Value:             big.NewInt(0),
+		GasLimit:          predeployGasLimit,
+		GasPrice:          big.NewInt(0),
+		GasFeeCap:         big.NewInt(0),
+		GasTipCap:         big.NewInt(0),
+		Data:              data,
+		SkipAccountChecks: true,
+	}
+	blockContext := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		BlockNumber: big.NewInt(0),
+		// Activates the latest network upgrade, so that all opcodes emitted by solc are available
+		Time:       uint64(upgrade.InitiallyActiveTime.Unix()),
+		GasLimit:   predeployGasLimit,
+		Difficulty: big.NewInt(0),
+		BaseFee:    big.NewInt(0),
+	}
+	evm := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), b.state, b.evmConfig, vm.Config{NoBaseFee: true})
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(predeployGasLimit))
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if result.Err != nil {
+		if reason, unpackErr := abi.UnpackRevert(result.Revert()); unpackErr == nil {
+			return common.Address{}, nil, fmt.Errorf("%w: %s", result.Err, reason)
+		}
+		return common.Address{}, nil, result.Err
+	}
+	var created common.Address
+	if to == nil {
+		created = crypto.CreateAddress(from, msg.Nonce)
+	}
+	b.state.Finalise(true)
+	return created, result.Return(), nil
+}
+
+// LinkLibrary replaces the library placeholders left by solc in bytecode with the address of
+// the library. The bytecode must link a single library, as all placeholders are replaced.
+func LinkLibrary(bytecode string, library common.Address) string {
+	return deploymentUtils.LinkLibrary(bytecode, library)
+}
+
+// Genesis returns the genesis of the chain, with the predeployed contracts and funded accounts
+// in its allocation.
+func (b *Builder) Genesis() (*core.Genesis, error) {
+	root, err := b.state.Commit(0, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to commit state")
+	}
+	committed, err := state.New(root, b.db, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open committed state")
+	}
+	dump := committed.RawDump(&state.DumpConfig{OnlyWithAddresses: true})
+
+	alloc := make(types.GenesisAlloc, len(b.accounts))
+	for address := range b.accounts {
+		dumped, ok := dump.Accounts[address.Hex()]
+		if !ok {
+			// Accounts that remained empty are deleted from the state
+			continue
+		}
+		balance, ok := new(big.Int).SetString(dumped.Balance, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid balance %s of %s", dumped.Balance, address)
+		}
+		account := types.Account{
+			Code:    dumped.Code,
+			Balance: balance,
+			Nonce:   dumped.Nonce,
+		}
+		if len(dumped.Storage) > 0 {
+			account.Storage = make(map[common.Hash]common.Hash, len(dumped.Storage))
+			for slot, value := range dumped.Storage {
+				account.Storage[slot] = common.HexToHash(value)
+			}
+		}
+		alloc[address] = account
+	}
+
+	feeConfig := b.feeConfig
+	feeConfig.GasLimit = new(big.Int).SetUint64(b.gasLimit)
+	config := &params.ChainConfig{
+		ChainID:             b.chainID,
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		MuirGlacierBlock:    big.NewInt(0),
+		FeeConfig:           feeConfig,
+		GenesisPrecompiles:  b.precompiles,
+	}
+	return &core.Genesis{
+		Config:     config,
+		Timestamp:  b.timestamp,
+		ExtraData:  []byte{0},
+		GasLimit:   b.gasLimit,
+		Difficulty: big.NewInt(0),
+		Alloc:      alloc,
+	}, nil
+}
@@ -0,0 +1,192 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/upgrade"
+	proxyadmin "github.com/ava-labs/icm-contracts/abi-bindings/go/ProxyAdmin"
+	teleportermessenger "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/TeleporterMessenger"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	deploymentUtils "github.com/ava-labs/icm-contracts/utils/deployment-utils"
+	proxyUtils "github.com/ava-labs/icm-contracts/utils/proxy-utils"
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core"
+	"github.com/ava-labs/subnet-evm/core/rawdb"
+	"github.com/ava-labs/subnet-evm/core/state"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/core/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/nativeminter"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	contractAddress          = common.HexToAddress("0x0000000000000000000000000000000000001001")
+	proxyAddress             = common.HexToAddress("0x0C0DEBA5E0000000000000000000000000000000")
+	implementationAddress    = common.HexToAddress("0x0000000000000000000000000000000000001003")
+	validatorMessagesAddress = common.HexToAddress("0x0000000000000000000000000000000000001004")
+	proxyAdminOwner          = common.HexToAddress("0x0000000000000000000000000000000000002001")
+	validatorManagerOwner    = common.HexToAddress("0x0000000000000000000000000000000000002002")
+	fundedAddress            = common.HexToAddress("0x0000000000000000000000000000000000002003")
+	caller                   = common.HexToAddress("0x0000000000000000000000000000000000002004")
+)
+
+// genesisChain executes calls against the state of a genesis allocation.
+type genesisChain struct {
+	config *params.ChainConfig
+	state  *state.StateDB
+}
+
+func newGenesisChain(t *testing.T, alloc types.GenesisAlloc) *genesisChain {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	for address, account := range alloc {
+		statedb.SetBalance(address, uint256.MustFromBig(account.Balance))
+		statedb.SetNonce(address, account.Nonce)
+		statedb.SetCode(address, account.Code)
+		for slot, value := range account.Storage {
+			statedb.SetState(address, slot, value)
+		}
+	}
+	config := *params.TestChainConfig
+	config.GenesisPrecompiles = params.Precompiles{
+		warp.ConfigKey: warp.NewDefaultConfig(subnetEvmUtils.NewUint64(0)),
+	}
+	config.SnowCtx = &snow.Context{ChainID: ids.GenerateTestID()}
+	return &genesisChain{config: &config, state: statedb}
+}
+
+func (c *genesisChain) call(t *testing.T, contractABI *abi.ABI, to common.Address, method string, args ...interface{}) []interface{} {
+	data, err := contractABI.Pack(method, args...)
+	require.NoError(t, err)
+	blockContext := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		BlockNumber: big.NewInt(1),
+		Time:        uint64(upgrade.InitiallyActiveTime.Unix()),
+		GasLimit:    predeployGasLimit,
+		Difficulty:  big.NewInt(0),
+		BaseFee:     big.NewInt(0),
+	}
+	evm := vm.NewEVM(blockContext, vm.TxContext{Origin: caller, GasPrice: big.NewInt(0)}, c.state, c.config, vm.Config{NoBaseFee: true})
+	ret, _, err := evm.StaticCall(vm.AccountRef(caller), to, data, predeployGasLimit)
+	require.NoError(t, err)
+	result, err := contractABI.Unpack(method, ret)
+	require.NoError(t, err)
+	return result
+}
+
+func TestBuilder(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+
+	builder, err := NewBuilder(big.NewInt(99999), 1719343601)
+	require.NoError(t, err)
+	builder.Fund(fundedAddress, big.NewInt(1e18))
+	builder.EnableWarp(0, true)
+	builder.EnableNativeMinter([]common.Address{fundedAddress}, nil, nil)
+
+	keylessTx, err := deploymentUtils.NewKeylessTransaction(common.FromHex(teleportermessenger.TeleporterMessengerMetaData.Bin), 0, nil)
+	require.NoError(t, err)
+	teleporterAddress, err := builder.PredeployKeyless(keylessTx.Tx)
+	require.NoError(t, err)
+	require.Equal(t, keylessTx.ContractAddress, teleporterAddress)
+	_, err = builder.PredeployKeyless(keylessTx.Tx)
+	require.ErrorContains(t, err, "has already been used")
+
+	proxyAdmin, err := builder.PredeployPoAValidatorManager(ValidatorManagerPredeploy{
+		ProxyAddress:             proxyAddress,
+		ProxyAdminOwner:          proxyAdminOwner,
+		ImplementationAddress:    implementationAddress,
+		ValidatorMessagesAddress: validatorMessagesAddress,
+		Settings: &poavalidatormanager.ValidatorManagerSettings{
+			SubnetID:               subnetID,
+			ChurnPeriodSeconds:     3600,
+			MaximumChurnPercentage: 20,
+		},
+		Owner: validatorManagerOwner,
+	})
+	require.NoError(t, err)
+
+	genesis, err := builder.Genesis()
+	require.NoError(t, err)
+
+	// The genesis is serialized as a subnet-evm genesis file
+	genesisJSON, err := json.Marshal(genesis)
+	require.NoError(t, err)
+	var parsed core.Genesis
+	require.NoError(t, json.Unmarshal(genesisJSON, &parsed))
+	require.Equal(t, big.NewInt(99999), parsed.Config.ChainID)
+	require.Equal(t, uint64(1719343601), parsed.Timestamp)
+	require.Contains(t, parsed.Config.GenesisPrecompiles, warp.ConfigKey)
+	require.True(t, parsed.Config.GenesisPrecompiles[warp.ConfigKey].(*warp.Config).RequirePrimaryNetworkSigners)
+	require.Equal(t, []common.Address{fundedAddress}, parsed.Config.GenesisPrecompiles[nativeminter.ConfigKey].(*nativeminter.Config).AdminAddresses)
+	// Network upgrades are set by the VM from the network's upgrade schedule
+	parsed.Config.SnowCtx = &snow.Context{NetworkUpgrades: upgrade.Default}
+	parsed.Config.SetNetworkUpgradeDefaults()
+	require.NoError(t, parsed.Config.Verify())
+
+	alloc := parsed.Alloc
+	require.Len(t, alloc, 7)
+	require.Equal(t, big.NewInt(1e18), alloc[fundedAddress].Balance)
+	require.Equal(t, uint64(1), alloc[keylessTx.DeployerAddress].Nonce)
+	require.NotContains(t, alloc, predeployerAddress)
+
+	// TeleporterMessenger is deployed with its reentrancy guards initialized
+	teleporter := alloc[teleporterAddress]
+	require.Equal(t, uint64(1), teleporter.Nonce)
+	require.Equal(t, map[common.Hash]common.Hash{
+		common.BigToHash(big.NewInt(0)): common.BigToHash(big.NewInt(1)),
+		common.BigToHash(big.NewInt(1)): common.BigToHash(big.NewInt(1)),
+	}, teleporter.Storage)
+
+	chain := newGenesisChain(t, alloc)
+
+	require.Equal(t, common.BytesToHash(implementationAddress[:]), alloc[proxyAddress].Storage[proxyUtils.ImplementationSlot])
+	require.Equal(t, common.BytesToHash(proxyAdmin[:]), alloc[proxyAddress].Storage[proxyUtils.AdminSlot])
+	proxyAdminABI, err := proxyadmin.ProxyAdminMetaData.GetAbi()
+	require.NoError(t, err)
+	require.Equal(t, proxyAdminOwner, chain.call(t, proxyAdminABI, proxyAdmin, "owner")[0])
+	validatorManagerABI, err := poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	require.Equal(t, validatorManagerOwner, chain.call(t, validatorManagerABI, proxyAddress, "owner")[0])
+	require.Equal(t, common.Hash(subnetID), alloc[proxyAddress].Storage[storageUtils.NamespaceSlot(storageUtils.ValidatorManagerNamespace)])
+	// The implementation cannot be initialized
+	require.Empty(t, alloc[implementationAddress].Storage[storageUtils.NamespaceSlot(storageUtils.ValidatorManagerNamespace)])
+}
+
+func TestPredeployErrors(t *testing.T) {
+	builder, err := NewBuilder(big.NewInt(99999), 0)
+	require.NoError(t, err)
+
+	// PUSH1 0 PUSH1 0 REVERT
+	err = builder.PredeployAt(common.Address{1}, common.FromHex("0x60006000fd"))
+	require.ErrorContains(t, err, "failed to run constructor")
+
+	// PUSH1 0 PUSH1 0 RETURN
+	err = builder.PredeployAt(common.Address{2}, common.FromHex("0x60006000f3"))
+	require.ErrorContains(t, err, "returned no code")
+
+	// Returns the single byte STOP as the runtime code: PUSH1 1 PUSH1 0 RETURN
+	require.NoError(t, builder.PredeployAt(contractAddress, common.FromHex("0x60016000f3")))
+	err = builder.PredeployAt(contractAddress, common.FromHex("0x60016000f3"))
+	require.ErrorContains(t, err, "already in use")
+}
+
+func TestLinkLibrary(t *testing.T) {
+	library := common.HexToAddress("0x00000000000000000000000000000000000000AB")
+	linked := LinkLibrary("0x6000__$fd0c147b4031eef6079b0498cbafa865f0$__6000", library)
+	require.Equal(t, "0x600000000000000000000000000000000000000000AB6000", linked)
+}
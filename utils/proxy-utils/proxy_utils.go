@@ -0,0 +1,191 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ImplementationSlot is the EIP-1967 slot storing the implementation of a proxy,
+	// bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1)
+	ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// AdminSlot is the EIP-1967 slot storing the admin of a proxy,
+	// bytes32(uint256(keccak256("eip1967.proxy.admin")) - 1)
+	AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+)
+
+// Client is the subset of an EVM client needed to inspect proxies.
+type Client interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// GetImplementation returns the implementation of an EIP-1967 proxy.
+func GetImplementation(ctx context.Context, client Client, proxy common.Address) (common.Address, error) {
+	return readAddressSlot(ctx, client, proxy, ImplementationSlot)
+}
+
+// GetAdmin returns the admin of an EIP-1967 proxy. For a TransparentUpgradeableProxy,
+// this is the ProxyAdmin contract deployed by the proxy's constructor.
+func GetAdmin(ctx context.Context, client Client, proxy common.Address) (common.Address, error) {
+	return readAddressSlot(ctx, client, proxy, AdminSlot)
+}
+
+func readAddressSlot(ctx context.Context, client Client, proxy common.Address, slot common.Hash) (common.Address, error) {
+	value, err := client.StorageAt(ctx, proxy, slot, nil)
+	if err != nil {
+		return common.Address{}, errors.Wrapf(err, "failed to read slot %s of %s", slot, proxy)
+	}
+	return common.BytesToAddress(value), nil
+}
+
+// libraryPlaceholderPattern matches the placeholder solc leaves for the address of a linked library
+var libraryPlaceholderPattern = regexp.MustCompile(`__\$[0-9a-fA-F]{34}\$__`)
+
+// ByteRange is a range of bytes of deployed bytecode.
+type ByteRange struct {
+	Start  uint64 `json:"start"`
+	Length uint64 `json:"length"`
+}
+
+// DeployedBytecode is the deployed bytecode of a contract from a foundry artifact.
+type DeployedBytecode struct {
+	// Object is the bytecode, with the addresses of linked libraries zeroed
+	Object hexutil.Bytes
+	// Byte ranges of the immutable variables, which are set by the constructor, keyed by AST ID
+	ImmutableReferences map[string][]ByteRange
+	// Byte ranges of the addresses of linked libraries, which are set when the contract is
+	// deployed, keyed by source file and library name
+	LinkReferences map[string]map[string][]ByteRange
+}
+
+// LoadDeployedBytecode reads the deployed bytecode from a foundry artifact.
+func LoadDeployedBytecode(path string) (*DeployedBytecode, error) {
+	artifactJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read artifact %s", path)
+	}
+	var artifact struct {
+		DeployedBytecode *struct {
+			Object              string                            `json:"object"`
+			ImmutableReferences map[string][]ByteRange            `json:"immutableReferences"`
+			LinkReferences      map[string]map[string][]ByteRange `json:"linkReferences"`
+		} `json:"deployedBytecode"`
+	}
+	if err := json.Unmarshal(artifactJSON, &artifact); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse artifact %s", path)
+	}
+	if artifact.DeployedBytecode == nil {
+		return nil, fmt.Errorf("artifact %s has no deployedBytecode", path)
+	}
+	// Unlinked library addresses are placeholders such as __$...$__, which are not valid hex.
+	// They are zeroed like the addresses of linked libraries are masked.
+	object, err := hexutil.Decode(libraryPlaceholderPattern.ReplaceAllString(
+		artifact.DeployedBytecode.Object,
+		strings.Repeat("0", 2*common.AddressLength),
+	))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse deployedBytecode of %s", path)
+	}
+	return &DeployedBytecode{
+		Object:              object,
+		ImmutableReferences: artifact.DeployedBytecode.ImmutableReferences,
+		LinkReferences:      artifact.DeployedBytecode.LinkReferences,
+	}, nil
+}
+
+// Matches returns an error if code does not match the deployed bytecode. Immutable variables
+// and the addresses of linked libraries are ignored, and if ignoreMetadata is set, so is the CBOR encoded metadata appended by solc,
+// which changes with the source file paths and comments.
+func (b *DeployedBytecode) Matches(code []byte, ignoreMetadata bool) error {
+	if len(code) == 0 {
+		return errors.New("no code deployed")
+	}
+	expected := b.mask(b.Object)
+	actual := b.mask(code)
+	if ignoreMetadata {
+		expected = stripMetadata(expected)
+		actual = stripMetadata(actual)
+	}
+	if len(expected) != len(actual) {
+		return fmt.Errorf("code is %d bytes, expected %d bytes", len(actual), len(expected))
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			return fmt.Errorf("code differs from the artifact at byte %d", i)
+		}
+	}
+	return nil
+}
+
+// mask returns a copy of code with the immutable variables and library addresses zeroed.
+func (b *DeployedBytecode) mask(code []byte) []byte {
+	masked := bytes.Clone(code)
+	clearRanges := func(ranges []ByteRange) {
+		for _, r := range ranges {
+			if r.Start+r.Length > uint64(len(masked)) {
+				continue
+			}
+			clear(masked[r.Start : r.Start+r.Length])
+		}
+	}
+	for _, refs := range b.ImmutableReferences {
+		clearRanges(refs)
+	}
+	for _, libraries := range b.LinkReferences {
+		for _, refs := range libraries {
+			clearRanges(refs)
+		}
+	}
+	return masked
+}
+
+// stripMetadata removes the CBOR encoded metadata from the end of the code,
+// whose length is given by the last two bytes.
+func stripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	n := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if n+2 > len(code) {
+		return code
+	}
+	return code[:len(code)-n-2]
+}
+
+// VerifyDeployedBytecode returns an error if the code at address does not match the
+// deployed bytecode of the foundry artifact at artifactPath.
+func VerifyDeployedBytecode(
+	ctx context.Context,
+	client Client,
+	address common.Address,
+	artifactPath string,
+	ignoreMetadata bool,
+) error {
+	bytecode, err := LoadDeployedBytecode(artifactPath)
+	if err != nil {
+		return err
+	}
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get code of %s", address)
+	}
+	if err := bytecode.Matches(code, ignoreMetadata); err != nil {
+		return errors.Wrapf(err, "code of %s does not match %s", address, artifactPath)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type mockClient struct {
+	storage map[common.Hash]common.Hash
+	code    []byte
+}
+
+func (m *mockClient) StorageAt(_ context.Context, _ common.Address, key common.Hash, _ *big.Int) ([]byte, error) {
+	return m.storage[key].Bytes(), nil
+}
+
+func (m *mockClient) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	return m.code, nil
+}
+
+func TestGetImplementationAndAdmin(t *testing.T) {
+	implementation := common.HexToAddress("0x0000000000000000000000000000000000001234")
+	admin := common.HexToAddress("0x0000000000000000000000000000000000005678")
+	client := &mockClient{storage: map[common.Hash]common.Hash{
+		ImplementationSlot: common.BytesToHash(implementation.Bytes()),
+		AdminSlot:          common.BytesToHash(admin.Bytes()),
+	}}
+	ctx := context.Background()
+
+	actual, err := GetImplementation(ctx, client, common.Address{})
+	require.NoError(t, err)
+	require.Equal(t, implementation, actual)
+	actual, err = GetAdmin(ctx, client, common.Address{})
+	require.NoError(t, err)
+	require.Equal(t, admin, actual)
+}
+
+func TestVerifyDeployedBytecode(t *testing.T) {
+	const artifact = "testdata/Example.json"
+	bytecode, err := LoadDeployedBytecode(artifact)
+	require.NoError(t, err)
+
+	// Code with an immutable address set by the constructor
+	code := hexutil.MustDecode("0x6080604052731111111111111111111111111111111111111111ffa2646970667358221220aaaa000c")
+	// Code of the same contract compiled with different metadata
+	otherMetadata := hexutil.MustDecode("0x6080604052731111111111111111111111111111111111111111ffa2646970667358221220bbbb000c")
+	// Code of another contract
+	otherCode := hexutil.MustDecode("0x6080604052731111111111111111111111111111111111111111fea2646970667358221220aaaa000c")
+
+	require.NoError(t, bytecode.Matches(code, false))
+	require.ErrorContains(t, bytecode.Matches(otherMetadata, false), "differs from the artifact at byte 37")
+	require.NoError(t, bytecode.Matches(otherMetadata, true))
+	require.ErrorContains(t, bytecode.Matches(otherCode, true), "differs from the artifact at byte 26")
+	require.ErrorContains(t, bytecode.Matches(code[:10], false), "code is 10 bytes")
+	require.ErrorContains(t, bytecode.Matches(nil, false), "no code deployed")
+
+	ctx := context.Background()
+	require.NoError(t, VerifyDeployedBytecode(ctx, &mockClient{code: code}, common.Address{}, artifact, false))
+	require.ErrorContains(
+		t,
+		VerifyDeployedBytecode(ctx, &mockClient{code: otherCode}, common.Address{}, artifact, false),
+		"does not match testdata/Example.json",
+	)
+}
+
+func TestVerifyLinkedDeployedBytecode(t *testing.T) {
+	// Deployed bytecode of PoAValidatorManager, which links the ValidatorMessages library
+	const artifact = "testdata/PoAValidatorManager.json"
+	bytecode, err := LoadDeployedBytecode(artifact)
+	require.NoError(t, err)
+	refs := bytecode.LinkReferences["contracts/validator-manager/ValidatorMessages.sol"]["ValidatorMessages"]
+	require.Len(t, refs, 7)
+
+	artifactJSON, err := os.ReadFile(artifact)
+	require.NoError(t, err)
+	var unlinked struct {
+		DeployedBytecode struct {
+			Object string `json:"object"`
+		} `json:"deployedBytecode"`
+	}
+	require.NoError(t, json.Unmarshal(artifactJSON, &unlinked))
+	link := func(library common.Address) []byte {
+		return hexutil.MustDecode(libraryPlaceholderPattern.ReplaceAllString(unlinked.DeployedBytecode.Object, library.Hex()[2:]))
+	}
+
+	// The code matches whatever the address of the library it was linked with
+	code := link(common.HexToAddress("0x1111111111111111111111111111111111111111"))
+	require.NoError(t, bytecode.Matches(code, false))
+	require.NoError(t, bytecode.Matches(link(common.HexToAddress("0x2222222222222222222222222222222222222222")), false))
+
+	// Differences outside of the library addresses are detected
+	code[refs[0].Start-1] ^= 0xff
+	require.ErrorContains(t, bytecode.Matches(code, false), fmt.Sprintf("differs from the artifact at byte %d", refs[0].Start-1))
+}
@@ -0,0 +1,245 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/pkg/errors"
+)
+
+// Mirrors of the constants defined in ExampleRewardCalculator.sol and RewardsCalculator.sol.
+// These must be kept up-to-date with the contracts.
+const (
+	SecondsInYear                    uint64 = 31536000
+	UptimeRewardsThresholdPercentage uint64 = 80
+	BipsConversionFactor             uint64 = 10000
+
+	// MaxUptimeSeconds is the reward cap applied by RewardsCalculator, equal to one 30 day validation epoch.
+	MaxUptimeSeconds uint64 = 2592000
+)
+
+// RewardCalculator is an off-chain implementation of an IRewardCalculator contract.
+// Implementations must return exactly the same results as their on-chain counterparts,
+// and must return an error wherever the contract call would revert.
+type RewardCalculator interface {
+	// CalculateReward mirrors IRewardCalculator.calculateReward
+	CalculateReward(
+		stakeAmount *big.Int,
+		validatorStartTime uint64,
+		stakingStartTime uint64,
+		stakingEndTime uint64,
+		uptimeSeconds uint64,
+	) (*big.Int, error)
+
+	// MinimumUptime returns the smallest uptimeSeconds for which CalculateReward
+	// does not return zero for the given period.
+	MinimumUptime(validatorStartTime uint64, stakingStartTime uint64, stakingEndTime uint64) (uint64, error)
+}
+
+// ExampleRewardCalculator mirrors ExampleRewardCalculator.sol
+type ExampleRewardCalculator struct {
+	RewardBasisPoints uint64
+}
+
+// RewardsCalculator mirrors the Coqnet RewardsCalculator.sol, which caps rewards to MaxUptimeSeconds
+// of staking per validation.
+type RewardsCalculator struct {
+	RewardBasisPoints uint64
+}
+
+var (
+	_ RewardCalculator = (*ExampleRewardCalculator)(nil)
+	_ RewardCalculator = (*RewardsCalculator)(nil)
+)
+
+func (c *ExampleRewardCalculator) CalculateReward(
+	stakeAmount *big.Int,
+	validatorStartTime uint64,
+	stakingStartTime uint64,
+	stakingEndTime uint64,
+	uptimeSeconds uint64,
+) (*big.Int, error) {
+	return calculateLinearReward(
+		c.RewardBasisPoints,
+		stakeAmount,
+		validatorStartTime,
+		stakingStartTime,
+		stakingEndTime,
+		uptimeSeconds,
+	)
+}
+
+func (c *ExampleRewardCalculator) MinimumUptime(
+	validatorStartTime uint64,
+	_ uint64,
+	stakingEndTime uint64,
+) (uint64, error) {
+	return minimumUptime(validatorStartTime, stakingEndTime)
+}
+
+func (c *RewardsCalculator) CalculateReward(
+	stakeAmount *big.Int,
+	validatorStartTime uint64,
+	stakingStartTime uint64,
+	stakingEndTime uint64,
+	uptimeSeconds uint64,
+) (*big.Int, error) {
+	stakingEndTime, err := capStakingEndTime(stakingStartTime, stakingEndTime)
+	if err != nil {
+		return nil, err
+	}
+	return calculateLinearReward(
+		c.RewardBasisPoints,
+		stakeAmount,
+		validatorStartTime,
+		stakingStartTime,
+		stakingEndTime,
+		uptimeSeconds,
+	)
+}
+
+func (c *RewardsCalculator) MinimumUptime(
+	validatorStartTime uint64,
+	stakingStartTime uint64,
+	stakingEndTime uint64,
+) (uint64, error) {
+	stakingEndTime, err := capStakingEndTime(stakingStartTime, stakingEndTime)
+	if err != nil {
+		return 0, err
+	}
+	return minimumUptime(validatorStartTime, stakingEndTime)
+}
+
+// Projection is the expected outcome of a staking period.
+type Projection struct {
+	// Reward is the reward that would be paid out for the expected uptime.
+	Reward *big.Int
+	// MinimumUptime is the smallest uptime that qualifies for a non-zero reward.
+	MinimumUptime time.Duration
+	// Qualifies is true if the expected uptime meets MinimumUptime.
+	Qualifies bool
+}
+
+// ProjectReward computes the reward a validator staking stakeAmount between startTime and endTime
+// earns with the given expected uptime, along with the minimum uptime needed to qualify for rewards.
+// Times are truncated to whole seconds, matching the granularity used on-chain.
+func ProjectReward(
+	calculator RewardCalculator,
+	stakeAmount *big.Int,
+	startTime time.Time,
+	endTime time.Time,
+	expectedUptime time.Duration,
+) (*Projection, error) {
+	if startTime.Unix() < 0 || endTime.Unix() < 0 || expectedUptime < 0 {
+		return nil, errors.New("times and uptime must not be negative")
+	}
+	if endTime.Before(startTime) {
+		return nil, errors.New("end time must not be before start time")
+	}
+	start := uint64(startTime.Unix())
+	end := uint64(endTime.Unix())
+	uptime := uint64(expectedUptime / time.Second)
+
+	reward, err := calculator.CalculateReward(stakeAmount, start, start, end, uptime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate reward")
+	}
+	minUptime, err := calculator.MinimumUptime(start, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate minimum uptime")
+	}
+	return &Projection{
+		Reward:        reward,
+		MinimumUptime: time.Duration(minUptime) * time.Second,
+		Qualifies:     uptime >= minUptime,
+	}, nil
+}
+
+// capStakingEndTime limits the staking period to MaxUptimeSeconds, reverting in the same
+// cases as RewardsCalculator.sol.
+func capStakingEndTime(stakingStartTime uint64, stakingEndTime uint64) (uint64, error) {
+	duration, err := math.Sub(stakingEndTime, stakingStartTime)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= MaxUptimeSeconds {
+		return stakingEndTime, nil
+	}
+	return math.Add(stakingStartTime, MaxUptimeSeconds)
+}
+
+// calculateLinearReward is the shared calculateReward body of ExampleRewardCalculator.sol and
+// RewardsCalculator.sol. Each checked operation is performed at the same bit width as in Solidity
+// so that overflows and underflows are reported wherever the contract would revert.
+func calculateLinearReward(
+	rewardBasisPoints uint64,
+	stakeAmount *big.Int,
+	validatorStartTime uint64,
+	stakingStartTime uint64,
+	stakingEndTime uint64,
+	uptimeSeconds uint64,
+) (*big.Int, error) {
+	if stakeAmount == nil || stakeAmount.Sign() < 0 || stakeAmount.BitLen() > 256 {
+		return nil, errors.Errorf("invalid stake amount %v", stakeAmount)
+	}
+
+	// uptimeSeconds * 100 < (stakingEndTime - validatorStartTime) * UPTIME_REWARDS_THRESHOLD_PERCENTAGE
+	scaledUptime, err := math.Mul(uptimeSeconds, 100)
+	if err != nil {
+		return nil, err
+	}
+	validatorDuration, err := math.Sub(stakingEndTime, validatorStartTime)
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := math.Mul(validatorDuration, UptimeRewardsThresholdPercentage)
+	if err != nil {
+		return nil, err
+	}
+	if scaledUptime < threshold {
+		return big.NewInt(0), nil
+	}
+
+	// (stakeAmount * rewardBasisPoints * (stakingEndTime - stakingStartTime)) / SECONDS_IN_YEAR / BIPS_CONVERSION_FACTOR
+	stakingDuration, err := math.Sub(stakingEndTime, stakingStartTime)
+	if err != nil {
+		return nil, err
+	}
+	reward := new(big.Int).Mul(stakeAmount, new(big.Int).SetUint64(rewardBasisPoints))
+	if reward.BitLen() > 256 {
+		return nil, math.ErrOverflow
+	}
+	reward.Mul(reward, new(big.Int).SetUint64(stakingDuration))
+	if reward.BitLen() > 256 {
+		return nil, math.ErrOverflow
+	}
+	reward.Div(reward, new(big.Int).SetUint64(SecondsInYear))
+	reward.Div(reward, new(big.Int).SetUint64(BipsConversionFactor))
+	return reward, nil
+}
+
+// minimumUptime returns the smallest uptime u satisfying
+// u * 100 >= (stakingEndTime - validatorStartTime) * UPTIME_REWARDS_THRESHOLD_PERCENTAGE
+func minimumUptime(validatorStartTime uint64, stakingEndTime uint64) (uint64, error) {
+	validatorDuration, err := math.Sub(stakingEndTime, validatorStartTime)
+	if err != nil {
+		return 0, err
+	}
+	threshold, err := math.Mul(validatorDuration, UptimeRewardsThresholdPercentage)
+	if err != nil {
+		return 0, err
+	}
+	minUptime := threshold / 100
+	if threshold%100 != 0 {
+		minUptime++
+	}
+	// The contract reverts if uptimeSeconds * 100 overflows, so no uptime can qualify in that case.
+	if _, err := math.Mul(minUptime, 100); err != nil {
+		return 0, err
+	}
+	return minUptime, nil
+}
@@ -0,0 +1,246 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+	rewardscalculator "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/RewardsCalculator"
+	examplerewardcalculator "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/ExampleRewardCalculator"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/eth/ethconfig"
+	"github.com/ava-labs/subnet-evm/ethclient/simulated"
+	"github.com/ava-labs/subnet-evm/node"
+	"github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRewardBasisPoints = 1000
+	differentialRuns      = 200
+)
+
+var oneToken = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+func TestCalculateReward(t *testing.T) {
+	stake := new(big.Int).Mul(big.NewInt(1_000_000), oneToken)
+	day := uint64(24 * 60 * 60)
+	testCases := []struct {
+		name           string
+		calculator     RewardCalculator
+		start          uint64
+		end            uint64
+		uptime         uint64
+		expectedReward *big.Int
+		expectedErr    error
+	}{
+		{
+			name:       "example full year",
+			calculator: &ExampleRewardCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:      0,
+			end:        SecondsInYear,
+			uptime:     SecondsInYear,
+			// 10% of the stake
+			expectedReward: new(big.Int).Div(stake, big.NewInt(10)),
+		},
+		{
+			name:           "example below threshold",
+			calculator:     &ExampleRewardCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:          0,
+			end:            100,
+			uptime:         79,
+			expectedReward: big.NewInt(0),
+		},
+		{
+			name:           "example at threshold",
+			calculator:     &ExampleRewardCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:          0,
+			end:            SecondsInYear,
+			uptime:         SecondsInYear * 8 / 10,
+			expectedReward: new(big.Int).Div(stake, big.NewInt(10)),
+		},
+		{
+			name:        "example end before start",
+			calculator:  &ExampleRewardCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:       100,
+			end:         99,
+			uptime:      0,
+			expectedErr: math.ErrUnderflow,
+		},
+		{
+			name:       "capped to one epoch",
+			calculator: &RewardsCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:      0,
+			end:        SecondsInYear,
+			uptime:     MaxUptimeSeconds,
+			// 30 days at 10% per year
+			expectedReward: new(big.Int).Div(
+				new(big.Int).Mul(stake, big.NewInt(int64(MaxUptimeSeconds))),
+				big.NewInt(int64(SecondsInYear*10)),
+			),
+		},
+		{
+			name:           "capped threshold",
+			calculator:     &RewardsCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:          0,
+			end:            60 * day,
+			uptime:         24 * day,
+			expectedReward: new(big.Int).Div(new(big.Int).Mul(stake, big.NewInt(int64(30*day))), big.NewInt(int64(SecondsInYear*10))),
+		},
+		{
+			name:           "capped below threshold",
+			calculator:     &RewardsCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:          0,
+			end:            60 * day,
+			uptime:         24*day - 1,
+			expectedReward: big.NewInt(0),
+		},
+		{
+			name:        "uptime overflow",
+			calculator:  &RewardsCalculator{RewardBasisPoints: testRewardBasisPoints},
+			start:       0,
+			end:         day,
+			uptime:      ^uint64(0),
+			expectedErr: math.ErrOverflow,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			reward, err := test.calculator.CalculateReward(stake, test.start, test.start, test.end, test.uptime)
+			if test.expectedErr != nil {
+				require.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedReward, reward)
+		})
+	}
+}
+
+func TestProjectReward(t *testing.T) {
+	stake := new(big.Int).Mul(big.NewInt(1000), oneToken)
+	start := time.Unix(1_700_000_000, 0)
+	end := start.Add(20 * 24 * time.Hour)
+	calculator := &RewardsCalculator{RewardBasisPoints: testRewardBasisPoints}
+
+	projection, err := ProjectReward(calculator, stake, start, end, 16*24*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 16*24*time.Hour, projection.MinimumUptime)
+	require.True(t, projection.Qualifies)
+	require.Positive(t, projection.Reward.Sign())
+
+	projection, err = ProjectReward(calculator, stake, start, end, 16*24*time.Hour-time.Second)
+	require.NoError(t, err)
+	require.False(t, projection.Qualifies)
+	require.Zero(t, projection.Reward.Sign())
+
+	// The minimum uptime is rounded up to a whole second.
+	projection, err = ProjectReward(calculator, stake, start, start.Add(101*time.Second), 0)
+	require.NoError(t, err)
+	require.Equal(t, 81*time.Second, projection.MinimumUptime)
+
+	_, err = ProjectReward(calculator, stake, end, start, 0)
+	require.Error(t, err)
+}
+
+// TestDifferentialExampleRewardCalculator checks that ExampleRewardCalculator matches
+// the deployed ExampleRewardCalculator contract for random inputs.
+func TestDifferentialExampleRewardCalculator(t *testing.T) {
+	backend, auth := newSimulatedBackend(t)
+	_, _, contract, err := examplerewardcalculator.DeployExampleRewardCalculator(
+		auth,
+		backend.Client(),
+		testRewardBasisPoints,
+	)
+	require.NoError(t, err)
+	backend.Commit(true)
+
+	testDifferential(t, &ExampleRewardCalculator{RewardBasisPoints: testRewardBasisPoints}, contract.CalculateReward)
+}
+
+// TestDifferentialRewardsCalculator checks that RewardsCalculator matches the deployed
+// Coqnet RewardsCalculator contract for random inputs.
+func TestDifferentialRewardsCalculator(t *testing.T) {
+	if rewardscalculator.RewardsCalculatorMetaData.Bin == "" {
+		t.Skip("RewardsCalculator bindings do not include bytecode, regenerate them with scripts/abi_bindings.sh")
+	}
+	backend, auth := newSimulatedBackend(t)
+	parsed, err := rewardscalculator.RewardsCalculatorMetaData.GetAbi()
+	require.NoError(t, err)
+	address, _, _, err := bind.DeployContract(
+		auth,
+		*parsed,
+		common.FromHex(rewardscalculator.RewardsCalculatorMetaData.Bin),
+		backend.Client(),
+		uint64(testRewardBasisPoints),
+	)
+	require.NoError(t, err)
+	backend.Commit(true)
+	contract, err := rewardscalculator.NewRewardsCalculator(address, backend.Client())
+	require.NoError(t, err)
+
+	testDifferential(t, &RewardsCalculator{RewardBasisPoints: testRewardBasisPoints}, contract.CalculateReward)
+}
+
+type calculateRewardFunc func(
+	opts *bind.CallOpts,
+	stakeAmount *big.Int,
+	validatorStartTime uint64,
+	stakingStartTime uint64,
+	stakingEndTime uint64,
+	uptimeSeconds uint64,
+) (*big.Int, error)
+
+func testDifferential(t *testing.T, calculator RewardCalculator, onChain calculateRewardFunc) {
+	rng := rand.New(rand.NewSource(0)) //nolint:gosec
+	maxDuration := int64(3 * MaxUptimeSeconds)
+	for i := 0; i < differentialRuns; i++ {
+		stake := new(big.Int).Mul(big.NewInt(rng.Int63n(1_000_000)+1), oneToken)
+		validatorStart := uint64(rng.Int63n(1_000_000_000))
+		// Occasionally start staking before the validator to exercise the underflow paths.
+		stakingStart := validatorStart + uint64(rng.Int63n(maxDuration)) - uint64(maxDuration/10)
+		stakingEnd := stakingStart + uint64(rng.Int63n(maxDuration)) - uint64(maxDuration/10)
+		uptime := uint64(rng.Int63n(maxDuration))
+
+		expected, expectedErr := onChain(&bind.CallOpts{}, stake, validatorStart, stakingStart, stakingEnd, uptime)
+		actual, err := calculator.CalculateReward(stake, validatorStart, stakingStart, stakingEnd, uptime)
+		if expectedErr != nil {
+			require.Error(t, err, "contract reverted but off-chain calculation did not")
+			continue
+		}
+		require.NoError(t, err)
+		require.Zero(t, expected.Cmp(actual), "expected %s, got %s", expected, actual)
+	}
+}
+
+// newSimulatedBackend returns a simulated chain with a funded deployer. The simulated chain's clock
+// starts at the Unix epoch, so Durango (Shanghai) is activated at genesis to support PUSH0.
+func newSimulatedBackend(t *testing.T) (*simulated.Backend, *bind.TransactOpts) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+
+	backend := simulated.NewBackend(
+		types.GenesisAlloc{
+			auth.From: {Balance: new(big.Int).Mul(big.NewInt(1000), oneToken)},
+		},
+		func(_ *node.Config, ethConf *ethconfig.Config) {
+			chainConfig := ethConf.Genesis.Config
+			chainConfig.DurangoTimestamp = utils.NewUint64(0)
+			chainConfig.ShanghaiTime = utils.NewUint64(0)
+		},
+	)
+	t.Cleanup(func() {
+		backend.Close()
+	})
+	return backend, auth
+}
@@ -0,0 +1,233 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/pkg/errors"
+)
+
+// DefaultQuorumPercentage is the quorum used when a request does not specify one, matching the
+// default quorum of the Warp precompile.
+const DefaultQuorumPercentage = 67
+
+// ValidatorState provides the validator sets Warp messages are signed by. It is implemented by
+// the validators.State of avalanchego, and by PChainValidatorState over the P-Chain API.
+type ValidatorState interface {
+	GetCurrentHeight(ctx context.Context) (uint64, error)
+	GetSubnetID(ctx context.Context, chainID ids.ID) (ids.ID, error)
+	GetValidatorSet(
+		ctx context.Context,
+		height uint64,
+		subnetID ids.ID,
+	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+}
+
+// SignatureSource provides the BLS signature of a validator over an unsigned Warp message.
+type SignatureSource interface {
+	GetSignature(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		unsignedMessage *avalancheWarp.UnsignedMessage,
+		justification []byte,
+	) (*bls.Signature, error)
+}
+
+// Aggregator is a Go implementation of the signature aggregator, which collects the signatures of
+// a subnet's validators from a SignatureSource and aggregates them once a quorum has signed. It
+// implements the /aggregate-signatures API of the signature aggregator as an http.Handler.
+type Aggregator struct {
+	state  ValidatorState
+	source SignatureSource
+}
+
+// NewAggregator returns an aggregator of the signatures of source over the validator sets of
+// state.
+func NewAggregator(state ValidatorState, source SignatureSource) *Aggregator {
+	return &Aggregator{
+		state:  state,
+		source: source,
+	}
+}
+
+// CreateSignedMessage signs unsignedMessage with the current validators of signingSubnetID, or of
+// the subnet of the message's source chain if signingSubnetID is empty, and returns the signed
+// message once quorumPercentage of the subnet's weight has signed.
+func (a *Aggregator) CreateSignedMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+	signingSubnetID ids.ID,
+	quorumPercentage uint64,
+) (*avalancheWarp.Message, error) {
+	return a.AggregateSignatures(
+		context.Background(),
+		unsignedMessage,
+		justification,
+		signingSubnetID,
+		quorumPercentage,
+	)
+}
+
+// AggregateSignatures is CreateSignedMessage with a context.
+func (a *Aggregator) AggregateSignatures(
+	ctx context.Context,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+	signingSubnetID ids.ID,
+	quorumPercentage uint64,
+) (*avalancheWarp.Message, error) {
+	if quorumPercentage == 0 {
+		quorumPercentage = DefaultQuorumPercentage
+	}
+	if quorumPercentage > 100 {
+		return nil, fmt.Errorf("invalid quorum percentage %d", quorumPercentage)
+	}
+	if signingSubnetID == ids.Empty {
+		if unsignedMessage.SourceChainID == constants.PlatformChainID {
+			return nil, errors.New("a signing subnet ID is required for P-Chain messages")
+		}
+		subnetID, err := a.state.GetSubnetID(ctx, unsignedMessage.SourceChainID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get subnet of chain %s", unsignedMessage.SourceChainID)
+		}
+		signingSubnetID = subnetID
+	}
+
+	height, err := a.state.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get P-Chain height")
+	}
+	validatorSet, err := a.state.GetValidatorSet(ctx, height, signingSubnetID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get validators of subnet %s", signingSubnetID)
+	}
+	// The bit set of a signature indexes the canonical ordering of the validator set.
+	canonical, totalWeight, err := avalancheWarp.FlattenValidatorSet(validatorSet)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get canonical validators of subnet %s", signingSubnetID)
+	}
+	if len(canonical) == 0 {
+		return nil, fmt.Errorf("subnet %s has no validators with BLS keys", signingSubnetID)
+	}
+
+	var (
+		signers      = set.NewBits()
+		signatures   []*bls.Signature
+		signedWeight uint64
+		lastErr      error
+	)
+	for i, vdr := range canonical {
+		// A validator signs with the BLS key shared by all its nodes, so one signature suffices.
+		for _, nodeID := range vdr.NodeIDs {
+			signature, err := a.source.GetSignature(ctx, nodeID, unsignedMessage, justification)
+			if err != nil {
+				lastErr = errors.Wrapf(err, "failed to get signature of %s", nodeID)
+				continue
+			}
+			if !bls.Verify(vdr.PublicKey, signature, unsignedMessage.Bytes()) {
+				lastErr = fmt.Errorf("invalid signature of %s", nodeID)
+				continue
+			}
+			signers.Add(i)
+			signatures = append(signatures, signature)
+			signedWeight += vdr.Weight
+			break
+		}
+		if err := avalancheWarp.VerifyWeight(signedWeight, totalWeight, quorumPercentage, 100); err == nil {
+			break
+		}
+	}
+	if err := avalancheWarp.VerifyWeight(signedWeight, totalWeight, quorumPercentage, 100); err != nil {
+		if lastErr != nil {
+			err = fmt.Errorf("%w: %v", err, lastErr)
+		}
+		return nil, errors.Wrapf(
+			err,
+			"failed to collect a threshold of signatures of subnet %s: %d of %d weight signed",
+			signingSubnetID,
+			signedWeight,
+			totalWeight,
+		)
+	}
+
+	aggregate, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to aggregate signatures")
+	}
+	signature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggregate))
+	return avalancheWarp.NewMessage(unsignedMessage, signature)
+}
+
+// ServeHTTP serves the /aggregate-signatures and /health APIs of the signature aggregator.
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case HealthPath:
+		w.WriteHeader(http.StatusOK)
+		return
+	case AggregateSignaturesPath:
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req AggregateSignaturesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	messageBytes, err := hex.DecodeString(req.Message)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode message: %v", err), http.StatusBadRequest)
+		return
+	}
+	unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(messageBytes)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse message: %v", err), http.StatusBadRequest)
+		return
+	}
+	justification, err := hex.DecodeString(req.Justification)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode justification: %v", err), http.StatusBadRequest)
+		return
+	}
+	var signingSubnetID ids.ID
+	if req.SigningSubnetID != "" {
+		signingSubnetID, err = ids.FromString(req.SigningSubnetID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid signing subnet ID: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	signedMessage, err := a.AggregateSignatures(
+		r.Context(),
+		unsignedMessage,
+		justification,
+		signingSubnetID,
+		req.QuorumPercentage,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(AggregateSignaturesResponse{
+		SignedMessage: hex.EncodeToString(signedMessage.Bytes()),
+	})
+}
@@ -0,0 +1,180 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+)
+
+const testNetworkID = 1337
+
+// fakeState is the validator state of a single subnet validating a single chain.
+type fakeState struct {
+	subnetID   ids.ID
+	chainID    ids.ID
+	validators map[ids.NodeID]*validators.GetValidatorOutput
+}
+
+func (*fakeState) GetMinimumHeight(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (*fakeState) GetCurrentHeight(context.Context) (uint64, error) {
+	return 10, nil
+}
+
+func (s *fakeState) GetSubnetID(_ context.Context, chainID ids.ID) (ids.ID, error) {
+	if chainID != s.chainID {
+		return ids.Empty, errors.New("unknown chain")
+	}
+	return s.subnetID, nil
+}
+
+func (s *fakeState) GetValidatorSet(
+	_ context.Context,
+	_ uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	if subnetID != s.subnetID {
+		return nil, nil
+	}
+	return s.validators, nil
+}
+
+func (*fakeState) GetCurrentValidatorSet(
+	context.Context,
+	ids.ID,
+) (map[ids.ID]*validators.GetCurrentValidatorOutput, uint64, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// keySource signs messages with the known BLS keys of validators.
+type keySource map[ids.NodeID]*bls.SecretKey
+
+func (s keySource) GetSignature(
+	_ context.Context,
+	nodeID ids.NodeID,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	_ []byte,
+) (*bls.Signature, error) {
+	sk, ok := s[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("unknown BLS key of %s", nodeID)
+	}
+	return bls.Sign(sk, unsignedMessage.Bytes()), nil
+}
+
+// newTestAggregator returns an aggregator over numValidators validators of equal weight, which
+// only knows the keys of the first numKeys of them, in canonical order.
+func newTestAggregator(t *testing.T, numValidators int, numKeys int) (*Aggregator, *fakeState) {
+	state := &fakeState{
+		subnetID:   ids.GenerateTestID(),
+		chainID:    ids.GenerateTestID(),
+		validators: make(map[ids.NodeID]*validators.GetValidatorOutput),
+	}
+	keys := make(map[ids.NodeID]*bls.SecretKey)
+	for i := 0; i < numValidators; i++ {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+		nodeID := ids.GenerateTestNodeID()
+		state.validators[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    100,
+		}
+		keys[nodeID] = sk
+	}
+	canonical, _, err := avalancheWarp.FlattenValidatorSet(state.validators)
+	require.NoError(t, err)
+	source := make(keySource)
+	for _, vdr := range canonical[:numKeys] {
+		source[vdr.NodeIDs[0]] = keys[vdr.NodeIDs[0]]
+	}
+	return NewAggregator(state, source), state
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	aggregator, state := newTestAggregator(t, 4, 3)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(testNetworkID, state.chainID, []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	// The signing subnet defaults to the subnet of the source chain.
+	signedMessage, err := aggregator.CreateSignedMessage(unsignedMessage, nil, ids.Empty, 0)
+	require.NoError(t, err)
+	require.NoError(t, signedMessage.Signature.Verify(
+		context.Background(), unsignedMessage, testNetworkID, state, 10, DefaultQuorumPercentage, 100,
+	))
+	numSigners, err := signedMessage.Signature.NumSigners()
+	require.NoError(t, err)
+	require.Equal(t, 3, numSigners)
+
+	// 3 of the 4 validators cannot reach a quorum of 80%.
+	_, err = aggregator.CreateSignedMessage(unsignedMessage, nil, state.subnetID, 80)
+	require.ErrorIs(t, err, avalancheWarp.ErrInsufficientWeight)
+	require.ErrorContains(t, err, "300 of 400 weight signed")
+
+	_, err = aggregator.CreateSignedMessage(unsignedMessage, nil, ids.GenerateTestID(), 67)
+	require.ErrorContains(t, err, "has no validators")
+
+	pChainMessage, err := avalancheWarp.NewUnsignedMessage(testNetworkID, constants.PlatformChainID, []byte{1})
+	require.NoError(t, err)
+	_, err = aggregator.CreateSignedMessage(pChainMessage, nil, ids.Empty, 67)
+	require.ErrorContains(t, err, "signing subnet ID is required")
+	_, err = aggregator.CreateSignedMessage(pChainMessage, nil, state.subnetID, 67)
+	require.NoError(t, err)
+}
+
+func TestAggregateSignaturesInvalidSignature(t *testing.T) {
+	aggregator, state := newTestAggregator(t, 2, 2)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(testNetworkID, state.chainID, []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	// A validator signing with another key is not counted.
+	source := aggregator.source.(keySource)
+	for nodeID := range source {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+		source[nodeID] = sk
+		break
+	}
+	_, err = aggregator.CreateSignedMessage(unsignedMessage, nil, state.subnetID, 100)
+	require.ErrorContains(t, err, "invalid signature of")
+
+	signedMessage, err := aggregator.CreateSignedMessage(unsignedMessage, nil, state.subnetID, 50)
+	require.NoError(t, err)
+	require.NoError(t, signedMessage.Signature.Verify(
+		context.Background(), unsignedMessage, testNetworkID, state, 10, 50, 100,
+	))
+}
+
+func TestAggregatorServer(t *testing.T) {
+	aggregator, state := newTestAggregator(t, 3, 3)
+	server := httptest.NewServer(aggregator)
+	defer server.Close()
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(testNetworkID, state.chainID, []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	client := NewClientWithRetries(server.URL, RetryConfig{MaxAttempts: 1})
+	require.NoError(t, client.WaitForHealthy(context.Background()))
+	signedMessage, err := client.CreateSignedMessage(unsignedMessage, []byte{4}, state.subnetID, 100)
+	require.NoError(t, err)
+	require.NoError(t, signedMessage.Signature.Verify(
+		context.Background(), unsignedMessage, testNetworkID, state, 10, 100, 100,
+	))
+
+	_, err = client.CreateSignedMessage(unsignedMessage, nil, ids.GenerateTestID(), 67)
+	require.ErrorContains(t, err, "expected status code 200, got 500")
+	require.ErrorContains(t, err, "has no validators")
+}
@@ -0,0 +1,240 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/pkg/errors"
+)
+
+const (
+	AggregateSignaturesPath = "/aggregate-signatures"
+	HealthPath              = "/health"
+	DefaultRequestTimeout   = 20 * time.Second
+
+	// Interval between the health checks of WaitForHealthy
+	healthPollInterval = 100 * time.Millisecond
+)
+
+// DefaultRetryConfig is the retry policy of the clients returned by NewClient.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+type AggregateSignaturesRequest struct {
+	Message          string `json:"message"`
+	Justification    string `json:"justification,omitempty"`
+	SigningSubnetID  string `json:"signing-subnet-id,omitempty"`
+	QuorumPercentage uint64 `json:"quorum-percentage,omitempty"`
+}
+
+type AggregateSignaturesResponse struct {
+	SignedMessage string `json:"signed-message"`
+}
+
+// AggregatorError is returned when the signature aggregator responds to a request with an error
+// status, with the body of its response.
+type AggregatorError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *AggregatorError) Error() string {
+	return fmt.Sprintf("expected status code 200, got %d: %s", e.StatusCode, e.Body)
+}
+
+// Temporary reports whether the request may succeed if retried, which is the case of server
+// errors, e.g. when too few validators have signed yet.
+func (e *AggregatorError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+// RetryConfig is the policy of retrying requests that fail with a temporary AggregatorError or
+// time out. The backoff between attempts doubles from InitialBackoff up to MaxBackoff.
+type RetryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Client requests aggregated Warp signatures from the /aggregate-signatures API
+// of a signature aggregator. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retry      RetryConfig
+}
+
+// NewClient returns a client for the signature aggregator API served at baseURL,
+// e.g. http://localhost:8080, which retries requests with DefaultRetryConfig.
+func NewClient(baseURL string) *Client {
+	return NewClientWithRetries(baseURL, DefaultRetryConfig)
+}
+
+// NewClientWithRetries returns a client for the signature aggregator API served at baseURL, which
+// retries requests with retry.
+func NewClientWithRetries(baseURL string, retry RetryConfig) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Allow concurrent requests to reuse their connections to the single aggregator host.
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout:   DefaultRequestTimeout,
+			Transport: transport,
+		},
+		retry: retry,
+	}
+}
+
+// CreateSignedMessage requests signatures for unsignedMessage from the validators of
+// signingSubnetID, and returns the signed message once quorumPercentage of the subnet's
+// weight has signed.
+func (c *Client) CreateSignedMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+	signingSubnetID ids.ID,
+	quorumPercentage uint64,
+) (*avalancheWarp.Message, error) {
+	return c.AggregateSignatures(
+		context.Background(),
+		unsignedMessage,
+		justification,
+		signingSubnetID,
+		quorumPercentage,
+	)
+}
+
+// AggregateSignatures is CreateSignedMessage with a context, which bounds the retries.
+func (c *Client) AggregateSignatures(
+	ctx context.Context,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+	signingSubnetID ids.ID,
+	quorumPercentage uint64,
+) (*avalancheWarp.Message, error) {
+	reqBody := AggregateSignaturesRequest{
+		Message:          hex.EncodeToString(unsignedMessage.Bytes()),
+		Justification:    hex.EncodeToString(justification),
+		SigningSubnetID:  signingSubnetID.String(),
+		QuorumPercentage: quorumPercentage,
+	}
+	b, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	backoff := c.retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		body, err = c.post(ctx, AggregateSignaturesPath, b)
+		if err == nil || attempt >= c.retry.MaxAttempts || !isTemporary(err) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "aggregate signatures request failed after %d attempts: %v", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, c.retry.MaxBackoff)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var response AggregateSignaturesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode aggregate signatures response")
+	}
+	decodedMessage, err := hex.DecodeString(response.SignedMessage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signed message")
+	}
+	return avalancheWarp.ParseMessage(decodedMessage)
+}
+
+// Health returns nil if the signature aggregator reports itself healthy.
+func (c *Client) Health(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+HealthPath, nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send health request")
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return &AggregatorError{StatusCode: res.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+	return nil
+}
+
+// WaitForHealthy polls the health of the signature aggregator until it is healthy, or ctx is
+// done.
+func (c *Client) WaitForHealthy(ctx context.Context) error {
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+	for {
+		err := c.Health(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "signature aggregator at %s is not healthy: %v", c.baseURL, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) post(ctx context.Context, path string, b []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send aggregate signatures request")
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, &AggregatorError{StatusCode: res.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+	return body, nil
+}
+
+// isTemporary reports whether a request that failed with err may be retried.
+func isTemporary(err error) bool {
+	var aggregatorErr *AggregatorError
+	if errors.As(err, &aggregatorErr) {
+		return aggregatorErr.Temporary()
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,161 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSignedMessage(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+	signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{
+		Signers: set.NewBits(0, 2).Bytes(),
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, AggregateSignaturesPath, r.URL.Path)
+		var req AggregateSignaturesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, hex.EncodeToString(unsignedMessage.Bytes()), req.Message)
+		require.Equal(t, subnetID.String(), req.SigningSubnetID)
+		if req.QuorumPercentage > 67 {
+			http.Error(w, "failed to collect a threshold of signatures", http.StatusInternalServerError)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(AggregateSignaturesResponse{
+			SignedMessage: hex.EncodeToString(signedMessage.Bytes()),
+		}))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL + "/")
+	result, err := client.CreateSignedMessage(unsignedMessage, nil, subnetID, 67)
+	require.NoError(t, err)
+	require.Equal(t, signedMessage.Bytes(), result.Bytes())
+
+	_, err = client.CreateSignedMessage(unsignedMessage, nil, subnetID, 100)
+	require.ErrorContains(t, err, "failed to collect a threshold of signatures")
+}
+
+func TestCreateSignedMessageRetries(t *testing.T) {
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+	signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{
+		Signers: set.NewBits(0).Bytes(),
+	})
+	require.NoError(t, err)
+
+	var (
+		attempts atomic.Int32
+		status   atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Fail the first two attempts of each request.
+		if attempts.Add(1)%3 != 0 {
+			http.Error(w, "not enough signatures yet", int(status.Load()))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(AggregateSignaturesResponse{
+			SignedMessage: hex.EncodeToString(signedMessage.Bytes()),
+		}))
+	}))
+	defer server.Close()
+	client := NewClientWithRetries(server.URL, RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+
+	// Server errors are retried.
+	status.Store(http.StatusServiceUnavailable)
+	result, err := client.CreateSignedMessage(unsignedMessage, nil, ids.Empty, 67)
+	require.NoError(t, err)
+	require.Equal(t, signedMessage.Bytes(), result.Bytes())
+	require.Equal(t, int32(3), attempts.Load())
+
+	// Client errors are not, and are returned with the body of the response.
+	status.Store(http.StatusBadRequest)
+	_, err = client.CreateSignedMessage(unsignedMessage, nil, ids.Empty, 67)
+	var aggregatorErr *AggregatorError
+	require.ErrorAs(t, err, &aggregatorErr)
+	require.Equal(t, http.StatusBadRequest, aggregatorErr.StatusCode)
+	require.Equal(t, "not enough signatures yet", aggregatorErr.Body)
+	require.Equal(t, int32(4), attempts.Load())
+
+	// Retries stop when the context is done.
+	attempts.Store(0)
+	status.Store(http.StatusInternalServerError)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.AggregateSignatures(ctx, unsignedMessage, nil, ids.Empty, 67)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCreateSignedMessageConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req AggregateSignaturesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		message, err := hex.DecodeString(req.Message)
+		require.NoError(t, err)
+		unsignedMessage, err := avalancheWarp.ParseUnsignedMessage(message)
+		require.NoError(t, err)
+		signedMessage, err := avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{})
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(w).Encode(AggregateSignaturesResponse{
+			SignedMessage: hex.EncodeToString(signedMessage.Bytes()),
+		}))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte{byte(i)})
+			require.NoError(t, err)
+			result, err := client.CreateSignedMessage(unsignedMessage, nil, ids.Empty, 67)
+			require.NoError(t, err)
+			require.Equal(t, unsignedMessage.Bytes(), result.UnsignedMessage.Bytes())
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestWaitForHealthy(t *testing.T) {
+	var checks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, HealthPath, r.URL.Path)
+		if checks.Add(1) < 3 {
+			http.Error(w, "starting", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	require.NoError(t, client.WaitForHealthy(context.Background()))
+	require.Equal(t, int32(3), checks.Load())
+
+	server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, client.WaitForHealthy(ctx), context.DeadlineExceeded)
+}
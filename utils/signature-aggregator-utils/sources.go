@@ -0,0 +1,255 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/acp118"
+	"github.com/ava-labs/avalanchego/network/peer"
+	p2ppb "github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+)
+
+// Time for a node to respond to a signature request
+const signatureRequestTimeout = 5 * time.Second
+
+var (
+	_ ValidatorState  = (*PChainValidatorState)(nil)
+	_ SignatureSource = (*NodeSignatureSource)(nil)
+)
+
+// PChainClient is the subset of the P-Chain API used by PChainValidatorState.
+type PChainClient interface {
+	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	ValidatedBy(ctx context.Context, blockchainID ids.ID, options ...rpc.Option) (ids.ID, error)
+	GetValidatorsAt(
+		ctx context.Context,
+		subnetID ids.ID,
+		height platformapi.Height,
+		options ...rpc.Option,
+	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+}
+
+// PChainValidatorState serves the validator sets of the P-Chain API of a node.
+type PChainValidatorState struct {
+	client PChainClient
+}
+
+// NewPChainValidatorState returns the validator state of the P-Chain API served at uri.
+func NewPChainValidatorState(uri string) *PChainValidatorState {
+	return &PChainValidatorState{client: platformvm.NewClient(uri)}
+}
+
+func (s *PChainValidatorState) GetCurrentHeight(ctx context.Context) (uint64, error) {
+	return s.client.GetHeight(ctx)
+}
+
+func (s *PChainValidatorState) GetSubnetID(ctx context.Context, chainID ids.ID) (ids.ID, error) {
+	return s.client.ValidatedBy(ctx, chainID)
+}
+
+func (s *PChainValidatorState) GetValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return s.client.GetValidatorsAt(ctx, subnetID, platformapi.Height(height))
+}
+
+// NodeSignatureSource requests signatures from the nodes of validators with ACP-118 signature
+// requests over the peer-to-peer network, as validators request them from each other. The VM of the
+// source chain of a message verifies it before the node signs, so that nodes only sign messages
+// sent by their chains, P-Chain messages consistent with the P-Chain state, and uptime proofs
+// consistent with the uptimes they observed.
+type NodeSignatureSource struct {
+	networkID uint32
+	// Staking addresses of the nodes of validators, e.g. 127.0.0.1:9651
+	addresses map[ids.NodeID]netip.AddrPort
+	creator   message.Creator
+
+	lock  sync.Mutex
+	peers map[ids.NodeID]*signaturePeer
+}
+
+// NewNodeSignatureSource returns a source of the signatures of the nodes of networkID listening at
+// addresses.
+func NewNodeSignatureSource(
+	networkID uint32,
+	addresses map[ids.NodeID]netip.AddrPort,
+) (*NodeSignatureSource, error) {
+	creator, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		constants.DefaultNetworkCompressionType,
+		signatureRequestTimeout,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create message creator")
+	}
+	return &NodeSignatureSource{
+		networkID: networkID,
+		addresses: addresses,
+		creator:   creator,
+		peers:     make(map[ids.NodeID]*signaturePeer),
+	}, nil
+}
+
+func (s *NodeSignatureSource) GetSignature(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	justification []byte,
+) (*bls.Signature, error) {
+	p, err := s.peer(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	requestBytes, err := proto.Marshal(&sdk.SignatureRequest{
+		Message:       unsignedMessage.Bytes(),
+		Justification: justification,
+	})
+	if err != nil {
+		return nil, err
+	}
+	requestID, responses := p.newRequest()
+	defer p.cancelRequest(requestID)
+	request, err := s.creator.AppRequest(
+		unsignedMessage.SourceChainID,
+		requestID,
+		signatureRequestTimeout,
+		p2p.PrefixMessage(p2p.ProtocolPrefix(acp118.HandlerID), requestBytes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !p.peer.Send(ctx, request) {
+		return nil, fmt.Errorf("failed to send signature request to %s", nodeID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, signatureRequestTimeout)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "no signature response from %s", nodeID)
+	case response := <-responses:
+		return parseSignatureResponse(response)
+	}
+}
+
+// Close disconnects from the nodes.
+func (s *NodeSignatureSource) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for nodeID, p := range s.peers {
+		p.peer.StartClose()
+		delete(s.peers, nodeID)
+	}
+}
+
+// peer returns the connection to the node of nodeID, which is established on first use, and again
+// if it was closed.
+func (s *NodeSignatureSource) peer(ctx context.Context, nodeID ids.NodeID) (*signaturePeer, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if p, ok := s.peers[nodeID]; ok && !p.peer.Closed() {
+		return p, nil
+	}
+	address, ok := s.addresses[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("unknown staking address of %s", nodeID)
+	}
+	p := &signaturePeer{pending: make(map[uint32]chan message.InboundMessage)}
+	var err error
+	p.peer, err = peer.StartTestPeer(ctx, address, s.networkID, router.InboundHandlerFunc(p.handle))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s at %s", nodeID, address)
+	}
+	s.peers[nodeID] = p
+	return p, nil
+}
+
+// signaturePeer is a connection to a node, which routes responses to their pending requests.
+type signaturePeer struct {
+	peer peer.Peer
+
+	lock          sync.Mutex
+	nextRequestID uint32
+	pending       map[uint32]chan message.InboundMessage
+}
+
+func (p *signaturePeer) newRequest() (uint32, <-chan message.InboundMessage) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	requestID := p.nextRequestID
+	p.nextRequestID++
+	responses := make(chan message.InboundMessage, 1)
+	p.pending[requestID] = responses
+	return requestID, responses
+}
+
+func (p *signaturePeer) cancelRequest(requestID uint32) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.pending, requestID)
+}
+
+func (p *signaturePeer) handle(_ context.Context, msg message.InboundMessage) {
+	defer msg.OnFinishedHandling()
+	var requestID uint32
+	switch m := msg.Message().(type) {
+	case *p2ppb.AppResponse:
+		requestID = m.RequestId
+	case *p2ppb.AppError:
+		requestID = m.RequestId
+	default:
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if responses, ok := p.pending[requestID]; ok {
+		responses <- msg
+		delete(p.pending, requestID)
+	}
+}
+
+// parseSignatureResponse returns the signature of an ACP-118 response, or the error the node
+// responded with, e.g. when its VM refused to sign.
+func parseSignatureResponse(response message.InboundMessage) (*bls.Signature, error) {
+	switch m := response.Message().(type) {
+	case *p2ppb.AppResponse:
+		var signatureResponse sdk.SignatureResponse
+		if err := proto.Unmarshal(m.AppBytes, &signatureResponse); err != nil {
+			return nil, errors.Wrap(err, "failed to parse signature response")
+		}
+		signature, err := bls.SignatureFromBytes(signatureResponse.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse signature")
+		}
+		return signature, nil
+	case *p2ppb.AppError:
+		return nil, fmt.Errorf("%s refused to sign: %s (code %d)", response.NodeID(), m.ErrorMessage, m.ErrorCode)
+	default:
+		return nil, fmt.Errorf("unexpected response %s", response.Op())
+	}
+}
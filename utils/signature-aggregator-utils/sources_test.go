@@ -0,0 +1,46 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSignaturePeerResponses(t *testing.T) {
+	p := &signaturePeer{pending: make(map[uint32]chan message.InboundMessage)}
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	signature := bls.Sign(sk, []byte{1, 2, 3})
+	responseBytes, err := proto.Marshal(&sdk.SignatureResponse{Signature: bls.SignatureToBytes(signature)})
+	require.NoError(t, err)
+
+	first, firstResponses := p.newRequest()
+	second, secondResponses := p.newRequest()
+	require.NotEqual(t, first, second)
+
+	// Responses are routed to their requests, whatever their order.
+	p.handle(context.Background(), message.InboundAppError(nodeID, chainID, second, 1, "unknown validation"))
+	p.handle(context.Background(), message.InboundAppResponse(chainID, first, responseBytes, nodeID))
+	// Responses to unknown requests are dropped.
+	p.handle(context.Background(), message.InboundAppResponse(chainID, first, responseBytes, nodeID))
+	require.Empty(t, p.pending)
+
+	parsed, err := parseSignatureResponse(<-firstResponses)
+	require.NoError(t, err)
+	require.Equal(t, bls.SignatureToBytes(signature), bls.SignatureToBytes(parsed))
+
+	_, err = parseSignatureResponse(<-secondResponses)
+	require.ErrorContains(t, err, "refused to sign: unknown validation (code 1)")
+}
@@ -0,0 +1,187 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Kinds of incompatible storage layout changes
+const (
+	ChangeRemoved          = "removed"
+	ChangeMoved            = "moved"
+	ChangeRenamed          = "renamed"
+	ChangeResized          = "resized"
+	ChangeTypeChanged      = "type-changed"
+	ChangeNamespaceRemoved = "namespace-removed"
+)
+
+var astIDRegex = regexp.MustCompile(`\)\d+`)
+
+// LayoutChange is a change between two storage layouts that breaks an upgrade from the old
+// layout to the new one.
+type LayoutChange struct {
+	Kind string `json:"kind"`
+	// ERC-7201 namespace ID of the changed variable, or empty for state variables
+	Namespace string `json:"namespace,omitempty"`
+	// Path of the changed variable, e.g. _validationPeriods.value.status
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+func (c LayoutChange) String() string {
+	if c.Namespace == "" {
+		return fmt.Sprintf("%s: %s", c.Path, c.Description)
+	}
+	return fmt.Sprintf("%s %s: %s", c.Namespace, c.Path, c.Description)
+}
+
+// CompareLayouts returns the changes from oldLayout to newLayout that are incompatible with
+// upgrading a contract with oldLayout to one with newLayout. Variables may be appended to the
+// state variables, to namespace structs and to structs stored as mapping values, and namespaces
+// may be added. Removing, moving, renaming, resizing or retyping existing variables or struct
+// members, and removing namespaces, are reported.
+func CompareLayouts(oldLayout *StorageLayout, newLayout *StorageLayout) []LayoutChange {
+	c := &layoutComparer{old: oldLayout, new: newLayout}
+	c.compareEntries("", "", oldLayout.Storage, newLayout.Storage, true)
+
+	namespaces := make([]string, 0, len(oldLayout.Namespaces))
+	for id := range oldLayout.Namespaces {
+		namespaces = append(namespaces, id)
+	}
+	sort.Strings(namespaces)
+	for _, id := range namespaces {
+		newEntries, ok := newLayout.Namespaces[id]
+		if !ok {
+			c.report(ChangeNamespaceRemoved, id, "", "namespace is no longer declared")
+			continue
+		}
+		c.compareEntries(id, "", oldLayout.Namespaces[id], newEntries, true)
+	}
+	return c.changes
+}
+
+type layoutComparer struct {
+	old     *StorageLayout
+	new     *StorageLayout
+	changes []LayoutChange
+}
+
+func (c *layoutComparer) report(kind string, namespace string, path string, format string, args ...interface{}) {
+	c.changes = append(c.changes, LayoutChange{
+		Kind:        kind,
+		Namespace:   namespace,
+		Path:        path,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// compareEntries compares the variables or struct members at the same positions. If extensible
+// is false, new entries may not be appended, since that would change the size of the enclosing type.
+func (c *layoutComparer) compareEntries(
+	namespace string,
+	prefix string,
+	oldEntries []StorageEntry,
+	newEntries []StorageEntry,
+	extensible bool,
+) {
+	newByLabel := make(map[string]StorageEntry, len(newEntries))
+	newByPosition := make(map[string]StorageEntry, len(newEntries))
+	for _, entry := range newEntries {
+		newByLabel[entry.Label] = entry
+		newByPosition[position(entry)] = entry
+	}
+	for _, oldEntry := range oldEntries {
+		path := prefix + oldEntry.Label
+		newEntry, ok := newByPosition[position(oldEntry)]
+		switch {
+		case ok && newEntry.Label == oldEntry.Label:
+			c.compareTypes(namespace, path, oldEntry.Type, newEntry.Type)
+		case ok:
+			if moved, ok := newByLabel[oldEntry.Label]; ok {
+				c.report(ChangeMoved, namespace, path, "moved from %s to %s, replaced by %s",
+					position(oldEntry), position(moved), newEntry.Label)
+				continue
+			}
+			c.report(ChangeRenamed, namespace, path, "renamed to %s", newEntry.Label)
+			c.compareTypes(namespace, path, oldEntry.Type, newEntry.Type)
+		default:
+			if moved, ok := newByLabel[oldEntry.Label]; ok {
+				c.report(ChangeMoved, namespace, path, "moved from %s to %s",
+					position(oldEntry), position(moved))
+				continue
+			}
+			c.report(ChangeRemoved, namespace, path, "removed from %s", position(oldEntry))
+		}
+	}
+	if !extensible && len(newEntries) > len(oldEntries) {
+		c.report(ChangeResized, namespace, prefix, "members appended from %s", newEntries[len(oldEntries)].Label)
+	}
+}
+
+// compareTypes compares the types of a variable in the old and new layouts. Types are compared
+// structurally, since the AST IDs in type identifiers differ between compilations.
+func (c *layoutComparer) compareTypes(namespace string, path string, oldID string, newID string) {
+	c.compareTypesIn(namespace, path, oldID, newID, false)
+}
+
+func (c *layoutComparer) compareTypesIn(namespace string, path string, oldID string, newID string, mappingValue bool) {
+	oldType, oldOK := c.old.Types[oldID]
+	newType, newOK := c.new.Types[newID]
+	if !oldOK || !newOK {
+		if normalizeTypeID(oldID) != normalizeTypeID(newID) {
+			c.report(ChangeTypeChanged, namespace, path, "type changed from %s to %s", oldID, newID)
+		}
+		return
+	}
+	oldKind := kindOf(oldID, oldType)
+	newKind := kindOf(newID, newType)
+	if oldKind != newKind || oldType.Encoding != newType.Encoding {
+		c.report(ChangeTypeChanged, namespace, path, "type changed from %s to %s", oldType.Label, newType.Label)
+		return
+	}
+
+	switch oldKind {
+	case kindMapping:
+		c.compareTypesIn(namespace, path+".key", oldType.Key, newType.Key, false)
+		c.compareTypesIn(namespace, path+".value", oldType.Value, newType.Value, true)
+	case kindDynamicArray:
+		c.compareTypesIn(namespace, path+"[]", oldType.Base, newType.Base, false)
+	case kindStaticArray:
+		if oldType.NumberOfBytes != newType.NumberOfBytes || oldType.Label != newType.Label {
+			c.report(ChangeResized, namespace, path, "resized from %s to %s", oldType.Label, newType.Label)
+			return
+		}
+		c.compareTypesIn(namespace, path+"[]", oldType.Base, newType.Base, false)
+	case kindStruct:
+		// Structs stored as mapping values may grow, since each value has its own slots
+		extensible := mappingValue
+		if !extensible && oldType.NumberOfBytes != newType.NumberOfBytes {
+			c.report(ChangeResized, namespace, path, "resized from %s to %s bytes", oldType.NumberOfBytes, newType.NumberOfBytes)
+			return
+		}
+		c.compareEntries(namespace, path+".", oldType.Members, newType.Members, extensible || oldType.NumberOfBytes == newType.NumberOfBytes)
+	default:
+		if oldType.NumberOfBytes != newType.NumberOfBytes {
+			c.report(ChangeResized, namespace, path, "resized from %s to %s", oldType.Label, newType.Label)
+			return
+		}
+		// Addresses may change between address and contract types
+		if oldKind != kindAddress && normalizeTypeID(oldID) != normalizeTypeID(newID) {
+			c.report(ChangeTypeChanged, namespace, path, "type changed from %s to %s", oldType.Label, newType.Label)
+		}
+	}
+}
+
+func position(entry StorageEntry) string {
+	return fmt.Sprintf("slot %s offset %d", entry.Slot, entry.Offset)
+}
+
+// normalizeTypeID removes AST IDs from a type identifier, e.g. t_enum(ValidatorStatus)1234
+// becomes t_enum(ValidatorStatus).
+func normalizeTypeID(typeID string) string {
+	return astIDRegex.ReplaceAllString(typeID, ")")
+}
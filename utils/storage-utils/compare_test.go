@@ -0,0 +1,172 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	exampleBaseNamespace    = "example.storage.ExampleBase"
+	exampleManagerNamespace = "example.storage.ExampleManager"
+)
+
+func TestLoadFoundryLayout(t *testing.T) {
+	layout, err := LoadFoundryLayout("testdata/v1", "ExampleManager")
+	require.NoError(t, err)
+	require.Len(t, layout.Storage, 1)
+	require.Len(t, layout.Namespaces, 2)
+
+	// _owner and _a are packed into the first slot, and _b does not fit
+	manager := layout.Namespaces[exampleManagerNamespace]
+	require.Len(t, manager, 3)
+	require.Equal(t, "0", manager[1].Slot)
+	require.Equal(t, uint64(20), manager[1].Offset)
+	require.Equal(t, "1", manager[2].Slot)
+	require.Equal(t, uint64(0), manager[2].Offset)
+
+	// Struct members declared in another artifact are resolved by AST ID
+	key := common.HexToHash("0x01")
+	infoSlot := new(big.Int).SetBytes(crypto.Keccak256(
+		key.Bytes(),
+		AddSlot(NamespaceSlot(exampleBaseNamespace), 1).Bytes(),
+	))
+	loc, err := layout.Resolve(exampleBaseNamespace, "_infos["+key.Hex()+"].weight")
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(infoSlot), loc.Slot)
+	require.Equal(t, uint64(1), loc.Offset)
+	loc, err = layout.Resolve(exampleBaseNamespace, "_infos["+key.Hex()+"].nodeID")
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(new(big.Int).Add(infoSlot, big.NewInt(1))), loc.Slot)
+
+	_, err = LoadFoundryLayout("testdata/v1", "Unknown")
+	require.ErrorContains(t, err, "no artifact found")
+	_, err = LoadFoundryLayout("testdata/v1", "IExample")
+	require.ErrorContains(t, err, "no storageLayout")
+}
+
+func TestLoadFoundryLayoutBuiltinNamespace(t *testing.T) {
+	// CoqnetMetricsStorage is not annotated with @custom:storage-location, so its layout is
+	// taken from the built-in layout, since the contract declares its root slot
+	layout, err := LoadFoundryLayout("testdata/coqnet", "CoqnetManager")
+	require.NoError(t, err)
+	builtin, err := LoadBuiltinLayout(ValidatorManagerLayout)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]StorageEntry{
+		CoqnetMetricsNamespace: builtin.Namespaces[CoqnetMetricsNamespace],
+	}, layout.Namespaces)
+
+	loc, err := layout.Resolve(CoqnetMetricsNamespace, "_epochs[1].validationIDs[2]")
+	require.NoError(t, err)
+	expected, err := builtin.Resolve(CoqnetMetricsNamespace, "_epochs[1].validationIDs[2]")
+	require.NoError(t, err)
+	require.Equal(t, expected, loc)
+}
+
+func TestCompareLayouts(t *testing.T) {
+	v1, err := LoadFoundryLayout("testdata/v1", "ExampleManager")
+	require.NoError(t, err)
+	v2, err := LoadFoundryLayout("testdata/v2", "ExampleManager")
+	require.NoError(t, err)
+
+	require.Empty(t, CompareLayouts(v1, v1))
+
+	// Appending to Info, which is a mapping value, and to a namespace struct is compatible
+	changes := CompareLayouts(v1, v2)
+	kinds := make(map[string]string)
+	for _, change := range changes {
+		kinds[change.Namespace+" "+change.Path] = change.Kind
+	}
+	require.Equal(t, map[string]string{
+		" _legacy":                       ChangeResized,
+		exampleBaseNamespace + " _count": ChangeMoved,
+		exampleBaseNamespace + " _infos": ChangeMoved,
+		exampleManagerNamespace + " _b":  ChangeResized,
+	}, kinds)
+
+	// Removing variables and namespaces is reported
+	changes = CompareLayouts(v2, v1)
+	kinds = make(map[string]string)
+	for _, change := range changes {
+		kinds[change.Namespace+" "+change.Path] = change.Kind
+	}
+	require.Equal(t, ChangeRenamed, kinds[exampleBaseNamespace+" _total"])
+	require.Equal(t, ChangeRemoved, kinds[exampleManagerNamespace+" _c"])
+
+	delete(v2.Namespaces, exampleBaseNamespace)
+	changes = CompareLayouts(v1, v2)
+	require.Contains(t, changes, LayoutChange{
+		Kind:        ChangeNamespaceRemoved,
+		Namespace:   exampleBaseNamespace,
+		Description: "namespace is no longer declared",
+	})
+}
+
+func TestCompareStructTypes(t *testing.T) {
+	layout := func(members []StorageEntry, size string) *StorageLayout {
+		return &StorageLayout{
+			Storage: []StorageEntry{
+				{Label: "_info", Slot: "0", Type: "t_struct(Info)1_storage"},
+				{Label: "_infos", Slot: "2", Type: "t_array(t_struct(Info)1_storage)dyn_storage"},
+			},
+			Types: map[string]StorageType{
+				"t_uint64":  {Encoding: EncodingInplace, Label: "uint64", NumberOfBytes: "8"},
+				"t_int64":   {Encoding: EncodingInplace, Label: "int64", NumberOfBytes: "8"},
+				"t_address": {Encoding: EncodingInplace, Label: "address", NumberOfBytes: "20"},
+				"t_struct(Info)1_storage": {
+					Encoding:      EncodingInplace,
+					Label:         "struct Info",
+					NumberOfBytes: size,
+					Members:       members,
+				},
+				"t_array(t_struct(Info)1_storage)dyn_storage": {
+					Encoding:      EncodingDynamicArray,
+					Label:         "struct Info[]",
+					NumberOfBytes: "32",
+					Base:          "t_struct(Info)1_storage",
+				},
+			},
+		}
+	}
+	oldLayout := layout([]StorageEntry{
+		{Label: "a", Slot: "0", Type: "t_uint64"},
+		{Label: "b", Slot: "0", Offset: 8, Type: "t_uint64"},
+	}, "32")
+
+	// Appending a member within the struct's last slot keeps its size
+	newLayout := layout([]StorageEntry{
+		{Label: "a", Slot: "0", Type: "t_uint64"},
+		{Label: "b", Slot: "0", Offset: 8, Type: "t_uint64"},
+		{Label: "c", Slot: "0", Offset: 16, Type: "t_uint64"},
+	}, "32")
+	require.Empty(t, CompareLayouts(oldLayout, newLayout))
+
+	// Growing a struct stored in place or in an array shifts the values after it
+	newLayout = layout([]StorageEntry{
+		{Label: "a", Slot: "0", Type: "t_uint64"},
+		{Label: "b", Slot: "0", Offset: 8, Type: "t_uint64"},
+		{Label: "c", Slot: "1", Type: "t_address"},
+	}, "64")
+	require.Equal(t, []LayoutChange{
+		{Kind: ChangeResized, Path: "_info", Description: "resized from 32 to 64 bytes"},
+		{Kind: ChangeResized, Path: "_infos[]", Description: "resized from 32 to 64 bytes"},
+	}, CompareLayouts(oldLayout, newLayout))
+
+	// Renaming and retyping members
+	newLayout = layout([]StorageEntry{
+		{Label: "a", Slot: "0", Type: "t_int64"},
+		{Label: "c", Slot: "0", Offset: 8, Type: "t_uint64"},
+	}, "32")
+	require.Equal(t, []LayoutChange{
+		{Kind: ChangeTypeChanged, Path: "_info.a", Description: "type changed from uint64 to int64"},
+		{Kind: ChangeRenamed, Path: "_info.b", Description: "renamed to c"},
+		{Kind: ChangeTypeChanged, Path: "_infos[].a", Description: "type changed from uint64 to int64"},
+		{Kind: ChangeRenamed, Path: "_infos[].b", Description: "renamed to c"},
+	}, CompareLayouts(oldLayout, newLayout))
+}
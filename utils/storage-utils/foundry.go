@@ -0,0 +1,533 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+var storageLocationRegex = regexp.MustCompile(`@custom:storage-location\s+erc7201:(\S+)`)
+
+// astNode is the subset of a solc AST node needed to compute storage layouts.
+type astNode struct {
+	ID                      int64                `json:"id"`
+	NodeType                string               `json:"nodeType"`
+	Name                    string               `json:"name"`
+	Nodes                   []astNode            `json:"nodes"`
+	Members                 []astNode            `json:"members"`
+	LinearizedBaseContracts []int64              `json:"linearizedBaseContracts"`
+	Constant                bool                 `json:"constant"`
+	Value                   json.RawMessage      `json:"value"`
+	Documentation           *astDocumentation    `json:"documentation"`
+	TypeName                *astTypeName         `json:"typeName"`
+	UnderlyingType          *astTypeName         `json:"underlyingType"`
+	TypeDescriptions        *astTypeDescriptions `json:"typeDescriptions"`
+}
+
+// astLiteral is the subset of a solc AST literal needed to read the value of a constant.
+type astLiteral struct {
+	NodeType string `json:"nodeType"`
+	Value    string `json:"value"`
+}
+
+type astDocumentation struct {
+	Text string `json:"text"`
+}
+
+type astTypeDescriptions struct {
+	TypeString string `json:"typeString"`
+}
+
+type astTypeName struct {
+	NodeType              string              `json:"nodeType"`
+	Name                  string              `json:"name"`
+	ReferencedDeclaration int64               `json:"referencedDeclaration"`
+	KeyType               *astTypeName        `json:"keyType"`
+	ValueType             *astTypeName        `json:"valueType"`
+	BaseType              *astTypeName        `json:"baseType"`
+	Length                json.RawMessage     `json:"length"`
+	Visibility            string              `json:"visibility"`
+	TypeDescriptions      astTypeDescriptions `json:"typeDescriptions"`
+}
+
+type foundryArtifact struct {
+	StorageLayout *StorageLayout `json:"storageLayout"`
+	AST           *astNode       `json:"ast"`
+}
+
+// LoadFoundryLayout returns the storage layout of contract from the foundry output directory outDir.
+// State variables are read from the contract artifact's storageLayout. ERC-7201 namespaces are
+// computed from the structs annotated with @custom:storage-location in the contract and its base
+// contracts, which requires the artifacts to include their ASTs (forge build --ast). Namespaces of
+// the built-in validator-manager layout whose struct is not annotated, such as
+// CoqnetMetricsNamespace, are found from the bytes32 constants holding their root slot, and
+// their layout is taken from the built-in layout.
+func LoadFoundryLayout(outDir string, contract string) (*StorageLayout, error) {
+	var artifactPath string
+	var artifacts []foundryArtifact
+	err := filepath.WalkDir(outDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "build-info" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".json" {
+			return nil
+		}
+		artifactJSON, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var artifact foundryArtifact
+		if err := json.Unmarshal(artifactJSON, &artifact); err != nil {
+			return errors.Wrapf(err, "failed to parse artifact %s", path)
+		}
+		if strings.TrimSuffix(d.Name(), ".json") == contract {
+			if artifactPath != "" {
+				return fmt.Errorf("multiple artifacts found for %s: %s and %s", contract, artifactPath, path)
+			}
+			artifactPath = path
+			// Keep the contract's artifact first
+			artifacts = append([]foundryArtifact{artifact}, artifacts...)
+			return nil
+		}
+		artifacts = append(artifacts, artifact)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read artifacts from %s", outDir)
+	}
+	if artifactPath == "" {
+		return nil, fmt.Errorf("no artifact found for %s in %s", contract, outDir)
+	}
+	if artifacts[0].StorageLayout == nil {
+		return nil, fmt.Errorf("artifact %s has no storageLayout, build with --extra-output storageLayout", artifactPath)
+	}
+	if artifacts[0].AST == nil {
+		return nil, fmt.Errorf("artifact %s has no AST, build with --ast", artifactPath)
+	}
+
+	builder := &layoutBuilder{
+		definitions: make(map[int64]astNode),
+		types:       artifacts[0].StorageLayout.Types,
+	}
+	if builder.types == nil {
+		builder.types = make(map[string]StorageType)
+	}
+	for _, artifact := range artifacts {
+		if artifact.AST != nil {
+			builder.index(*artifact.AST)
+		}
+	}
+	contractNode, err := builder.contract(contract, *artifacts[0].AST)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := builder.namespaces(contractNode)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageLayout{
+		Storage:    artifacts[0].StorageLayout.Storage,
+		Namespaces: namespaces,
+		Types:      builder.types,
+	}, nil
+}
+
+// layoutBuilder computes storage layouts from solc ASTs, following the solc storage layout rules.
+type layoutBuilder struct {
+	definitions map[int64]astNode
+	types       map[string]StorageType
+}
+
+// index records the contracts, structs, enums and user defined value types declared in a source unit.
+func (b *layoutBuilder) index(sourceUnit astNode) {
+	for _, node := range sourceUnit.Nodes {
+		b.definitions[node.ID] = node
+		if node.NodeType != "ContractDefinition" {
+			continue
+		}
+		for _, child := range node.Nodes {
+			b.definitions[child.ID] = child
+		}
+	}
+}
+
+func (b *layoutBuilder) contract(name string, sourceUnit astNode) (astNode, error) {
+	for _, node := range sourceUnit.Nodes {
+		if node.NodeType == "ContractDefinition" && node.Name == name {
+			return node, nil
+		}
+	}
+	return astNode{}, fmt.Errorf("contract %s not found in its artifact's AST", name)
+}
+
+// namespaces computes the layout of each ERC-7201 namespace struct declared by the contract
+// or its base contracts.
+func (b *layoutBuilder) namespaces(contract astNode) (map[string][]StorageEntry, error) {
+	namespaces := make(map[string][]StorageEntry)
+	slots := make(map[common.Hash]struct{})
+	for _, baseID := range contract.LinearizedBaseContracts {
+		base, ok := b.definitions[baseID]
+		if !ok {
+			return nil, fmt.Errorf("base contract %d of %s not found in artifacts", baseID, contract.Name)
+		}
+		for _, node := range base.Nodes {
+			if slot, ok := bytes32Constant(node); ok {
+				slots[slot] = struct{}{}
+			}
+			if node.NodeType != "StructDefinition" || node.Documentation == nil {
+				continue
+			}
+			match := storageLocationRegex.FindStringSubmatch(node.Documentation.Text)
+			if match == nil {
+				continue
+			}
+			members, _, err := b.members(base.Name, node.Members)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to compute layout of %s.%s", base.Name, node.Name)
+			}
+			namespaces[match[1]] = members
+		}
+	}
+	if err := b.builtinNamespaces(namespaces, slots); err != nil {
+		return nil, err
+	}
+	return namespaces, nil
+}
+
+// builtinNamespaces adds the namespaces of the built-in validator-manager layout whose root slot
+// is one of slots, but whose struct is not annotated with @custom:storage-location, so that they
+// are not ignored.
+func (b *layoutBuilder) builtinNamespaces(namespaces map[string][]StorageEntry, slots map[common.Hash]struct{}) error {
+	builtin, err := LoadBuiltinLayout(ValidatorManagerLayout)
+	if err != nil {
+		return err
+	}
+	for id, members := range builtin.Namespaces {
+		if _, ok := namespaces[id]; ok {
+			continue
+		}
+		if _, ok := slots[NamespaceSlot(id)]; !ok {
+			continue
+		}
+		for _, member := range members {
+			if err := b.copyType(builtin, member.Type); err != nil {
+				return errors.Wrapf(err, "failed to copy layout of namespace %s", id)
+			}
+		}
+		namespaces[id] = members
+	}
+	return nil
+}
+
+// copyType registers a type of layout, and the types it references.
+func (b *layoutBuilder) copyType(layout *StorageLayout, typeID string) error {
+	if _, ok := b.types[typeID]; ok {
+		return nil
+	}
+	t, ok := layout.Types[typeID]
+	if !ok {
+		return fmt.Errorf("type %s not found", typeID)
+	}
+	b.types[typeID] = t
+	referenced := []string{t.Key, t.Value, t.Base}
+	for _, member := range t.Members {
+		referenced = append(referenced, member.Type)
+	}
+	for _, id := range referenced {
+		if id == "" {
+			continue
+		}
+		if err := b.copyType(layout, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bytes32Constant returns the value of node if it is a bytes32 constant initialized with a literal.
+func bytes32Constant(node astNode) (common.Hash, bool) {
+	if node.NodeType != "VariableDeclaration" || !node.Constant || node.TypeName == nil ||
+		node.TypeName.TypeDescriptions.TypeString != "bytes32" || len(node.Value) == 0 {
+		return common.Hash{}, false
+	}
+	var literal astLiteral
+	if err := json.Unmarshal(node.Value, &literal); err != nil || literal.NodeType != "Literal" {
+		return common.Hash{}, false
+	}
+	value, ok := new(big.Int).SetString(literal.Value, 0)
+	if !ok || value.BitLen() > 256 {
+		return common.Hash{}, false
+	}
+	return common.BigToHash(value), true
+}
+
+// members lays out struct members in storage, returning the members and the number of slots used.
+// Value types are packed into slots in declaration order, while structs, arrays, mappings, bytes
+// and strings start a new slot, and the item following them does too.
+func (b *layoutBuilder) members(contract string, nodes []astNode) ([]StorageEntry, uint64, error) {
+	var (
+		entries []StorageEntry
+		slot    uint64
+		offset  uint64
+	)
+	for _, node := range nodes {
+		if node.TypeName == nil {
+			return nil, 0, fmt.Errorf("member %s has no type", node.Name)
+		}
+		typeID, err := b.typeOf(node.TypeName)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to get type of member %s", node.Name)
+		}
+		t := b.types[typeID]
+		size, err := t.Size()
+		if err != nil {
+			return nil, 0, err
+		}
+		if slotAligned(typeID, t) {
+			if offset > 0 {
+				slot++
+				offset = 0
+			}
+			entries = append(entries, StorageEntry{
+				Contract: contract,
+				Label:    node.Name,
+				Slot:     strconv.FormatUint(slot, 10),
+				Type:     typeID,
+			})
+			slot += (size + common.HashLength - 1) / common.HashLength
+			continue
+		}
+		if offset+size > common.HashLength {
+			slot++
+			offset = 0
+		}
+		entries = append(entries, StorageEntry{
+			Contract: contract,
+			Label:    node.Name,
+			Offset:   offset,
+			Slot:     strconv.FormatUint(slot, 10),
+			Type:     typeID,
+		})
+		offset += size
+	}
+	if offset > 0 {
+		slot++
+	}
+	return entries, slot, nil
+}
+
+func slotAligned(typeID string, t StorageType) bool {
+	switch kindOf(typeID, t) {
+	case kindStruct, kindStaticArray, kindDynamicArray, kindMapping, kindBytes, kindString:
+		return true
+	default:
+		return false
+	}
+}
+
+// typeOf registers the storage type of a type name, and returns its solc type identifier.
+func (b *layoutBuilder) typeOf(typeName *astTypeName) (string, error) {
+	switch typeName.NodeType {
+	case "ElementaryTypeName":
+		return b.elementaryType(typeName.TypeDescriptions.TypeString)
+	case "UserDefinedTypeName":
+		return b.userDefinedType(typeName.ReferencedDeclaration)
+	case "Mapping":
+		if typeName.KeyType == nil || typeName.ValueType == nil {
+			return "", fmt.Errorf("invalid mapping type %s", typeName.TypeDescriptions.TypeString)
+		}
+		keyID, err := b.typeOf(typeName.KeyType)
+		if err != nil {
+			return "", err
+		}
+		valueID, err := b.typeOf(typeName.ValueType)
+		if err != nil {
+			return "", err
+		}
+		typeID := fmt.Sprintf("t_mapping(%s,%s)", keyID, valueID)
+		b.types[typeID] = StorageType{
+			Encoding:      EncodingMapping,
+			Label:         fmt.Sprintf("mapping(%s => %s)", b.types[keyID].Label, b.types[valueID].Label),
+			NumberOfBytes: "32",
+			Key:           keyID,
+			Value:         valueID,
+		}
+		return typeID, nil
+	case "ArrayTypeName":
+		if typeName.BaseType == nil {
+			return "", fmt.Errorf("invalid array type %s", typeName.TypeDescriptions.TypeString)
+		}
+		baseID, err := b.typeOf(typeName.BaseType)
+		if err != nil {
+			return "", err
+		}
+		base := b.types[baseID]
+		if len(typeName.Length) == 0 || string(typeName.Length) == "null" {
+			typeID := fmt.Sprintf("t_array(%s)dyn_storage", baseID)
+			b.types[typeID] = StorageType{
+				Encoding:      EncodingDynamicArray,
+				Label:         base.Label + "[]",
+				NumberOfBytes: "32",
+				Base:          baseID,
+			}
+			return typeID, nil
+		}
+		// The length may be a constant expression, so read it from the type string
+		t := StorageType{Label: typeName.TypeDescriptions.TypeString}
+		length, err := staticArrayLength(t)
+		if err != nil {
+			return "", err
+		}
+		baseSize, err := base.Size()
+		if err != nil {
+			return "", err
+		}
+		var slots uint64
+		if baseSize <= common.HashLength/2 && !slotAligned(baseID, base) {
+			perSlot := common.HashLength / baseSize
+			slots = (length + perSlot - 1) / perSlot
+		} else {
+			slots = length * ((baseSize + common.HashLength - 1) / common.HashLength)
+		}
+		typeID := fmt.Sprintf("t_array(%s)%d_storage", baseID, length)
+		b.types[typeID] = StorageType{
+			Encoding:      EncodingInplace,
+			Label:         fmt.Sprintf("%s[%d]", base.Label, length),
+			NumberOfBytes: strconv.FormatUint(slots*common.HashLength, 10),
+			Base:          baseID,
+		}
+		return typeID, nil
+	case "FunctionTypeName":
+		size := "8"
+		if typeName.Visibility == "external" {
+			size = "24"
+		}
+		typeID := "t_function_" + typeName.Visibility
+		b.types[typeID] = StorageType{
+			Encoding:      EncodingInplace,
+			Label:         typeName.TypeDescriptions.TypeString,
+			NumberOfBytes: size,
+		}
+		return typeID, nil
+	default:
+		return "", fmt.Errorf("unsupported type name %s", typeName.NodeType)
+	}
+}
+
+func (b *layoutBuilder) elementaryType(typeString string) (string, error) {
+	var (
+		typeID   = "t_" + strings.ReplaceAll(typeString, " ", "_")
+		encoding = EncodingInplace
+		size     uint64
+	)
+	switch {
+	case typeString == "bool":
+		size = 1
+	case typeString == "address" || typeString == "address payable":
+		size = 20
+	case typeString == "string" || typeString == "bytes":
+		typeID += "_storage"
+		encoding = EncodingBytes
+		size = 32
+	case strings.HasPrefix(typeString, "uint"), strings.HasPrefix(typeString, "int"):
+		bits, err := strconv.ParseUint(strings.TrimLeft(typeString, "uint"), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("unsupported elementary type %s", typeString)
+		}
+		size = bits / 8
+	case strings.HasPrefix(typeString, "bytes"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(typeString, "bytes"), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("unsupported elementary type %s", typeString)
+		}
+		size = n
+	default:
+		return "", fmt.Errorf("unsupported elementary type %s", typeString)
+	}
+	b.types[typeID] = StorageType{
+		Encoding:      encoding,
+		Label:         typeString,
+		NumberOfBytes: strconv.FormatUint(size, 10),
+	}
+	return typeID, nil
+}
+
+func (b *layoutBuilder) userDefinedType(id int64) (string, error) {
+	node, ok := b.definitions[id]
+	if !ok {
+		return "", fmt.Errorf("declaration %d not found in artifacts", id)
+	}
+	switch node.NodeType {
+	case "ContractDefinition":
+		typeID := fmt.Sprintf("t_contract(%s)%d", node.Name, node.ID)
+		b.types[typeID] = StorageType{
+			Encoding:      EncodingInplace,
+			Label:         "contract " + node.Name,
+			NumberOfBytes: "20",
+		}
+		return typeID, nil
+	case "EnumDefinition":
+		size := 1
+		for n := len(node.Members) - 1; n > 255; n >>= 8 {
+			size++
+		}
+		typeID := fmt.Sprintf("t_enum(%s)%d", node.Name, node.ID)
+		b.types[typeID] = StorageType{
+			Encoding:      EncodingInplace,
+			Label:         "enum " + node.Name,
+			NumberOfBytes: strconv.Itoa(size),
+		}
+		return typeID, nil
+	case "UserDefinedValueTypeDefinition":
+		if node.UnderlyingType == nil {
+			return "", fmt.Errorf("user defined value type %s has no underlying type", node.Name)
+		}
+		underlyingID, err := b.typeOf(node.UnderlyingType)
+		if err != nil {
+			return "", err
+		}
+		typeID := fmt.Sprintf("t_userDefinedValueType(%s)%d", node.Name, node.ID)
+		b.types[typeID] = StorageType{
+			Encoding:      EncodingInplace,
+			Label:         node.Name,
+			NumberOfBytes: b.types[underlyingID].NumberOfBytes,
+		}
+		return typeID, nil
+	case "StructDefinition":
+		typeID := fmt.Sprintf("t_struct(%s)%d_storage", node.Name, node.ID)
+		if _, ok := b.types[typeID]; ok {
+			return typeID, nil
+		}
+		members, slots, err := b.members("", node.Members)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to compute layout of struct %s", node.Name)
+		}
+		b.types[typeID] = StorageType{
+			Encoding:      EncodingInplace,
+			Label:         "struct " + node.Name,
+			NumberOfBytes: strconv.FormatUint(slots*common.HashLength, 10),
+			Members:       members,
+		}
+		return typeID, nil
+	default:
+		return "", fmt.Errorf("unsupported user defined type %s", node.NodeType)
+	}
+}
@@ -0,0 +1,558 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMaxArrayLength is the maximum number of array elements decoded by a Reader.
+	DefaultMaxArrayLength uint64 = 100
+	// Maximum length of bytes and strings decoded by a Reader
+	maxBytesLength = 1 << 16
+)
+
+type valueKind int
+
+const (
+	kindOther valueKind = iota
+	kindUint
+	kindInt
+	kindBool
+	kindAddress
+	kindFixedBytes
+	kindBytes
+	kindString
+	kindStruct
+	kindStaticArray
+	kindDynamicArray
+	kindMapping
+)
+
+// kindOf classifies a storage type by its solc type identifier, e.g. t_uint256 or
+// t_mapping(t_bytes32,t_uint256).
+func kindOf(typeID string, t StorageType) valueKind {
+	switch {
+	case t.Encoding == EncodingMapping:
+		return kindMapping
+	case t.Encoding == EncodingDynamicArray:
+		return kindDynamicArray
+	case t.Encoding == EncodingBytes && strings.HasPrefix(typeID, "t_string"):
+		return kindString
+	case t.Encoding == EncodingBytes:
+		return kindBytes
+	case strings.HasPrefix(typeID, "t_struct"):
+		return kindStruct
+	case strings.HasPrefix(typeID, "t_array"):
+		return kindStaticArray
+	case strings.HasPrefix(typeID, "t_uint"), strings.HasPrefix(typeID, "t_enum"):
+		return kindUint
+	case strings.HasPrefix(typeID, "t_int"):
+		return kindInt
+	case typeID == "t_bool":
+		return kindBool
+	case strings.HasPrefix(typeID, "t_address"), strings.HasPrefix(typeID, "t_contract"):
+		return kindAddress
+	case strings.HasPrefix(typeID, "t_bytes"):
+		return kindFixedBytes
+	default:
+		return kindOther
+	}
+}
+
+// Location is the position of a value in storage.
+type Location struct {
+	Slot common.Hash
+	// Offset of the value in bytes from the least significant end of the slot
+	Offset uint64
+	// Type identifier of the value. Empty for the root of a namespace or of
+	// the contract's storage, in which case Members describes the value.
+	Type    string
+	Members []StorageEntry
+}
+
+type pathStep struct {
+	member string
+	key    string
+	isKey  bool
+}
+
+// parsePath splits a path such as _epochs[3].validationIDs[0] into its member and key steps.
+func parsePath(path string) ([]pathStep, error) {
+	var steps []pathStep
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated key in path %q", path)
+			}
+			steps = append(steps, pathStep{key: path[i+1 : i+end], isKey: true})
+			i += end + 1
+		case '.':
+			if len(steps) == 0 {
+				return nil, fmt.Errorf("path %q must start with a member", path)
+			}
+			i++
+			fallthrough
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty member in path %q", path)
+			}
+			steps = append(steps, pathStep{member: path[i : i+end]})
+			i += end
+		}
+	}
+	if len(steps) > 0 && steps[0].isKey {
+		return nil, fmt.Errorf("path %q must start with a member", path)
+	}
+	return steps, nil
+}
+
+// Resolve returns the storage location of path within the given ERC-7201 namespace, or within
+// the contract's state variables if namespace is empty. Paths consist of member names, mapping
+// keys and array indices, e.g. _validationPeriods[0x1234...].status or _epochs[3].validationIDs[0].
+// Mapping keys are given as decimal or hex numbers, addresses, hex bytes, true/false or raw strings,
+// according to the mapping's key type.
+func (l *StorageLayout) Resolve(namespace string, path string) (Location, error) {
+	root := Location{Members: l.Storage}
+	if namespace != "" {
+		members, ok := l.Namespaces[namespace]
+		if !ok {
+			return Location{}, fmt.Errorf("unknown namespace %s", namespace)
+		}
+		root = Location{Slot: NamespaceSlot(namespace), Members: members}
+	}
+	steps, err := parsePath(path)
+	if err != nil {
+		return Location{}, err
+	}
+
+	loc := root
+	for _, step := range steps {
+		if step.isKey {
+			loc, err = l.index(loc, step.key)
+		} else {
+			loc, err = l.member(loc, step.member)
+		}
+		if err != nil {
+			return Location{}, err
+		}
+	}
+	return loc, nil
+}
+
+func (l *StorageLayout) member(loc Location, label string) (Location, error) {
+	members := loc.Members
+	if loc.Type != "" {
+		t, ok := l.Types[loc.Type]
+		if !ok || kindOf(loc.Type, t) != kindStruct {
+			return Location{}, fmt.Errorf("cannot access member %s of non-struct type %s", label, loc.Type)
+		}
+		members = t.Members
+	}
+	for _, member := range members {
+		if member.Label != label {
+			continue
+		}
+		slot, err := strconv.ParseUint(member.Slot, 10, 64)
+		if err != nil {
+			return Location{}, errors.Wrapf(err, "invalid slot for member %s", label)
+		}
+		return Location{
+			Slot:   AddSlot(loc.Slot, slot),
+			Offset: member.Offset,
+			Type:   member.Type,
+		}, nil
+	}
+	return Location{}, fmt.Errorf("unknown member %s", label)
+}
+
+func (l *StorageLayout) index(loc Location, key string) (Location, error) {
+	t, ok := l.Types[loc.Type]
+	if !ok {
+		return Location{}, fmt.Errorf("cannot index %s", loc.Type)
+	}
+	switch kindOf(loc.Type, t) {
+	case kindMapping:
+		encodedKey, err := l.encodeMappingKey(t.Key, key)
+		if err != nil {
+			return Location{}, err
+		}
+		return Location{Slot: MappingSlot(loc.Slot, encodedKey), Type: t.Value}, nil
+	case kindDynamicArray, kindStaticArray:
+		index, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return Location{}, errors.Wrapf(err, "invalid array index %s", key)
+		}
+		base := loc.Slot
+		if kindOf(loc.Type, t) == kindDynamicArray {
+			base = DynamicArraySlot(loc.Slot)
+		} else {
+			length, err := staticArrayLength(t)
+			if err != nil {
+				return Location{}, err
+			}
+			if index >= length {
+				return Location{}, fmt.Errorf("index %d out of bounds for %s", index, t.Label)
+			}
+		}
+		return l.element(base, t.Base, index)
+	default:
+		return Location{}, fmt.Errorf("cannot index non-mapping, non-array type %s", t.Label)
+	}
+}
+
+// element returns the location of the element at index of an array starting at base.
+// Elements of at most 16 bytes are packed into slots, while larger elements start at a new slot.
+func (l *StorageLayout) element(base common.Hash, elementType string, index uint64) (Location, error) {
+	t, ok := l.Types[elementType]
+	if !ok {
+		return Location{}, fmt.Errorf("unknown type %s", elementType)
+	}
+	size, err := t.Size()
+	if err != nil {
+		return Location{}, err
+	}
+	if size == 0 {
+		return Location{}, fmt.Errorf("invalid size for type %s", t.Label)
+	}
+	if size <= common.HashLength/2 {
+		perSlot := common.HashLength / size
+		return Location{
+			Slot:   AddSlot(base, index/perSlot),
+			Offset: (index % perSlot) * size,
+			Type:   elementType,
+		}, nil
+	}
+	slots := (size + common.HashLength - 1) / common.HashLength
+	return Location{Slot: AddSlot(base, index*slots), Type: elementType}, nil
+}
+
+func staticArrayLength(t StorageType) (uint64, error) {
+	start := strings.LastIndexByte(t.Label, '[')
+	if start < 0 || !strings.HasSuffix(t.Label, "]") {
+		return 0, fmt.Errorf("invalid static array type %s", t.Label)
+	}
+	return strconv.ParseUint(t.Label[start+1:len(t.Label)-1], 10, 64)
+}
+
+// encodeMappingKey encodes key as it is hashed with the mapping slot.
+func (l *StorageLayout) encodeMappingKey(keyType string, key string) ([]byte, error) {
+	t := l.Types[keyType]
+	switch kindOf(keyType, t) {
+	case kindString:
+		return []byte(key), nil
+	case kindBytes:
+		return hexutil.Decode(key)
+	case kindAddress:
+		if !common.IsHexAddress(key) {
+			return nil, fmt.Errorf("invalid address key %s", key)
+		}
+		return common.LeftPadBytes(common.HexToAddress(key).Bytes(), common.HashLength), nil
+	case kindBool:
+		switch key {
+		case "true":
+			return common.BigToHash(big.NewInt(1)).Bytes(), nil
+		case "false":
+			return common.Hash{}.Bytes(), nil
+		}
+		return nil, fmt.Errorf("invalid bool key %s", key)
+	case kindUint, kindInt:
+		n, ok := new(big.Int).SetString(key, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer key %s", key)
+		}
+		if n.Sign() < 0 && kindOf(keyType, t) == kindUint {
+			return nil, fmt.Errorf("invalid unsigned integer key %s", key)
+		}
+		return math.U256Bytes(n), nil
+	case kindFixedBytes:
+		b, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bytes key %s", key)
+		}
+		if len(b) > common.HashLength {
+			return nil, fmt.Errorf("bytes key %s longer than 32 bytes", key)
+		}
+		return common.RightPadBytes(b, common.HashLength), nil
+	default:
+		return nil, fmt.Errorf("unsupported mapping key type %s", keyType)
+	}
+}
+
+// StorageClient reads contract storage, and is implemented by ethclient.Client.
+type StorageClient interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// Reader reads and decodes the storage of a contract via eth_getStorageAt.
+type Reader struct {
+	client      StorageClient
+	address     common.Address
+	layout      *StorageLayout
+	blockNumber *big.Int
+
+	// MaxArrayLength is the maximum number of array elements decoded.
+	// Longer arrays are truncated, and their elements can be read by index.
+	MaxArrayLength uint64
+}
+
+// NewReader returns a Reader for the contract at address with the given layout.
+// Storage is read at blockNumber, or at the latest block if it is nil.
+func NewReader(client StorageClient, address common.Address, layout *StorageLayout, blockNumber *big.Int) *Reader {
+	return &Reader{
+		client:         client,
+		address:        address,
+		layout:         layout,
+		blockNumber:    blockNumber,
+		MaxArrayLength: DefaultMaxArrayLength,
+	}
+}
+
+// StructValue is a decoded struct, which marshals to a JSON object with members in declaration order.
+// Mapping members are omitted, since they cannot be enumerated.
+type StructValue []StructMember
+
+type StructMember struct {
+	Label string
+	Value interface{}
+}
+
+func (s StructValue) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, member := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		label, err := json.Marshal(member.Label)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(member.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(label)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Get returns the value of the member with the given label.
+func (s StructValue) Get(label string) (interface{}, bool) {
+	for _, member := range s {
+		if member.Label == label {
+			return member.Value, true
+		}
+	}
+	return nil, false
+}
+
+// ArrayValue is a decoded static or dynamic array. Elements holds at most MaxArrayLength elements.
+type ArrayValue struct {
+	Length   uint64        `json:"length"`
+	Elements []interface{} `json:"elements"`
+}
+
+// Read resolves path within namespace (see StorageLayout.Resolve) and decodes the value stored there.
+// Integers and enums decode to *big.Int, addresses and contracts to common.Address, fixed-size bytes
+// and bytes to hexutil.Bytes, structs to StructValue and arrays to ArrayValue.
+func (r *Reader) Read(ctx context.Context, namespace string, path string) (interface{}, error) {
+	loc, err := r.layout.Resolve(namespace, path)
+	if err != nil {
+		return nil, err
+	}
+	return r.ReadLocation(ctx, loc)
+}
+
+// ReadLocation decodes the value stored at loc.
+func (r *Reader) ReadLocation(ctx context.Context, loc Location) (interface{}, error) {
+	d := &decoder{
+		Reader: r,
+		ctx:    ctx,
+		slots:  make(map[common.Hash]common.Hash),
+	}
+	if loc.Type == "" {
+		return d.decodeMembers(loc.Slot, loc.Members)
+	}
+	return d.decode(loc)
+}
+
+// decoder caches the slots read while decoding a single value.
+type decoder struct {
+	*Reader
+	ctx   context.Context
+	slots map[common.Hash]common.Hash
+}
+
+func (d *decoder) readSlot(slot common.Hash) (common.Hash, error) {
+	if value, ok := d.slots[slot]; ok {
+		return value, nil
+	}
+	value, err := d.client.StorageAt(d.ctx, d.address, slot, d.blockNumber)
+	if err != nil {
+		return common.Hash{}, errors.Wrapf(err, "failed to read slot %s", slot)
+	}
+	d.slots[slot] = common.BytesToHash(value)
+	return d.slots[slot], nil
+}
+
+func (d *decoder) decode(loc Location) (interface{}, error) {
+	t, ok := d.layout.Types[loc.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", loc.Type)
+	}
+	switch kind := kindOf(loc.Type, t); kind {
+	case kindMapping:
+		return nil, fmt.Errorf("cannot read %s without a key", t.Label)
+	case kindStruct:
+		return d.decodeMembers(loc.Slot, t.Members)
+	case kindStaticArray:
+		length, err := staticArrayLength(t)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(loc.Slot, t.Base, length)
+	case kindDynamicArray:
+		word, err := d.readSlot(loc.Slot)
+		if err != nil {
+			return nil, err
+		}
+		length := word.Big()
+		if !length.IsUint64() {
+			return nil, fmt.Errorf("invalid length for %s", t.Label)
+		}
+		return d.decodeArray(DynamicArraySlot(loc.Slot), t.Base, length.Uint64())
+	case kindBytes, kindString:
+		b, err := d.decodeBytes(loc.Slot)
+		if err != nil {
+			return nil, err
+		}
+		if kind == kindString {
+			return string(b), nil
+		}
+		return hexutil.Bytes(b), nil
+	default:
+		return d.decodeValue(loc, t, kind)
+	}
+}
+
+func (d *decoder) decodeMembers(slot common.Hash, members []StorageEntry) (StructValue, error) {
+	value := make(StructValue, 0, len(members))
+	for _, member := range members {
+		if t, ok := d.layout.Types[member.Type]; ok && kindOf(member.Type, t) == kindMapping {
+			continue
+		}
+		memberSlot, err := strconv.ParseUint(member.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid slot for member %s", member.Label)
+		}
+		memberValue, err := d.decode(Location{
+			Slot:   AddSlot(slot, memberSlot),
+			Offset: member.Offset,
+			Type:   member.Type,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode member %s", member.Label)
+		}
+		value = append(value, StructMember{Label: member.Label, Value: memberValue})
+	}
+	return value, nil
+}
+
+func (d *decoder) decodeArray(base common.Hash, elementType string, length uint64) (*ArrayValue, error) {
+	value := &ArrayValue{Length: length, Elements: []interface{}{}}
+	for i := uint64(0); i < min(length, d.MaxArrayLength); i++ {
+		loc, err := d.layout.element(base, elementType, i)
+		if err != nil {
+			return nil, err
+		}
+		element, err := d.decode(loc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode element %d", i)
+		}
+		value.Elements = append(value.Elements, element)
+	}
+	return value, nil
+}
+
+// decodeBytes decodes a bytes or string value. Values of at most 31 bytes are stored in the
+// slot itself with length*2 in the lowest byte, while longer values store length*2+1 in the slot
+// and their data starting at keccak256(slot).
+func (d *decoder) decodeBytes(slot common.Hash) ([]byte, error) {
+	word, err := d.readSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	if word[common.HashLength-1]&1 == 0 {
+		length := word[common.HashLength-1] / 2
+		return common.CopyBytes(word[:length]), nil
+	}
+	length := new(big.Int).Rsh(word.Big(), 1)
+	if !length.IsUint64() || length.Uint64() > maxBytesLength {
+		return nil, fmt.Errorf("bytes length %s exceeds maximum of %d", length, maxBytesLength)
+	}
+	data := make([]byte, 0, length.Uint64()+common.HashLength)
+	dataSlot := DynamicArraySlot(slot)
+	for i := uint64(0); uint64(len(data)) < length.Uint64(); i++ {
+		word, err := d.readSlot(AddSlot(dataSlot, i))
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, word.Bytes()...)
+	}
+	return data[:length.Uint64()], nil
+}
+
+func (d *decoder) decodeValue(loc Location, t StorageType, kind valueKind) (interface{}, error) {
+	size, err := t.Size()
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 || loc.Offset+size > common.HashLength {
+		return nil, fmt.Errorf("invalid size %d and offset %d for type %s", size, loc.Offset, t.Label)
+	}
+	word, err := d.readSlot(loc.Slot)
+	if err != nil {
+		return nil, err
+	}
+	raw := word[common.HashLength-loc.Offset-size : common.HashLength-loc.Offset]
+	switch kind {
+	case kindUint:
+		return new(big.Int).SetBytes(raw), nil
+	case kindInt:
+		n := new(big.Int).SetBytes(raw)
+		if raw[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*size)))
+		}
+		return n, nil
+	case kindBool:
+		return raw[len(raw)-1] != 0, nil
+	case kindAddress:
+		return common.BytesToAddress(raw), nil
+	default:
+		return hexutil.Bytes(common.CopyBytes(raw)), nil
+	}
+}
@@ -0,0 +1,155 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Storage type encodings, as reported by solc in storage layouts
+const (
+	EncodingInplace      = "inplace"
+	EncodingMapping      = "mapping"
+	EncodingDynamicArray = "dynamic_array"
+	EncodingBytes        = "bytes"
+)
+
+// ERC-7201 namespace IDs of the validator manager contracts
+const (
+	ValidatorManagerNamespace         = "avalanche-icm.storage.ValidatorManager"
+	PoSValidatorManagerNamespace      = "avalanche-icm.storage.PoSValidatorManager"
+	ERC20TokenStakingManagerNamespace = "avalanche-icm.storage.ERC20TokenStakingManager"
+	CoqnetMetricsNamespace            = "coqnet.storage.CoqnetMetricsStorage"
+)
+
+// ERC-7201 namespace IDs of the OpenZeppelin upgradeable contracts the validator managers inherit
+const (
+	OwnableNamespace       = "openzeppelin.storage.Ownable"
+	InitializableNamespace = "openzeppelin.storage.Initializable"
+)
+
+// ValidatorManagerLayout is the name of the built-in layout describing the ERC-7201 namespaces
+// of the validator manager contracts, including CoqnetERC20TokenStakingManager.
+const ValidatorManagerLayout = "validator-manager"
+
+//go:embed layouts/*.json
+var builtinLayouts embed.FS
+
+// StorageLayout describes the storage of a contract, in the format of the storageLayout
+// output of solc and foundry. Since solc does not describe ERC-7201 namespaced storage,
+// Namespaces additionally maps each namespace ID to the members of its storage struct,
+// with slots relative to the namespace's root slot.
+type StorageLayout struct {
+	Storage    []StorageEntry            `json:"storage"`
+	Namespaces map[string][]StorageEntry `json:"namespaces,omitempty"`
+	Types      map[string]StorageType    `json:"types"`
+}
+
+// StorageEntry is a state variable or struct member in a storage layout.
+type StorageEntry struct {
+	Contract string `json:"contract,omitempty"`
+	Label    string `json:"label"`
+	Offset   uint64 `json:"offset"`
+	// Decimal slot index, relative to the start of the enclosing struct or namespace
+	Slot string `json:"slot"`
+	Type string `json:"type"`
+}
+
+// StorageType describes a type referenced by a storage layout.
+type StorageType struct {
+	Encoding      string         `json:"encoding"`
+	Label         string         `json:"label"`
+	NumberOfBytes string         `json:"numberOfBytes"`
+	Key           string         `json:"key,omitempty"`
+	Value         string         `json:"value,omitempty"`
+	Base          string         `json:"base,omitempty"`
+	Members       []StorageEntry `json:"members,omitempty"`
+}
+
+// Size returns the number of bytes the type occupies in storage.
+func (t StorageType) Size() (uint64, error) {
+	size, err := strconv.ParseUint(t.NumberOfBytes, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid number of bytes for type %s", t.Label)
+	}
+	return size, nil
+}
+
+// LoadLayout reads a storage layout from a JSON file. The file may either contain the layout
+// itself, or a foundry artifact with the layout under "storageLayout".
+func LoadLayout(path string) (*StorageLayout, error) {
+	layoutJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read storage layout %s", path)
+	}
+	return ParseLayout(layoutJSON)
+}
+
+// ParseLayout parses a storage layout, or a foundry artifact containing one.
+func ParseLayout(layoutJSON []byte) (*StorageLayout, error) {
+	var artifact struct {
+		StorageLayout *StorageLayout `json:"storageLayout"`
+	}
+	if err := json.Unmarshal(layoutJSON, &artifact); err != nil {
+		return nil, errors.Wrap(err, "failed to parse storage layout")
+	}
+	if artifact.StorageLayout != nil {
+		return artifact.StorageLayout, nil
+	}
+	var layout StorageLayout
+	if err := json.Unmarshal(layoutJSON, &layout); err != nil {
+		return nil, errors.Wrap(err, "failed to parse storage layout")
+	}
+	if layout.Types == nil {
+		return nil, fmt.Errorf("storage layout has no types")
+	}
+	return &layout, nil
+}
+
+// LoadBuiltinLayout returns the built-in storage layout with the given name.
+func LoadBuiltinLayout(name string) (*StorageLayout, error) {
+	layoutJSON, err := builtinLayouts.ReadFile("layouts/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown built-in storage layout %s", name)
+	}
+	return ParseLayout(layoutJSON)
+}
+
+// NamespaceSlot returns the root slot of the ERC-7201 namespace with the given ID:
+// keccak256(abi.encode(uint256(keccak256(id)) - 1)) & ~bytes32(uint256(0xff))
+func NamespaceSlot(id string) common.Hash {
+	idHash := new(big.Int).SetBytes(crypto.Keccak256([]byte(id)))
+	idHash.Sub(idHash, big.NewInt(1))
+	slot := crypto.Keccak256Hash(common.BigToHash(idHash).Bytes())
+	slot[common.HashLength-1] = 0
+	return slot
+}
+
+// MappingSlot returns the slot of the value for the ABI-encoded key in the mapping at slot.
+// Value type keys are padded to 32 bytes, while string and bytes keys are not padded.
+func MappingSlot(slot common.Hash, key []byte) common.Hash {
+	return crypto.Keccak256Hash(key, slot.Bytes())
+}
+
+// DynamicArraySlot returns the slot of the first element of the dynamic array, or the data
+// of the long bytes or string, at slot.
+func DynamicArraySlot(slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(slot.Bytes())
+}
+
+// AddSlot returns slot + n, modulo 2^256.
+func AddSlot(slot common.Hash, n uint64) common.Hash {
+	sum := new(big.Int).SetBytes(slot.Bytes())
+	sum.Add(sum, new(big.Int).SetUint64(n))
+	return common.BytesToHash(sum.Bytes())
+}
@@ -0,0 +1,181 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStorageClient map[common.Hash]common.Hash
+
+func (m mockStorageClient) StorageAt(
+	_ context.Context,
+	_ common.Address,
+	key common.Hash,
+	_ *big.Int,
+) ([]byte, error) {
+	return m[key].Bytes(), nil
+}
+
+func TestNamespaceSlot(t *testing.T) {
+	// Storage locations declared by the contracts
+	testCases := map[string]string{
+		ValidatorManagerNamespace:                    "0xe92546d698950ddd38910d2e15ed1d923cd0a7b3dde9e2a6a3f380565559cb00",
+		PoSValidatorManagerNamespace:                 "0x4317713f7ecbdddd4bc99e95d903adedaa883b2e7c2551610bd13e2c7e473d00",
+		ERC20TokenStakingManagerNamespace:            "0x6e5bdfcce15e53c3406ea67bfce37dcd26f5152d5492824e43fd5e3c8ac5ab00",
+		CoqnetMetricsNamespace:                       "0x15948f25c54ec2687bf5cd60236db66c5e145b7bc4b04f89902ccb02ee706d00",
+		"avalanche-ictt.storage.TokenHome":           "0x9316912b5a9db88acbe872c934fdd0a46c436c6dcba332d649c4d57c7bc9e600",
+		"teleporter.storage.TeleporterRegistryApp":   "0xde77a4dc7391f6f8f2d9567915d687d3aee79e7a1fc7300392f2727e9a0f1d00",
+		"avalanche-ictt.storage.SendReentrancyGuard": "0xd2f1ed38b7d242bfb8b41862afb813a15193219a4bc717f2056607593e6c7500",
+	}
+	for id, expected := range testCases {
+		require.Equal(t, common.HexToHash(expected), NamespaceSlot(id), id)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	steps, err := parsePath("_epochs[3].validationIDs[0]")
+	require.NoError(t, err)
+	require.Equal(t, []pathStep{
+		{member: "_epochs"},
+		{key: "3", isKey: true},
+		{member: "validationIDs"},
+		{key: "0", isKey: true},
+	}, steps)
+
+	for _, path := range []string{"[0]", ".a", "a.", "a..b", "a[0"} {
+		_, err := parsePath(path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestReadValidatorManagerLayout(t *testing.T) {
+	layout, err := LoadBuiltinLayout(ValidatorManagerLayout)
+	require.NoError(t, err)
+
+	// Build the storage of a validator manager, computing slots independently of the layout
+	storage := mockStorageClient{}
+	vmRoot := new(big.Int).SetBytes(NamespaceSlot(ValidatorManagerNamespace).Bytes())
+	vmSlot := func(n int64) []byte {
+		return common.BigToHash(new(big.Int).Add(vmRoot, big.NewInt(n))).Bytes()
+	}
+	// _churnPeriodSeconds = 3600, _maximumChurnPercentage = 20
+	storage[common.BytesToHash(vmSlot(1))] = common.BigToHash(
+		new(big.Int).Or(new(big.Int).Lsh(big.NewInt(20), 64), big.NewInt(3600)),
+	)
+
+	validationID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	validatorSlot := new(big.Int).SetBytes(crypto.Keccak256(validationID[:], vmSlot(5)))
+	offsetSlot := func(base *big.Int, n int64) common.Hash {
+		return common.BigToHash(new(big.Int).Add(base, big.NewInt(n)))
+	}
+	// status = Active
+	storage[offsetSlot(validatorSlot, 0)] = common.BigToHash(big.NewInt(2))
+	// Short bytes store their length * 2 in the lowest byte
+	var nodeIDWord common.Hash
+	copy(nodeIDWord[:], nodeID[:])
+	nodeIDWord[31] = byte(2 * len(nodeID))
+	storage[offsetSlot(validatorSlot, 1)] = nodeIDWord
+	// startingWeight = 100, messageNonce = 1, weight = 200, startedAt = 1000
+	packed := new(big.Int).Lsh(big.NewInt(1000), 192)
+	packed.Or(packed, new(big.Int).Lsh(big.NewInt(200), 128))
+	packed.Or(packed, new(big.Int).Lsh(big.NewInt(1), 64))
+	packed.Or(packed, big.NewInt(100))
+	storage[offsetSlot(validatorSlot, 2)] = common.BigToHash(packed)
+
+	// Long bytes store length * 2 + 1, with their data starting at keccak256(slot)
+	message := make([]byte, 40)
+	for i := range message {
+		message[i] = byte(i + 1)
+	}
+	messageSlot := crypto.Keccak256Hash(validationID[:], vmSlot(4))
+	storage[messageSlot] = common.BigToHash(big.NewInt(2*40 + 1))
+	messageData := new(big.Int).SetBytes(crypto.Keccak256(messageSlot.Bytes()))
+	storage[offsetSlot(messageData, 0)] = common.BytesToHash(message[:32])
+	storage[offsetSlot(messageData, 1)] = common.BytesToHash(common.RightPadBytes(message[32:], 32))
+
+	// Coqnet epoch 3 with two validation IDs
+	coqnetRoot := new(big.Int).SetBytes(NamespaceSlot(CoqnetMetricsNamespace).Bytes())
+	coqnetSlot := func(n int64) []byte {
+		return common.BigToHash(new(big.Int).Add(coqnetRoot, big.NewInt(n))).Bytes()
+	}
+	epochSlot := new(big.Int).SetBytes(crypto.Keccak256(common.BigToHash(big.NewInt(3)).Bytes(), coqnetSlot(6)))
+	storage[offsetSlot(epochSlot, 0)] = common.BigToHash(big.NewInt(3))
+	storage[offsetSlot(epochSlot, 3)] = common.BigToHash(big.NewInt(2))
+	validationIDsSlot := new(big.Int).SetBytes(crypto.Keccak256(offsetSlot(epochSlot, 3).Bytes()))
+	otherValidationID := ids.GenerateTestID()
+	storage[offsetSlot(validationIDsSlot, 0)] = common.Hash(validationID)
+	storage[offsetSlot(validationIDsSlot, 1)] = common.Hash(otherValidationID)
+
+	owner := common.Address(ids.GenerateTestShortID())
+	storage[crypto.Keccak256Hash(common.LeftPadBytes(owner.Bytes(), 32), coqnetSlot(3))] = common.BigToHash(big.NewInt(2))
+
+	reader := NewReader(storage, common.Address{}, layout, nil)
+	ctx := context.Background()
+	read := func(namespace string, path string) interface{} {
+		value, err := reader.Read(ctx, namespace, path)
+		require.NoError(t, err, path)
+		return value
+	}
+
+	require.Equal(t, big.NewInt(3600), read(ValidatorManagerNamespace, "_churnPeriodSeconds"))
+	require.Equal(t, big.NewInt(20), read(ValidatorManagerNamespace, "_maximumChurnPercentage"))
+	require.Equal(t, hexutil.Bytes(message), read(ValidatorManagerNamespace, "_pendingRegisterValidationMessages["+validationID.Hex()+"]"))
+
+	validator := read(ValidatorManagerNamespace, "_validationPeriods[0x"+validationID.Hex()+"]").(StructValue)
+	expected := map[string]interface{}{
+		"status":         big.NewInt(2),
+		"nodeID":         hexutil.Bytes(nodeID[:]),
+		"startingWeight": big.NewInt(100),
+		"messageNonce":   big.NewInt(1),
+		"weight":         big.NewInt(200),
+		"startedAt":      big.NewInt(1000),
+		"endedAt":        big.NewInt(0),
+	}
+	require.Len(t, validator, len(expected))
+	for label, value := range expected {
+		actual, ok := validator.Get(label)
+		require.True(t, ok, label)
+		if n, ok := value.(*big.Int); ok {
+			require.Zero(t, n.Cmp(actual.(*big.Int)), label)
+			continue
+		}
+		require.Equal(t, value, actual, label)
+	}
+
+	require.Equal(t, common.Hash(otherValidationID).Bytes(), []byte(read(CoqnetMetricsNamespace, "_epochs[3].validationIDs[1]").(hexutil.Bytes)))
+	epoch := read(CoqnetMetricsNamespace, "_epochs[3]").(StructValue)
+	validationIDs, ok := epoch.Get("validationIDs")
+	require.True(t, ok)
+	require.Equal(t, uint64(2), validationIDs.(*ArrayValue).Length)
+	require.Equal(t, big.NewInt(2), read(CoqnetMetricsNamespace, "_nodesPerValidator["+owner.Hex()+"]"))
+
+	// Reading a namespace root skips mappings
+	metrics := read(CoqnetMetricsNamespace, "").(StructValue)
+	metricsJSON, err := json.Marshal(metrics)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"_validatorsRegistered": 0,
+		"_maxValidators": 0,
+		"_maxNodesPerValidator": 0,
+		"_currentEpoch": 0
+	}`, string(metricsJSON))
+
+	_, err = reader.Read(ctx, CoqnetMetricsNamespace, "_epochs")
+	require.ErrorContains(t, err, "without a key")
+	_, err = reader.Read(ctx, CoqnetMetricsNamespace, "_currentEpoch[0]")
+	require.ErrorContains(t, err, "cannot index")
+	_, err = reader.Read(ctx, "unknown", "")
+	require.ErrorContains(t, err, "unknown namespace")
+}
@@ -0,0 +1,188 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Chain config key of the off-chain Warp messages signed by a chain's validators
+	OffChainMessagesConfigKey = "warp-off-chain-messages"
+	// Gas limit of addProtocolVersion transactions, which verify a Warp message
+	AddProtocolVersionGasLimit uint64 = 500_000
+)
+
+// NewOffChainRegistryMessage returns the off-chain Warp message that registers the
+// TeleporterMessenger at teleporterAddress as version of the TeleporterRegistry at
+// registryAddress on the chain blockchainID. The message is sent from the empty source
+// address, so that it is only accepted if it is in the off-chain messages of the chain config
+// of the chain's validators.
+func NewOffChainRegistryMessage(
+	networkID uint32,
+	blockchainID ids.ID,
+	registryAddress common.Address,
+	version *big.Int,
+	teleporterAddress common.Address,
+) (*avalancheWarp.UnsignedMessage, error) {
+	payloadBytes, err := teleporterregistry.PackTeleporterRegistryWarpPayload(
+		teleporterregistry.ProtocolRegistryEntry{
+			Version:         version,
+			ProtocolAddress: teleporterAddress,
+		},
+		registryAddress,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack registry payload")
+	}
+	addressedPayload, err := payload.NewAddressedCall([]byte{}, payloadBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create addressed call")
+	}
+	return avalancheWarp.NewUnsignedMessage(networkID, blockchainID, addressedPayload.Bytes())
+}
+
+// AddOffChainMessages adds messages to the off-chain Warp messages of a JSON chain config,
+// and returns the indented config. Other options are preserved, as are messages already in
+// the config, which are not added again. An empty config is treated as {}.
+func AddOffChainMessages(chainConfig []byte, messages ...*avalancheWarp.UnsignedMessage) ([]byte, error) {
+	config := make(map[string]interface{})
+	if len(chainConfig) > 0 {
+		// Numbers are kept as written, since large values such as gas limits or timestamps would
+		// lose precision as float64.
+		decoder := json.NewDecoder(bytes.NewReader(chainConfig))
+		decoder.UseNumber()
+		if err := decoder.Decode(&config); err != nil {
+			return nil, errors.Wrap(err, "failed to parse chain config")
+		}
+		if decoder.More() {
+			return nil, errors.New("failed to parse chain config: unexpected data after the config")
+		}
+	}
+
+	var offChainMessages []string
+	if existing, ok := config[OffChainMessagesConfigKey]; ok {
+		list, ok := existing.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not a list", OffChainMessagesConfigKey)
+		}
+		for _, message := range list {
+			hexMessage, ok := message.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s contains a non-string message", OffChainMessagesConfigKey)
+			}
+			offChainMessages = append(offChainMessages, hexMessage)
+		}
+	}
+	for _, message := range messages {
+		hexMessage := hexutil.Encode(message.Bytes())
+		if !containsMessage(offChainMessages, hexMessage) {
+			offChainMessages = append(offChainMessages, hexMessage)
+		}
+	}
+	config[OffChainMessagesConfigKey] = offChainMessages
+
+	configJSON, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal chain config")
+	}
+	return configJSON, nil
+}
+
+func containsMessage(hexMessages []string, hexMessage string) bool {
+	for _, m := range hexMessages {
+		if m == hexMessage {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAddProtocolVersionTx returns an unsigned transaction calling addProtocolVersion on the
+// TeleporterRegistry at registryAddress, with the signed registry message as Warp predicate.
+func NewAddProtocolVersionTx(
+	chainID *big.Int,
+	nonce uint64,
+	registryAddress common.Address,
+	gasFeeCap *big.Int,
+	gasTipCap *big.Int,
+	signedMessage *avalancheWarp.Message,
+) (*types.Transaction, error) {
+	callData, err := teleporterregistry.PackAddProtocolVersion(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack addProtocolVersion")
+	}
+	return predicateutils.NewPredicateTx(
+		chainID,
+		nonce,
+		&registryAddress,
+		AddProtocolVersionGasLimit,
+		gasFeeCap,
+		gasTipCap,
+		big.NewInt(0),
+		callData,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedMessage.Bytes(),
+	), nil
+}
+
+// CheckProtocolVersionAdded returns an error unless the logs of an addProtocolVersion receipt
+// show that the TeleporterRegistry at registryAddress registered teleporterAddress as version,
+// and updated its latest version to it.
+func CheckProtocolVersionAdded(
+	receipt *types.Receipt,
+	registryAddress common.Address,
+	version *big.Int,
+	teleporterAddress common.Address,
+) error {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("addProtocolVersion transaction %s failed", receipt.TxHash)
+	}
+	registry, err := teleporterregistry.NewTeleporterRegistryFilterer(registryAddress, nil)
+	if err != nil {
+		return err
+	}
+
+	var added, updated bool
+	for _, log := range receipt.Logs {
+		if log.Address != registryAddress {
+			continue
+		}
+		if event, err := registry.ParseAddProtocolVersion(*log); err == nil {
+			if event.Version.Cmp(version) != 0 || event.ProtocolAddress != teleporterAddress {
+				return fmt.Errorf("registry added version %s at %s, expected version %s at %s",
+					event.Version, event.ProtocolAddress, version, teleporterAddress)
+			}
+			added = true
+		}
+		if event, err := registry.ParseLatestVersionUpdated(*log); err == nil {
+			if event.NewVersion.Cmp(version) != 0 {
+				return fmt.Errorf("registry updated latest version to %s, expected %s", event.NewVersion, version)
+			}
+			updated = true
+		}
+	}
+	if !added {
+		return fmt.Errorf("no AddProtocolVersion event in transaction %s", receipt.TxHash)
+	}
+	if !updated {
+		return fmt.Errorf("no LatestVersionUpdated event in transaction %s", receipt.TxHash)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	teleporterregistry "github.com/ava-labs/icm-contracts/abi-bindings/go/teleporter/registry/TeleporterRegistry"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	registryAddress = common.HexToAddress("0x0000000000000000000000000000000000001234")
+	blockchainID    = ids.ID{1, 2, 3}
+)
+
+func newRegistryMessage(t *testing.T, version int64) *avalancheWarp.UnsignedMessage {
+	message, err := NewOffChainRegistryMessage(5, blockchainID, registryAddress, big.NewInt(version), teleporterMessengerAddress)
+	require.NoError(t, err)
+	return message
+}
+
+func TestNewOffChainRegistryMessage(t *testing.T) {
+	message := newRegistryMessage(t, 2)
+	require.Equal(t, uint32(5), message.NetworkID)
+	require.Equal(t, blockchainID, message.SourceChainID)
+
+	addressedCall, err := payload.ParseAddressedCall(message.Payload)
+	require.NoError(t, err)
+	require.Empty(t, addressedCall.SourceAddress)
+	entry, destination, err := teleporterregistry.UnpackTeleporterRegistryWarpPayload(addressedCall.Payload)
+	require.NoError(t, err)
+	require.Equal(t, registryAddress, destination)
+	require.Equal(t, big.NewInt(2), entry.Version)
+	require.Equal(t, teleporterMessengerAddress, entry.ProtocolAddress)
+}
+
+func TestAddOffChainMessages(t *testing.T) {
+	existing := newRegistryMessage(t, 2)
+	added := newRegistryMessage(t, 3)
+	existingHex := hexutil.Encode(existing.Bytes())
+	addedHex := hexutil.Encode(added.Bytes())
+
+	testCases := []struct {
+		name     string
+		config   string
+		messages []string
+		err      string
+	}{
+		{
+			name:     "empty config",
+			config:   "",
+			messages: []string{addedHex},
+		},
+		{
+			name:     "existing messages",
+			config:   `{"log-level":"info","warp-off-chain-messages":["` + existingHex + `"]}`,
+			messages: []string{existingHex, addedHex},
+		},
+		{
+			name:     "already added",
+			config:   `{"log-level":"info","warp-off-chain-messages":["` + addedHex + `"]}`,
+			messages: []string{addedHex},
+		},
+		{
+			name:   "invalid messages",
+			config: `{"warp-off-chain-messages":"` + existingHex + `"}`,
+			err:    "warp-off-chain-messages is not a list",
+		},
+		{
+			name:   "invalid config",
+			config: `[]`,
+			err:    "failed to parse chain config",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON, err := AddOffChainMessages([]byte(tc.config), added)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			var config map[string]interface{}
+			require.NoError(t, json.Unmarshal(configJSON, &config))
+			messages := make([]string, 0)
+			for _, m := range config[OffChainMessagesConfigKey].([]interface{}) {
+				messages = append(messages, m.(string))
+			}
+			require.Equal(t, tc.messages, messages)
+			if tc.config != "" {
+				require.Equal(t, "info", config["log-level"])
+			}
+		})
+	}
+}
+
+func TestAddOffChainMessagesKeepsNumbers(t *testing.T) {
+	config := `{"pruning-enabled":false,"state-sync-min-blocks":300000,"tx-lookup-limit":18446744073709551615,"rpc-gas-cap":50000000.5}`
+	configJSON, err := AddOffChainMessages([]byte(config), newRegistryMessage(t, 2))
+	require.NoError(t, err)
+	require.Contains(t, string(configJSON), `"state-sync-min-blocks": 300000,`)
+	require.Contains(t, string(configJSON), `"tx-lookup-limit": 18446744073709551615`)
+	require.Contains(t, string(configJSON), `"rpc-gas-cap": 50000000.5,`)
+
+	_, err = AddOffChainMessages([]byte(config+"{}"), newRegistryMessage(t, 2))
+	require.ErrorContains(t, err, "unexpected data after the config")
+}
+
+func TestNewAddProtocolVersionTx(t *testing.T) {
+	signedMessage, err := avalancheWarp.NewMessage(newRegistryMessage(t, 2), &avalancheWarp.BitSetSignature{})
+	require.NoError(t, err)
+	tx, err := NewAddProtocolVersionTx(big.NewInt(43114), 7, registryAddress, big.NewInt(50), big.NewInt(1), signedMessage)
+	require.NoError(t, err)
+
+	require.Equal(t, registryAddress, *tx.To())
+	require.Equal(t, uint64(7), tx.Nonce())
+	require.Equal(t, AddProtocolVersionGasLimit, tx.Gas())
+	callData, err := teleporterregistry.PackAddProtocolVersion(0)
+	require.NoError(t, err)
+	require.Equal(t, callData, tx.Data())
+	require.Len(t, tx.AccessList(), 1)
+	require.Equal(t, warp.ContractAddress, tx.AccessList()[0].Address)
+}
+
+func TestCheckProtocolVersionAdded(t *testing.T) {
+	registryABI, err := teleporterregistry.TeleporterRegistryMetaData.GetAbi()
+	require.NoError(t, err)
+	addProtocolVersionLog := func(version int64, protocolAddress common.Address) *types.Log {
+		return &types.Log{
+			Address: registryAddress,
+			Topics: []common.Hash{
+				registryABI.Events["AddProtocolVersion"].ID,
+				common.BigToHash(big.NewInt(version)),
+				common.BytesToHash(protocolAddress[:]),
+			},
+		}
+	}
+	latestVersionUpdatedLog := func(oldVersion int64, newVersion int64) *types.Log {
+		return &types.Log{
+			Address: registryAddress,
+			Topics: []common.Hash{
+				registryABI.Events["LatestVersionUpdated"].ID,
+				common.BigToHash(big.NewInt(oldVersion)),
+				common.BigToHash(big.NewInt(newVersion)),
+			},
+		}
+	}
+	otherAddress := common.HexToAddress("0x0000000000000000000000000000000000005678")
+
+	testCases := []struct {
+		name   string
+		status uint64
+		logs   []*types.Log
+		err    string
+	}{
+		{
+			name:   "success",
+			status: types.ReceiptStatusSuccessful,
+			logs:   []*types.Log{addProtocolVersionLog(2, teleporterMessengerAddress), latestVersionUpdatedLog(1, 2)},
+		},
+		{
+			name:   "failed",
+			status: types.ReceiptStatusFailed,
+			err:    "failed",
+		},
+		{
+			name:   "wrong address",
+			status: types.ReceiptStatusSuccessful,
+			logs:   []*types.Log{addProtocolVersionLog(2, otherAddress), latestVersionUpdatedLog(1, 2)},
+			err:    "registry added version 2",
+		},
+		{
+			name:   "latest version not updated",
+			status: types.ReceiptStatusSuccessful,
+			logs:   []*types.Log{addProtocolVersionLog(2, teleporterMessengerAddress)},
+			err:    "no LatestVersionUpdated event",
+		},
+		{
+			name:   "other contract",
+			status: types.ReceiptStatusSuccessful,
+			logs: []*types.Log{
+				{Address: otherAddress, Topics: addProtocolVersionLog(2, teleporterMessengerAddress).Topics},
+				latestVersionUpdatedLog(1, 2),
+			},
+			err: "no AddProtocolVersion event",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receipt := &types.Receipt{Status: tc.status, Logs: tc.logs}
+			err := CheckProtocolVersionAdded(receipt, registryAddress, big.NewInt(2), teleporterMessengerAddress)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package utils provides fakes shared by the unit tests of the SDK and the commands.
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"testing"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeClient is an in-memory EVM client. It serves the receipts added to it and the logs they
+// emit, and records the transactions sent to it without executing them. Calls to the methods of
+// ethclient.Client it does not implement panic.
+type FakeClient struct {
+	ethclient.Client
+
+	// Head is the number of the last block
+	Head      uint64
+	BaseFee   *big.Int
+	GasTipCap *big.Int
+	Gas       uint64
+	Nonce     uint64
+	// Receipts are the receipts of the accepted transactions, in the order they were added
+	Receipts []*types.Receipt
+	// Sent are the transactions sent to the client
+	Sent []*types.Transaction
+
+	// PendingPolls is the number of receipt queries answered with interfaces.NotFound before
+	// the receipts are served, and ReceiptErr fails all receipt queries.
+	PendingPolls int
+	ReceiptErr   error
+	ReceiptPolls int
+	// MaxFilterRange fails the log queries spanning more blocks, if set.
+	MaxFilterRange uint64
+}
+
+// NewFakeClient returns a client without blocks, with a base fee of 25 gwei and a tip of 1 wei.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		BaseFee:   big.NewInt(25_000_000_000),
+		GasTipCap: big.NewInt(1),
+		Gas:       100_000,
+	}
+}
+
+// AddReceipt adds receipt, setting the transaction hash and block number of its logs, and moves
+// the head to its block if it is ahead.
+func (c *FakeClient) AddReceipt(receipt *types.Receipt) *types.Receipt {
+	for _, log := range receipt.Logs {
+		log.TxHash = receipt.TxHash
+		log.BlockNumber = receipt.BlockNumber.Uint64()
+	}
+	c.Receipts = append(c.Receipts, receipt)
+	c.Head = max(c.Head, receipt.BlockNumber.Uint64())
+	return receipt
+}
+
+// Accept adds a block after the head, with a successful transaction emitting logs.
+func (c *FakeClient) Accept(logs ...*types.Log) *types.Receipt {
+	blockNumber := c.Head + 1
+	return c.AddReceipt(&types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+		Logs:        logs,
+	})
+}
+
+func (c *FakeClient) BlockNumber(context.Context) (uint64, error) {
+	return c.Head, nil
+}
+
+func (c *FakeClient) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(c.Head), BaseFee: c.BaseFee}, nil
+}
+
+func (c *FakeClient) EstimateBaseFee(context.Context) (*big.Int, error) {
+	return c.BaseFee, nil
+}
+
+func (c *FakeClient) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return c.GasTipCap, nil
+}
+
+func (c *FakeClient) EstimateGas(context.Context, interfaces.CallMsg) (uint64, error) {
+	return c.Gas, nil
+}
+
+func (c *FakeClient) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return c.Nonce, nil
+}
+
+func (c *FakeClient) AcceptedCodeAt(context.Context, common.Address) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *FakeClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.Sent = append(c.Sent, tx)
+	return nil
+}
+
+func (c *FakeClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.ReceiptPolls++
+	if c.ReceiptErr != nil {
+		return nil, c.ReceiptErr
+	}
+	if c.ReceiptPolls <= c.PendingPolls {
+		return nil, interfaces.NotFound
+	}
+	for _, receipt := range c.Receipts {
+		if receipt.TxHash == txHash {
+			return receipt, nil
+		}
+	}
+	return nil, interfaces.NotFound
+}
+
+// FilterLogs returns the logs of the receipts in the block range of q, emitted by one of its
+// addresses and matching its topics.
+func (c *FakeClient) FilterLogs(_ context.Context, q interfaces.FilterQuery) ([]types.Log, error) {
+	fromBlock, toBlock := uint64(0), c.Head
+	if q.FromBlock != nil {
+		fromBlock = q.FromBlock.Uint64()
+	}
+	if q.ToBlock != nil {
+		toBlock = q.ToBlock.Uint64()
+	}
+	if c.MaxFilterRange != 0 && toBlock-fromBlock > c.MaxFilterRange {
+		return nil, fmt.Errorf("block range %d-%d exceeds %d blocks", fromBlock, toBlock, c.MaxFilterRange)
+	}
+	var logs []types.Log
+	for _, receipt := range c.Receipts {
+		blockNumber := receipt.BlockNumber.Uint64()
+		if blockNumber < fromBlock || blockNumber > toBlock {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			if matches(log, q) {
+				logs = append(logs, *log)
+			}
+		}
+	}
+	return logs, nil
+}
+
+func matches(log *types.Log, q interfaces.FilterQuery) bool {
+	if len(q.Addresses) != 0 && !slices.Contains(q.Addresses, log.Address) {
+		return false
+	}
+	for i, topics := range q.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) || !slices.Contains(topics, log.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// EventLog returns a log of the event name of contractABI emitted by address, with the indexed
+// topics and the non-indexed args.
+func EventLog(
+	t *testing.T,
+	contractABI *abi.ABI,
+	address common.Address,
+	name string,
+	topics []common.Hash,
+	args ...interface{},
+) *types.Log {
+	event, ok := contractABI.Events[name]
+	require.True(t, ok, "unknown event %s", name)
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	require.NoError(t, err)
+	return &types.Log{
+		Address: address,
+		Topics:  append([]common.Hash{event.ID}, topics...),
+		Data:    data,
+	}
+}
+
+// WarpLog returns the log of the Warp precompile sending unsignedMessage from sender.
+func WarpLog(t *testing.T, sender common.Address, unsignedMessage *avalancheWarp.UnsignedMessage) *types.Log {
+	topics, data, err := warp.PackSendWarpMessageEvent(sender, common.Hash(unsignedMessage.ID()), unsignedMessage.Bytes())
+	require.NoError(t, err)
+	return &types.Log{Address: warp.ContractAddress, Topics: topics, Data: data}
+}
@@ -0,0 +1,132 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	iposvalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/interfaces/IPoSValidatorManager"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/warp/messages"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// UptimeProofQuorumPercentage is the percentage of the L1's weight that must sign
+	// a ValidationUptimeMessage for it to be accepted by the validator manager.
+	UptimeProofQuorumPercentage uint64 = 67
+
+	// WarpMessageTxGasLimit is the gas limit used for transactions delivering a Warp message
+	// to the validator manager.
+	WarpMessageTxGasLimit uint64 = 2_000_000
+)
+
+// SignatureAggregator aggregates signatures over an unsigned Warp message from the
+// validators of signingSubnetID.
+type SignatureAggregator interface {
+	CreateSignedMessage(
+		unsignedMessage *avalancheWarp.UnsignedMessage,
+		justification []byte,
+		signingSubnetID ids.ID,
+		quorumPercentage uint64,
+	) (*avalancheWarp.Message, error)
+}
+
+// NewUptimeProofUnsignedMessage returns the unsigned ValidationUptimeMessage attesting that
+// validationID has been up for uptime seconds, sent by the L1 blockchain blockchainID.
+func NewUptimeProofUnsignedMessage(
+	networkID uint32,
+	blockchainID ids.ID,
+	validationID ids.ID,
+	uptime uint64,
+) (*avalancheWarp.UnsignedMessage, error) {
+	uptimePayload, err := messages.NewValidatorUptime(validationID, uptime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create validator uptime payload")
+	}
+	addressedCall, err := warpPayload.NewAddressedCall(nil, uptimePayload.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create addressed call")
+	}
+	return avalancheWarp.NewUnsignedMessage(networkID, blockchainID, addressedCall.Bytes())
+}
+
+// ConstructUptimeProofMessage requests signatures over the ValidationUptimeMessage for
+// validationID from the validators of the L1 subnetID, and returns the signed message.
+// The L1's validators only sign if their own view of the validator's uptime is at least uptime.
+func ConstructUptimeProofMessage(
+	signatureAggregator SignatureAggregator,
+	networkID uint32,
+	blockchainID ids.ID,
+	subnetID ids.ID,
+	validationID ids.ID,
+	uptime uint64,
+) (*avalancheWarp.Message, error) {
+	unsignedMessage, err := NewUptimeProofUnsignedMessage(networkID, blockchainID, validationID, uptime)
+	if err != nil {
+		return nil, err
+	}
+	signedMessage, err := signatureAggregator.CreateSignedMessage(
+		unsignedMessage,
+		nil,
+		subnetID,
+		UptimeProofQuorumPercentage,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to aggregate uptime proof signatures for %s", validationID)
+	}
+	return signedMessage, nil
+}
+
+// PackSubmitUptimeProof packs the calldata of submitUptimeProof for validationID,
+// reading the uptime proof from the Warp message at index 0.
+func PackSubmitUptimeProof(validationID ids.ID) ([]byte, error) {
+	abi, err := iposvalidatormanager.IPoSValidatorManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return abi.Pack("submitUptimeProof", validationID, uint32(0))
+}
+
+// PackInitializeEndValidation packs the calldata of initializeEndValidation for validationID.
+// If includeUptimeProof is true, the uptime proof is read from the Warp message at index 0.
+func PackInitializeEndValidation(validationID ids.ID, includeUptimeProof bool) ([]byte, error) {
+	abi, err := iposvalidatormanager.IPoSValidatorManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abi")
+	}
+	return abi.Pack("initializeEndValidation", validationID, includeUptimeProof, uint32(0))
+}
+
+// NewWarpMessageTx returns an unsigned transaction calling contract with callData, with
+// signedMessage included as the transaction's Warp predicate at index 0.
+func NewWarpMessageTx(
+	chainID *big.Int,
+	nonce uint64,
+	contract common.Address,
+	gasFeeCap *big.Int,
+	gasTipCap *big.Int,
+	callData []byte,
+	signedMessage *avalancheWarp.Message,
+) *types.Transaction {
+	return predicateutils.NewPredicateTx(
+		chainID,
+		nonce,
+		&contract,
+		WarpMessageTxGasLimit,
+		gasFeeCap,
+		gasTipCap,
+		big.NewInt(0),
+		callData,
+		types.AccessList{},
+		warp.ContractAddress,
+		signedMessage.Bytes(),
+	)
+}
@@ -0,0 +1,88 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	"github.com/ava-labs/subnet-evm/warp/messages"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSignatureAggregator struct {
+	signingSubnetID  ids.ID
+	quorumPercentage uint64
+}
+
+func (m *mockSignatureAggregator) CreateSignedMessage(
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	_ []byte,
+	signingSubnetID ids.ID,
+	quorumPercentage uint64,
+) (*avalancheWarp.Message, error) {
+	m.signingSubnetID = signingSubnetID
+	m.quorumPercentage = quorumPercentage
+	return avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{})
+}
+
+func TestConstructUptimeProofMessage(t *testing.T) {
+	blockchainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	validationID := ids.GenerateTestID()
+	aggregator := &mockSignatureAggregator{}
+
+	signedMessage, err := ConstructUptimeProofMessage(aggregator, 5, blockchainID, subnetID, validationID, 3600)
+	require.NoError(t, err)
+	require.Equal(t, subnetID, aggregator.signingSubnetID)
+	require.Equal(t, UptimeProofQuorumPercentage, aggregator.quorumPercentage)
+	require.Equal(t, uint32(5), signedMessage.NetworkID)
+	require.Equal(t, blockchainID, signedMessage.SourceChainID)
+
+	addressedCall, err := warpPayload.ParseAddressedCall(signedMessage.Payload)
+	require.NoError(t, err)
+	require.Empty(t, addressedCall.SourceAddress)
+	uptime, err := messages.ParseValidatorUptime(addressedCall.Payload)
+	require.NoError(t, err)
+	require.Equal(t, validationID, uptime.ValidationID)
+	require.Equal(t, uint64(3600), uptime.TotalUptime)
+}
+
+func TestNewWarpMessageTx(t *testing.T) {
+	validationID := ids.GenerateTestID()
+	contract := common.Address(ids.GenerateTestShortID())
+	signedMessage, err := ConstructUptimeProofMessage(
+		&mockSignatureAggregator{},
+		5,
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		validationID,
+		3600,
+	)
+	require.NoError(t, err)
+	callData, err := PackSubmitUptimeProof(validationID)
+	require.NoError(t, err)
+
+	tx := NewWarpMessageTx(big.NewInt(1337), 7, contract, big.NewInt(2), big.NewInt(1), callData, signedMessage)
+	require.Equal(t, uint64(7), tx.Nonce())
+	require.Equal(t, contract, *tx.To())
+	require.Equal(t, WarpMessageTxGasLimit, tx.Gas())
+	require.Equal(t, callData, tx.Data())
+	require.Len(t, tx.AccessList(), 1)
+	require.Equal(t, warp.ContractAddress, tx.AccessList()[0].Address)
+
+	var packedPredicate []byte
+	for _, key := range tx.AccessList()[0].StorageKeys {
+		packedPredicate = append(packedPredicate, key.Bytes()...)
+	}
+	predicate, err := predicateutils.UnpackPredicate(packedPredicate)
+	require.NoError(t, err)
+	require.Equal(t, signedMessage.Bytes(), predicate)
+}
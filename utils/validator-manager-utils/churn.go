@@ -0,0 +1,201 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ChurnClient is the subset of an EVM client needed to read the churn state of a validator manager.
+type ChurnClient interface {
+	storageUtils.StorageClient
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// ChurnState is the churn configuration and the current churn period of a validator manager, as in
+// the ValidatorChurnPeriod of IValidatorManager.
+type ChurnState struct {
+	Manager     common.Address `json:"manager"`
+	BlockNumber uint64         `json:"blockNumber"`
+	// Timestamp of the block the state was read at
+	Timestamp              uint64 `json:"timestamp"`
+	ChurnPeriodSeconds     uint64 `json:"churnPeriodSeconds"`
+	MaximumChurnPercentage uint8  `json:"maximumChurnPercentage"`
+	// StartedAt is the start of the current churn period, or zero if no weight has changed since
+	// the initial validator set was set.
+	StartedAt     uint64 `json:"startedAt"`
+	InitialWeight uint64 `json:"initialWeight"`
+	TotalWeight   uint64 `json:"totalWeight"`
+	ChurnAmount   uint64 `json:"churnAmount"`
+}
+
+// ReadChurnState reads the churn state of the validator manager at manager, in the latest block.
+func ReadChurnState(ctx context.Context, client ChurnClient, manager common.Address) (*ChurnState, error) {
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest block")
+	}
+	layout, err := storageUtils.LoadBuiltinLayout(storageUtils.ValidatorManagerLayout)
+	if err != nil {
+		return nil, err
+	}
+	reader := storageUtils.NewReader(client, manager, layout, header.Number)
+	state := &ChurnState{
+		Manager:     manager,
+		BlockNumber: header.Number.Uint64(),
+		Timestamp:   header.Time,
+	}
+	periodSeconds, err := reader.Read(ctx, storageUtils.ValidatorManagerNamespace, "_churnPeriodSeconds")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read churn period")
+	}
+	state.ChurnPeriodSeconds = periodSeconds.(*big.Int).Uint64()
+	maximumPercentage, err := reader.Read(ctx, storageUtils.ValidatorManagerNamespace, "_maximumChurnPercentage")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read maximum churn percentage")
+	}
+	state.MaximumChurnPercentage = uint8(maximumPercentage.(*big.Int).Uint64())
+	tracker, err := reader.Read(ctx, storageUtils.ValidatorManagerNamespace, "_churnTracker")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read churn tracker")
+	}
+	for label, field := range map[string]*uint64{
+		"startedAt":     &state.StartedAt,
+		"initialWeight": &state.InitialWeight,
+		"totalWeight":   &state.TotalWeight,
+		"churnAmount":   &state.ChurnAmount,
+	} {
+		value, ok := tracker.(storageUtils.StructValue).Get(label)
+		if !ok {
+			return nil, fmt.Errorf("churn tracker has no member %s", label)
+		}
+		*field = value.(*big.Int).Uint64()
+	}
+	return state, nil
+}
+
+// PlannedChange is a validator set change to simulate against the churn limit.
+type PlannedChange struct {
+	Kind string `json:"kind"`
+	// Label identifies the change in predictions, e.g. a node ID.
+	Label string `json:"label,omitempty"`
+	// Weight is the weight added or removed by the change: the weight of a registered or removed
+	// validator, or of a registered or ended delegation.
+	Weight uint64 `json:"weight"`
+	// NotBefore is the earliest time the change is planned for, or zero to send it as soon as
+	// possible.
+	NotBefore uint64 `json:"notBefore,omitempty"`
+}
+
+// Kinds of PlannedChange
+const (
+	ChurnRegistration  = "registration"
+	ChurnRemoval       = "removal"
+	ChurnDelegation    = "delegation"
+	ChurnDelegationEnd = "delegation-end"
+)
+
+// ChurnPrediction is the simulated outcome of a PlannedChange.
+type ChurnPrediction struct {
+	Change PlannedChange `json:"change"`
+	// Time the change is simulated at: the latest of its NotBefore, the time the state was read,
+	// and the EarliestTime of the previous change.
+	Time uint64 `json:"time"`
+	// Succeeds is whether the change would succeed at Time.
+	Succeeds bool `json:"succeeds"`
+	// EarliestTime is the earliest time from Time at which the change would succeed, if it can.
+	EarliestTime uint64 `json:"earliestTime,omitempty"`
+	// ChurnAmount and TotalWeight are the churn tracker after the change, at EarliestTime.
+	ChurnAmount uint64 `json:"churnAmount,omitempty"`
+	TotalWeight uint64 `json:"totalWeight,omitempty"`
+	// Error is the reason the change can never succeed, in which case the following changes are
+	// simulated without it.
+	Error string `json:"error,omitempty"`
+}
+
+// SimulateChurn predicts whether each of changes would pass the churn check of the validator
+// manager in state, when sent in order, and the earliest time at which it would. A change that
+// exceeds the churn limit of the current churn period is delayed to the start of the next one,
+// and the following changes are sent after it. The simulation assumes no other weight changes,
+// and that the weights of the changes are applied when they are initialized, as the validator
+// manager does.
+func SimulateChurn(state *ChurnState, changes []PlannedChange) ([]ChurnPrediction, error) {
+	tracker := *state
+	now := state.Timestamp
+	predictions := make([]ChurnPrediction, len(changes))
+	for i, change := range changes {
+		var adds bool
+		switch change.Kind {
+		case ChurnRegistration, ChurnDelegation:
+			adds = true
+		case ChurnRemoval, ChurnDelegationEnd:
+		default:
+			return nil, fmt.Errorf("invalid kind %q of change %d", change.Kind, i)
+		}
+		prediction := ChurnPrediction{
+			Change: change,
+			Time:   max(now, change.NotBefore),
+		}
+		next, err := tracker.apply(prediction.Time, change.Weight, adds)
+		if err == nil {
+			prediction.Succeeds = true
+		} else if tracker.StartedAt != 0 && prediction.Time < tracker.StartedAt+tracker.ChurnPeriodSeconds {
+			// Retry at the start of the next churn period, which resets the churn amount.
+			next, err = tracker.apply(tracker.StartedAt+tracker.ChurnPeriodSeconds, change.Weight, adds)
+		}
+		if err != nil {
+			prediction.Error = err.Error()
+			predictions[i] = prediction
+			continue
+		}
+		prediction.EarliestTime = next.Timestamp
+		prediction.ChurnAmount = next.ChurnAmount
+		prediction.TotalWeight = next.TotalWeight
+		predictions[i] = prediction
+		tracker = next
+		now = next.Timestamp
+	}
+	return predictions, nil
+}
+
+// apply returns the churn state after changing weight at timestamp, as in
+// ValidatorManager._checkAndUpdateChurnTracker, or the error it would revert with.
+func (s ChurnState) apply(timestamp uint64, weight uint64, adds bool) (ChurnState, error) {
+	next := s
+	next.Timestamp = timestamp
+	if s.StartedAt == 0 || timestamp >= s.StartedAt+s.ChurnPeriodSeconds {
+		next.StartedAt = timestamp
+		next.InitialWeight = s.TotalWeight
+		next.ChurnAmount = weight
+	} else {
+		next.ChurnAmount += weight
+	}
+	maximumChurnPercentage := new(big.Int).SetUint64(uint64(s.MaximumChurnPercentage))
+	limit := new(big.Int).Mul(maximumChurnPercentage, new(big.Int).SetUint64(next.InitialWeight))
+	churn := new(big.Int).Mul(new(big.Int).SetUint64(next.ChurnAmount), big.NewInt(100))
+	if limit.Cmp(churn) < 0 {
+		return s, fmt.Errorf(
+			"churn amount %d exceeds %d%% of initial weight %d",
+			next.ChurnAmount, s.MaximumChurnPercentage, next.InitialWeight,
+		)
+	}
+	if adds {
+		next.TotalWeight += weight
+	} else if weight > next.TotalWeight {
+		return s, fmt.Errorf("weight %d exceeds total weight %d", weight, next.TotalWeight)
+	} else {
+		next.TotalWeight -= weight
+	}
+	if next.TotalWeight*uint64(s.MaximumChurnPercentage) < 100 {
+		return s, fmt.Errorf("total weight %d is too low for a maximum churn of %d%%", next.TotalWeight, s.MaximumChurnPercentage)
+	}
+	return next, nil
+}
@@ -0,0 +1,130 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChurnClient serves the storage of testManager at a block with the given timestamp.
+type fakeChurnClient struct {
+	*fakeClient
+	timestamp uint64
+}
+
+func (c *fakeChurnClient) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(c.blockNumber), Time: c.timestamp}, nil
+}
+
+func TestReadChurnState(t *testing.T) {
+	client := &fakeChurnClient{fakeClient: newFakeClient(100), timestamp: 5000}
+	// ValidatorManagerStorage: _churnPeriodSeconds and _maximumChurnPercentage share slot 1, and
+	// _churnTracker takes slots 2 and 3.
+	slot := func(n uint64) common.Hash {
+		return storageUtils.AddSlot(storageUtils.NamespaceSlot(storageUtils.ValidatorManagerNamespace), n)
+	}
+	client.storage[slot(1)] = common.BigToHash(new(big.Int).Or(new(big.Int).Lsh(big.NewInt(20), 64), big.NewInt(3600)))
+	client.storage[slot(2)] = common.BigToHash(big.NewInt(4000))
+	tracker := new(big.Int).Lsh(big.NewInt(30), 128)
+	tracker.Or(tracker, new(big.Int).Lsh(big.NewInt(1200), 64))
+	tracker.Or(tracker, big.NewInt(1000))
+	client.storage[slot(3)] = common.BigToHash(tracker)
+
+	state, err := ReadChurnState(context.Background(), client, testManager)
+	require.NoError(t, err)
+	require.Equal(t, &ChurnState{
+		Manager:                testManager,
+		BlockNumber:            100,
+		Timestamp:              5000,
+		ChurnPeriodSeconds:     3600,
+		MaximumChurnPercentage: 20,
+		StartedAt:              4000,
+		InitialWeight:          1000,
+		TotalWeight:            1200,
+		ChurnAmount:            30,
+	}, state)
+}
+
+func TestSimulateChurn(t *testing.T) {
+	state := &ChurnState{
+		Timestamp:              5000,
+		ChurnPeriodSeconds:     3600,
+		MaximumChurnPercentage: 20,
+		StartedAt:              4000,
+		InitialWeight:          1000,
+		TotalWeight:            1100,
+		ChurnAmount:            100,
+	}
+	tests := []struct {
+		name        string
+		changes     []PlannedChange
+		predictions []ChurnPrediction
+	}{
+		{
+			name:    "within the current period",
+			changes: []PlannedChange{{Kind: ChurnRegistration, Weight: 100}},
+			predictions: []ChurnPrediction{
+				{Time: 5000, Succeeds: true, EarliestTime: 5000, ChurnAmount: 200, TotalWeight: 1200},
+			},
+		},
+		{
+			name: "delayed to the next period",
+			changes: []PlannedChange{
+				{Kind: ChurnRemoval, Weight: 100},
+				{Kind: ChurnDelegation, Weight: 50},
+				{Kind: ChurnDelegationEnd, Weight: 50},
+			},
+			predictions: []ChurnPrediction{
+				{Time: 5000, Succeeds: true, EarliestTime: 5000, ChurnAmount: 200, TotalWeight: 1000},
+				{Time: 5000, EarliestTime: 7600, ChurnAmount: 50, TotalWeight: 1050},
+				{Time: 7600, Succeeds: true, EarliestTime: 7600, ChurnAmount: 100, TotalWeight: 1000},
+			},
+		},
+		{
+			name:    "planned after the current period",
+			changes: []PlannedChange{{Kind: ChurnRegistration, Weight: 200, NotBefore: 8000}},
+			predictions: []ChurnPrediction{
+				{Time: 8000, Succeeds: true, EarliestTime: 8000, ChurnAmount: 200, TotalWeight: 1300},
+			},
+		},
+		{
+			name: "exceeds any period",
+			changes: []PlannedChange{
+				{Kind: ChurnRegistration, Weight: 500},
+				{Kind: ChurnRegistration, Weight: 50},
+			},
+			predictions: []ChurnPrediction{
+				{Time: 5000, Error: "churn amount 500 exceeds 20% of initial weight 1100"},
+				{Time: 5000, Succeeds: true, EarliestTime: 5000, ChurnAmount: 150, TotalWeight: 1150},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			predictions, err := SimulateChurn(state, test.changes)
+			require.NoError(t, err)
+			for i := range test.predictions {
+				test.predictions[i].Change = test.changes[i]
+			}
+			require.Equal(t, test.predictions, predictions)
+		})
+	}
+
+	_, err := SimulateChurn(state, []PlannedChange{{Kind: "invalid"}})
+	require.ErrorContains(t, err, `invalid kind "invalid" of change 0`)
+}
+
+func TestSimulateChurnTotalWeight(t *testing.T) {
+	state := &ChurnState{ChurnPeriodSeconds: 3600, MaximumChurnPercentage: 100, TotalWeight: 2}
+	predictions, err := SimulateChurn(state, []PlannedChange{{Kind: ChurnRemoval, Weight: 2}})
+	require.NoError(t, err)
+	require.Equal(t, "total weight 0 is too low for a maximum churn of 100%", predictions[0].Error)
+}
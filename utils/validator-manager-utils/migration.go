@@ -0,0 +1,204 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	proxyadmin "github.com/ava-labs/icm-contracts/abi-bindings/go/ProxyAdmin"
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	genesisUtils "github.com/ava-labs/icm-contracts/utils/genesis-utils"
+	proxyUtils "github.com/ava-labs/icm-contracts/utils/proxy-utils"
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/pkg/errors"
+)
+
+// PoSInitializerVersion is the reinitializer version of CoqnetERC20TokenStakingManager.initialize,
+// which is set once the proxy has been migrated.
+const PoSInitializerVersion uint64 = 10
+
+// StateClient is the subset of an EVM client needed to read the state of a validator manager proxy.
+type StateClient interface {
+	Client
+	proxyUtils.Client
+}
+
+// ManagerState is the state of a validator manager behind a TransparentUpgradeableProxy.
+type ManagerState struct {
+	Proxy           common.Address `json:"proxy"`
+	Implementation  common.Address `json:"implementation"`
+	ProxyAdmin      common.Address `json:"proxyAdmin"`
+	ProxyAdminOwner common.Address `json:"proxyAdminOwner"`
+	// Owner is the owner of an Ownable validator manager, such as PoAValidatorManager. It is kept
+	// in storage after migrating to a PoS validator manager, which uses AccessControl instead.
+	Owner    common.Address `json:"owner"`
+	SubnetID ids.ID         `json:"subnetID"`
+	// InitializedVersion is the version of the last initializer called through the proxy
+	InitializedVersion uint64        `json:"initializedVersion"`
+	Validators         *ValidatorSet `json:"validators"`
+}
+
+// ReadManagerState reads the proxy, ownership, and validators of the validator manager behind
+// proxy. Validators are read from the events emitted since fromBlock.
+func ReadManagerState(ctx context.Context, client StateClient, proxy common.Address, fromBlock uint64) (*ManagerState, error) {
+	validators, err := ReadValidatorSet(ctx, client, proxy, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	blockNumber := new(big.Int).SetUint64(validators.BlockNumber)
+	state := &ManagerState{
+		Proxy:      proxy,
+		Validators: validators,
+	}
+	state.Implementation, err = proxyUtils.GetImplementation(ctx, client, proxy)
+	if err != nil {
+		return nil, err
+	}
+	state.ProxyAdmin, err = proxyUtils.GetAdmin(ctx, client, proxy)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := proxyadmin.NewProxyAdminCaller(state.ProxyAdmin, client)
+	if err != nil {
+		return nil, err
+	}
+	state.ProxyAdminOwner, err = admin.Owner(&bind.CallOpts{Context: ctx, BlockNumber: blockNumber})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ProxyAdmin owner")
+	}
+
+	layout, err := storageUtils.LoadBuiltinLayout("validator-manager")
+	if err != nil {
+		return nil, err
+	}
+	reader := storageUtils.NewReader(client, proxy, layout, blockNumber)
+	owner, err := reader.Read(ctx, storageUtils.OwnableNamespace, "_owner")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read owner")
+	}
+	state.Owner = owner.(common.Address)
+	subnetID, err := reader.Read(ctx, storageUtils.ValidatorManagerNamespace, "_subnetID")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read subnet ID")
+	}
+	copy(state.SubnetID[:], subnetID.(hexutil.Bytes))
+	initialized, err := reader.Read(ctx, storageUtils.InitializableNamespace, "_initialized")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read initialized version")
+	}
+	state.InitializedVersion = initialized.(*big.Int).Uint64()
+	return state, nil
+}
+
+// PoSMigrationConfig configures the migration of a PoAValidatorManager proxy to a
+// CoqnetERC20TokenStakingManager, as done by PoaToPos.s.sol.
+type PoSMigrationConfig struct {
+	SubnetID                 ids.ID                `json:"subnetID"`
+	ChurnPeriodSeconds       uint64                `json:"churnPeriodSeconds"`
+	MaximumChurnPercentage   uint8                 `json:"maximumChurnPercentage"`
+	MinimumStakeAmount       *math.HexOrDecimal256 `json:"minimumStakeAmount"`
+	MaximumStakeAmount       *math.HexOrDecimal256 `json:"maximumStakeAmount"`
+	MinimumStakeDuration     uint64                `json:"minimumStakeDuration"`
+	MinimumDelegationFeeBips uint16                `json:"minimumDelegationFeeBips"`
+	MaximumStakeMultiplier   uint8                 `json:"maximumStakeMultiplier"`
+	WeightToValueFactor      *math.HexOrDecimal256 `json:"weightToValueFactor"`
+	RewardCalculator         common.Address        `json:"rewardCalculator"`
+	UptimeBlockchainID       ids.ID                `json:"uptimeBlockchainID"`
+	// Token is the staking token, which must grant its ISSUER_ROLE to the proxy to mint rewards
+	Token common.Address `json:"token"`
+	// Admin is granted the DEFAULT_ADMIN_ROLE of the PoS validator manager. If not set, the owner
+	// of the PoAValidatorManager is used, so that it keeps control of the validator set.
+	Admin common.Address `json:"admin,omitempty"`
+	// Registrars are granted the REGISTER_ROLE, which is required to register validators
+	Registrars []common.Address `json:"registrars,omitempty"`
+}
+
+// LoadPoSMigrationConfig reads and validates a PoSMigrationConfig from a JSON file.
+func LoadPoSMigrationConfig(path string) (*PoSMigrationConfig, error) {
+	configJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read migration config")
+	}
+	var config PoSMigrationConfig
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse migration config")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks that the settings are set and consistent. The contract checks the settings
+// again when it is initialized.
+func (c *PoSMigrationConfig) Validate() error {
+	switch {
+	case c.SubnetID == ids.Empty:
+		return errors.New("subnet ID is not set")
+	case c.UptimeBlockchainID == ids.Empty:
+		return errors.New("uptime blockchain ID is not set")
+	case c.Token == common.Address{}:
+		return errors.New("token is not set")
+	case c.RewardCalculator == common.Address{}:
+		return errors.New("reward calculator is not set")
+	case c.MinimumStakeAmount == nil || c.MaximumStakeAmount == nil:
+		return errors.New("stake amounts are not set")
+	case (*big.Int)(c.MinimumStakeAmount).Cmp((*big.Int)(c.MaximumStakeAmount)) > 0:
+		return fmt.Errorf(
+			"minimum stake amount %s is greater than maximum stake amount %s",
+			(*big.Int)(c.MinimumStakeAmount), (*big.Int)(c.MaximumStakeAmount),
+		)
+	case c.WeightToValueFactor == nil || (*big.Int)(c.WeightToValueFactor).Sign() == 0:
+		return errors.New("weight to value factor is not set")
+	case c.MaximumChurnPercentage == 0 || c.MaximumChurnPercentage > 100:
+		return fmt.Errorf("invalid maximum churn percentage %d", c.MaximumChurnPercentage)
+	case c.MaximumStakeMultiplier == 0:
+		return errors.New("maximum stake multiplier is not set")
+	}
+	return nil
+}
+
+// Settings returns the PoSValidatorManagerSettings the validator manager is initialized with.
+func (c *PoSMigrationConfig) Settings() coqnetstakingmanager.PoSValidatorManagerSettings {
+	return coqnetstakingmanager.PoSValidatorManagerSettings{
+		BaseSettings: coqnetstakingmanager.ValidatorManagerSettings{
+			SubnetID:               c.SubnetID,
+			ChurnPeriodSeconds:     c.ChurnPeriodSeconds,
+			MaximumChurnPercentage: c.MaximumChurnPercentage,
+		},
+		MinimumStakeAmount:       (*big.Int)(c.MinimumStakeAmount),
+		MaximumStakeAmount:       (*big.Int)(c.MaximumStakeAmount),
+		MinimumStakeDuration:     c.MinimumStakeDuration,
+		MinimumDelegationFeeBips: c.MinimumDelegationFeeBips,
+		MaximumStakeMultiplier:   c.MaximumStakeMultiplier,
+		WeightToValueFactor:      (*big.Int)(c.WeightToValueFactor),
+		RewardCalculator:         c.RewardCalculator,
+		UptimeBlockchainID:       c.UptimeBlockchainID,
+	}
+}
+
+// PackPoSInitialize packs the call to initialize made through the proxy when it is upgraded,
+// granting the DEFAULT_ADMIN_ROLE to admin.
+func (c *PoSMigrationConfig) PackPoSInitialize(admin common.Address) ([]byte, error) {
+	managerABI, err := coqnetstakingmanager.CoqnetERC20TokenStakingManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return managerABI.Pack("initialize", c.Settings(), c.Token, admin)
+}
+
+// PoSImplementationConstructorArgs returns the ABI encoded constructor arguments of a PoS
+// validator manager implementation deployed behind a proxy, which disable its initializers.
+func PoSImplementationConstructorArgs() []byte {
+	return common.LeftPadBytes([]byte{genesisUtils.InitializersDisallowed}, common.HashLength)
+}
@@ -0,0 +1,109 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	coqnetstakingmanager "github.com/ava-labs/icm-contracts/abi-bindings/go/coqnet/CoqnetERC20TokenStakingManager"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSubnetID     = ids.ID{1}
+	testBlockchainID = ids.ID{2}
+)
+
+// testMigrationConfig mirrors the settings of PoaToPos.s.sol, with the given fields replaced.
+func testMigrationConfig(replacements ...string) string {
+	config := `{
+		"subnetID": "` + testSubnetID.String() + `",
+		"churnPeriodSeconds": 60,
+		"maximumChurnPercentage": 80,
+		"minimumStakeAmount": "560000000000000000000000000",
+		"maximumStakeAmount": "560000000000000000000000000",
+		"minimumStakeDuration": 61,
+		"minimumDelegationFeeBips": 100,
+		"maximumStakeMultiplier": 1,
+		"weightToValueFactor": "10000000000000000000000000",
+		"rewardCalculator": "0x7906466991143f662faC3B06D5e3846e4c6CC893",
+		"uptimeBlockchainID": "` + testBlockchainID.String() + `",
+		"token": "0xDc3b0E30d1D079159B616b2Bf618D17167EBd5fB"
+	}`
+	return strings.NewReplacer(replacements...).Replace(config)
+}
+
+func TestLoadPoSMigrationConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "valid",
+			config: testMigrationConfig(),
+		},
+		{
+			name:   "missing token",
+			config: testMigrationConfig(`"token": "0xDc3b0E30d1D079159B616b2Bf618D17167EBd5fB"`, `"admin": "0x0000000000000000000000000000000000000001"`),
+			err:    "token is not set",
+		},
+		{
+			name:   "invalid stake amounts",
+			config: testMigrationConfig(`"minimumStakeAmount": "560000000000000000000000000"`, `"minimumStakeAmount": "560000000000000000000000001"`),
+			err:    "is greater than maximum stake amount",
+		},
+		{
+			name:   "invalid churn percentage",
+			config: testMigrationConfig(`"maximumChurnPercentage": 80`, `"maximumChurnPercentage": 101`),
+			err:    "invalid maximum churn percentage 101",
+		},
+		{
+			name:   "invalid JSON",
+			config: `[]`,
+			err:    "failed to parse migration config",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "migration.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o644))
+			_, err := LoadPoSMigrationConfig(path)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPackPoSInitialize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "migration.json")
+	require.NoError(t, os.WriteFile(path, []byte(testMigrationConfig()), 0o644))
+	config, err := LoadPoSMigrationConfig(path)
+	require.NoError(t, err)
+
+	admin := common.HexToAddress("0xb4f69B081E784d50FF0a1ec1d46570ABAC7a221d")
+	calldata, err := config.PackPoSInitialize(admin)
+	require.NoError(t, err)
+
+	managerABI, err := coqnetstakingmanager.CoqnetERC20TokenStakingManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	method, err := managerABI.MethodById(calldata[:4])
+	require.NoError(t, err)
+	require.Equal(t, "initialize", method.Name)
+	args, err := method.Inputs.Unpack(calldata[4:])
+	require.NoError(t, err)
+	require.Len(t, args, 3)
+	require.Equal(t, config.Token, args[1])
+	require.Equal(t, admin, args[2])
+	require.Equal(t, [32]byte(testSubnetID), config.Settings().BaseSettings.SubnetID)
+	require.Equal(t, "10000000000000000000000000", config.Settings().WeightToValueFactor.String())
+}
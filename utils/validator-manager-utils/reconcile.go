@@ -0,0 +1,250 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// PChainClient is the subset of the P-Chain API needed to read the validators of an L1.
+type PChainClient interface {
+	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	GetValidatorsAt(
+		ctx context.Context,
+		subnetID ids.ID,
+		height platformapi.Height,
+		options ...rpc.Option,
+	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	GetL1Validator(ctx context.Context, validationID ids.ID, options ...rpc.Option) (platformvm.L1Validator, uint64, error)
+}
+
+// PChainValidatorSet is the validator set of an L1 on the P-Chain.
+type PChainValidatorSet struct {
+	SubnetID ids.ID
+	Height   uint64
+	// Weights of the active validators of the L1, by node ID
+	Weights map[ids.NodeID]uint64
+	// L1Validators are the validations known to the P-Chain, by validation ID
+	L1Validators map[ids.ID]platformvm.L1Validator
+}
+
+// ReadPChainValidatorSet reads the active validators of subnetID at the current P-Chain height,
+// and the P-Chain's state of each of validationIDs. Validations unknown to the P-Chain, because
+// they were never registered or were removed, are not in the L1Validators of the set.
+func ReadPChainValidatorSet(
+	ctx context.Context,
+	pChain PChainClient,
+	subnetID ids.ID,
+	validationIDs []ids.ID,
+) (*PChainValidatorSet, error) {
+	height, err := pChain.GetHeight(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get P-Chain height")
+	}
+	current, err := pChain.GetValidatorsAt(ctx, subnetID, platformapi.Height(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get validators of %s at P-Chain height %d", subnetID, height)
+	}
+	set := &PChainValidatorSet{
+		SubnetID:     subnetID,
+		Height:       height,
+		Weights:      make(map[ids.NodeID]uint64, len(current)),
+		L1Validators: make(map[ids.ID]platformvm.L1Validator, len(validationIDs)),
+	}
+	for nodeID, validator := range current {
+		set.Weights[nodeID] = validator.Weight
+	}
+	for _, validationID := range validationIDs {
+		validator, _, err := pChain.GetL1Validator(ctx, validationID)
+		if err != nil && strings.Contains(err.Error(), database.ErrNotFound.Error()) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get L1 validator %s", validationID)
+		}
+		set.L1Validators[validationID] = validator
+	}
+	return set, nil
+}
+
+// Discrepancy is a difference between the validator set of a validator manager and the P-Chain.
+type Discrepancy struct {
+	Kind         string          `json:"kind"`
+	NodeID       ids.NodeID      `json:"nodeID"`
+	ValidationID ids.ID          `json:"validationID"`
+	Status       ValidatorStatus `json:"status,omitempty"`
+	// Weights of the validator in the validator manager and on the P-Chain
+	ManagerWeight uint64 `json:"managerWeight"`
+	PChainWeight  uint64 `json:"pChainWeight"`
+	// MessageNonce is the nonce of the latest weight update sent by the validator manager, and
+	// PChainMinNonce the lowest nonce the P-Chain accepts.
+	MessageNonce   uint64 `json:"messageNonce,omitempty"`
+	PChainMinNonce uint64 `json:"pChainMinNonce,omitempty"`
+}
+
+// Kinds of Discrepancy
+const (
+	// MissingOnPChain is an active validation of the validator manager unknown to the P-Chain.
+	MissingOnPChain = "missing"
+	// ExtraOnPChain is a validator of the L1 on the P-Chain that the validator manager does not
+	// track.
+	ExtraOnPChain = "extra"
+	// WeightMismatch is a validation with different weights, although the P-Chain applied every
+	// weight update of the validator manager.
+	WeightMismatch = "weight"
+	// PendingNonce is a weight update of the validator manager not yet applied by the P-Chain.
+	PendingNonce = "pending-nonce"
+	// RegistrationNotCompleted is a validation registered by the P-Chain that is pending in the
+	// validator manager, whose completeValidatorRegistration was not delivered.
+	RegistrationNotCompleted = "registration-not-completed"
+	// RemovalNotCompleted is a validation removed by the P-Chain that is pending removal in the
+	// validator manager, whose completeEndValidation was not delivered.
+	RemovalNotCompleted = "removal-not-completed"
+	// InactiveOnPChain is a validation without balance to pay the P-Chain's continuous fee.
+	InactiveOnPChain = "inactive"
+)
+
+func (d Discrepancy) String() string {
+	switch d.Kind {
+	case MissingOnPChain:
+		return fmt.Sprintf("%s (%s) is active with weight %d, but unknown to the P-Chain", d.NodeID, d.ValidationID, d.ManagerWeight)
+	case ExtraOnPChain:
+		return fmt.Sprintf("%s validates with weight %d on the P-Chain, but is not tracked by the validator manager", d.NodeID, d.PChainWeight)
+	case WeightMismatch:
+		return fmt.Sprintf("%s (%s) has weight %d, but %d on the P-Chain", d.NodeID, d.ValidationID, d.ManagerWeight, d.PChainWeight)
+	case PendingNonce:
+		return fmt.Sprintf(
+			"%s (%s) weight update %d to weight %d is not applied by the P-Chain, which has weight %d and expects nonce %d",
+			d.NodeID, d.ValidationID, d.MessageNonce, d.ManagerWeight, d.PChainWeight, d.PChainMinNonce,
+		)
+	case RegistrationNotCompleted:
+		return fmt.Sprintf("%s (%s) is registered on the P-Chain, but its registration is not completed", d.NodeID, d.ValidationID)
+	case RemovalNotCompleted:
+		return fmt.Sprintf("%s (%s) is removed from the P-Chain, but its removal is not completed", d.NodeID, d.ValidationID)
+	case InactiveOnPChain:
+		return fmt.Sprintf("%s (%s) is inactive on the P-Chain, as its balance is exhausted", d.NodeID, d.ValidationID)
+	default:
+		return fmt.Sprintf("%s (%s) %s", d.NodeID, d.ValidationID, d.Kind)
+	}
+}
+
+// Reconciliation is the comparison of the validator set of a validator manager with the P-Chain.
+type Reconciliation struct {
+	Manager      common.Address `json:"manager"`
+	SubnetID     ids.ID         `json:"subnetID"`
+	BlockNumber  uint64         `json:"blockNumber"`
+	PChainHeight uint64         `json:"pChainHeight"`
+	// Total weights of the active and pending removal validations of the validator manager, and
+	// of the validators of the L1 on the P-Chain
+	ManagerWeight uint64        `json:"managerWeight"`
+	PChainWeight  uint64        `json:"pChainWeight"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// ReconcileValidatorSet reads the validator set of the validator manager at manager, which
+// manages subnetID, and compares it with the validators of subnetID on the P-Chain.
+func ReconcileValidatorSet(
+	ctx context.Context,
+	client Client,
+	pChain PChainClient,
+	manager common.Address,
+	subnetID ids.ID,
+	fromBlock uint64,
+) (*Reconciliation, error) {
+	set, err := ReadValidatorSet(ctx, client, manager, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	validationIDs := make([]ids.ID, len(set.Validators))
+	for i, validator := range set.Validators {
+		validationIDs[i] = validator.ValidationID
+	}
+	pChainSet, err := ReadPChainValidatorSet(ctx, pChain, subnetID, validationIDs)
+	if err != nil {
+		return nil, err
+	}
+	return ReconcileValidatorSets(set, pChainSet), nil
+}
+
+// ReconcileValidatorSets compares the validator set of a validator manager with the validator set
+// of its L1 on the P-Chain. Discrepancies are ordered by node ID. The sets are read at different
+// times, so an operation in progress may be reported as pending.
+func ReconcileValidatorSets(set *ValidatorSet, pChainSet *PChainValidatorSet) *Reconciliation {
+	reconciliation := &Reconciliation{
+		Manager:       set.Manager,
+		SubnetID:      pChainSet.SubnetID,
+		BlockNumber:   set.BlockNumber,
+		PChainHeight:  pChainSet.Height,
+		Discrepancies: []Discrepancy{},
+	}
+	tracked := make(map[ids.NodeID]struct{}, len(set.Validators))
+	for _, validator := range set.Validators {
+		tracked[validator.NodeID] = struct{}{}
+		if validator.Status == Active || validator.Status == PendingRemoved {
+			reconciliation.ManagerWeight += validator.Weight
+		}
+		discrepancy := Discrepancy{
+			NodeID:        validator.NodeID,
+			ValidationID:  validator.ValidationID,
+			Status:        validator.Status,
+			ManagerWeight: validator.Weight,
+			MessageNonce:  validator.MessageNonce,
+		}
+		l1Validator, registered := pChainSet.L1Validators[validator.ValidationID]
+		if registered {
+			discrepancy.PChainWeight = l1Validator.Weight
+			discrepancy.PChainMinNonce = l1Validator.MinNonce
+		}
+		switch {
+		case validator.Status == PendingAdded && registered:
+			discrepancy.Kind = RegistrationNotCompleted
+		case validator.Status == PendingAdded:
+			// The registration is not issued to the P-Chain yet, or has expired.
+			continue
+		case validator.Status == Active && !registered:
+			discrepancy.Kind = MissingOnPChain
+		case validator.Status == PendingRemoved && !registered:
+			discrepancy.Kind = RemovalNotCompleted
+		// The P-Chain's minimum nonce is one more than the nonce of the latest update it applied.
+		case validator.MessageNonce > 0 && validator.MessageNonce >= l1Validator.MinNonce:
+			discrepancy.Kind = PendingNonce
+		case validator.Weight != l1Validator.Weight:
+			discrepancy.Kind = WeightMismatch
+		}
+		if discrepancy.Kind != "" {
+			reconciliation.Discrepancies = append(reconciliation.Discrepancies, discrepancy)
+		}
+		if registered && l1Validator.Balance == 0 && validator.Status == Active {
+			discrepancy.Kind = InactiveOnPChain
+			reconciliation.Discrepancies = append(reconciliation.Discrepancies, discrepancy)
+		}
+	}
+	for nodeID, weight := range pChainSet.Weights {
+		reconciliation.PChainWeight += weight
+		if _, ok := tracked[nodeID]; ok {
+			continue
+		}
+		reconciliation.Discrepancies = append(reconciliation.Discrepancies, Discrepancy{
+			Kind:         ExtraOnPChain,
+			NodeID:       nodeID,
+			PChainWeight: weight,
+		})
+	}
+	sort.SliceStable(reconciliation.Discrepancies, func(i, j int) bool {
+		return reconciliation.Discrepancies[i].NodeID.Compare(reconciliation.Discrepancies[j].NodeID) < 0
+	})
+	return reconciliation
+}
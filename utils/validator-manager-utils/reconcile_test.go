@@ -0,0 +1,199 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePChainClient serves the validators of a single L1.
+type fakePChainClient struct {
+	height       uint64
+	subnetID     ids.ID
+	weights      map[ids.NodeID]uint64
+	l1Validators map[ids.ID]platformvm.L1Validator
+}
+
+func (c *fakePChainClient) GetHeight(context.Context, ...rpc.Option) (uint64, error) {
+	return c.height, nil
+}
+
+func (c *fakePChainClient) GetValidatorsAt(
+	_ context.Context,
+	subnetID ids.ID,
+	height platformapi.Height,
+	_ ...rpc.Option,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	if subnetID != c.subnetID || uint64(height) != c.height {
+		return nil, fmt.Errorf("unexpected validators of %s at height %d", subnetID, height)
+	}
+	output := make(map[ids.NodeID]*validators.GetValidatorOutput, len(c.weights))
+	for nodeID, weight := range c.weights {
+		output[nodeID] = &validators.GetValidatorOutput{NodeID: nodeID, Weight: weight}
+	}
+	return output, nil
+}
+
+func (c *fakePChainClient) GetL1Validator(_ context.Context, validationID ids.ID, _ ...rpc.Option) (platformvm.L1Validator, uint64, error) {
+	validator, ok := c.l1Validators[validationID]
+	if !ok {
+		return platformvm.L1Validator{}, 0, fmt.Errorf("fetching L1 validator %q failed: %w", validationID, database.ErrNotFound)
+	}
+	return validator, c.height, nil
+}
+
+func TestReconcileValidatorSet(t *testing.T) {
+	client := newFakeClient(100)
+	activeID := client.addValidator(t, "InitialValidatorCreated", 1, poavalidatormanager.Validator{
+		Status: uint8(Active), NodeID: testNodeID(1), StartingWeight: 100, Weight: 100,
+	})
+	missingID := client.addValidator(t, "ValidationPeriodCreated", 2, poavalidatormanager.Validator{
+		Status: uint8(Active), NodeID: testNodeID(2), StartingWeight: 20, Weight: 20,
+	})
+	pendingID := client.addValidator(t, "ValidationPeriodCreated", 3, poavalidatormanager.Validator{
+		Status: uint8(Active), NodeID: testNodeID(3), StartingWeight: 20, MessageNonce: 2, Weight: 40,
+	})
+	subnetID := ids.GenerateTestID()
+	extraNodeID := ids.NodeID(testNodeID(4))
+	pChain := &fakePChainClient{
+		height:   10,
+		subnetID: subnetID,
+		weights: map[ids.NodeID]uint64{
+			ids.NodeID(testNodeID(1)): 100,
+			ids.NodeID(testNodeID(3)): 30,
+			extraNodeID:               50,
+		},
+		l1Validators: map[ids.ID]platformvm.L1Validator{
+			activeID:  {Weight: 100, MinNonce: 0, Balance: 1},
+			pendingID: {Weight: 30, MinNonce: 2, Balance: 1},
+		},
+	}
+
+	reconciliation, err := ReconcileValidatorSet(context.Background(), client, pChain, testManager, subnetID, 0)
+	require.NoError(t, err)
+	require.Equal(t, testManager, reconciliation.Manager)
+	require.Equal(t, subnetID, reconciliation.SubnetID)
+	require.Equal(t, uint64(100), reconciliation.BlockNumber)
+	require.Equal(t, uint64(10), reconciliation.PChainHeight)
+	require.Equal(t, uint64(160), reconciliation.ManagerWeight)
+	require.Equal(t, uint64(180), reconciliation.PChainWeight)
+	require.Equal(t, []Discrepancy{
+		{Kind: MissingOnPChain, NodeID: ids.NodeID(testNodeID(2)), ValidationID: missingID, Status: Active, ManagerWeight: 20},
+		{
+			Kind:           PendingNonce,
+			NodeID:         ids.NodeID(testNodeID(3)),
+			ValidationID:   pendingID,
+			Status:         Active,
+			ManagerWeight:  40,
+			PChainWeight:   30,
+			MessageNonce:   2,
+			PChainMinNonce: 2,
+		},
+		{Kind: ExtraOnPChain, NodeID: extraNodeID, PChainWeight: 50},
+	}, reconciliation.Discrepancies)
+}
+
+func TestReconcileValidatorSets(t *testing.T) {
+	validationID := ids.GenerateTestID()
+	nodeID := ids.NodeID(testNodeID(1))
+	tests := []struct {
+		name         string
+		validator    Validator
+		l1Validator  *platformvm.L1Validator
+		pChainWeight uint64
+		kinds        []string
+	}{
+		{
+			name:         "in sync",
+			validator:    Validator{Status: Active, Weight: 100, MessageNonce: 1},
+			l1Validator:  &platformvm.L1Validator{Weight: 100, MinNonce: 2, Balance: 1},
+			pChainWeight: 100,
+		},
+		{
+			name:      "registration not issued",
+			validator: Validator{Status: PendingAdded, Weight: 100},
+		},
+		{
+			name:         "registration not completed",
+			validator:    Validator{Status: PendingAdded, Weight: 100},
+			l1Validator:  &platformvm.L1Validator{Weight: 100, Balance: 1},
+			pChainWeight: 100,
+			kinds:        []string{RegistrationNotCompleted},
+		},
+		{
+			name:      "missing",
+			validator: Validator{Status: Active, Weight: 100},
+			kinds:     []string{MissingOnPChain},
+		},
+		{
+			name:         "weight mismatch",
+			validator:    Validator{Status: Active, Weight: 100, MessageNonce: 1},
+			l1Validator:  &platformvm.L1Validator{Weight: 50, MinNonce: 2, Balance: 1},
+			pChainWeight: 50,
+			kinds:        []string{WeightMismatch},
+		},
+		{
+			name:         "pending nonce",
+			validator:    Validator{Status: Active, Weight: 100, MessageNonce: 1},
+			l1Validator:  &platformvm.L1Validator{Weight: 50, Balance: 1},
+			pChainWeight: 50,
+			kinds:        []string{PendingNonce},
+		},
+		{
+			name:        "removal not issued",
+			validator:   Validator{Status: PendingRemoved, Weight: 100, MessageNonce: 1},
+			l1Validator: &platformvm.L1Validator{Weight: 100, Balance: 1},
+			kinds:       []string{PendingNonce},
+		},
+		{
+			name:      "removal not completed",
+			validator: Validator{Status: PendingRemoved, Weight: 100, MessageNonce: 1},
+			kinds:     []string{RemovalNotCompleted},
+		},
+		{
+			name:        "inactive",
+			validator:   Validator{Status: Active, Weight: 100},
+			l1Validator: &platformvm.L1Validator{Weight: 100},
+			kinds:       []string{InactiveOnPChain},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.validator.ValidationID = validationID
+			test.validator.NodeID = nodeID
+			pChainSet := &PChainValidatorSet{
+				Weights:      map[ids.NodeID]uint64{},
+				L1Validators: map[ids.ID]platformvm.L1Validator{},
+			}
+			if test.l1Validator != nil {
+				pChainSet.L1Validators[validationID] = *test.l1Validator
+			}
+			if test.pChainWeight != 0 {
+				pChainSet.Weights[nodeID] = test.pChainWeight
+			}
+			reconciliation := ReconcileValidatorSets(&ValidatorSet{Validators: []Validator{test.validator}}, pChainSet)
+			kinds := []string{}
+			for _, discrepancy := range reconciliation.Discrepancies {
+				require.Equal(t, nodeID, discrepancy.NodeID)
+				require.Equal(t, validationID, discrepancy.ValidationID)
+				kinds = append(kinds, discrepancy.Kind)
+			}
+			if test.kinds == nil {
+				test.kinds = []string{}
+			}
+			require.Equal(t, test.kinds, kinds)
+		})
+	}
+}
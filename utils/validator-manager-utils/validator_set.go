@@ -0,0 +1,280 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// MaxLogBlockRange is the number of blocks requested per eth_getLogs call when enumerating validators.
+const MaxLogBlockRange uint64 = 2048
+
+// ValidatorStatus mirrors the ValidatorStatus enum of IValidatorManager.
+type ValidatorStatus uint8
+
+const (
+	Unknown ValidatorStatus = iota
+	PendingAdded
+	Active
+	PendingRemoved
+	Completed
+	Invalidated
+)
+
+func (s ValidatorStatus) String() string {
+	switch s {
+	case Unknown:
+		return "Unknown"
+	case PendingAdded:
+		return "PendingAdded"
+	case Active:
+		return "Active"
+	case PendingRemoved:
+		return "PendingRemoved"
+	case Completed:
+		return "Completed"
+	case Invalidated:
+		return "Invalidated"
+	default:
+		return fmt.Sprintf("ValidatorStatus(%d)", uint8(s))
+	}
+}
+
+func (s ValidatorStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *ValidatorStatus) UnmarshalText(text []byte) error {
+	for status := Unknown; status <= Invalidated; status++ {
+		if status.String() == string(text) {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid validator status %s", text)
+}
+
+// Validator is a validation tracked by a validator manager.
+type Validator struct {
+	ValidationID   ids.ID          `json:"validationID"`
+	NodeID         ids.NodeID      `json:"nodeID"`
+	Status         ValidatorStatus `json:"status"`
+	StartingWeight uint64          `json:"startingWeight"`
+	Weight         uint64          `json:"weight"`
+	MessageNonce   uint64          `json:"messageNonce"`
+	StartedAt      uint64          `json:"startedAt"`
+	EndedAt        uint64          `json:"endedAt,omitempty"`
+}
+
+// ValidatorSet is the set of validations of a validator manager that have not ended, read at a
+// given block.
+type ValidatorSet struct {
+	Manager     common.Address `json:"manager"`
+	BlockNumber uint64         `json:"blockNumber"`
+	TotalWeight uint64         `json:"totalWeight"`
+	Validators  []Validator    `json:"validators"`
+}
+
+// Client is the subset of an EVM client needed to read the validators of a validator manager.
+type Client interface {
+	bind.ContractCaller
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q interfaces.FilterQuery) ([]types.Log, error)
+}
+
+// ReadValidatorSet reads the validators of the validator manager at manager, in the latest block.
+// Validator managers do not enumerate their validations, so validation IDs are collected from the
+// InitialValidatorCreated and ValidationPeriodCreated events emitted since fromBlock, and the
+// validations that have not ended are read with getValidator. The events have the same signature
+// in every validator manager, so any of them can be read.
+func ReadValidatorSet(
+	ctx context.Context,
+	client Client,
+	manager common.Address,
+	fromBlock uint64,
+) (*ValidatorSet, error) {
+	blockNumber, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block number")
+	}
+	validationIDs, err := readValidationIDs(ctx, client, manager, fromBlock, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorManager, err := poavalidatormanager.NewPoAValidatorManagerCaller(manager, client)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	set := &ValidatorSet{
+		Manager:     manager,
+		BlockNumber: blockNumber,
+		Validators:  []Validator{},
+	}
+	for _, validationID := range validationIDs {
+		validator, err := validatorManager.GetValidator(opts, validationID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get validator %s", validationID)
+		}
+		status := ValidatorStatus(validator.Status)
+		if status != PendingAdded && status != Active && status != PendingRemoved {
+			continue
+		}
+		nodeID, err := ids.ToNodeID(validator.NodeID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid node ID of validator %s", validationID)
+		}
+		set.Validators = append(set.Validators, Validator{
+			ValidationID:   validationID,
+			NodeID:         nodeID,
+			Status:         status,
+			StartingWeight: validator.StartingWeight,
+			Weight:         validator.Weight,
+			MessageNonce:   validator.MessageNonce,
+			StartedAt:      validator.StartedAt,
+			EndedAt:        validator.EndedAt,
+		})
+		set.TotalWeight += validator.Weight
+	}
+	sort.Slice(set.Validators, func(i, j int) bool {
+		return set.Validators[i].NodeID.Compare(set.Validators[j].NodeID) < 0
+	})
+	return set, nil
+}
+
+func readValidationIDs(
+	ctx context.Context,
+	client Client,
+	manager common.Address,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]ids.ID, error) {
+	managerABI, err := poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	topics := [][]common.Hash{{
+		managerABI.Events["InitialValidatorCreated"].ID,
+		managerABI.Events["ValidationPeriodCreated"].ID,
+	}}
+
+	seen := make(map[ids.ID]struct{})
+	var validationIDs []ids.ID
+	for start := fromBlock; start <= toBlock; start += MaxLogBlockRange {
+		end := min(start+MaxLogBlockRange-1, toBlock)
+		logs, err := client.FilterLogs(ctx, interfaces.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{manager},
+			Topics:    topics,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get logs from block %d to %d", start, end)
+		}
+		for _, log := range logs {
+			if len(log.Topics) < 2 {
+				continue
+			}
+			validationID := ids.ID(log.Topics[1])
+			if _, ok := seen[validationID]; ok {
+				continue
+			}
+			seen[validationID] = struct{}{}
+			validationIDs = append(validationIDs, validationID)
+		}
+	}
+	return validationIDs, nil
+}
+
+// ValidatorChange is a difference between two validator sets.
+type ValidatorChange struct {
+	Kind         string          `json:"kind"`
+	NodeID       ids.NodeID      `json:"nodeID"`
+	ValidationID ids.ID          `json:"validationID"`
+	OldWeight    uint64          `json:"oldWeight,omitempty"`
+	NewWeight    uint64          `json:"newWeight,omitempty"`
+	OldStatus    ValidatorStatus `json:"oldStatus,omitempty"`
+	NewStatus    ValidatorStatus `json:"newStatus,omitempty"`
+}
+
+// Kinds of ValidatorChange
+const (
+	ValidatorAdded         = "added"
+	ValidatorRemoved       = "removed"
+	ValidationIDChanged    = "validation-id"
+	ValidatorWeightChanged = "weight"
+	ValidatorStatusChanged = "status"
+)
+
+func (c ValidatorChange) String() string {
+	switch c.Kind {
+	case ValidatorAdded:
+		return fmt.Sprintf("%s added with weight %d", c.NodeID, c.NewWeight)
+	case ValidatorRemoved:
+		return fmt.Sprintf("%s removed, had weight %d", c.NodeID, c.OldWeight)
+	case ValidatorWeightChanged:
+		return fmt.Sprintf("%s weight changed from %d to %d", c.NodeID, c.OldWeight, c.NewWeight)
+	case ValidatorStatusChanged:
+		return fmt.Sprintf("%s status changed from %s to %s", c.NodeID, c.OldStatus, c.NewStatus)
+	default:
+		return fmt.Sprintf("%s validation ID changed to %s", c.NodeID, c.ValidationID)
+	}
+}
+
+// DiffValidatorSets returns the changes from pre to post, ordered by node ID. Validators are
+// matched by node ID.
+func DiffValidatorSets(pre *ValidatorSet, post *ValidatorSet) []ValidatorChange {
+	preValidators := make(map[ids.NodeID]Validator, len(pre.Validators))
+	for _, validator := range pre.Validators {
+		preValidators[validator.NodeID] = validator
+	}
+	changes := []ValidatorChange{}
+	for _, validator := range post.Validators {
+		old, ok := preValidators[validator.NodeID]
+		delete(preValidators, validator.NodeID)
+		change := ValidatorChange{NodeID: validator.NodeID, ValidationID: validator.ValidationID}
+		switch {
+		case !ok:
+			change.Kind = ValidatorAdded
+			change.NewWeight = validator.Weight
+		case old.ValidationID != validator.ValidationID:
+			change.Kind = ValidationIDChanged
+		case old.Weight != validator.Weight:
+			change.Kind = ValidatorWeightChanged
+			change.OldWeight = old.Weight
+			change.NewWeight = validator.Weight
+		case old.Status != validator.Status:
+			change.Kind = ValidatorStatusChanged
+			change.OldStatus = old.Status
+			change.NewStatus = validator.Status
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	for _, validator := range preValidators {
+		changes = append(changes, ValidatorChange{
+			Kind:         ValidatorRemoved,
+			NodeID:       validator.NodeID,
+			ValidationID: validator.ValidationID,
+			OldWeight:    validator.Weight,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].NodeID.Compare(changes[j].NodeID) < 0
+	})
+	return changes
+}
@@ -0,0 +1,210 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	proxyadmin "github.com/ava-labs/icm-contracts/abi-bindings/go/ProxyAdmin"
+	poavalidatormanager "github.com/ava-labs/icm-contracts/abi-bindings/go/validator-manager/PoAValidatorManager"
+	proxyUtils "github.com/ava-labs/icm-contracts/utils/proxy-utils"
+	storageUtils "github.com/ava-labs/icm-contracts/utils/storage-utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testManager    = common.HexToAddress("0x0000000000000000000000000000000000001000")
+	testProxyAdmin = common.HexToAddress("0x0000000000000000000000000000000000002000")
+	testOwner      = common.HexToAddress("0x0000000000000000000000000000000000003000")
+)
+
+// fakeClient serves the validators of testManager, and the ProxyAdmin owner of testProxyAdmin.
+type fakeClient struct {
+	blockNumber uint64
+	logs        []types.Log
+	validators  map[ids.ID]poavalidatormanager.Validator
+	storage     map[common.Hash]common.Hash
+	// Block ranges of the eth_getLogs calls
+	logQueries [][2]uint64
+}
+
+func newFakeClient(blockNumber uint64) *fakeClient {
+	return &fakeClient{
+		blockNumber: blockNumber,
+		validators:  make(map[ids.ID]poavalidatormanager.Validator),
+		storage:     make(map[common.Hash]common.Hash),
+	}
+}
+
+// addValidator adds a validator created by the given event at blockNumber.
+func (c *fakeClient) addValidator(t *testing.T, event string, blockNumber uint64, validator poavalidatormanager.Validator) ids.ID {
+	managerABI, err := poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	validationID := ids.GenerateTestID()
+	c.validators[validationID] = validator
+	c.logs = append(c.logs, types.Log{
+		Address:     testManager,
+		BlockNumber: blockNumber,
+		Topics:      []common.Hash{managerABI.Events[event].ID, common.Hash(validationID)},
+	})
+	return validationID
+}
+
+func (c *fakeClient) BlockNumber(context.Context) (uint64, error) {
+	return c.blockNumber, nil
+}
+
+func (c *fakeClient) FilterLogs(_ context.Context, q interfaces.FilterQuery) ([]types.Log, error) {
+	c.logQueries = append(c.logQueries, [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()})
+	var logs []types.Log
+	for _, log := range c.logs {
+		if log.BlockNumber >= q.FromBlock.Uint64() && log.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (c *fakeClient) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *fakeClient) StorageAt(_ context.Context, _ common.Address, key common.Hash, _ *big.Int) ([]byte, error) {
+	value := c.storage[key]
+	return value[:], nil
+}
+
+func (c *fakeClient) CallContract(_ context.Context, call interfaces.CallMsg, _ *big.Int) ([]byte, error) {
+	managerABI, err := poavalidatormanager.PoAValidatorManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	adminABI, err := proxyadmin.ProxyAdminMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	getValidator := managerABI.Methods["getValidator"]
+	switch {
+	case *call.To == testManager && bytes.Equal(call.Data[:4], getValidator.ID):
+		validationID := ids.ID(call.Data[4:36])
+		return getValidator.Outputs.Pack(c.validators[validationID])
+	case *call.To == testProxyAdmin && bytes.Equal(call.Data[:4], adminABI.Methods["owner"].ID):
+		return adminABI.Methods["owner"].Outputs.Pack(testOwner)
+	}
+	return nil, fmt.Errorf("unexpected call to %s", call.To)
+}
+
+func testNodeID(b byte) []byte {
+	return bytes.Repeat([]byte{b}, ids.NodeIDLen)
+}
+
+func TestReadValidatorSet(t *testing.T) {
+	client := newFakeClient(5000)
+	initialID := client.addValidator(t, "InitialValidatorCreated", 10, poavalidatormanager.Validator{
+		Status: uint8(Active), NodeID: testNodeID(2), StartingWeight: 100, Weight: 100, StartedAt: 1,
+	})
+	client.addValidator(t, "InitialValidatorCreated", 10, poavalidatormanager.Validator{
+		Status: uint8(Completed), NodeID: testNodeID(3), StartingWeight: 100, EndedAt: 2,
+	})
+	registeredID := client.addValidator(t, "ValidationPeriodCreated", 4500, poavalidatormanager.Validator{
+		Status: uint8(PendingAdded), NodeID: testNodeID(1), StartingWeight: 20, Weight: 20,
+	})
+	// Logs before fromBlock are not read
+	client.addValidator(t, "ValidationPeriodCreated", 5, poavalidatormanager.Validator{
+		Status: uint8(Active), NodeID: testNodeID(4), StartingWeight: 20, Weight: 20,
+	})
+
+	set, err := ReadValidatorSet(context.Background(), client, testManager, 10)
+	require.NoError(t, err)
+	require.Equal(t, [][2]uint64{{10, 2057}, {2058, 4105}, {4106, 5000}}, client.logQueries)
+	require.Equal(t, testManager, set.Manager)
+	require.Equal(t, uint64(5000), set.BlockNumber)
+	require.Equal(t, uint64(120), set.TotalWeight)
+	require.Equal(t, []Validator{
+		{
+			ValidationID:   registeredID,
+			NodeID:         ids.NodeID(testNodeID(1)),
+			Status:         PendingAdded,
+			StartingWeight: 20,
+			Weight:         20,
+		},
+		{
+			ValidationID:   initialID,
+			NodeID:         ids.NodeID(testNodeID(2)),
+			Status:         Active,
+			StartingWeight: 100,
+			Weight:         100,
+			StartedAt:      1,
+		},
+	}, set.Validators)
+}
+
+func TestReadManagerState(t *testing.T) {
+	client := newFakeClient(100)
+	client.addValidator(t, "InitialValidatorCreated", 1, poavalidatormanager.Validator{
+		Status: uint8(Active), NodeID: testNodeID(1), StartingWeight: 100, Weight: 100,
+	})
+	implementation := common.HexToAddress("0x0000000000000000000000000000000000004000")
+	subnetID := ids.GenerateTestID()
+	client.storage[proxyUtils.ImplementationSlot] = common.BytesToHash(implementation[:])
+	client.storage[proxyUtils.AdminSlot] = common.BytesToHash(testProxyAdmin[:])
+	client.storage[storageUtils.NamespaceSlot(storageUtils.OwnableNamespace)] = common.BytesToHash(testOwner[:])
+	client.storage[storageUtils.NamespaceSlot(storageUtils.ValidatorManagerNamespace)] = common.Hash(subnetID)
+	client.storage[storageUtils.NamespaceSlot(storageUtils.InitializableNamespace)] = common.BigToHash(big.NewInt(1))
+
+	state, err := ReadManagerState(context.Background(), client, testManager, 0)
+	require.NoError(t, err)
+	require.Equal(t, testManager, state.Proxy)
+	require.Equal(t, implementation, state.Implementation)
+	require.Equal(t, testProxyAdmin, state.ProxyAdmin)
+	require.Equal(t, testOwner, state.ProxyAdminOwner)
+	require.Equal(t, testOwner, state.Owner)
+	require.Equal(t, subnetID, state.SubnetID)
+	require.Equal(t, uint64(1), state.InitializedVersion)
+	require.Len(t, state.Validators.Validators, 1)
+}
+
+func TestDiffValidatorSets(t *testing.T) {
+	validator := func(nodeID byte, validationID ids.ID, status ValidatorStatus, weight uint64) Validator {
+		return Validator{
+			ValidationID: validationID,
+			NodeID:       ids.NodeID(testNodeID(nodeID)),
+			Status:       status,
+			Weight:       weight,
+		}
+	}
+	ids1, ids2, ids3, ids4, ids5 := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+	pre := &ValidatorSet{Validators: []Validator{
+		validator(1, ids1, Active, 100),
+		validator(2, ids2, Active, 100),
+		validator(3, ids3, Active, 100),
+		validator(4, ids4, PendingAdded, 100),
+		validator(5, ids5, Active, 100),
+	}}
+	post := &ValidatorSet{Validators: []Validator{
+		validator(1, ids1, Active, 100),
+		validator(3, ids3, Active, 50),
+		validator(4, ids4, Active, 100),
+		validator(5, ids1, Active, 100),
+		validator(6, ids2, PendingAdded, 10),
+	}}
+
+	require.Empty(t, DiffValidatorSets(pre, pre))
+	changes := DiffValidatorSets(pre, post)
+	require.Equal(t, []ValidatorChange{
+		{Kind: ValidatorRemoved, NodeID: ids.NodeID(testNodeID(2)), ValidationID: ids2, OldWeight: 100},
+		{Kind: ValidatorWeightChanged, NodeID: ids.NodeID(testNodeID(3)), ValidationID: ids3, OldWeight: 100, NewWeight: 50},
+		{Kind: ValidatorStatusChanged, NodeID: ids.NodeID(testNodeID(4)), ValidationID: ids4, OldStatus: PendingAdded, NewStatus: Active},
+		{Kind: ValidationIDChanged, NodeID: ids.NodeID(testNodeID(5)), ValidationID: ids1},
+		{Kind: ValidatorAdded, NodeID: ids.NodeID(testNodeID(6)), ValidationID: ids2, NewWeight: 10},
+	}, changes)
+}